@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/DIMO-Network/clickhouse-infra/pkg/connect"
 	"github.com/DIMO-Network/devices-api/internal/config"
@@ -15,8 +16,10 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/controllers"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/controllers/user/sd"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/internal/middleware"
 	"github.com/DIMO-Network/devices-api/internal/middleware/address"
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/middleware/metrics"
 	"github.com/DIMO-Network/devices-api/internal/middleware/owner"
 	"github.com/DIMO-Network/devices-api/internal/rpc"
@@ -24,12 +27,14 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/services/autopi"
 	"github.com/DIMO-Network/devices-api/internal/services/fingerprint"
 	"github.com/DIMO-Network/devices-api/internal/services/genericad"
+	"github.com/DIMO-Network/devices-api/internal/services/geofence"
+	"github.com/DIMO-Network/devices-api/internal/services/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/services/integration"
 	"github.com/DIMO-Network/devices-api/internal/services/ipfs"
+	"github.com/DIMO-Network/devices-api/internal/services/oauth"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/internal/services/tmpcred"
 	pb "github.com/DIMO-Network/devices-api/pkg/grpc"
-	"github.com/DIMO-Network/shared"
 	pbuser "github.com/DIMO-Network/shared/api/users"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/shared/middleware/privilegetoken"
@@ -64,13 +69,18 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		JSONDecoder:           json.Unmarshal,
 	})
 
-	var cipher shared.Cipher
-	if settings.Environment == "dev" || settings.IsProduction() {
-		cipher = createKMS(settings, &logger)
-	} else {
-		logger.Warn().Msg("Using ROT13 encrypter. Only use this for testing!")
-		cipher = new(shared.ROT13Cipher)
+	cipher := createCipher(settings, &logger)
+
+	idempotencyWindow := 24 * time.Hour
+	if settings.IdempotencyKeyWindow != "" {
+		var err error
+		idempotencyWindow, err = time.ParseDuration(settings.IdempotencyKeyWindow)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Couldn't parse idempotency key window %q.", settings.IdempotencyKeyWindow)
+		}
 	}
+	idempotencyStore := idempotency.NewStore(pdb.DBS, idempotencyWindow)
+	idempotent := idempotencymw.New(idempotencyStore, &logger)
 
 	registryClient := registry.Client{
 		Producer:     producer,
@@ -81,6 +91,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 			Name:    "DIMO",
 			Version: "1",
 		},
+		Idempotency: idempotencyStore,
 	}
 
 	gcon, err := grpc.NewClient(settings.UsersAPIGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -97,9 +108,13 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Error creating IPFS client.")
 	}
-	scTaskSvc := services.NewSmartcarTaskService(settings, producer)
+	scTaskSvc := services.NewSmartcarTaskService(settings, producer, cipher)
 	smartcarClient := services.NewSmartcarClient(settings)
-	teslaTaskService := services.NewTeslaTaskService(settings, producer)
+	teslaTaskService := services.NewTeslaTaskService(settings, producer, cipher)
+
+	commandRegistry := services.NewCommandRegistry()
+	services.RegisterSmartcarCommands(commandRegistry, scTaskSvc)
+	services.RegisterTeslaCommands(commandRegistry, teslaTaskService)
 	teslaSvc := services.NewTeslaService(settings)
 	teslaFleetAPISvc, err := services.NewTeslaFleetAPIService(settings, &logger)
 	if err != nil {
@@ -109,7 +124,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	autoPiIngest := services.NewIngestRegistrar(producer)
 	deviceDefinitionRegistrar := services.NewDeviceDefinitionRegistrar(producer, settings)
 	hardwareTemplateService := autopi.NewHardwareTemplateService(autoPiSvc, pdb.DBS, &logger)
-	genericADIntegration := genericad.NewIntegration(pdb.DBS, ddSvc, autoPiIngest, eventService, deviceDefinitionRegistrar, &logger)
+	genericADIntegration := genericad.NewIntegration(ddSvc, autoPiIngest, eventService, deviceDefinitionRegistrar, &logger)
 	userDeviceSvc := services.NewUserDeviceService(ddSvc, logger, pdb.DBS, eventService, usersClient)
 
 	openAI := services.NewOpenAI(&logger, *settings)
@@ -141,16 +156,21 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		logger.Fatal().Err(err).Msg("Failed to ping ClickHouse.")
 	}
 
+	oauthProviders := oauth.Providers{
+		constants.TeslaVendor:    oauth.NewTeslaProvider(teslaFleetAPISvc, ddSvc, oauth.ParseScopes(settings.TeslaRequiredScopes)),
+		constants.SmartCarVendor: oauth.NewSmartcarProvider(smartcarClient, ddSvc, oauth.ParseScopes(settings.SmartcarRequiredScopes)),
+	}
+
 	// controllers
 	userDeviceController := controllers.NewUserDevicesController(settings, pdb.DBS, &logger, ddSvc, ddIntSvc, eventService,
 		smartcarClient, scTaskSvc, teslaSvc, teslaTaskService, cipher, autoPiSvc, autoPiIngest,
 		deviceDefinitionRegistrar, producer, s3NFTServiceClient, redisCache, openAI, usersClient,
-		ddaSvc, natsSvc, wallet, userDeviceSvc, teslaFleetAPISvc, ipfsSvc, chConn)
+		ddaSvc, natsSvc, wallet, userDeviceSvc, teslaFleetAPISvc, ipfsSvc, chConn, commandRegistry, idempotencyStore)
 	geofenceController := controllers.NewGeofencesController(settings, pdb.DBS, &logger, producer, ddSvc, usersClient)
-	webhooksController := controllers.NewWebhooksController(settings, pdb.DBS, &logger, autoPiSvc, ddIntSvc)
+	webhooksController := controllers.NewWebhooksController(settings, pdb.DBS, &logger, autoPiSvc, ddIntSvc, ddSvc, scTaskSvc, eventService)
 	documentsController := controllers.NewDocumentsController(settings, &logger, s3ServiceClient, pdb.DBS)
 	countriesController := controllers.NewCountriesController()
-	userIntegrationAuthController := controllers.NewUserIntegrationAuthController(settings, pdb.DBS, &logger, ddSvc, teslaFleetAPISvc, &tmpcred.Store{
+	userIntegrationAuthController := controllers.NewUserIntegrationAuthController(settings, pdb.DBS, &logger, ddSvc, oauthProviders, &tmpcred.Store{
 		Redis:  redisCache,
 		Cipher: cipher,
 	})
@@ -170,13 +190,18 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	v1.Get("/swagger/*", swagger.HandlerDefault)
 	// Device Definitions
-	nftController := controllers.NewNFTController(settings, pdb.DBS, &logger, s3NFTServiceClient, ddSvc, scTaskSvc, teslaTaskService, ddIntSvc)
+	nftController := controllers.NewNFTController(settings, pdb.DBS, &logger, s3NFTServiceClient, ddSvc, commandRegistry, ddIntSvc)
 
 	v1.Get("/countries", countriesController.GetSupportedCountries)
 	v1.Get("/countries/:countryCode", countriesController.GetCountry)
 
 	// webhooks, performs signature validation
 	v1.Post(constants.AutoPiWebhookPath, webhooksController.ProcessCommand)
+	if settings.SmartcarManagementToken != "" {
+		v1.Post(constants.SmartcarWebhookPath, webhooksController.ProcessSmartcarWebhook)
+	} else {
+		logger.Warn().Msg("SMARTCAR_MANAGEMENT_TOKEN is not set, not accepting Smartcar webhooks.")
+	}
 
 	privilegeAuth := jwtware.New(jwtware.Config{
 		JWKSetURLs: []string{settings.TokenExchangeJWTKeySetURL},
@@ -189,11 +214,20 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 	vehicleAddr := common.HexToAddress(settings.VehicleNFTAddress)
 
 	// vehicle command privileges
-	vPriv.Patch("/vin", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), userDeviceController.UpdateVINV2)
-	vPriv.Post("/commands/doors/unlock", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.UnlockDoors)
-	vPriv.Post("/commands/doors/lock", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.LockDoors)
-	vPriv.Post("/commands/trunk/open", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.OpenTrunk)
-	vPriv.Post("/commands/frunk/open", privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), nftController.OpenFrunk)
+	vehicleCommandPriv := privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands})
+	vPriv.Patch("/vin", vehicleCommandPriv, userDeviceController.UpdateVINV2)
+	vPriv.Get("/commands", vehicleCommandPriv, nftController.ListCommandRequests)
+	for _, command := range commandRegistry.Commands() {
+		vPriv.Post("/commands/"+command, vehicleCommandPriv, idempotent, nftController.EnqueueCommand(command))
+	}
+	vPriv.Get("/commands/:requestID", vehicleCommandPriv, userDeviceController.GetVehicleCommandRequestStatus)
+
+	// vehicle read privileges
+	vehicleReadPriv := privTokenWare.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData})
+	vPriv.Get("/integrations/:integrationID", vehicleReadPriv, userDeviceController.GetVehicleIntegration)
+	vPriv.Get("/integrations/:integrationID/history", vehicleReadPriv, userDeviceController.GetVehicleIntegrationHistory)
+	vPriv.Get("/error-codes", vehicleReadPriv, userDeviceController.GetVehicleErrorCodeQueries)
+	vPriv.Get("/aftermarket-device", vehicleReadPriv, userDeviceController.GetVehicleAftermarketDevice)
 
 	// Traditional tokens
 
@@ -209,15 +243,28 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	// Device creation.
 	v1Auth.Post("/user/devices/fromvin", userDeviceController.RegisterDeviceForUserFromVIN)
+	v1Auth.Post("/user/devices/fromvin/batch", userDeviceController.RegisterDevicesForUserFromVINs)
+	v1Auth.Get("/user/devices/fromvin/batch/:jobID", userDeviceController.GetVINRegistrationJob)
 	v1Auth.Post("/user/devices/fromsmartcar", userDeviceController.RegisterDeviceForUserFromSmartcar)
 	v1Auth.Post("/user/devices", userDeviceController.RegisterDeviceForUser)
 
+	// Bulk minting. Registered ahead of the owner group, which would treat "mint" as a device id.
+	v1Auth.Get("/user/devices/mint/batch", userDeviceController.GetMintBatchPayloads)
+	v1Auth.Post("/user/devices/mint/batch", idempotent, userDeviceController.PostMintBatch)
+	v1Auth.Get("/user/devices/mint/batch/:batchID", userDeviceController.GetMintBatch)
+
 	// Autopi specific routes.
 	amdOwnerMw := owner.AftermarketDevice(pdb, usersClient, &logger)
 	// same as above but AftermarketDevice
 	amdOwner := v1Auth.Group("/aftermarket/device/by-serial/:serial", amdOwnerMw)
 
 	amdOwner.Get("/", userDeviceController.GetAftermarketDeviceInfo)
+	amdOwner.Get("/commands/claim", userDeviceController.GetAftermarketDeviceClaimMessage)
+	amdOwner.Post("/commands/claim", idempotent, userDeviceController.PostAftermarketDeviceClaim)
+	amdOwner.Get("/commands/pair", userDeviceController.GetAftermarketDevicePairMessage)
+	amdOwner.Post("/commands/pair", idempotent, userDeviceController.PostAftermarketDevicePair)
+	amdOwner.Get("/commands/unpair", userDeviceController.GetAftermarketDeviceUnpairMessage)
+	amdOwner.Post("/commands/unpair", idempotent, userDeviceController.PostAftermarketDeviceUnpair)
 
 	// geofence
 	v1Auth.Post("/user/geofences", geofenceController.Create)
@@ -238,7 +285,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	udOwner.Delete("/", userDeviceController.DeleteUserDevice)
 	udOwner.Get("/commands/mint", userDeviceController.GetMintDevice)
-	udOwner.Post("/commands/mint", userDeviceController.PostMintDevice)
+	udOwner.Post("/commands/mint", idempotent, userDeviceController.PostMintDevice)
 
 	udOwner.Post("/error-codes", userDeviceController.QueryDeviceErrorCodes)
 	udOwner.Get("/error-codes", userDeviceController.GetUserDeviceErrorCodeQueries)
@@ -249,6 +296,7 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	// device integrations
 	udOwner.Get("/integrations/:integrationID", userDeviceController.GetUserDeviceIntegration)
+	udOwner.Get("/integrations/:integrationID/history", userDeviceController.GetUserDeviceIntegrationHistory)
 	udOwner.Delete("/integrations/:integrationID", userDeviceController.DeleteUserDeviceIntegration)
 	udOwner.Post("/integrations/:integrationID", userDeviceController.RegisterDeviceIntegration)
 	udOwner.Post("/commands/refresh", userDeviceController.RefreshUserDeviceStatus)
@@ -269,8 +317,8 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 				Redis:  redisCache,
 				Cipher: cipher,
 			},
-			TeslaAPI: teslaFleetAPISvc,
-			Cipher:   cipher,
+			Providers: oauthProviders,
+			Cipher:    cipher,
 		}
 
 		v1Auth.Post("/user/synthetic/device/:tokenID/commands/reauthenticate", addr, sdc.PostReauthenticate)
@@ -278,22 +326,24 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	vOwner := v1Auth.Group("/user/vehicle/:tokenID", vehicleOwnerMw)
 	vOwner.Get("/commands/burn", userDeviceController.GetBurnDevice)
-	vOwner.Post("/commands/burn", userDeviceController.PostBurnDevice)
+	vOwner.Post("/commands/burn", idempotent, userDeviceController.PostBurnDevice)
+	vOwner.Get("/privileges", userDeviceController.GetVehiclePrivileges)
 
 	syntheticController := controllers.NewSyntheticDevicesController(settings, pdb.DBS, &logger, ddSvc, usersClient, wallet, registryClient)
 
 	udOwner.Get("/integrations/:integrationID/commands/mint", syntheticController.GetSyntheticDeviceMintingPayload)
-	udOwner.Post("/integrations/:integrationID/commands/mint", syntheticController.MintSyntheticDevice)
+	udOwner.Post("/integrations/:integrationID/commands/mint", idempotent, syntheticController.MintSyntheticDevice)
 
 	udOwner.Get("/integrations/:integrationID/commands/burn", syntheticController.GetSyntheticDeviceBurnPayload)
-	udOwner.Post("/integrations/:integrationID/commands/burn", syntheticController.BurnSyntheticDevice)
+	udOwner.Post("/integrations/:integrationID/commands/burn", idempotent, syntheticController.BurnSyntheticDevice)
 
 	// Vehicle commands.
-	udOwner.Post("/integrations/:integrationID/commands/doors/unlock", userDeviceController.UnlockDoors)
-	udOwner.Post("/integrations/:integrationID/commands/doors/lock", userDeviceController.LockDoors)
-	udOwner.Post("/integrations/:integrationID/commands/trunk/open", userDeviceController.OpenTrunk)
-	udOwner.Post("/integrations/:integrationID/commands/frunk/open", userDeviceController.OpenFrunk)
+	for _, command := range commandRegistry.Commands() {
+		udOwner.Post("/integrations/:integrationID/commands/"+command, idempotent, userDeviceController.EnqueueCommand(command))
+	}
+	udOwner.Get("/commands", userDeviceController.ListCommandRequests)
 	udOwner.Get("/integrations/:integrationID/commands/:requestID", userDeviceController.GetCommandRequestStatus)
+	udOwner.Delete("/integrations/:integrationID/commands/:requestID", userDeviceController.CancelCommandRequest)
 
 	if !settings.IsProduction() {
 		udOwner.Post("/integrations/:integrationID/commands/telemetry/subscribe", userDeviceController.TelemetrySubscribe)
@@ -319,11 +369,15 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 
 	ctx := context.Background()
 
-	if err := fingerprint.RunConsumer(ctx, settings, &logger, pdb); err != nil {
+	if err := fingerprint.RunConsumer(ctx, settings, &logger, pdb, newDeadLetterQueue(logger, settings, producer, kafka.ConsumerDeviceFingerprint, settings.DeviceFingerprintTopic)); err != nil {
 		logger.Fatal().Err(err).Msg("Failed to create vin credentialer listener")
 	}
 
-	startContractEventsConsumer(logger, settings, pdb, genericADIntegration, ddSvc, eventService, scTaskSvc, teslaTaskService)
+	if err := geofence.RunConsumer(ctx, settings, &logger, pdb, eventService); err != nil {
+		logger.Fatal().Err(err).Msg("Failed to create geofence listener")
+	}
+
+	startContractEventsConsumer(logger, settings, pdb, genericADIntegration, ddSvc, eventService, scTaskSvc, teslaTaskService, geofenceController.EmitPrivacyFenceUpdates, newDeadLetterQueue(logger, settings, producer, kafka.ConsumerContractEvents, settings.ContractsEventTopic))
 
 	store, err := registry.NewProcessor(pdb.DBS, &logger, settings, eventService, scTaskSvc, teslaTaskService, ddSvc)
 	if err != nil {
@@ -334,6 +388,34 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, pdb db.Store,
 		logger.Fatal().Err(err).Msg("Failed to create transaction listener")
 	}
 
+	commandTimeout := 2 * time.Minute
+	if settings.CommandRequestTimeout != "" {
+		commandTimeout, err = time.ParseDuration(settings.CommandRequestTimeout)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Couldn't parse command request timeout %q.", settings.CommandRequestTimeout)
+		}
+	}
+	go services.NewCommandRequestSweeper(pdb.DBS, &logger, commandTimeout, 30*time.Second).Run(ctx)
+	tokenRefreshWindow := 15 * time.Minute
+	if settings.TokenRefreshWindow != "" {
+		tokenRefreshWindow, err = time.ParseDuration(settings.TokenRefreshWindow)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Couldn't parse token refresh window %q.", settings.TokenRefreshWindow)
+		}
+	}
+	go services.NewTokenRefresher(pdb.DBS, &logger, cipher, ddSvc, smartcarClient, teslaFleetAPISvc, scTaskSvc, teslaTaskService, tokenRefreshWindow, time.Minute).Run(ctx)
+	go geofence.NewScheduleWatcher(pdb.DBS, &logger, geofenceController.EmitPrivacyFenceUpdates, time.Minute).Run(ctx)
+	privilegeExpiryNotice := 24 * time.Hour
+	if settings.PrivilegeExpiryNotice != "" {
+		privilegeExpiryNotice, err = time.ParseDuration(settings.PrivilegeExpiryNotice)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Couldn't parse privilege expiry notice %q.", settings.PrivilegeExpiryNotice)
+		}
+	}
+	go services.NewPrivilegeExpiryNotifier(pdb.DBS, &logger, eventService, common.HexToAddress(settings.VehicleNFTAddress), privilegeExpiryNotice, time.Minute).Run(ctx)
+	go services.NewVINRegistrationWorker(pdb.DBS, &logger, ddSvc, userDeviceSvc, 5*time.Second).Run(ctx)
+	go idempotencyStore.Run(ctx, &logger, time.Hour)
+
 	go startGRPCServer(settings, pdb.DBS, hardwareTemplateService, &logger, ddSvc, eventService, userDeviceSvc, teslaTaskService, scTaskSvc)
 
 	c := make(chan os.Signal, 1)                    // Create channel to signify a signal being sent with length of 1
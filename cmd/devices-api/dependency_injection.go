@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 
+	"github.com/DIMO-Network/devices-api/internal/controllers"
 	"github.com/DIMO-Network/devices-api/internal/elasticsearch"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/genericad"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/IBM/sarama"
 
@@ -105,3 +107,19 @@ func (dc *dependencyContainer) getElasticSearchService() elasticsearch.ElasticSe
 
 	return dc.elasticSearch
 }
+
+// getContractsEventsConsumer builds the contract events consumer without starting it, for
+// commands that apply chain events themselves.
+func (dc *dependencyContainer) getContractsEventsConsumer(pdb db.Store) *services.ContractsEventsConsumer {
+	producer := dc.getKafkaProducer()
+	ddSvc := dc.getDeviceDefinitionService()
+	evtSvc := services.NewEventService(dc.logger, dc.settings, producer)
+	genericADIntegration := genericad.NewIntegration(ddSvc, services.NewIngestRegistrar(producer), evtSvc, services.NewDeviceDefinitionRegistrar(producer, dc.settings), dc.logger)
+	// Emitting privacy fences doesn't need the users service.
+	geofenceController := controllers.NewGeofencesController(dc.settings, pdb.DBS, dc.logger, producer, ddSvc, nil)
+
+	cipher := createCipher(dc.settings, dc.logger)
+
+	return services.NewContractsEventsConsumer(pdb, dc.logger, dc.settings, genericADIntegration, ddSvc, evtSvc,
+		services.NewSmartcarTaskService(dc.settings, producer, cipher), services.NewTeslaTaskService(dc.settings, producer, cipher), geofenceController.EmitPrivacyFenceUpdates)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
 	"flag"
 	"fmt"
 	"os"
@@ -15,6 +16,7 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/keyring"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/IBM/sarama"
@@ -62,6 +64,11 @@ func main() {
 	}
 	zerolog.SetGlobalLevel(level)
 
+	// A typo here would otherwise quietly behave like keep.
+	if err := services.ValidateVehicleTransferPolicy(settings.VehicleTransferPolicy); err != nil {
+		logger.Fatal().Err(err).Msg("Invalid VEHICLE_TRANSFER_POLICY.")
+	}
+
 	pdb := db.NewDbConnectionFromSettings(ctx, &settings.DB, true)
 	// check db ready, this is not ideal btw, the db connection handler would be nicer if it did this.
 	totalTime := 0
@@ -83,7 +90,7 @@ func main() {
 	if len(os.Args) == 1 {
 		startMonitoringServer(logger, &settings)
 		eventService := services.NewEventService(&logger, &settings, deps.getKafkaProducer())
-		startCredentialConsumer(logger, &settings, pdb)
+		startCredentialConsumer(logger, &settings, pdb, deps.getKafkaProducer())
 		startTaskStatusConsumer(logger, &settings, pdb)
 		startWebAPI(logger, &settings, pdb, eventService, deps.getKafkaProducer(), deps.getS3ServiceClient(ctx), deps.getS3NFTServiceClient(ctx))
 	} else {
@@ -91,22 +98,19 @@ func main() {
 		subcommands.Register(&findOldStyleTasks{logger: logger, settings: settings, pdb: pdb}, "events")
 
 		subcommands.Register(&generateEventCmd{logger: logger, settings: settings, pdb: pdb, ddSvc: deps.getDeviceDefinitionService()}, "events")
+		subcommands.Register(&replayDLQCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "events")
 		subcommands.Register(&setCommandCompatibilityCmd{logger: logger, settings: settings, pdb: pdb, ddSvc: deps.getDeviceDefinitionService()}, "device integrations")
 		subcommands.Register(&remakeAutoPiTopicCmd{logger: logger, settings: settings, pdb: pdb, ddSvc: deps.getDeviceDefinitionService()}, "device integrations")
 		subcommands.Register(&remakeAftermarketTopicCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "device integrations")
 		subcommands.Register(&remakeUserDeviceTokenTableCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "device integrations")
 		subcommands.Register(&remakeFenceTopicCmd{logger: logger, settings: settings, pdb: pdb}, "device integrations")
+		subcommands.Register(&previewTransferCleanupCmd{logger: logger, settings: settings, pdb: pdb}, "device integrations")
 
 		{
-			var cipher shared.Cipher
-			if settings.Environment == "dev" || settings.IsProduction() {
-				cipher = createKMS(&settings, &logger)
-			} else {
-				logger.Warn().Msg("Using ROT13 encrypter. Only use this for testing!")
-				cipher = new(shared.ROT13Cipher)
-			}
+			cipher := createCipher(&settings, &logger)
 			subcommands.Register(&checkVirtualKeyCmd{logger: logger, settings: settings, pdb: pdb, cipher: cipher}, "device integrations")
 			subcommands.Register(&enableTelemetryCmd{logger: logger, settings: settings, pdb: pdb, cipher: cipher}, "device integrations")
+			subcommands.Register(&rotateCredentialsCmd{logger: logger, pdb: pdb, cipher: cipher}, "device integrations")
 		}
 
 		subcommands.Register(&populateSDInfoTopicCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "device integrations")
@@ -127,6 +131,7 @@ func main() {
 
 		subcommands.Register(&syncDeviceTemplatesCmd{logger: logger, settings: settings, pdb: pdb}, "user devices")
 		subcommands.Register(&vinDecodeCompareCmd{logger: logger, settings: settings, pdb: pdb}, "user devices")
+		subcommands.Register(&reconcileChainCmd{logger: logger, settings: settings, pdb: pdb, container: deps}, "user devices")
 
 		flag.Parse()
 		os.Exit(int(subcommands.Execute(ctx)))
@@ -147,6 +152,13 @@ func createKafkaProducer(settings *config.Settings) (sarama.SyncProducer, error)
 }
 
 func createKMS(settings *config.Settings, logger *zerolog.Logger) shared.Cipher {
+	return &shared.KMSCipher{
+		KeyID:  settings.KMSKeyID,
+		Client: createKMSClient(settings, logger),
+	}
+}
+
+func createKMSClient(settings *config.Settings, logger *zerolog.Logger) *kms.Client {
 	// Need AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY to be set.
 	// TODO(elffjs): Can we let the SDK grab the region too?
 	awscfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(settings.AWSRegion))
@@ -154,10 +166,58 @@ func createKMS(settings *config.Settings, logger *zerolog.Logger) shared.Cipher
 		logger.Fatal().Err(err).Msg("Couldn't create AWS config.")
 	}
 
-	return &shared.KMSCipher{
-		KeyID:  settings.KMSKeyID,
-		Client: kms.NewFromConfig(awscfg),
+	return kms.NewFromConfig(awscfg)
+}
+
+// createCipher builds the keyring used for stored credentials. Ciphertext from
+// before key ids were introduced is still read with the old single KMS key, or
+// ROT13 outside of dev and prod.
+func createCipher(settings *config.Settings, logger *zerolog.Logger) *keyring.Keyring {
+	var legacy shared.Cipher
+	if settings.Environment == "dev" || settings.IsProduction() {
+		legacy = createKMS(settings, logger)
+	} else {
+		logger.Warn().Msg("Using ROT13 encrypter. Only use this for testing!")
+		legacy = new(shared.ROT13Cipher)
+	}
+
+	material, err := keyring.ParseKeys(settings.CipherKeys)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't parse CIPHER_KEYS.")
+	}
+
+	keys := make(map[string]shared.Cipher, len(material))
+	switch settings.CipherBackend {
+	case "":
+		if len(material) != 0 {
+			logger.Fatal().Msg("CIPHER_KEYS is set but CIPHER_BACKEND is not.")
+		}
+	case "kms":
+		client := createKMSClient(settings, logger)
+		for id, keyID := range material {
+			keys[id] = &shared.KMSCipher{KeyID: keyID, Client: client}
+		}
+	case "aesgcm":
+		for id, encKey := range material {
+			key, err := base64.StdEncoding.DecodeString(encKey)
+			if err != nil {
+				logger.Fatal().Err(err).Str("keyId", id).Msg("Couldn't decode AES key.")
+			}
+			keys[id], err = keyring.NewAESGCMCipher(key)
+			if err != nil {
+				logger.Fatal().Err(err).Str("keyId", id).Msg("Couldn't create AES-GCM cipher.")
+			}
+		}
+	default:
+		logger.Fatal().Msgf("Unrecognized CIPHER_BACKEND %q.", settings.CipherBackend)
 	}
+
+	kr, err := keyring.New(settings.CipherCurrentKeyID, keys, legacy)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't create credential keyring.")
+	}
+
+	return kr
 }
 
 func changeLogLevel(c *fiber.Ctx) error {
@@ -175,7 +235,7 @@ func changeLogLevel(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).SendString("log level set to: " + level.String())
 }
 
-func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store) {
+func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store, producer sarama.SyncProducer) {
 	clusterConfig := sarama.NewConfig()
 	clusterConfig.Version = sarama.V2_8_1_0
 	clusterConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
@@ -191,7 +251,8 @@ func startCredentialConsumer(logger zerolog.Logger, settings *config.Settings, p
 	if err != nil {
 		logger.Fatal().Err(err).Msg("Could not start credential update consumer")
 	}
-	credService := services.NewCredentialListener(pdb.DBS, &logger)
+	dlq := newDeadLetterQueue(logger, settings, producer, kafka.ConsumerTaskCredentials, settings.TaskCredentialTopic)
+	credService := services.NewCredentialListener(pdb.DBS, &logger, createCipher(settings, &logger), dlq)
 	consumer.Start(context.Background(), credService.ProcessCredentialsMessages)
 
 	logger.Info().Msg("Credential update consumer started")
@@ -226,21 +287,52 @@ func startTaskStatusConsumer(logger zerolog.Logger, settings *config.Settings, p
 
 	ddSvc := services.NewDeviceDefinitionService(pdb.DBS, &logger, settings)
 
-	taskStatusService := services.NewTaskStatusListener(pdb.DBS, &logger, ddSvc, kp, settings)
+	dlq := newDeadLetterQueue(logger, settings, kp, kafka.ConsumerTaskStatus, settings.TaskStatusTopic)
+	taskStatusService := services.NewTaskStatusListener(pdb.DBS, &logger, ddSvc, kp, settings, dlq)
 	consumer.Start(context.Background(), taskStatusService.ProcessTaskUpdates)
 
 	logger.Info().Msg("Task status consumer started")
 }
 
-func startContractEventsConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store, genericADInteg services.Integration, ddSvc services.DeviceDefinitionService, evtSvc services.EventService, scTask services.SmartcarTaskService, teslaTask services.TeslaTaskService) {
-	cevConsumer := services.NewContractsEventsConsumer(pdb, &logger, settings, genericADInteg, ddSvc, evtSvc, scTask, teslaTask)
-	if err := cevConsumer.RunConsumer(); err != nil {
+func startContractEventsConsumer(logger zerolog.Logger, settings *config.Settings, pdb db.Store, genericADInteg services.Integration, ddSvc services.DeviceDefinitionService, evtSvc services.EventService, scTask services.SmartcarTaskService, teslaTask services.TeslaTaskService, fenceEmitter services.PrivacyFenceEmitter, dlq *kafka.DeadLetterQueue) {
+	cevConsumer := services.NewContractsEventsConsumer(pdb, &logger, settings, genericADInteg, ddSvc, evtSvc, scTask, teslaTask, fenceEmitter)
+	if err := cevConsumer.RunConsumer(dlq); err != nil {
 		logger.Fatal().Err(err).Msg("error occurred processing contract events")
 	}
 
 	logger.Info().Msg("Contracts events consumer started")
 }
 
+// newDeadLetterQueue returns the dead-letter queue for the named consumer, or nil if
+// DEAD_LETTER_TOPIC_PREFIX isn't set.
+func newDeadLetterQueue(logger zerolog.Logger, settings *config.Settings, producer sarama.SyncProducer, consumer, topic string) *kafka.DeadLetterQueue {
+	if settings.DeadLetterTopicPrefix == "" {
+		return nil
+	}
+
+	maxAttempts := 5
+	if settings.DeadLetterMaxAttempts > 0 {
+		maxAttempts = settings.DeadLetterMaxAttempts
+	}
+
+	backoff := 2 * time.Second
+	if settings.DeadLetterRetryBackoff != "" {
+		var err error
+		backoff, err = time.ParseDuration(settings.DeadLetterRetryBackoff)
+		if err != nil {
+			logger.Fatal().Err(err).Msgf("Couldn't parse dead-letter retry backoff %q.", settings.DeadLetterRetryBackoff)
+		}
+	}
+
+	return kafka.NewDeadLetterQueue(producer, kafka.DeadLetterConfig{
+		Consumer:        consumer,
+		Topic:           topic,
+		DeadLetterTopic: kafka.DeadLetterTopic(settings.DeadLetterTopicPrefix, consumer),
+		MaxAttempts:     maxAttempts,
+		Backoff:         backoff,
+	}, &logger)
+}
+
 func startMonitoringServer(logger zerolog.Logger, config *config.Settings) {
 	monApp := fiber.New(fiber.Config{DisableStartupMessage: true})
 
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/shared/dbtypes"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+)
+
+type previewTransferCleanupCmd struct {
+	logger   zerolog.Logger
+	settings config.Settings
+	pdb      db.Store
+
+	policy string
+}
+
+func (*previewTransferCleanupCmd) Name() string { return "preview-transfer-cleanup" }
+func (*previewTransferCleanupCmd) Synopsis() string {
+	return "lists the state a vehicle transfer would clean up, without changing anything"
+}
+func (*previewTransferCleanupCmd) Usage() string {
+	return `preview-transfer-cleanup [-policy keep|wipe] [vehicleTokenId ...]:
+	Prints the geofences, integrations and error code history that the transfer handler
+	would remove for each vehicle. With no token ids, looks at every vehicle that still has
+	a geofence belonging to someone other than its owner, which is what a transfer handled
+	before the cleanup existed leaves behind.
+`
+}
+
+func (p *previewTransferCleanupCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.policy, "policy", p.settings.VehicleTransferPolicy, "transfer policy to preview; defaults to VEHICLE_TRANSFER_POLICY")
+}
+
+func (p *previewTransferCleanupCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if err := services.ValidateVehicleTransferPolicy(p.policy); err != nil {
+		p.logger.Fatal().Err(err).Msg("Invalid -policy.")
+	}
+
+	uds, err := p.vehicles(ctx, f.Args())
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to find vehicles.")
+	}
+
+	var fences, integrations int
+	var ecqs int64
+
+	for _, ud := range uds {
+		cl, err := services.PlanVehicleTransferCleanup(ctx, p.pdb.DBS().Reader, ud.ID, p.policy)
+		if err != nil {
+			p.logger.Fatal().Err(err).Str("userDeviceId", ud.ID).Msg("Failed to plan transfer cleanup.")
+		}
+
+		fences += len(cl.Geofences)
+		integrations += len(cl.Integrations)
+		ecqs += cl.ErrorCodeQueries
+
+		p.logger.Info().
+			Str("userDeviceId", ud.ID).
+			Str("vehicleTokenId", ud.TokenID.String()).
+			Strs("geofences", cl.Geofences).
+			Strs("integrations", cl.IntegrationIDs()).
+			Int64("errorCodeQueries", cl.ErrorCodeQueries).
+			Msg("Would clean up.")
+	}
+
+	p.logger.Info().
+		Int("vehicles", len(uds)).
+		Int("geofences", fences).
+		Int("integrations", integrations).
+		Int64("errorCodeQueries", ecqs).
+		Str("policy", p.policy).
+		Msg("Finished transfer cleanup preview. Nothing was changed.")
+
+	return subcommands.ExitSuccess
+}
+
+// vehicles looks up the given token ids or, if there are none, the vehicles with geofences
+// that their owner didn't create.
+func (p *previewTransferCleanupCmd) vehicles(ctx context.Context, args []string) (models.UserDeviceSlice, error) {
+	if len(args) == 0 {
+		return models.UserDevices(
+			models.UserDeviceWhere.TokenID.IsNotNull(),
+			qm.Where(`EXISTS (
+				SELECT 1 FROM devices_api.user_device_to_geofence udg
+				JOIN devices_api.geofences g ON g.id = udg.geofence_id
+				WHERE udg.user_device_id = user_devices.id AND g.user_id <> user_devices.user_id
+			)`),
+			qm.OrderBy(models.UserDeviceColumns.TokenID),
+		).All(ctx, p.pdb.DBS().Reader)
+	}
+
+	uds := make(models.UserDeviceSlice, 0, len(args))
+	for _, arg := range args {
+		tokenID, ok := new(big.Int).SetString(arg, 10)
+		if !ok {
+			return nil, fmt.Errorf("couldn't parse token id %q", arg)
+		}
+
+		ud, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		).One(ctx, p.pdb.DBS().Reader)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't find vehicle %d: %w", tokenID, err)
+		}
+
+		uds = append(uds, ud)
+	}
+
+	return uds, nil
+}
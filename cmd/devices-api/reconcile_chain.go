@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+
+	"github.com/DIMO-Network/shared/db"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
+)
+
+type reconcileChainCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	rpcURL   string
+	entities string
+	repair   bool
+}
+
+func (*reconcileChainCmd) Name() string { return "reconcile-chain" }
+func (*reconcileChainCmd) Synopsis() string {
+	return "compares vehicle, aftermarket device and synthetic device state in the database with the registry contract"
+}
+func (*reconcileChainCmd) Usage() string {
+	return `reconcile-chain [-rpc url] [-entities vehicle,aftermarket_device,synthetic_device] [-repair]:
+	Reads the owner of every minted vehicle and aftermarket device, and the vehicle each
+	aftermarket and synthetic device is paired to, from the registry and logs every field
+	where the database disagrees. With -repair, each difference is written back and
+	recorded in chain_reconciliation_changes. Vehicle transfers and burns and aftermarket
+	device pairings go through the contract events consumer's handlers, so privileges,
+	geofences, integrations and Kafka messages follow as they would have for the missed
+	event. Links to vehicles that we don't have and burned synthetic devices are only
+	reported.
+`
+}
+
+func (p *reconcileChainCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.rpcURL, "rpc", p.settings.MainRPCURL, "RPC endpoint to read the chain from; defaults to MAIN_RPC_URL")
+	f.StringVar(&p.entities, "entities", strings.Join([]string{services.ChainEntityVehicle, services.ChainEntityAftermarketDevice, services.ChainEntitySyntheticDevice}, ","), "comma-separated list of entities to check")
+	f.BoolVar(&p.repair, "repair", false, "update the database to match the chain")
+}
+
+func (p *reconcileChainCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	client, err := ethclient.DialContext(ctx, p.rpcURL)
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to connect to RPC.")
+	}
+	defer client.Close()
+
+	reader, err := services.NewRegistryChainReader(
+		client,
+		common.HexToAddress(p.settings.DIMORegistryAddr),
+		common.HexToAddress(p.settings.VehicleNFTAddress),
+		common.HexToAddress(p.settings.AftermarketDeviceContractAddress),
+		common.HexToAddress(p.settings.SyntheticDeviceNFTAddress),
+	)
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to bind registry contracts.")
+	}
+
+	var entities []string
+	for _, e := range strings.Split(p.entities, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			entities = append(entities, e)
+		}
+	}
+
+	// Only repairs need the consumer, and with it Kafka.
+	var events *services.ContractsEventsConsumer
+	if p.repair {
+		events = p.container.getContractsEventsConsumer(p.pdb)
+	}
+
+	runID, ds, err := services.NewChainReconciler(p.pdb.DBS, &p.logger, reader, events).Reconcile(ctx, entities, p.repair)
+
+	var repaired int
+	for _, d := range ds {
+		if d.Repaired {
+			repaired++
+		}
+		p.logger.Info().Str("entity", d.Entity).Str("tokenId", d.TokenID.String()).Str("field", d.Field).
+			Str("db", d.DB).Str("chain", d.Chain).Bool("repairable", d.Repairable).Bool("repaired", d.Repaired).
+			Msg("Discrepancy.")
+	}
+
+	if err != nil {
+		p.logger.Fatal().Err(err).Str("runId", runID).Msg("Reconciliation failed.")
+	}
+
+	p.logger.Info().Str("runId", runID).Int("discrepancies", len(ds)).Int("repaired", repaired).Msg("Reconciliation finished.")
+
+	return subcommands.ExitSuccess
+}
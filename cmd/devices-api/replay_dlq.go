@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+
+	"github.com/DIMO-Network/shared/db"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/fingerprint"
+)
+
+type replayDLQCmd struct {
+	logger    zerolog.Logger
+	settings  config.Settings
+	pdb       db.Store
+	container dependencyContainer
+
+	consumer string
+	since    string
+	until    string
+	subject  string
+	group    string
+	dryRun   bool
+}
+
+func (*replayDLQCmd) Name() string { return "replay-dlq" }
+func (*replayDLQCmd) Synopsis() string {
+	return "runs messages from a consumer's dead-letter topic through its handler again"
+}
+func (*replayDLQCmd) Usage() string {
+	return `replay-dlq -consumer name [-since time] [-until time] [-subject subject] [-group group] [-dry-run]:
+	Reads the dead-letter topic of the named consumer (contract-events, device-fingerprint,
+	task-status or task-credentials) up to its current end and hands each message that
+	matches the filters to the same handler the consumer uses. Times are RFC 3339 and are
+	compared with when the message was dead-lettered. Messages that fail again are logged
+	and stay on the topic; nothing is removed from it.
+
+	With -group, the run starts where the last run with that group left off and commits its
+	progress for the next one. Progress stops at the first message that fails again, so it
+	is retried next time. Messages passed over by the filters count as done.
+`
+}
+
+func (p *replayDLQCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.consumer, "consumer", "", "consumer whose dead-letter topic to replay")
+	f.StringVar(&p.since, "since", "", "only replay messages dead-lettered at or after this time")
+	f.StringVar(&p.until, "until", "", "only replay messages dead-lettered before this time")
+	f.StringVar(&p.subject, "subject", "", "only replay CloudEvents with this subject")
+	f.StringVar(&p.group, "group", "", "consumer group in which to record replayed offsets")
+	f.BoolVar(&p.dryRun, "dry-run", false, "list the matching messages without replaying them")
+}
+
+func (p *replayDLQCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.settings.DeadLetterTopicPrefix == "" {
+		p.logger.Fatal().Msg("DEAD_LETTER_TOPIC_PREFIX is not set.")
+	}
+
+	var since, until time.Time
+	var err error
+	if p.since != "" {
+		if since, err = time.Parse(time.RFC3339, p.since); err != nil {
+			p.logger.Fatal().Err(err).Msg("Couldn't parse -since.")
+		}
+	}
+	if p.until != "" {
+		if until, err = time.Parse(time.RFC3339, p.until); err != nil {
+			p.logger.Fatal().Err(err).Msg("Couldn't parse -until.")
+		}
+	}
+
+	var handler kafka.Handler
+	if !p.dryRun {
+		handler, err = p.handler()
+		if err != nil {
+			p.logger.Fatal().Err(err).Msg("Couldn't build handler.")
+		}
+	}
+
+	topic := kafka.DeadLetterTopic(p.settings.DeadLetterTopicPrefix, p.consumer)
+
+	kc := sarama.NewConfig()
+	kc.Version = sarama.V3_6_0_0
+	kc.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	client, err := sarama.NewClient(strings.Split(p.settings.KafkaBrokers, ","), kc)
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to create Kafka client.")
+	}
+	defer client.Close() //nolint
+
+	cons, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to create Kafka consumer.")
+	}
+	defer cons.Close() //nolint
+
+	var om sarama.OffsetManager
+	if p.group != "" {
+		om, err = sarama.NewOffsetManagerFromClient(p.group, client)
+		if err != nil {
+			p.logger.Fatal().Err(err).Msg("Failed to create offset manager.")
+		}
+		defer om.Close() //nolint
+	}
+
+	ps, err := cons.Partitions(topic)
+	if err != nil {
+		p.logger.Fatal().Err(err).Msgf("Failed to list partitions of %s.", topic)
+	}
+
+	var matched, replayed, failed int
+
+	for _, part := range ps {
+		oldest, err := client.GetOffset(topic, part, sarama.OffsetOldest)
+		if err != nil {
+			p.logger.Fatal().Err(err).Msg("Failed to get oldest offset.")
+		}
+		hwm, err := client.GetOffset(topic, part, sarama.OffsetNewest)
+		if err != nil {
+			p.logger.Fatal().Err(err).Msg("Failed to get newest offset.")
+		}
+
+		start := oldest
+		var pom sarama.PartitionOffsetManager
+		if om != nil {
+			pom, err = om.ManagePartition(topic, part)
+			if err != nil {
+				p.logger.Fatal().Err(err).Msgf("Failed to get offset of partition %d.", part)
+			}
+			if next, _ := pom.NextOffset(); next > start {
+				start = next
+			}
+		}
+
+		if start >= hwm {
+			if pom != nil {
+				_ = pom.Close()
+			}
+			continue
+		}
+
+		pc, err := cons.ConsumePartition(topic, part, start)
+		if err != nil {
+			p.logger.Fatal().Err(err).Msgf("Failed to consume partition %d.", part)
+		}
+
+		// Whether every message so far has been handled, and so can be committed. Dry runs
+		// start from the group's offsets but don't move them.
+		done := pom != nil && !p.dryRun
+
+		for m := range pc.Messages() {
+			dl := kafka.ParseDeadLetter(m)
+
+			if p.matches(dl, since, until) {
+				matched++
+
+				logger := p.logger.With().Int32("partition", part).Int64("offset", m.Offset).Str("originalTopic", dl.Message.Topic).
+					Int64("originalOffset", dl.Message.Offset).Time("failedAt", dl.FailedAt).Str("error", dl.Error).Logger()
+
+				if p.dryRun {
+					logger.Info().Msg("Would replay message.")
+				} else if err := handler(ctx, dl.Message); err != nil {
+					failed++
+					done = false
+					logger.Err(err).Msg("Replayed message failed again.")
+				} else {
+					replayed++
+					logger.Info().Msg("Replayed message.")
+				}
+			}
+
+			if done {
+				pom.MarkOffset(m.Offset+1, "")
+			}
+
+			if m.Offset >= hwm-1 {
+				break
+			}
+		}
+
+		_ = pc.Close()
+		if pom != nil {
+			_ = pom.Close()
+		}
+	}
+
+	if om != nil {
+		om.Commit()
+	}
+
+	p.logger.Info().Str("topic", topic).Int("matched", matched).Int("replayed", replayed).Int("failed", failed).Msg("Finished replaying dead-letter topic.")
+
+	if failed != 0 {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func (p *replayDLQCmd) matches(dl *kafka.DeadLetter, since, until time.Time) bool {
+	if !since.IsZero() && dl.FailedAt.Before(since) {
+		return false
+	}
+	if !until.IsZero() && !dl.FailedAt.Before(until) {
+		return false
+	}
+
+	if p.subject != "" {
+		var event struct {
+			Subject string `json:"subject"`
+		}
+		if err := json.Unmarshal(dl.Message.Value, &event); err != nil || event.Subject != p.subject {
+			return false
+		}
+	}
+
+	return true
+}
+
+// handler builds the message handler of the consumer being replayed. Replays go straight
+// to the handler, with no retries or dead-lettering of their own.
+func (p *replayDLQCmd) handler() (kafka.Handler, error) {
+	switch p.consumer {
+	case kafka.ConsumerContractEvents:
+		return p.container.getContractsEventsConsumer(p.pdb).HandleMessage, nil
+	case kafka.ConsumerDeviceFingerprint:
+		return fingerprint.NewConsumer(p.pdb, &p.logger).HandleMessage, nil
+	case kafka.ConsumerTaskStatus:
+		return services.NewTaskStatusListener(p.pdb.DBS, &p.logger, p.container.getDeviceDefinitionService(), p.container.getKafkaProducer(), &p.settings, nil).HandleMessage, nil
+	case kafka.ConsumerTaskCredentials:
+		return services.NewCredentialListener(p.pdb.DBS, &p.logger, createCipher(&p.settings, &p.logger), nil).HandleMessage, nil
+	default:
+		return nil, fmt.Errorf("unrecognized consumer %q", p.consumer)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+
+	"github.com/DIMO-Network/devices-api/internal/services/keyring"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+)
+
+type rotateCredentialsCmd struct {
+	logger zerolog.Logger
+	pdb    db.Store
+	cipher *keyring.Keyring
+
+	batchSize int
+	dryRun    bool
+}
+
+func (*rotateCredentialsCmd) Name() string { return "rotate-credentials" }
+func (*rotateCredentialsCmd) Synopsis() string {
+	return "re-encrypts stored integration credentials under the current cipher key"
+}
+func (*rotateCredentialsCmd) Usage() string {
+	return `rotate-credentials [-batch-size N] [-dry-run]:
+	Re-encrypts user_device_api_integrations access and refresh tokens that were not
+	produced by CIPHER_CURRENT_KEY_ID. Both the old and new keys must be in CIPHER_KEYS
+	while this runs. Polling tasks are not sent anything: they are always handed
+	credentials under the legacy KMS cipher, so that can't be retired by this command.
+`
+}
+
+func (p *rotateCredentialsCmd) SetFlags(f *flag.FlagSet) {
+	f.IntVar(&p.batchSize, "batch-size", 100, "number of integrations to re-encrypt per transaction")
+	f.BoolVar(&p.dryRun, "dry-run", false, "count the integrations that need re-encrypting without changing them")
+}
+
+func (p *rotateCredentialsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.cipher.CurrentKeyID() == "" {
+		p.logger.Fatal().Msg("No CIPHER_CURRENT_KEY_ID set, nothing to rotate to.")
+	}
+	if p.batchSize <= 0 {
+		p.logger.Fatal().Msg("Batch size must be positive.")
+	}
+
+	r := credentialRotator{
+		dbs:       p.pdb.DBS,
+		logger:    &p.logger,
+		cipher:    p.cipher,
+		batchSize: p.batchSize,
+		dryRun:    p.dryRun,
+	}
+
+	rotated, skipped, err := r.rotate(ctx)
+	if err != nil {
+		p.logger.Fatal().Err(err).Int("rotated", rotated).Msg("Failed to rotate credentials.")
+	}
+
+	p.logger.Info().Int("rotated", rotated).Int("skipped", skipped).Bool("dryRun", p.dryRun).Str("keyId", p.cipher.CurrentKeyID()).Msg("Finished rotating credentials.")
+	return subcommands.ExitSuccess
+}
+
+type credentialRotator struct {
+	dbs       func() *db.ReaderWriter
+	logger    *zerolog.Logger
+	cipher    *keyring.Keyring
+	batchSize int
+	dryRun    bool
+}
+
+// rotate walks the integrations in primary key order and re-encrypts those
+// with credentials under an old key. It returns the number re-encrypted and
+// the number skipped because they changed underneath us; running it again
+// picks the latter up.
+//
+// Running polling tasks keep the credentials they were started with. Those
+// were converted to the legacy cipher before being sent, and tasks can't read
+// anything else, so there is nothing to republish.
+func (r *credentialRotator) rotate(ctx context.Context) (int, int, error) {
+	var (
+		rotated, skipped int
+		lastUserDevice   string
+		lastIntegration  string
+	)
+
+	for {
+		udais, err := models.UserDeviceAPIIntegrations(
+			qm.Expr(
+				models.UserDeviceAPIIntegrationWhere.AccessToken.IsNotNull(),
+				qm.Or2(models.UserDeviceAPIIntegrationWhere.RefreshToken.IsNotNull()),
+			),
+			qm.Where("("+models.UserDeviceAPIIntegrationColumns.UserDeviceID+", "+models.UserDeviceAPIIntegrationColumns.IntegrationID+") > (?, ?)", lastUserDevice, lastIntegration),
+			qm.OrderBy(models.UserDeviceAPIIntegrationColumns.UserDeviceID+", "+models.UserDeviceAPIIntegrationColumns.IntegrationID),
+			qm.Limit(r.batchSize),
+		).All(ctx, r.dbs().Reader)
+		if err != nil {
+			return rotated, skipped, err
+		}
+		if len(udais) == 0 {
+			return rotated, skipped, nil
+		}
+
+		last := udais[len(udais)-1]
+		lastUserDevice, lastIntegration = last.UserDeviceID, last.IntegrationID
+
+		var stale []*models.UserDeviceAPIIntegration
+		for _, udai := range udais {
+			if (udai.AccessToken.Valid && r.cipher.NeedsRotation(udai.AccessToken.String)) || (udai.RefreshToken.Valid && r.cipher.NeedsRotation(udai.RefreshToken.String)) {
+				stale = append(stale, udai)
+			}
+		}
+
+		if r.dryRun {
+			rotated += len(stale)
+			continue
+		}
+
+		done, err := r.rotateBatch(ctx, stale)
+		if err != nil {
+			return rotated, skipped, err
+		}
+		rotated += len(done)
+		skipped += len(stale) - len(done)
+
+		r.logger.Info().Int("rotated", rotated).Int("skipped", skipped).Msg("Finished batch.")
+	}
+}
+
+// rotateBatch re-encrypts the integrations' tokens in one transaction. Rows
+// whose tokens changed since they were read, say because they were just
+// refreshed, are left alone.
+func (r *credentialRotator) rotateBatch(ctx context.Context, udais []*models.UserDeviceAPIIntegration) ([]*models.UserDeviceAPIIntegration, error) {
+	if len(udais) == 0 {
+		return nil, nil
+	}
+
+	tx, err := r.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	var done []*models.UserDeviceAPIIntegration
+	for _, udai := range udais {
+		access, err := r.reencrypt(udai.AccessToken)
+		if err != nil {
+			return nil, fmt.Errorf("access token for device %s, integration %s: %w", udai.UserDeviceID, udai.IntegrationID, err)
+		}
+		refresh, err := r.reencrypt(udai.RefreshToken)
+		if err != nil {
+			return nil, fmt.Errorf("refresh token for device %s, integration %s: %w", udai.UserDeviceID, udai.IntegrationID, err)
+		}
+
+		n, err := models.UserDeviceAPIIntegrations(
+			models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(udai.UserDeviceID),
+			models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(udai.IntegrationID),
+			qm.Where(models.UserDeviceAPIIntegrationColumns.AccessToken+" IS NOT DISTINCT FROM ?", udai.AccessToken),
+			qm.Where(models.UserDeviceAPIIntegrationColumns.RefreshToken+" IS NOT DISTINCT FROM ?", udai.RefreshToken),
+		).UpdateAll(ctx, tx, models.M{
+			models.UserDeviceAPIIntegrationColumns.AccessToken:  access,
+			models.UserDeviceAPIIntegrationColumns.RefreshToken: refresh,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			continue
+		}
+
+		udai.AccessToken, udai.RefreshToken = access, refresh
+		done = append(done, udai)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return done, nil
+}
+
+func (r *credentialRotator) reencrypt(s null.String) (null.String, error) {
+	if !s.Valid || !r.cipher.NeedsRotation(s.String) {
+		return s, nil
+	}
+
+	plain, err := r.cipher.Decrypt(s.String)
+	if err != nil {
+		return null.String{}, fmt.Errorf("couldn't decrypt: %w", err)
+	}
+
+	enc, err := r.cipher.Encrypt(plain)
+	if err != nil {
+		return null.String{}, fmt.Errorf("couldn't encrypt: %w", err)
+	}
+
+	return null.StringFrom(enc), nil
+}
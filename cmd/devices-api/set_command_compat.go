@@ -56,7 +56,7 @@ func (p *setCommandCompatibilityCmd) Execute(ctx context.Context, _ *flag.FlagSe
 	return subcommands.ExitSuccess
 }
 
-var teslaEnabledCommands = []string{constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen, constants.ChargeLimit}
+var teslaEnabledCommands = []string{constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen, constants.ChargeLimit, constants.ChargeStart, constants.ChargeStop, constants.ClimateStart, constants.ClimateStop}
 
 func setCommandCompatibility(ctx context.Context, settings *config.Settings, pdb db.Store, ddSvc services.DeviceDefinitionService) error {
 
@@ -122,14 +122,16 @@ func setCommandCompatSmartcar(ctx context.Context, settings *config.Settings, pd
 		return err
 	}
 
+	scClient := services.NewSmartcarClient(settings)
+
 	for _, su := range scUDAIs {
 		country := constants.FindCountry(su.R.UserDevice.CountryCode.String)
-		doors, err := checkSmartcarDoorCompatibility(settings, su.R.UserDevice.VinIdentifier.String, country.Alpha2)
+		capable, err := smartcarCapableCommands(settings, scClient, su.R.UserDevice.VinIdentifier.String, country.Alpha2)
 		if err != nil {
 			log.Err(err).Msg("Error getting compat")
 			continue
 		}
-		if !doors {
+		if len(capable) == 0 {
 			continue
 		}
 		md := new(services.UserDeviceAPIIntegrationsMetadata)
@@ -145,7 +147,7 @@ func setCommandCompatSmartcar(ctx context.Context, settings *config.Settings, pd
 			continue
 		}
 
-		md.Commands.Capable = []string{constants.DoorsLock, constants.DoorsUnlock}
+		md.Commands.Capable = capable
 
 		if err := su.Metadata.Marshal(md); err != nil {
 			return err
@@ -166,8 +168,27 @@ type capResp struct {
 	} `json:"capabilities"`
 }
 
-func checkSmartcarDoorCompatibility(settings *config.Settings, vin, countryAlpha2 string) (bool, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.smartcar.com/v2.0/compatibility?vin=%s&scope=control_security&country=%s", vin, countryAlpha2), nil)
+// smartcarControlScopes are the Smartcar permissions that unlock commands.
+var smartcarControlScopes = []string{"control_security", "control_charge", "control_climate"}
+
+// smartcarCapableCommands lists the commands that the vehicle could run, were the
+// owner to grant the permissions for them.
+func smartcarCapableCommands(settings *config.Settings, scClient services.SmartcarClient, vin, countryAlpha2 string) ([]string, error) {
+	var capable []string
+	for _, scope := range smartcarControlScopes {
+		ok, err := checkSmartcarCompatibility(settings, vin, countryAlpha2, scope)
+		if err != nil {
+			return nil, err
+		}
+		if cmds := scClient.GetAvailableCommands([]string{scope}); ok && cmds != nil {
+			capable = append(capable, cmds.Enabled...)
+		}
+	}
+	return capable, nil
+}
+
+func checkSmartcarCompatibility(settings *config.Settings, vin, countryAlpha2, scope string) (bool, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.smartcar.com/v2.0/compatibility?vin=%s&scope=%s&country=%s", vin, scope, countryAlpha2), nil)
 	if err != nil {
 		return false, err
 	}
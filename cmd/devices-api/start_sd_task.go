@@ -48,8 +48,9 @@ func (p *startSDTask) SetFlags(f *flag.FlagSet) {
 
 func (p *startSDTask) Execute(_ context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
 	p.producer = p.container.getKafkaProducer()
-	p.scTask = services.NewSmartcarTaskService(&p.settings, p.producer)
-	p.teslaTask = services.NewTeslaTaskService(&p.settings, p.producer)
+	cipher := createCipher(&p.settings, &p.logger)
+	p.scTask = services.NewSmartcarTaskService(&p.settings, p.producer, cipher)
+	p.teslaTask = services.NewTeslaTaskService(&p.settings, p.producer, cipher)
 	err := p.startSDTaskGo()
 	if err != nil {
 		p.logger.Fatal().Err(err).Msg("Error running SD task start.")
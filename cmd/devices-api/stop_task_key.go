@@ -16,6 +16,7 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
@@ -68,11 +69,24 @@ func (p *stopTaskByKeyCmd) stopTaskByKey(settings *config.Settings, taskKey stri
 			return err
 		}
 	} else {
+		tx, err := p.container.dbs().Writer.BeginTx(context.TODO(), nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback() //nolint
+
+		fromStatus := udai.Status
 		udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
-		_, err := udai.Update(context.TODO(), p.container.dbs().Writer, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status, models.UserDeviceAPIIntegrationColumns.UpdatedAt))
+		_, err = udai.Update(context.TODO(), tx, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status, models.UserDeviceAPIIntegrationColumns.UpdatedAt))
 		if err != nil {
 			return err
 		}
+		if err := services.RecordIntegrationStatusChange(context.TODO(), tx, udai, fromStatus, services.IntegrationStatusSourceAdmin, "Task stopped by key."); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
 	}
 
 	tt := shared.CloudEvent[any]{
@@ -68,11 +68,21 @@ func (p *web2PairCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interfa
 	eventService := services.NewEventService(&p.logger, &p.settings, producer)
 	deviceDefinitionRegistrar := services.NewDeviceDefinitionRegistrar(producer, &p.settings)
 
-	integ := genericad.NewIntegration(p.pdb.DBS, ddSvc, autoPiIngest, eventService, deviceDefinitionRegistrar, &p.logger)
-	if err := integ.Pair(ctx, amToken, vToken); err != nil {
+	integ := genericad.NewIntegration(ddSvc, autoPiIngest, eventService, deviceDefinitionRegistrar, &p.logger)
+	tx, err := services.BeginEventTx(ctx, p.container.dbs())
+	if err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to start transaction.")
+	}
+	defer tx.Rollback() //nolint
+
+	if err := integ.Pair(ctx, tx, amToken, vToken); err != nil {
 		p.logger.Fatal().Err(err).Msg("Pairing failure.")
 	}
 
+	if err := tx.Commit(); err != nil {
+		p.logger.Fatal().Err(err).Msg("Failed to commit pairing.")
+	}
+
 	p.logger.Info().Msg("Pairing success.")
 
 	return subcommands.ExitSuccess
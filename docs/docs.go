@@ -48,6 +48,241 @@ const docTemplate = `{
                 }
             }
         },
+        "/aftermarket/device/by-serial/{serial}/commands/claim": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the data the user and the device must sign to claim the device.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a claim request for the device to the blockchain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signatures from the user and the device",
+                        "name": "claimRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceClaimRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/aftermarket/device/by-serial/{serial}/commands/pair": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the data that must be signed to pair the device with a vehicle. The vehicle owner\nalways signs; if the device belongs to someone else, the device must sign too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Token id of the vehicle to pair with",
+                        "name": "vehicleTokenId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a request to pair the device with a vehicle to the blockchain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vehicle and signatures",
+                        "name": "pairRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AftermarketDevicePairRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/aftermarket/device/by-serial/{serial}/commands/unpair": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the data that the owner of the device or of its vehicle must sign to unpair them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a request to unpair the device from its vehicle to the blockchain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "AutoPi unit id or Macaron serial number",
+                        "name": "serial",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signature",
+                        "name": "unpairRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AftermarketDeviceUnpairRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
         "/countries": {
             "get": {
                 "description": "Returns all the supported countries",
@@ -294,7 +529,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Complete Tesla auth and get devices for authenticated user",
+                "description": "Complete OAuth for an integration and get the vehicles that the user granted access to. The\ncredentials are held briefly so that the next registration or reauthentication call can use them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -482,74 +717,259 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/devices/me": {
-            "get": {
+        "/user/devices/fromvin/batch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "gets all devices associated with current user - pulled from token",
+                "description": "Adds many devices to a user by decoding their VINs. The body is either JSON or a CSV with\na header row naming the columns vin, countryCode and, optionally, canProtocol. Rows are\nprocessed in the background; poll the returned job for the outcome of each one.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "user-devices"
                 ],
+                "parameters": [
+                    {
+                        "description": "VINs and countries to register",
+                        "name": "vehicles",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.RegisterUserDevicesVINBatch"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
+                            "$ref": "#/definitions/internal_controllers.VINRegistrationJobResp"
                         }
+                    },
+                    "400": {
+                        "description": "validation failure"
                     }
                 }
             }
         },
-        "/user/devices/shared": {
+        "/user/devices/fromvin/batch/{jobID}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "gets all devices shared with current user - pulled from token",
+                "description": "Gets the progress of a batch VIN registration, with the outcome of each row so far.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "user-devices"
                 ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "jobID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
+                            "$ref": "#/definitions/internal_controllers.VINRegistrationJobResp"
                         }
+                    },
+                    "404": {
+                        "description": "no job with that id found"
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}": {
-            "delete": {
+        "/user/devices/me": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete the user device record (hard delete)",
+                "description": "gets all devices associated with current user - pulled from token",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "user-devices"
                 ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "device id",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/devices/mint/batch": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the data the user must sign to mint each of their unminted vehicles, up to 100 at a time.\nVehicles that can't be minted yet, for example because their VIN isn't confirmed, are listed\nseparately with the reason.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated user device ids to restrict the batch to",
+                        "name": "userDeviceIds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchPayloadsResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a mint request to the blockchain for each signed vehicle. Each signature is checked\nas for the single-vehicle mint; vehicles that fail are rejected without affecting the rest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "parameters": [
+                    {
+                        "description": "Signatures and NFT data, one per vehicle",
+                        "name": "mintRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchStatusResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/devices/mint/batch/{batchID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Shows the status of the transactions sent for a bulk mint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "batchID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MintBatchStatusResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/devices/shared": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "gets all devices shared with current user - pulled from token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.MyDevicesResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/devices/{userDeviceID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete the user device record (hard delete)",
+                "tags": [
+                    "user-devices"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "device id",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "204": {
                         "description": "No Content"
@@ -557,6 +977,91 @@ const docTemplate = `{
                 }
             }
         },
+        "/user/devices/{userDeviceID}/commands": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the commands sent to the device, newest first. Results can be filtered and are paginated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "command"
+                ],
+                "summary": "List the device's command history.",
+                "operationId": "list-command-requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "userDeviceID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands with this path, e.g., doors/unlock",
+                        "name": "command",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Pending",
+                            "Complete",
+                            "Failed",
+                            "TimedOut",
+                            "Cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Only commands in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands sent through this integration",
+                        "name": "integrationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands created at or after this RFC 3339 time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands created before this RFC 3339 time",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandRequestHistoryResp"
+                        }
+                    }
+                }
+            }
+        },
         "/user/devices/{userDeviceID}/commands/mint": {
             "get": {
                 "security": [
@@ -612,6 +1117,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_controllers.VehicleMintRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -922,6 +1433,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/internal_controllers.BurnSyntheticDeviceRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -937,30 +1454,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/doors/lock": {
-            "post": {
-                "description": "Lock the device's doors.",
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/mint": {
+            "get": {
+                "description": "Produces the payload that the user signs and submits to mint a synthetic device for\nthe given vehicle and integration.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "integrations"
                 ],
-                "summary": "Lock the device's doors",
-                "operationId": "lock-doors",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
+                        "type": "integer",
+                        "description": "user device KSUID",
                         "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Integration ID",
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based",
                         "name": "integrationID",
                         "in": "path",
                         "required": true
@@ -970,54 +1483,63 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/apitypes.TypedData"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/doors/unlock": {
+            },
             "post": {
-                "description": "Unlock the device's doors.",
+                "description": "Submit a metadata",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "integrations"
                 ],
-                "summary": "Unlock the device's doors",
-                "operationId": "unlock-doors",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device ID",
+                        "type": "integer",
+                        "description": "user device KSUID, must be minted",
                         "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Integration ID",
+                        "type": "integer",
+                        "description": "integration KSUD, must be software-based",
                         "name": "integrationID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    {
+                        "description": "only field is the signed EIP-712",
+                        "name": "signed",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.MintSyntheticDeviceRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
-                }
-            }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/frunk/open": {
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/telemetry/subscribe": {
             "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+                "description": "Subscribe vehicle for Telemetry Data. Currently, this only works for Teslas connected through Tesla.",
                 "produces": [
                     "application/json"
                 ],
@@ -1026,8 +1548,8 @@ const docTemplate = `{
                     "integration",
                     "command"
                 ],
-                "summary": "Open the device's front trunk",
-                "operationId": "open-frunk",
+                "summary": "Subscribe vehicle for Telemetry Data",
+                "operationId": "telemetry-subscribe",
                 "parameters": [
                     {
                         "type": "string",
@@ -1044,96 +1566,72 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
-                        }
-                    }
-                }
+                "responses": {}
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/mint": {
-            "get": {
-                "description": "Produces the payload that the user signs and submits to mint a synthetic device for\nthe given vehicle and integration.",
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/{command}": {
+            "post": {
+                "description": "Send a command, such as \"doors/unlock\", through the given integration. Which commands are\navailable depends on the integration and the vehicle. Commands that take arguments,\n\"charge/limit\" and \"climate/start\", read them from the JSON body.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "integrations"
+                    "device",
+                    "integration",
+                    "command"
                 ],
+                "summary": "Send a command to the device",
+                "operationId": "enqueue-command",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user device KSUID",
+                        "type": "string",
+                        "description": "Device ID",
                         "name": "userDeviceID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "integration KSUD, must be software-based",
+                        "type": "string",
+                        "description": "Integration ID",
                         "name": "integrationID",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/apitypes.TypedData"
-                            }
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Submit a metadata",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "integrations"
-                ],
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "user device KSUID, must be minted",
-                        "name": "userDeviceID",
-                        "in": "path",
-                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "integration KSUD, must be software-based",
-                        "name": "integrationID",
+                        "type": "string",
+                        "description": "Command path, e.g., doors/unlock",
+                        "name": "command",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "only field is the signed EIP-712",
-                        "name": "signed",
+                        "description": "Arguments for commands such as charge/limit and climate/start",
+                        "name": "params",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.MintSyntheticDeviceRequest"
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.CommandParams"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/telemetry/subscribe": {
-            "post": {
-                "description": "Subscribe vehicle for Telemetry Data. Currently, this only works for Teslas connected through Tesla.",
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/{requestID}": {
+            "get": {
+                "description": "Get the status of a submitted command by request id.",
                 "produces": [
                     "application/json"
                 ],
@@ -1142,8 +1640,8 @@ const docTemplate = `{
                     "integration",
                     "command"
                 ],
-                "summary": "Subscribe vehicle for Telemetry Data",
-                "operationId": "telemetry-subscribe",
+                "summary": "Get the status of a submitted command.",
+                "operationId": "get-command-request-status",
                 "parameters": [
                     {
                         "type": "string",
@@ -1158,14 +1656,26 @@ const docTemplate = `{
                         "name": "integrationID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "responses": {}
-            }
-        },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/trunk/open": {
-            "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandRequestStatusResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel a submitted command that has not yet received a status. Only pending commands can be cancelled.",
                 "produces": [
                     "application/json"
                 ],
@@ -1174,8 +1684,8 @@ const docTemplate = `{
                     "integration",
                     "command"
                 ],
-                "summary": "Open the device's rear trunk",
-                "operationId": "open-trunk",
+                "summary": "Cancel a pending command.",
+                "operationId": "cancel-command-request",
                 "parameters": [
                     {
                         "type": "string",
@@ -1190,31 +1700,41 @@ const docTemplate = `{
                         "name": "integrationID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.CommandRequestStatusResp"
                         }
                     }
                 }
             }
         },
-        "/user/devices/{userDeviceID}/integrations/{integrationID}/commands/{requestID}": {
+        "/user/devices/{userDeviceID}/integrations/{integrationID}/history": {
             "get": {
-                "description": "Get the status of a submitted command by request id.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every status the integration has moved through, newest first, along with its current status and how long it has been in it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "integrations"
                 ],
-                "summary": "Get the status of a submitted command.",
-                "operationId": "get-command-request-status",
+                "summary": "List an integration's status changes.",
+                "operationId": "get-user-device-integration-history",
                 "parameters": [
                     {
                         "type": "string",
@@ -1231,18 +1751,23 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Command request ID",
-                        "name": "requestID",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandRequestStatusResp"
+                            "$ref": "#/definitions/internal_controllers.IntegrationStatusHistoryResp"
                         }
                     }
                 }
@@ -1451,110 +1976,334 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/vehicle/{tokenID}/commands/burn": {
+        "/user/vehicle/{tokenID}/commands/burn": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the data the user must sign in order to burn the device.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/apitypes.TypedData"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a burn device request to the blockchain",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "token id",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Signature",
+                        "name": "burnRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.BurnRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/vehicle/{tokenID}/privileges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every address holding an unexpired privilege on the vehicle, along with the privilege ids and their expiries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-devices"
+                ],
+                "summary": "List the privileges granted on the vehicle.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.VehiclePrivilegesResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/aftermarket-device": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as the by-serial route, for holders of a privilege token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "integrations"
+                ],
+                "summary": "Get the aftermarket device paired with the vehicle.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.AutoPiDeviceInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenID}/commands": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the data the user must sign in order to burn the device.",
+                "description": "Lists the commands sent to the vehicle, newest first. Results can be filtered and are paginated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "device",
+                    "command"
+                ],
+                "summary": "List the vehicle's command history.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "token id",
+                        "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands with this path, e.g., doors/unlock",
+                        "name": "command",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "Pending",
+                            "Complete",
+                            "Failed",
+                            "TimedOut",
+                            "Cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Only commands in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands sent through this integration",
+                        "name": "integrationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands created at or after this RFC 3339 time",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only commands created before this RFC 3339 time",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/apitypes.TypedData"
+                            "$ref": "#/definitions/internal_controllers.CommandRequestHistoryResp"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/vehicle/{tokenID}/commands/{command}": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Send a command, such as \"doors/unlock\", to the vehicle. Which commands are available\ndepends on the vehicle's active integration. Commands that take arguments, \"charge/limit\"\nand \"climate/start\", read them from the JSON body.",
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Sends a burn device request to the blockchain",
+                "tags": [
+                    "device",
+                    "integration",
+                    "command"
+                ],
+                "summary": "Send a command to the vehicle",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "token id",
+                        "type": "string",
+                        "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Signature",
-                        "name": "burnRequest",
+                        "type": "string",
+                        "description": "Command path, e.g., doors/unlock",
+                        "name": "command",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Arguments for commands such as charge/limit and climate/start",
+                        "name": "params",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.BurnRequest"
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.CommandParams"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                        }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/doors/lock": {
-            "post": {
-                "description": "Lock the device's doors.",
+        "/vehicle/{tokenID}/commands/{requestID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the status of a command sent to the vehicle, by request id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "device",
-                    "integration",
                     "command"
                 ],
-                "summary": "Lock the device's doors",
+                "summary": "Get the status of a submitted command.",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Command request ID",
+                        "name": "requestID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.CommandRequestStatusResp"
                         }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/doors/unlock": {
-            "post": {
-                "description": "Unlock the device's doors.",
+        "/vehicle/{tokenID}/error-codes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as the user device route, for holders of a privilege token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "error-codes"
                 ],
-                "summary": "Unlock the device's doors",
+                "summary": "List all error code queries made for this vehicle.",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
@@ -1565,69 +2314,101 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.GetUserDeviceErrorCodeQueriesResponse"
                         }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/frunk/open": {
-            "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+        "/vehicle/{tokenID}/integrations/{integrationID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as the user device route, for holders of a privilege token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "integrations"
                 ],
-                "summary": "Open the device's front trunk",
+                "summary": "Get the status of one of the vehicle's integrations.",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Integration ID",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.GetUserDeviceIntegrationResponse"
                         }
                     }
                 }
             }
         },
-        "/vehicle/{tokenID}/commands/trunk/open": {
-            "post": {
-                "description": "Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.",
+        "/vehicle/{tokenID}/integrations/{integrationID}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Same as the user device route, for holders of a privilege token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "device",
-                    "integration",
-                    "command"
+                    "integrations"
                 ],
-                "summary": "Open the device's rear trunk",
+                "summary": "List an integration's status changes.",
                 "parameters": [
                     {
-                        "type": "string",
+                        "type": "integer",
                         "description": "Token ID",
                         "name": "tokenID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Integration ID",
+                        "name": "integrationID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_controllers.CommandResponse"
+                            "$ref": "#/definitions/internal_controllers.IntegrationStatusHistoryResp"
                         }
                     }
                 }
@@ -1789,6 +2570,21 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_devices-api_internal_services.CommandParams": {
+            "type": "object",
+            "properties": {
+                "chargeLimit": {
+                    "description": "ChargeLimit is the target state of charge, in percent, for charge/limit.",
+                    "type": "number",
+                    "example": 80
+                },
+                "temperature": {
+                    "description": "Temperature is the target cabin temperature, in degrees Celsius, for\nclimate/start. If omitted, the vehicle's last setting is used.",
+                    "type": "number",
+                    "example": 21.5
+                }
+            }
+        },
         "github_com_DIMO-Network_devices-api_internal_services.DeviceAttribute": {
             "type": "object",
             "properties": {
@@ -1998,6 +2794,10 @@ const docTemplate = `{
                 "elasticRegionSynced": {
                     "type": "boolean"
                 },
+                "fingerprintVin": {
+                    "description": "FingerprintVIN is the VIN most recently reported by the paired aftermarket device.",
+                    "type": "string"
+                },
                 "geoDecodedCountry": {
                     "type": "string"
                 },
@@ -2009,6 +2809,96 @@ const docTemplate = `{
                 },
                 "powertrainType": {
                     "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services.PowertrainType"
+                },
+                "vinMismatch": {
+                    "description": "VINMismatch is set when FingerprintVIN differs from the VIN we have for the vehicle.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_DIMO-Network_devices-api_internal_services_geofence.Schedule": {
+            "type": "object",
+            "properties": {
+                "timeZone": {
+                    "description": "TimeZone is an IANA time zone name, such as \"America/New_York\".",
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "windows": {
+                    "description": "Windows are the times during which the fence is active.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services_geofence.Window"
+                    }
+                }
+            }
+        },
+        "github_com_DIMO-Network_devices-api_internal_services_geofence.Window": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Days the window starts on, from \"Mon\" to \"Sun\". Empty means every day.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Mon",
+                        "Tue",
+                        "Wed",
+                        "Thu",
+                        "Fri"
+                    ]
+                },
+                "end": {
+                    "description": "End is a local time of the form \"15:04\".",
+                    "type": "string",
+                    "example": "06:00"
+                },
+                "start": {
+                    "description": "Start is a local time of the form \"15:04\".",
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDeviceClaimRequest": {
+            "type": "object",
+            "properties": {
+                "aftermarketDeviceSignature": {
+                    "description": "AftermarketDeviceSignature is the device's own signature of the same data.",
+                    "type": "string"
+                },
+                "userSignature": {
+                    "description": "UserSignature is the new owner's signature of the claim typed data, hex-encoded.",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.AftermarketDevicePairRequest": {
+            "type": "object",
+            "properties": {
+                "aftermarketDeviceSignature": {
+                    "description": "AftermarketDeviceSignature is the device's own signature of the same data. It is\nrequired only if the device and the vehicle have different owners.",
+                    "type": "string"
+                },
+                "userSignature": {
+                    "description": "UserSignature is the vehicle owner's signature of the pair typed data, hex-encoded.",
+                    "type": "string"
+                },
+                "vehicleTokenId": {
+                    "description": "VehicleTokenID is the vehicle NFT to pair with.",
+                    "type": "number",
+                    "example": 37
+                }
+            }
+        },
+        "internal_controllers.AftermarketDeviceUnpairRequest": {
+            "type": "object",
+            "properties": {
+                "userSignature": {
+                    "description": "UserSignature is the signature of the unpair typed data, by the owner of either the\ndevice or the vehicle, hex-encoded.",
+                    "type": "string"
                 }
             }
         },
@@ -2112,6 +3002,30 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.CommandRequestHistoryResp": {
+            "type": "object",
+            "properties": {
+                "commands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.CommandRequestStatusResp"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "Total is the number of requests matching the filters, across all pages.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
         "internal_controllers.CommandRequestStatusResp": {
             "type": "object",
             "properties": {
@@ -2123,16 +3037,36 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2022-08-09T19:38:39Z"
                 },
+                "failureReason": {
+                    "description": "FailureReason explains why the command failed or timed out.",
+                    "type": "string",
+                    "example": "Vehicle is asleep."
+                },
                 "id": {
                     "type": "string",
                     "example": "2D8LqUHQtaMHH6LYPqznmJMBeZm"
                 },
+                "integrationId": {
+                    "type": "string",
+                    "example": "26A5Dk3vvvQutjSyF0Jka2DP5lg"
+                },
+                "requester": {
+                    "description": "Requester is the subject of the token used to send the command: a user id\nor, for privilege tokens, the grantee's address.",
+                    "type": "string",
+                    "example": "ChFrc3VpZC0xMjM0NTY3ODkwEgRnb29nbGU"
+                },
+                "result": {
+                    "description": "Result is the integration's response to the command, if it sent one.",
+                    "type": "object"
+                },
                 "status": {
                     "type": "string",
                     "enum": [
                         "Pending",
                         "Complete",
-                        "Failed"
+                        "Failed",
+                        "TimedOut",
+                        "Cancelled"
                     ],
                     "example": "Complete"
                 },
@@ -2189,6 +3123,14 @@ const docTemplate = `{
         "internal_controllers.CreateGeofence": {
             "type": "object",
             "properties": {
+                "activeFrom": {
+                    "description": "Optionally make the fence take effect at a later time\nrequired: false",
+                    "type": "string"
+                },
+                "activeUntil": {
+                    "description": "Optionally make the fence stop taking effect at some time\nrequired: false",
+                    "type": "string"
+                },
                 "h3Indexes": {
                     "description": "required: false",
                     "type": "array",
@@ -2200,6 +3142,22 @@ const docTemplate = `{
                     "description": "required: true",
                     "type": "string"
                 },
+                "schedule": {
+                    "description": "Optionally limit the fence to certain times of the week\nrequired: false",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services_geofence.Schedule"
+                        }
+                    ]
+                },
+                "shape": {
+                    "description": "Shape is an alternative to h3Indexes: the server converts it to H3 cells.\nrequired: false",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.GeofenceShape"
+                        }
+                    ]
+                },
                 "type": {
                     "description": "one of following: \"PrivacyFence\", \"TriggerEntry\", \"TriggerExit\"\nrequired: true",
                     "type": "string"
@@ -2210,6 +3168,13 @@ const docTemplate = `{
                     "items": {
                         "type": "string"
                     }
+                },
+                "vehicleTokenIds": {
+                    "description": "Optionally link the geofence with vehicles owned by the user's wallet",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
@@ -2286,14 +3251,82 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "tokenId": {
+                    "type": "integer"
+                },
                 "userDeviceId": {
                     "type": "string"
                 }
             }
         },
+        "internal_controllers.GeoJSONPolygon": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "description": "required: true",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "array",
+                            "items": {
+                                "type": "number"
+                            }
+                        }
+                    }
+                },
+                "type": {
+                    "description": "required: true",
+                    "type": "string",
+                    "example": "Polygon"
+                }
+            }
+        },
+        "internal_controllers.GeofenceCircle": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "description": "required: true",
+                    "type": "number",
+                    "example": 37.7749
+                },
+                "longitude": {
+                    "description": "required: true",
+                    "type": "number",
+                    "example": -122.4194
+                },
+                "radiusMeters": {
+                    "description": "required: true",
+                    "type": "number",
+                    "example": 500
+                }
+            }
+        },
+        "internal_controllers.GeofenceShape": {
+            "type": "object",
+            "properties": {
+                "circle": {
+                    "$ref": "#/definitions/internal_controllers.GeofenceCircle"
+                },
+                "polygon": {
+                    "$ref": "#/definitions/internal_controllers.GeoJSONPolygon"
+                },
+                "resolution": {
+                    "description": "required: true",
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
         "internal_controllers.GetGeofence": {
             "type": "object",
             "properties": {
+                "activeFrom": {
+                    "type": "string"
+                },
+                "activeUntil": {
+                    "type": "string"
+                },
                 "createdAt": {
                     "type": "string"
                 },
@@ -2309,6 +3342,17 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "schedule": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_devices-api_internal_services_geofence.Schedule"
+                },
+                "shape": {
+                    "description": "Shape is the area the fence was created from, if it wasn't given as\na list of H3 indexes.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/internal_controllers.GeofenceShape"
+                        }
+                    ]
+                },
                 "type": {
                     "type": "string"
                 },
@@ -2379,6 +3423,67 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.IntegrationStatusHistoryResp": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "status": {
+                    "description": "Status is the integration's current status. It is absent if the\nintegration has since been removed.",
+                    "type": "string",
+                    "example": "AuthenticationFailure"
+                },
+                "statusSince": {
+                    "description": "StatusSince is when the integration entered its current status.",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total is the number of transitions across all pages.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.IntegrationStatusTransition"
+                    }
+                }
+            }
+        },
+        "internal_controllers.IntegrationStatusTransition": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "description": "FromStatus is absent when the integration was created.",
+                    "type": "string",
+                    "example": "Active"
+                },
+                "reason": {
+                    "description": "Reason explains the change, if we know why it happened.",
+                    "type": "string",
+                    "example": "Polling task reported an authentication failure."
+                },
+                "source": {
+                    "description": "Source is the part of the system that made the change, such as \"task\" or \"reauthentication\".",
+                    "type": "string",
+                    "example": "task"
+                },
+                "toStatus": {
+                    "description": "ToStatus is absent when the integration was removed.",
+                    "type": "string",
+                    "example": "AuthenticationFailure"
+                }
+            }
+        },
         "internal_controllers.ManufacturerInfo": {
             "type": "object",
             "properties": {
@@ -2390,6 +3495,140 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.MintBatchPayload": {
+            "type": "object",
+            "properties": {
+                "typedData": {
+                    "$ref": "#/definitions/apitypes.TypedData"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchPayloadsResp": {
+            "type": "object",
+            "properties": {
+                "skipped": {
+                    "description": "Skipped lists the requested vehicles that can't be minted, and why.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchSkip"
+                    }
+                },
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchPayload"
+                    }
+                }
+            }
+        },
+        "internal_controllers.MintBatchRequest": {
+            "type": "object",
+            "properties": {
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchVehicle"
+                    }
+                }
+            }
+        },
+        "internal_controllers.MintBatchSkip": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchStatusResp": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "Counts gives the number of vehicles in each status.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.MintBatchVehicleStatus"
+                    }
+                }
+            }
+        },
+        "internal_controllers.MintBatchVehicle": {
+            "type": "object",
+            "required": [
+                "imageData",
+                "signature"
+            ],
+            "properties": {
+                "imageData": {
+                    "description": "ImageData contains the base64-encoded NFT PNG image.",
+                    "type": "string"
+                },
+                "imageDataTransparent": {
+                    "description": "ImageDataTransparent contains the base64-encoded NFT PNG image\nwith a transparent background, for use in the app. For compatibility\nwith older versions it is not required.",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Signature is the hex encoding of the EIP-712 signature result.",
+                    "type": "string"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.MintBatchVehicleStatus": {
+            "type": "object",
+            "properties": {
+                "failureReason": {
+                    "type": "string"
+                },
+                "hash": {
+                    "description": "Hash is the hash of the transaction, once submitted.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "RequestID is the id of the meta-transaction request. Absent if the vehicle was\nrejected.",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is that of the transaction or, if none was sent, \"Rejected\".",
+                    "type": "string",
+                    "enum": [
+                        "Rejected",
+                        "Unsubmitted",
+                        "Submitted",
+                        "Mined",
+                        "Confirmed",
+                        "Failed"
+                    ]
+                },
+                "tokenId": {
+                    "description": "TokenID is the id of the vehicle NFT, once minted.",
+                    "type": "number"
+                },
+                "userDeviceId": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controllers.MintSyntheticDeviceRequest": {
             "type": "object",
             "properties": {
@@ -2496,7 +3735,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "code": {
-                    "description": "Code is an OAuth authorization code. Not used in all integrations.",
+                    "description": "Code is an OAuth authorization code. Not used in all integrations. For Smartcar, leave this\nempty and set ExternalID to use the credentials from the integration credentials endpoint.",
                     "type": "string"
                 },
                 "expiresIn": {
@@ -2581,6 +3820,17 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.RegisterUserDevicesVINBatch": {
+            "type": "object",
+            "properties": {
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.RegisterUserDeviceVIN"
+                    }
+                }
+            }
+        },
         "internal_controllers.SyntheticDeviceStatus": {
             "type": "object",
             "properties": {
@@ -2799,6 +4049,70 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.VINRegistrationJobResp": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "counts": {
+                    "description": "Counts gives the number of rows in each row status.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.VINRegistrationRowResp"
+                    }
+                },
+                "status": {
+                    "description": "Status is \"Pending\" while rows remain to be processed, then \"Complete\".",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controllers.VINRegistrationRowResp": {
+            "type": "object",
+            "properties": {
+                "countryCode": {
+                    "type": "string"
+                },
+                "failureReason": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "Row is the zero-based position of the row in the submitted list.",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is one of \"Pending\", \"Created\", \"Duplicate\", \"DecodeFailed\" and \"Failed\".",
+                    "type": "string",
+                    "enum": [
+                        "Pending",
+                        "Created",
+                        "Duplicate",
+                        "DecodeFailed",
+                        "Failed"
+                    ]
+                },
+                "userDeviceId": {
+                    "description": "UserDeviceID is the created vehicle or, for a duplicate, the caller's\nexisting vehicle with the same VIN.",
+                    "type": "string"
+                },
+                "vin": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controllers.VehicleMintRequest": {
             "type": "object",
             "required": [
@@ -2869,6 +4183,17 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controllers.VehiclePrivilegesResp": {
+            "type": "object",
+            "properties": {
+                "grantees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_controllers.PrivilegeUser"
+                    }
+                }
+            }
+        },
         "internal_controllers_user_sd.Message": {
             "type": "object",
             "properties": {
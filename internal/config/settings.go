@@ -86,6 +86,8 @@ type Settings struct {
 	DeviceFingerprintConsumerGroup    string `yaml:"DEVICE_FINGERPRINT_CONSUMER_GROUP"`
 	SyntheticFingerprintTopic         string `yaml:"SYNTHETIC_FINGERPRINT_TOPIC"`
 	SyntheticFingerprintConsumerGroup string `yaml:"SYNTHETIC_FINGERPRINT_CONSUMER_GROUP"`
+	DeviceLocationTopic               string `yaml:"DEVICE_LOCATION_TOPIC"`
+	GeofenceConsumerGroup             string `yaml:"GEOFENCE_CONSUMER_GROUP"`
 	TeslaClientID                     string `yaml:"TESLA_CLIENT_ID"`
 	TeslaClientSecret                 string `yaml:"TESLA_CLIENT_SECRET"`
 	TeslaTokenURL                     string `yaml:"TESLA_TOKEN_URL"`
@@ -106,6 +108,55 @@ type Settings struct {
 	DeviceDefinitionsGetByKSUIDEndpoint string `yaml:"DEVICE_DEFINITIONS_GET_BY_KSUID_ENDPOINT"`
 
 	TeslaRequiredScopes string `json:"TESLA_REQUIRED_SCOPES"`
+	// SmartcarRequiredScopes is a comma-separated list of the permissions
+	// that users must grant when connecting through Smartcar.
+	SmartcarRequiredScopes string `yaml:"SMARTCAR_REQUIRED_SCOPES"`
+
+	// CommandRequestTimeout is how long, as a Go duration string, a command
+	// request may stay pending before it is marked as timed out.
+	CommandRequestTimeout string `yaml:"COMMAND_REQUEST_TIMEOUT"`
+
+	// TokenRefreshWindow is how long, as a Go duration string, before an
+	// integration's access token expires we try to refresh it.
+	TokenRefreshWindow string `yaml:"TOKEN_REFRESH_WINDOW"`
+
+	// IdempotencyKeyWindow is how long, as a Go duration string, an
+	// Idempotency-Key is remembered after its first use.
+	IdempotencyKeyWindow string `yaml:"IDEMPOTENCY_KEY_WINDOW"`
+
+	// SmartcarManagementToken is the Smartcar application management token,
+	// used to verify webhook signatures. Smartcar webhooks aren't accepted
+	// without it.
+	SmartcarManagementToken string `yaml:"SMARTCAR_MANAGEMENT_TOKEN"`
+
+	// CipherBackend selects how stored credentials are encrypted: "kms" or
+	// "aesgcm". If empty, the single KMS key (ROT13 outside of dev and prod)
+	// is used without key ids.
+	CipherBackend string `yaml:"CIPHER_BACKEND"`
+	// CipherKeys is a comma-separated list of id:material pairs. For KMS the
+	// material is a key id or ARN; for AES-GCM it is a base64-encoded key.
+	CipherKeys string `yaml:"CIPHER_KEYS"`
+	// CipherCurrentKeyID is the entry in CipherKeys used for new ciphertext.
+	CipherCurrentKeyID string `yaml:"CIPHER_CURRENT_KEY_ID"`
+
+	// VehicleTransferPolicy decides what happens to the previous owner's
+	// integrations when a vehicle NFT changes hands: "keep" (the default) or
+	// "wipe".
+	VehicleTransferPolicy string `yaml:"VEHICLE_TRANSFER_POLICY"`
+
+	// PrivilegeExpiryNotice is how long, as a Go duration string, before a
+	// vehicle privilege expires that we emit a warning event for it.
+	PrivilegeExpiryNotice string `yaml:"PRIVILEGE_EXPIRY_NOTICE"`
+
+	// DeadLetterTopicPrefix is joined with a consumer's name to get the topic
+	// its failed messages are sent to. If empty, failures are only logged.
+	DeadLetterTopicPrefix string `yaml:"DEAD_LETTER_TOPIC_PREFIX"`
+	// DeadLetterMaxAttempts is how many times a message is tried before it
+	// goes to the dead-letter topic.
+	DeadLetterMaxAttempts int `yaml:"DEAD_LETTER_MAX_ATTEMPTS"`
+	// DeadLetterRetryBackoff is how long, as a Go duration string, to wait
+	// before retrying a failed message. It doubles after each attempt.
+	DeadLetterRetryBackoff string `yaml:"DEAD_LETTER_RETRY_BACKOFF"`
 }
 
 func (s *Settings) IsProduction() bool {
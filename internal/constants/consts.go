@@ -7,8 +7,9 @@ const (
 )
 
 const (
-	SmartCarVendor = "SmartCar"
-	TeslaVendor    = "Tesla"
+	SmartCarVendor      = "SmartCar"
+	TeslaVendor         = "Tesla"
+	SmartcarWebhookPath = "/webhooks/smartcar"
 )
 
 const (
@@ -52,6 +53,10 @@ func (r AutoPiSubStatusEnum) String() string {
 
 const (
 	ChargeLimit        string = "charge/limit"
+	ChargeStart        string = "charge/start"
+	ChargeStop         string = "charge/stop"
+	ClimateStart       string = "climate/start"
+	ClimateStop        string = "climate/stop"
 	FrunkOpen          string = "frunk/open"
 	TrunkOpen          string = "trunk/open"
 	DoorsLock          string = "doors/lock"
@@ -0,0 +1,570 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The handlers in this file start the claim, pair and unpair meta-transactions for an
+// aftermarket device. The resulting on-chain events are what actually change the
+// device's owner and vehicle; see ContractsEventsConsumer.
+
+// AftermarketDeviceClaimRequest carries the signatures for a claim.
+type AftermarketDeviceClaimRequest struct {
+	// UserSignature is the new owner's signature of the claim typed data, hex-encoded.
+	UserSignature string `json:"userSignature"`
+	// AftermarketDeviceSignature is the device's own signature of the same data.
+	AftermarketDeviceSignature string `json:"aftermarketDeviceSignature"`
+}
+
+// AftermarketDevicePairRequest carries the signatures for a pairing.
+type AftermarketDevicePairRequest struct {
+	// VehicleTokenID is the vehicle NFT to pair with.
+	VehicleTokenID *big.Int `json:"vehicleTokenId" swaggertype:"number" example:"37"`
+	// UserSignature is the vehicle owner's signature of the pair typed data, hex-encoded.
+	UserSignature string `json:"userSignature"`
+	// AftermarketDeviceSignature is the device's own signature of the same data. It is
+	// required only if the device and the vehicle have different owners.
+	AftermarketDeviceSignature string `json:"aftermarketDeviceSignature,omitempty"`
+}
+
+// AftermarketDeviceUnpairRequest carries the signature for an unpairing.
+type AftermarketDeviceUnpairRequest struct {
+	// UserSignature is the signature of the unpair typed data, by the owner of either the
+	// device or the vehicle, hex-encoded.
+	UserSignature string `json:"userSignature"`
+}
+
+// GetAftermarketDeviceClaimMessage godoc
+// @Description Returns the data the user and the device must sign to claim the device.
+// @Tags        integrations
+// @Produce     json
+// @Param       serial path     string true "AutoPi unit id or Macaron serial number"
+// @Success     200    {object} apitypes.TypedData
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/claim [get]
+func (udc *UserDevicesController) GetAftermarketDeviceClaimMessage(c *fiber.Ctx) error {
+	ad, err := udc.aftermarketDeviceForCommand(c, udc.DBS().Reader, false, qm.Load(models.AftermarketDeviceRels.ClaimMetaTransactionRequest))
+	if err != nil {
+		return err
+	}
+
+	cads, err := udc.checkAftermarketDeviceClaim(c, ad)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient("")
+
+	return c.JSON(client.GetPayload(cads))
+}
+
+// PostAftermarketDeviceClaim godoc
+// @Description Sends a claim request for the device to the blockchain.
+// @Tags        integrations
+// @Accept      json
+// @Param       serial          path   string                                   true  "AutoPi unit id or Macaron serial number"
+// @Param       claimRequest    body   controllers.AftermarketDeviceClaimRequest true  "Signatures from the user and the device"
+// @Param       Idempotency-Key header string                                   false "Key that makes retries of this request safe"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/claim [post]
+func (udc *UserDevicesController) PostAftermarketDeviceClaim(c *fiber.Ctx) error {
+	logger := helpers.GetLogger(c, udc.log)
+
+	var req AftermarketDeviceClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := udc.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ad, err := udc.aftermarketDeviceForCommand(c, tx, true, qm.Load(models.AftermarketDeviceRels.ClaimMetaTransactionRequest))
+	if err != nil {
+		return err
+	}
+
+	cads, err := udc.checkAftermarketDeviceClaim(c, ad)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient(idempotencymw.Key(c))
+
+	hash, err := client.Hash(cads)
+	if err != nil {
+		return err
+	}
+
+	userSig := common.FromHex(req.UserSignature)
+	if err := verifyOwnerSignature(c.Context(), udc.Settings.MainRPCURL, cads.Owner, hash, userSig); err != nil {
+		return err
+	}
+
+	adSig := common.FromHex(req.AftermarketDeviceSignature)
+	if err := verifyDeviceSignature(ad, hash, adSig); err != nil {
+		return err
+	}
+
+	requestID, err := linkAftermarketDeviceRequest(c.Context(), tx, ad, &ad.ClaimMetaTransactionRequestID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Str("requestId", requestID).Msg("Submitting aftermarket device claim.")
+
+	return udc.sendAftermarketDeviceRequest(c.Context(), requestID, func() error {
+		return client.ClaimAftermarketDeviceSign(requestID, cads.AftermarketDeviceNode, cads.Owner, userSig, adSig)
+	})
+}
+
+// GetAftermarketDevicePairMessage godoc
+// @Description Returns the data that must be signed to pair the device with a vehicle. The vehicle owner
+// @Description always signs; if the device belongs to someone else, the device must sign too.
+// @Tags        integrations
+// @Produce     json
+// @Param       serial         path     string true "AutoPi unit id or Macaron serial number"
+// @Param       vehicleTokenId query    int    true "Token id of the vehicle to pair with"
+// @Success     200            {object} apitypes.TypedData
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/pair [get]
+func (udc *UserDevicesController) GetAftermarketDevicePairMessage(c *fiber.Ctx) error {
+	vehicleTokenID, ok := new(big.Int).SetString(c.Query("vehicleTokenId"), 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse vehicle token id %q.", c.Query("vehicleTokenId")))
+	}
+
+	ad, err := udc.aftermarketDeviceForCommand(c, udc.DBS().Reader, false, qm.Load(models.AftermarketDeviceRels.PairRequest))
+	if err != nil {
+		return err
+	}
+
+	pads, _, err := udc.checkAftermarketDevicePair(c, udc.DBS().Reader, ad, vehicleTokenID)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient("")
+
+	return c.JSON(client.GetPayload(pads))
+}
+
+// PostAftermarketDevicePair godoc
+// @Description Sends a request to pair the device with a vehicle to the blockchain.
+// @Tags        integrations
+// @Accept      json
+// @Param       serial          path   string                                  true  "AutoPi unit id or Macaron serial number"
+// @Param       pairRequest     body   controllers.AftermarketDevicePairRequest true  "Vehicle and signatures"
+// @Param       Idempotency-Key header string                                  false "Key that makes retries of this request safe"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/pair [post]
+func (udc *UserDevicesController) PostAftermarketDevicePair(c *fiber.Ctx) error {
+	logger := helpers.GetLogger(c, udc.log)
+
+	var req AftermarketDevicePairRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if req.VehicleTokenID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Vehicle token id is required.")
+	}
+
+	tx, err := udc.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ad, err := udc.aftermarketDeviceForCommand(c, tx, true, qm.Load(models.AftermarketDeviceRels.PairRequest))
+	if err != nil {
+		return err
+	}
+
+	pads, vehicleOwner, err := udc.checkAftermarketDevicePair(c, tx, ad, req.VehicleTokenID)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient(idempotencymw.Key(c))
+
+	hash, err := client.Hash(pads)
+	if err != nil {
+		return err
+	}
+
+	userSig := common.FromHex(req.UserSignature)
+	if err := verifyOwnerSignature(c.Context(), udc.Settings.MainRPCURL, vehicleOwner, hash, userSig); err != nil {
+		return err
+	}
+
+	sameOwner := common.BytesToAddress(ad.OwnerAddress.Bytes) == vehicleOwner
+
+	var adSig []byte
+	if !sameOwner {
+		adSig = common.FromHex(req.AftermarketDeviceSignature)
+		if err := verifyDeviceSignature(ad, hash, adSig); err != nil {
+			return err
+		}
+	}
+
+	requestID, err := linkAftermarketDeviceRequest(c.Context(), tx, ad, &ad.PairRequestID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Str("requestId", requestID).Int64("vehicleTokenId", pads.VehicleNode.Int64()).Bool("sameOwner", sameOwner).Msg("Submitting aftermarket device pairing.")
+
+	return udc.sendAftermarketDeviceRequest(c.Context(), requestID, func() error {
+		if sameOwner {
+			return client.PairAftermarketDeviceSignSameOwner(requestID, pads.AftermarketDeviceNode, pads.VehicleNode, userSig)
+		}
+		return client.PairAftermarketDeviceSignTwoOwners(requestID, pads.AftermarketDeviceNode, pads.VehicleNode, adSig, userSig)
+	})
+}
+
+// GetAftermarketDeviceUnpairMessage godoc
+// @Description Returns the data that the owner of the device or of its vehicle must sign to unpair them.
+// @Tags        integrations
+// @Produce     json
+// @Param       serial path     string true "AutoPi unit id or Macaron serial number"
+// @Success     200    {object} apitypes.TypedData
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/unpair [get]
+func (udc *UserDevicesController) GetAftermarketDeviceUnpairMessage(c *fiber.Ctx) error {
+	ad, err := udc.aftermarketDeviceForCommand(c, udc.DBS().Reader, false, qm.Load(models.AftermarketDeviceRels.UnpairRequest))
+	if err != nil {
+		return err
+	}
+
+	upads, _, err := udc.checkAftermarketDeviceUnpair(c, udc.DBS().Reader, ad)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient("")
+
+	return c.JSON(client.GetPayload(upads))
+}
+
+// PostAftermarketDeviceUnpair godoc
+// @Description Sends a request to unpair the device from its vehicle to the blockchain.
+// @Tags        integrations
+// @Accept      json
+// @Param       serial          path   string                                    true  "AutoPi unit id or Macaron serial number"
+// @Param       unpairRequest   body   controllers.AftermarketDeviceUnpairRequest true  "Signature"
+// @Param       Idempotency-Key header string                                    false "Key that makes retries of this request safe"
+// @Success     200
+// @Security    BearerAuth
+// @Router      /aftermarket/device/by-serial/{serial}/commands/unpair [post]
+func (udc *UserDevicesController) PostAftermarketDeviceUnpair(c *fiber.Ctx) error {
+	logger := helpers.GetLogger(c, udc.log)
+
+	var req AftermarketDeviceUnpairRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	tx, err := udc.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	ad, err := udc.aftermarketDeviceForCommand(c, tx, true, qm.Load(models.AftermarketDeviceRels.UnpairRequest))
+	if err != nil {
+		return err
+	}
+
+	upads, userAddr, err := udc.checkAftermarketDeviceUnpair(c, tx, ad)
+	if err != nil {
+		return err
+	}
+
+	client := udc.aftermarketDeviceRegistryClient(idempotencymw.Key(c))
+
+	hash, err := client.Hash(upads)
+	if err != nil {
+		return err
+	}
+
+	userSig := common.FromHex(req.UserSignature)
+	if err := verifyOwnerSignature(c.Context(), udc.Settings.MainRPCURL, userAddr, hash, userSig); err != nil {
+		return err
+	}
+
+	requestID, err := linkAftermarketDeviceRequest(c.Context(), tx, ad, &ad.UnpairRequestID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Str("requestId", requestID).Int64("vehicleTokenId", upads.VehicleNode.Int64()).Msg("Submitting aftermarket device unpairing.")
+
+	return udc.sendAftermarketDeviceRequest(c.Context(), requestID, func() error {
+		return client.UnPairAftermarketDeviceSign(requestID, upads.AftermarketDeviceNode, upads.VehicleNode, userSig)
+	})
+}
+
+// aftermarketDeviceForCommand loads the device named by the serial that the owner
+// middleware put in the context. With lock set, the row is locked until the
+// transaction ends, so that two requests can't both start the same operation.
+func (udc *UserDevicesController) aftermarketDeviceForCommand(c *fiber.Ctx, exec boil.ContextExecutor, lock bool, mods ...qm.QueryMod) (*models.AftermarketDevice, error) {
+	mods = append(mods, models.AftermarketDeviceWhere.Serial.EQ(c.Locals("serial").(string)))
+	if lock {
+		mods = append(mods, qm.For("UPDATE"))
+	}
+
+	ad, err := models.AftermarketDevices(mods...).One(c.Context(), exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "AftermarketDevice not minted, or serial is invalid.")
+		}
+		return nil, err
+	}
+
+	return ad, nil
+}
+
+func (udc *UserDevicesController) checkAftermarketDeviceClaim(c *fiber.Ctx, ad *models.AftermarketDevice) (*registry.ClaimAftermarketDeviceSign, error) {
+	if ad.OwnerAddress.Valid {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Device already claimed by %s.", common.BytesToAddress(ad.OwnerAddress.Bytes)))
+	}
+	if metaTransactionInFlight(ad.R.ClaimMetaTransactionRequest) {
+		return nil, fiber.NewError(fiber.StatusConflict, "Claim already in progress.")
+	}
+
+	userAddr, err := udc.requireEthAddr(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &registry.ClaimAftermarketDeviceSign{
+		AftermarketDeviceNode: ad.TokenID.Int(nil),
+		Owner:                 userAddr,
+	}, nil
+}
+
+// checkAftermarketDevicePair returns the pairing message and the vehicle's owner, who
+// must be the user.
+func (udc *UserDevicesController) checkAftermarketDevicePair(c *fiber.Ctx, exec boil.ContextExecutor, ad *models.AftermarketDevice, vehicleTokenID *big.Int) (*registry.PairAftermarketDeviceSign, common.Address, error) {
+	if !ad.OwnerAddress.Valid {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusBadRequest, "Device must be claimed before it can be paired.")
+	}
+	if !ad.VehicleTokenID.IsZero() {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Device already paired with vehicle %d.", ad.VehicleTokenID.Int(nil)))
+	}
+	if metaTransactionInFlight(ad.R.PairRequest) {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusConflict, "Pairing already in progress.")
+	}
+
+	vtid := types.NewNullDecimal(new(decimal.Big).SetBigMantScale(vehicleTokenID, 0))
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(vtid),
+	).One(c.Context(), exec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.Address{}, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("No vehicle with token id %d.", vehicleTokenID))
+		}
+		return nil, common.Address{}, err
+	}
+
+	userAddr, err := udc.requireEthAddr(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	vehicleOwner := common.BytesToAddress(ud.OwnerAddress.Bytes)
+	if userAddr != vehicleOwner {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusForbidden, "Only the vehicle's owner may pair a device with it.")
+	}
+
+	paired, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.VehicleTokenID.EQ(vtid),
+	).Exists(c.Context(), exec)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+	if paired {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Vehicle %d already has a paired device.", vehicleTokenID))
+	}
+
+	return &registry.PairAftermarketDeviceSign{
+		AftermarketDeviceNode: ad.TokenID.Int(nil),
+		VehicleNode:           vehicleTokenID,
+	}, vehicleOwner, nil
+}
+
+// checkAftermarketDeviceUnpair returns the unpairing message and the user's address,
+// which must be that of the owner of the device or of the vehicle.
+func (udc *UserDevicesController) checkAftermarketDeviceUnpair(c *fiber.Ctx, exec boil.ContextExecutor, ad *models.AftermarketDevice) (*registry.UnPairAftermarketDeviceSign, common.Address, error) {
+	if ad.VehicleTokenID.IsZero() {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusBadRequest, "Device is not paired.")
+	}
+	if metaTransactionInFlight(ad.R.UnpairRequest) {
+		return nil, common.Address{}, fiber.NewError(fiber.StatusConflict, "Unpairing already in progress.")
+	}
+
+	userAddr, err := udc.requireEthAddr(c)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	if !ad.OwnerAddress.Valid || common.BytesToAddress(ad.OwnerAddress.Bytes) != userAddr {
+		ownsVehicle, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.EQ(ad.VehicleTokenID),
+			models.UserDeviceWhere.OwnerAddress.EQ(null.BytesFrom(userAddr.Bytes())),
+		).Exists(c.Context(), exec)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		if !ownsVehicle {
+			return nil, common.Address{}, fiber.NewError(fiber.StatusForbidden, "Only the owner of the device or of the vehicle may unpair them.")
+		}
+	}
+
+	return &registry.UnPairAftermarketDeviceSign{
+		AftermarketDeviceNode: ad.TokenID.Int(nil),
+		VehicleNode:           ad.VehicleTokenID.Int(nil),
+	}, userAddr, nil
+}
+
+func (udc *UserDevicesController) requireEthAddr(c *fiber.Ctx) (common.Address, error) {
+	userAddr, hasAddr, err := udc.userAddrGetter.GetEthAddr(c)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !hasAddr {
+		return common.Address{}, fiber.NewError(fiber.StatusForbidden, "User has no Ethereum address on file.")
+	}
+	return userAddr, nil
+}
+
+func (udc *UserDevicesController) aftermarketDeviceRegistryClient(idempotencyKey string) *registry.Client {
+	return &registry.Client{
+		Producer:     udc.producer,
+		RequestTopic: "topic.transaction.request.send",
+		Contract: registry.Contract{
+			ChainID: big.NewInt(udc.Settings.DIMORegistryChainID),
+			Address: common.HexToAddress(udc.Settings.DIMORegistryAddr),
+			Name:    "DIMO",
+			Version: "1",
+		},
+		Idempotency:    udc.idempotencyStore,
+		IdempotencyKey: idempotencyKey,
+	}
+}
+
+// metaTransactionInFlight reports whether the request might still go through. Failed
+// and confirmed requests are finished; a device can be paired and unpaired many times.
+func metaTransactionInFlight(mtr *models.MetaTransactionRequest) bool {
+	return mtr != nil && mtr.Status != models.MetaTransactionRequestStatusFailed && mtr.Status != models.MetaTransactionRequestStatusConfirmed
+}
+
+// linkAftermarketDeviceRequest creates a meta-transaction request, points the given
+// column of the device at it and commits.
+func linkAftermarketDeviceRequest(ctx context.Context, tx *sql.Tx, ad *models.AftermarketDevice, col *null.String) (string, error) {
+	requestID := ksuid.New().String()
+
+	mtr := models.MetaTransactionRequest{
+		ID:     requestID,
+		Status: models.MetaTransactionRequestStatusUnsubmitted,
+	}
+	if err := mtr.Insert(ctx, tx, boil.Infer()); err != nil {
+		return "", err
+	}
+
+	*col = null.StringFrom(requestID)
+	if _, err := ad.Update(ctx, tx, boil.Infer()); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+
+	return requestID, nil
+}
+
+// sendAftermarketDeviceRequest hands a request made by linkAftermarketDeviceRequest to
+// the registry. The request row is already committed, so if the send fails it is marked
+// failed; otherwise it would count as in progress and block every retry.
+func (udc *UserDevicesController) sendAftermarketDeviceRequest(ctx context.Context, requestID string, send func() error) error {
+	sendErr := send()
+	if sendErr == nil {
+		return nil
+	}
+
+	cols := models.MetaTransactionRequestColumns
+
+	if _, err := models.MetaTransactionRequests(
+		models.MetaTransactionRequestWhere.ID.EQ(requestID),
+	).UpdateAll(ctx, udc.DBS().Writer, models.M{
+		cols.Status:        models.MetaTransactionRequestStatusFailed,
+		cols.FailureReason: null.StringFrom("Couldn't submit the transaction."),
+		cols.UpdatedAt:     time.Now(),
+	}); err != nil {
+		udc.log.Err(err).Str("requestId", requestID).Msg("Failed to mark unsent request as failed.")
+	}
+
+	return registryRequestError(sendErr)
+}
+
+// verifyOwnerSignature checks that sig is owner's signature of hash, either directly or,
+// for smart contract wallets, through ERC-1271.
+func verifyOwnerSignature(ctx context.Context, rpcURL string, owner common.Address, hash, sig []byte) error {
+	recAddr, err := helpers.Ecrecover(hash, sig)
+	if err == nil && recAddr == owner {
+		return nil
+	}
+
+	ethClient, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer ethClient.Close()
+
+	sigCon, err := sig2.NewErc1271(owner, ethClient)
+	if err != nil {
+		return err
+	}
+
+	ret, err := sigCon.IsValidSignature(nil, common.BytesToHash(hash), sig)
+	if err != nil || ret != erc1271magicValue {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid user signature.")
+	}
+
+	return nil
+}
+
+// verifyDeviceSignature checks that sig is the device's signature of hash.
+func verifyDeviceSignature(ad *models.AftermarketDevice, hash, sig []byte) error {
+	recAddr, err := helpers.Ecrecover(hash, sig)
+	if err != nil || recAddr != common.BytesToAddress(ad.EthereumAddress) {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid aftermarket device signature.")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	pb "github.com/DIMO-Network/shared/api/users"
+	"github.com/ethereum/go-ethereum/common"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *UserDevicesControllerTestSuite) TestGetAftermarketDeviceClaimMessage() {
+	adAddr := common.HexToAddress("0x00000000000000000000000000000000000a0001")
+	_ = test.SetupCreateMintedAftermarketDevice(s.T(), s.testUserID, "claim-serial-1", big.NewInt(101), adAddr, nil, s.pdb)
+
+	addr := s.testUserEthAddr.Hex()
+	s.usersClient.EXPECT().GetUser(gomock.Any(), &pb.GetUserRequest{Id: s.testUserID}).Return(&pb.User{Id: s.testUserID, EthereumAddress: &addr}, nil)
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/aftermarket/device/by-serial/claim-serial-1/commands/claim", ""))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	var td signer.TypedData
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&td))
+	s.Equal("ClaimAftermarketDeviceSign", td.PrimaryType)
+	s.Equal(s.testUserEthAddr.Hex(), td.Message["owner"])
+}
+
+func (s *UserDevicesControllerTestSuite) TestGetAftermarketDeviceClaimMessageAlreadyClaimed() {
+	adAddr := common.HexToAddress("0x00000000000000000000000000000000000a0002")
+	ad := test.SetupCreateMintedAftermarketDevice(s.T(), s.testUserID, "claim-serial-2", big.NewInt(102), adAddr, nil, s.pdb)
+	ad.OwnerAddress = null.BytesFrom(s.testUserEthAddr.Bytes())
+	_, err := ad.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/aftermarket/device/by-serial/claim-serial-2/commands/claim", ""))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusConflict, res.StatusCode)
+}
+
+func (s *UserDevicesControllerTestSuite) TestGetAftermarketDevicePairMessageUnclaimed() {
+	adAddr := common.HexToAddress("0x00000000000000000000000000000000000a0003")
+	_ = test.SetupCreateMintedAftermarketDevice(s.T(), s.testUserID, "pair-serial-1", big.NewInt(103), adAddr, nil, s.pdb)
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/aftermarket/device/by-serial/pair-serial-1/commands/pair?vehicleTokenId=7", ""))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusBadRequest, res.StatusCode)
+}
+
+func (s *UserDevicesControllerTestSuite) TestSendAftermarketDeviceRequestFailure() {
+	adAddr := common.HexToAddress("0x00000000000000000000000000000000000a0004")
+	ad := test.SetupCreateMintedAftermarketDevice(s.T(), s.testUserID, "claim-serial-3", big.NewInt(104), adAddr, nil, s.pdb)
+
+	tx, err := s.pdb.DBS().Writer.BeginTx(s.ctx, nil)
+	s.Require().NoError(err)
+	defer tx.Rollback() //nolint
+
+	requestID, err := linkAftermarketDeviceRequest(s.ctx, tx, ad, &ad.ClaimMetaTransactionRequestID)
+	s.Require().NoError(err)
+
+	err = s.controller.sendAftermarketDeviceRequest(s.ctx, requestID, func() error {
+		return errors.New("kafka is down")
+	})
+	s.Require().Error(err)
+
+	mtr, err := models.FindMetaTransactionRequest(s.ctx, s.pdb.DBS().Reader, requestID)
+	s.Require().NoError(err)
+	s.Equal(models.MetaTransactionRequestStatusFailed, mtr.Status)
+
+	// So a retry isn't turned away as a claim in progress.
+	s.False(metaTransactionInFlight(mtr))
+}
@@ -198,9 +198,11 @@ func (udc *UserDevicesController) QueryDeviceErrorCodes(c *fiber.Ctx) error {
 // @Security    BearerAuth
 // @Router      /user/devices/{userDeviceID}/error-codes [get]
 func (udc *UserDevicesController) GetUserDeviceErrorCodeQueries(c *fiber.Ctx) error {
-	logger := helpers.GetLogger(c, udc.log)
+	return udc.getUserDeviceErrorCodeQueries(c, c.Params("userDeviceID"))
+}
 
-	userDeviceID := c.Params("userDeviceID")
+func (udc *UserDevicesController) getUserDeviceErrorCodeQueries(c *fiber.Ctx, userDeviceID string) error {
+	logger := helpers.GetLogger(c, udc.log)
 
 	userDevice, err := models.UserDevices(
 		models.UserDeviceWhere.ID.EQ(userDeviceID),
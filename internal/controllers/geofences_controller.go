@@ -5,17 +5,21 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"slices"
 	"time"
 
 	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/h3"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	geofencesvc "github.com/DIMO-Network/devices-api/internal/services/geofence"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/api/users"
 	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/shared/dbtypes"
 	"github.com/IBM/sarama"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
@@ -91,17 +95,31 @@ func (g *GeofencesController) Create(c *fiber.Ctx) error {
 		return helpers.ErrorResponseHandler(c, errors.New("Geofence with that name already exists for this user"), fiber.StatusBadRequest)
 	}
 
-	uds, err := g.createDeviceList(c, tx, userID, create.UserDeviceIDs)
+	uds, err := g.createDeviceList(c, tx, userID, create.UserDeviceIDs, create.VehicleTokenIDs)
+	if err != nil {
+		return err
+	}
+
+	indexes, shape, err := create.area()
+	if err != nil {
+		return err
+	}
+
+	schedule, err := create.schedule()
 	if err != nil {
 		return err
 	}
 
 	geofence := models.Geofence{
-		ID:        ksuid.New().String(),
-		UserID:    userID,
-		Name:      create.Name,
-		Type:      create.Type,
-		H3Indexes: create.H3Indexes,
+		ID:          ksuid.New().String(),
+		UserID:      userID,
+		Name:        create.Name,
+		Type:        create.Type,
+		H3Indexes:   indexes,
+		Shape:       shape,
+		Schedule:    schedule,
+		ActiveFrom:  null.TimeFromPtr(create.ActiveFrom),
+		ActiveUntil: null.TimeFromPtr(create.ActiveUntil),
 	}
 	err = geofence.Insert(c.Context(), tx, boil.Infer())
 	if err != nil {
@@ -150,11 +168,19 @@ func (g *GeofencesController) EmitPrivacyFenceUpdates(ctx context.Context, db bo
 	}
 
 	indexes := shared.NewStringSet()
+	now := time.Now()
 
 	for _, rel := range rels {
 		if rel.R.Geofence.Type != models.GeofenceTypePrivacyFence {
 			continue
 		}
+		if active, err := geofencesvc.IsActive(rel.R.Geofence, now); err != nil {
+			// Don't let one broken fence hold back the vehicle's others.
+			g.log.Err(err).Str("geofenceId", rel.GeofenceID).Str("userDeviceId", userDeviceID).Msg("Skipping geofence with an invalid schedule.")
+			continue
+		} else if !active {
+			continue
+		}
 		for _, index := range rel.R.Geofence.H3Indexes {
 			indexes.Add(index)
 		}
@@ -265,12 +291,24 @@ func (g *GeofencesController) GetAll(c *fiber.Ctx) error {
 	fences := make([]GetGeofence, len(items))
 	for i, item := range items {
 		f := GetGeofence{
-			ID:        item.ID,
-			Name:      item.Name,
-			Type:      item.Type,
-			H3Indexes: item.H3Indexes,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
+			ID:          item.ID,
+			Name:        item.Name,
+			Type:        item.Type,
+			H3Indexes:   item.H3Indexes,
+			ActiveFrom:  item.ActiveFrom.Ptr(),
+			ActiveUntil: item.ActiveUntil.Ptr(),
+			CreatedAt:   item.CreatedAt,
+			UpdatedAt:   item.UpdatedAt,
+		}
+		if item.Shape.Valid {
+			if err := item.Shape.Unmarshal(&f.Shape); err != nil {
+				return errors.Wrapf(err, "error parsing shape of geofence %s", item.ID)
+			}
+		}
+		if item.Schedule.Valid {
+			if err := item.Schedule.Unmarshal(&f.Schedule); err != nil {
+				return errors.Wrapf(err, "error parsing schedule of geofence %s", item.ID)
+			}
 		}
 		for _, udtg := range item.R.UserDeviceToGeofences {
 			var deviceDef *ddgrpc.GetDeviceDefinitionItemResponse
@@ -279,11 +317,15 @@ func (g *GeofencesController) GetAll(c *fiber.Ctx) error {
 					deviceDef = dd
 				}
 			}
-			f.UserDevices = append(f.UserDevices, GeoFenceUserDevice{
+			gfud := GeoFenceUserDevice{
 				UserDeviceID: udtg.UserDeviceID,
 				Name:         udtg.R.UserDevice.Name.Ptr(),
 				MMY:          deviceDef.Name,
-			})
+			}
+			if tokenID, ok := udtg.R.UserDevice.TokenID.Int64(); ok {
+				gfud.TokenID = &tokenID
+			}
+			f.UserDevices = append(f.UserDevices, gfud)
 		}
 		fences[i] = f
 	}
@@ -336,27 +378,46 @@ func (g *GeofencesController) Update(c *fiber.Ctx) error {
 		affectedDeviceIDs = append(affectedDeviceIDs, rel.UserDeviceID)
 	}
 
+	indexes, shape, err := update.area()
+	if err != nil {
+		return err
+	}
+
+	schedule, err := update.schedule()
+	if err != nil {
+		return err
+	}
+
 	geofence.Name = update.Name
 	geofence.Type = update.Type
-	geofence.H3Indexes = update.H3Indexes
+	geofence.H3Indexes = indexes
+	geofence.Shape = shape
+	geofence.Schedule = schedule
+	geofence.ActiveFrom = null.TimeFromPtr(update.ActiveFrom)
+	geofence.ActiveUntil = null.TimeFromPtr(update.ActiveUntil)
 
 	_, err = geofence.Update(c.Context(), tx, boil.Whitelist(
 		models.GeofenceColumns.Name,
 		models.GeofenceColumns.Type,
 		models.GeofenceColumns.H3Indexes,
+		models.GeofenceColumns.Shape,
+		models.GeofenceColumns.Schedule,
+		models.GeofenceColumns.ActiveFrom,
+		models.GeofenceColumns.ActiveUntil,
 		models.GeofenceColumns.UpdatedAt))
 	if err != nil {
 		return errors.Wrap(err, "error updating geofence")
 	}
 
-	uds, err := g.createDeviceList(c, tx, userID, affectedDeviceIDs)
+	uds, err := g.createDeviceList(c, tx, userID, affectedDeviceIDs, update.VehicleTokenIDs)
 	if err != nil {
 		return err
 	}
 
-	for _, uID := range update.UserDeviceIDs {
+	// Vehicles that were already attached get a no-op upsert.
+	for _, ud := range uds {
 		geoToUser := models.UserDeviceToGeofence{
-			UserDeviceID: uID,
+			UserDeviceID: ud.ID,
 			GeofenceID:   geofence.ID,
 		}
 
@@ -385,12 +446,14 @@ func (g *GeofencesController) Update(c *fiber.Ctx) error {
 }
 
 // createDeviceList checks that the given user can attach geofences to the vehicles
-// with the given ids, and returns a slice of database objects for those vehicles.
+// with the given user device ids and vehicle token ids, and returns a slice of database
+// objects for those vehicles.
 //
-// Specifically, the vehicles must exist, be minted, and be owned by the user. This function
-// performs deduplication, so the length of the output slice may not match that of
-// the input slice. Errors returned from this function are safe to return to Fiber.
-func (g *GeofencesController) createDeviceList(c *fiber.Ctx, tx *sql.Tx, userID string, userDeviceIDs []string) ([]*models.UserDevice, error) {
+// Specifically, the vehicles must exist, be minted, and be owned by the user. Vehicles
+// named by token id must be owned by the user's wallet, as in owner.VehicleToken. This
+// function performs deduplication, so the length of the output slice may not match that
+// of the input slices. Errors returned from this function are safe to return to Fiber.
+func (g *GeofencesController) createDeviceList(c *fiber.Ctx, tx *sql.Tx, userID string, userDeviceIDs []string, vehicleTokenIDs []int64) ([]*models.UserDevice, error) {
 
 	addr, hasAddr, err := g.ethAddrGetter.GetEthAddr(c)
 	if err != nil {
@@ -436,6 +499,31 @@ func (g *GeofencesController) createDeviceList(c *fiber.Ctx, tx *sql.Tx, userID
 		out = append(out, ud)
 	}
 
+	if len(vehicleTokenIDs) != 0 && !hasAddr {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Add a wallet to your account before attaching geofences to vehicles by token id.")
+	}
+
+	for _, tokenID := range vehicleTokenIDs {
+		ud, err := models.UserDevices(
+			models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(big.NewInt(tokenID))),
+			models.UserDeviceWhere.OwnerAddress.EQ(null.BytesFrom(addr.Bytes())),
+		).One(c.Context(), tx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("User doesn't own a vehicle with token id %d.", tokenID))
+			}
+			return nil, err
+		}
+
+		if seenIDs.Contains(ud.ID) {
+			continue
+		}
+
+		seenIDs.Add(ud.ID)
+
+		out = append(out, ud)
+	}
+
 	return out, nil
 }
 
@@ -487,19 +575,26 @@ func (g *GeofencesController) Delete(c *fiber.Ctx) error {
 }
 
 type GetGeofence struct {
-	ID          string               `json:"id"`
-	Name        string               `json:"name"`
-	Type        string               `json:"type"`
-	H3Indexes   []string             `json:"h3Indexes"`
-	UserDevices []GeoFenceUserDevice `json:"userDevices"`
-	CreatedAt   time.Time            `json:"createdAt"`
-	UpdatedAt   time.Time            `json:"updatedAt"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	H3Indexes []string `json:"h3Indexes"`
+	// Shape is the area the fence was created from, if it wasn't given as
+	// a list of H3 indexes.
+	Shape       *GeofenceShape        `json:"shape,omitempty"`
+	Schedule    *geofencesvc.Schedule `json:"schedule,omitempty"`
+	ActiveFrom  *time.Time            `json:"activeFrom,omitempty"`
+	ActiveUntil *time.Time            `json:"activeUntil,omitempty"`
+	UserDevices []GeoFenceUserDevice  `json:"userDevices"`
+	CreatedAt   time.Time             `json:"createdAt"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
 }
 
 type GeoFenceUserDevice struct {
 	UserDeviceID string  `json:"userDeviceId"`
 	Name         *string `json:"name"`
 	MMY          string  `json:"mmy"`
+	TokenID      *int64  `json:"tokenId,omitempty"`
 }
 
 type CreateGeofence struct {
@@ -510,15 +605,185 @@ type CreateGeofence struct {
 	Type string `json:"type"`
 	// required: false
 	H3Indexes []string `json:"h3Indexes"`
+	// Shape is an alternative to h3Indexes: the server converts it to H3 cells.
+	// required: false
+	Shape *GeofenceShape `json:"shape"`
+	// Optionally limit the fence to certain times of the week
+	// required: false
+	Schedule *geofencesvc.Schedule `json:"schedule"`
+	// Optionally make the fence take effect at a later time
+	// required: false
+	ActiveFrom *time.Time `json:"activeFrom"`
+	// Optionally make the fence stop taking effect at some time
+	// required: false
+	ActiveUntil *time.Time `json:"activeUntil"`
 	// Optionally link the geofence with a list of user device ID
 	UserDeviceIDs []string `json:"userDeviceIds"`
+	// Optionally link the geofence with vehicles owned by the user's wallet
+	VehicleTokenIDs []int64 `json:"vehicleTokenIds"`
 }
 
 func (g *CreateGeofence) Validate() error {
 	return validation.ValidateStruct(g,
 		validation.Field(&g.Name, validation.Required),
 		validation.Field(&g.Type, validation.Required, validation.In("PrivacyFence", "TriggerEntry", "TriggerExit")),
-		// TODO(elffjs): Validate this more. Is it hex? Is it a reasonable length for H3?
-		validation.Field(&g.H3Indexes, validation.Length(0, maxFenceTiles)),
+		validation.Field(&g.H3Indexes, validation.Length(0, maxFenceTiles), validation.Each(validation.By(validateH3Index))),
+		validation.Field(&g.Shape, validation.Nil.When(len(g.H3Indexes) != 0).Error("cannot be combined with h3Indexes")),
+		validation.Field(&g.Schedule),
+		validation.Field(&g.VehicleTokenIDs, validation.Each(validation.Min(int64(1)))),
+		validation.Field(&g.ActiveUntil, validation.By(func(any) error {
+			if g.ActiveFrom != nil && g.ActiveUntil != nil && !g.ActiveUntil.After(*g.ActiveFrom) {
+				return errors.New("must be after activeFrom")
+			}
+			return nil
+		})),
+	)
+}
+
+// schedule returns the request's schedule in the form we store it.
+func (g *CreateGeofence) schedule() (null.JSON, error) {
+	if g.Schedule == nil {
+		return null.JSON{}, nil
+	}
+
+	b, err := json.Marshal(g.Schedule)
+	if err != nil {
+		return null.JSON{}, err
+	}
+
+	return null.JSONFrom(b), nil
+}
+
+// area returns the H3 indexes to store for the fence and, if the request used a
+// shape, the shape itself. Errors are safe to return to Fiber.
+func (g *CreateGeofence) area() ([]string, null.JSON, error) {
+	if g.Shape == nil {
+		return g.H3Indexes, null.JSON{}, nil
+	}
+
+	cells, err := g.Shape.cells()
+	if err != nil {
+		if errors.Is(err, h3.ErrShapeTooLarge) {
+			return nil, null.JSON{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Shape is too large for resolution %d. Try a coarser resolution.", g.Shape.Resolution))
+		}
+		return nil, null.JSON{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid shape: %s.", err))
+	}
+
+	cells = h3.Compact(cells)
+	if len(cells) == 0 {
+		return nil, null.JSON{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Shape doesn't contain any cell centers at resolution %d. Try a finer resolution.", g.Shape.Resolution))
+	}
+	if len(cells) > maxFenceTiles {
+		return nil, null.JSON{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Shape covers %d cells at resolution %d, but fences are limited to %d. Try a coarser resolution.", len(cells), g.Shape.Resolution, maxFenceTiles))
+	}
+
+	indexes := make([]string, len(cells))
+	for i, c := range cells {
+		indexes[i] = c.String()
+	}
+
+	b, err := json.Marshal(g.Shape)
+	if err != nil {
+		return nil, null.JSON{}, err
+	}
+
+	return indexes, null.JSONFrom(b), nil
+}
+
+func validateH3Index(value any) error {
+	s, _ := value.(string)
+	if _, err := h3.ParseCell(s); err != nil {
+		return errors.New("must be a valid H3 index")
+	}
+	return nil
+}
+
+// GeofenceShape is an area given as either a GeoJSON polygon or a circle. The
+// area is stored as the H3 cells at Resolution whose centers lie inside it,
+// compacted where possible.
+type GeofenceShape struct {
+	Polygon *GeoJSONPolygon `json:"polygon,omitempty"`
+	Circle  *GeofenceCircle `json:"circle,omitempty"`
+	// required: true
+	Resolution int `json:"resolution" example:"9"`
+}
+
+func (s GeofenceShape) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Polygon,
+			validation.Required.When(s.Circle == nil).Error("either polygon or circle is required"),
+			validation.Nil.When(s.Circle != nil).Error("cannot be combined with circle"),
+		),
+		validation.Field(&s.Circle),
+		validation.Field(&s.Resolution, validation.Min(0), validation.Max(h3.MaxResolution)),
+	)
+}
+
+func (s *GeofenceShape) cells() ([]h3.Cell, error) {
+	if s.Circle != nil {
+		return h3.CircleToCells(h3.LatLng{Lat: s.Circle.Latitude, Lng: s.Circle.Longitude}, s.Circle.RadiusMeters, s.Resolution)
+	}
+
+	loops := make([][]h3.LatLng, len(s.Polygon.Coordinates))
+	for i, ring := range s.Polygon.Coordinates {
+		// GeoJSON rings repeat the first position at the end.
+		loops[i] = make([]h3.LatLng, len(ring)-1)
+		for j, pos := range ring[:len(ring)-1] {
+			loops[i][j] = h3.LatLng{Lat: pos[1], Lng: pos[0]}
+		}
+	}
+	return h3.PolygonToCells(loops, s.Resolution)
+}
+
+// GeoJSONPolygon is a GeoJSON Polygon geometry. The first ring is the boundary
+// and any others are holes. Positions are [longitude, latitude].
+type GeoJSONPolygon struct {
+	// required: true
+	Type string `json:"type" example:"Polygon"`
+	// required: true
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+func (p GeoJSONPolygon) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Type, validation.Required, validation.In("Polygon")),
+		validation.Field(&p.Coordinates, validation.Required, validation.Each(validation.By(validateLinearRing))),
+	)
+}
+
+func validateLinearRing(value any) error {
+	ring, _ := value.([][]float64)
+	if len(ring) < 4 {
+		return errors.New("rings must have at least four positions")
+	}
+	for _, pos := range ring {
+		if len(pos) < 2 || len(pos) > 3 {
+			return errors.New("positions must be [longitude, latitude]")
+		}
+		if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+			return errors.New("positions must be [longitude, latitude]")
+		}
+	}
+	first, last := ring[0], ring[len(ring)-1]
+	if first[0] != last[0] || first[1] != last[1] {
+		return errors.New("rings must end with their first position")
+	}
+	return nil
+}
+
+type GeofenceCircle struct {
+	// required: true
+	Latitude float64 `json:"latitude" example:"37.7749"`
+	// required: true
+	Longitude float64 `json:"longitude" example:"-122.4194"`
+	// required: true
+	RadiusMeters float64 `json:"radiusMeters" example:"500"`
+}
+
+func (c GeofenceCircle) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Latitude, validation.Min(-90.0), validation.Max(90.0)),
+		validation.Field(&c.Longitude, validation.Min(-180.0), validation.Max(180.0)),
+		validation.Field(&c.RadiusMeters, validation.Required, validation.Min(0.0).Exclusive()),
 	)
 }
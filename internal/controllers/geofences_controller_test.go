@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/DIMO-Network/shared/api/users"
 	"github.com/DIMO-Network/shared/db"
@@ -17,6 +18,7 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/types"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	geofencesvc "github.com/DIMO-Network/devices-api/internal/services/geofence"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
 	"github.com/DIMO-Network/devices-api/internal/test"
 	"github.com/DIMO-Network/devices-api/models"
@@ -133,13 +135,13 @@ func (s *GeofencesControllerTestSuite) TestPostGeofence() {
 	req := CreateGeofence{
 		Name:          "Home",
 		Type:          "PrivacyFence",
-		H3Indexes:     []string{"123", "321"},
+		H3Indexes:     []string{"8928308280fffff", "8928308280bffff"},
 		UserDeviceIDs: []string{ud.ID},
 	}
 	j, _ := json.Marshal(req)
 
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"123", "321"}))
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"123", "321"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"8928308280fffff", "8928308280bffff"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"8928308280fffff", "8928308280bffff"}))
 
 	request := test.BuildRequest("POST", "/user/geofences", string(j))
 	response, _ := app.Test(request)
@@ -151,8 +153,8 @@ func (s *GeofencesControllerTestSuite) TestPostGeofence() {
 	createdID := gjson.Get(string(body), "id").String()
 	assert.Len(s.T(), createdID, 27)
 
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"123", "321"}))
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"123", "321"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"8928308280fffff", "8928308280bffff"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"8928308280fffff", "8928308280bffff"}))
 
 	// create one without h3 indexes required
 	req = CreateGeofence{
@@ -188,13 +190,13 @@ func (s *GeofencesControllerTestSuite) TestPostGeofenceRespectsWallet() {
 	req := CreateGeofence{
 		Name:          "Home",
 		Type:          "PrivacyFence",
-		H3Indexes:     []string{"123", "321"},
+		H3Indexes:     []string{"8928308280fffff", "8928308280bffff"},
 		UserDeviceIDs: []string{ud.ID},
 	}
 	j, _ := json.Marshal(req)
 
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"123", "321"}))
-	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"123", "321"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"8928308280fffff", "8928308280bffff"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.TokenID.String(), []string{"8928308280fffff", "8928308280bffff"}))
 
 	request := test.BuildRequest("POST", "/user/geofences", string(j))
 	response, err := app.Test(request, 60*1000)
@@ -290,7 +292,7 @@ func (s *GeofencesControllerTestSuite) TestPutGeofence() {
 	req := CreateGeofence{
 		Name:          "School",
 		Type:          "TriggerEntry",
-		H3Indexes:     []string{"123", "321", "1234555"},
+		H3Indexes:     []string{"8928308280fffff", "8928308280bffff", "89283082847ffff"},
 		UserDeviceIDs: nil,
 	}
 	j, _ := json.Marshal(req)
@@ -333,3 +335,192 @@ func (s *GeofencesControllerTestSuite) TestDeleteGeofence() {
 
 	_ = producer.Close()
 }
+
+func (s *GeofencesControllerTestSuite) TestEmitPrivacyFenceUpdatesSkipsInvalidSchedule() {
+	userID := ksuid.New().String()
+	producer := saramamocks.NewSyncProducer(s.T(), sarama.NewConfig())
+	c := NewGeofencesController(&config.Settings{Port: "3000"}, s.pdb.DBS, s.logger, producer, s.deviceDefSvc, nil)
+
+	ud := test.SetupCreateUserDevice(s.T(), userID, ksuid.New().String(), nil, "", s.pdb)
+
+	good := test.SetupCreateGeofence(s.T(), userID, "Home", &ud, s.pdb)
+	good.H3Indexes = types.StringArray{"8928308280fffff"}
+	_, err := good.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	broken := test.SetupCreateGeofence(s.T(), userID, "Work", &ud, s.pdb)
+	broken.H3Indexes = types.StringArray{"8928308280bffff"}
+	broken.Schedule = null.JSONFrom([]byte(`{"timeZone": "Nowhere/Special"}`))
+	_, err = broken.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"8928308280fffff"}))
+
+	s.Require().NoError(c.EmitPrivacyFenceUpdates(s.ctx, s.pdb.DBS().Reader, ud.ID, ud.TokenID))
+
+	_ = producer.Close()
+}
+
+func (s *GeofencesControllerTestSuite) TestPostGeofenceByTokenID() {
+	injectedUserID := ksuid.New().String()
+	usersClient := mock_services.NewMockUserServiceClient(s.mockCtrl)
+	addr := "0x00000000219ab540356cbb839cbe05303d7705fa"
+	usersClient.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(3).Return(&users.User{EthereumAddress: &addr}, nil)
+	producer := saramamocks.NewSyncProducer(s.T(), sarama.NewConfig())
+	c := NewGeofencesController(&config.Settings{Port: "3000"}, s.pdb.DBS, s.logger, producer, s.deviceDefSvc, usersClient)
+	app := fiber.New()
+	app.Post("/user/geofences", test.AuthInjectorTestHandler(injectedUserID, nil), c.Create)
+
+	// Owned through the wallet only, as after a transfer.
+	ud := test.SetupCreateUserDevice(s.T(), ksuid.New().String(), ksuid.New().String(), nil, "", s.pdb)
+	ud.TokenID = types.NewNullDecimal(decimal.New(7, 0))
+	ud.OwnerAddress = null.BytesFrom(common.HexToAddress(addr).Bytes())
+	_, err := ud.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	// Someone else's vehicle.
+	other := test.SetupCreateUserDevice(s.T(), ksuid.New().String(), ksuid.New().String(), nil, "", s.pdb)
+	other.TokenID = types.NewNullDecimal(decimal.New(8, 0))
+	other.OwnerAddress = null.BytesFrom(common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263").Bytes())
+	_, err = other.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3(ud.ID, []string{"8928308280fffff"}))
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checkForDeviceAndH3("7", []string{"8928308280fffff"}))
+
+	body := `{"name": "Home", "type": "PrivacyFence", "h3Indexes": ["8928308280fffff"], "vehicleTokenIds": [7, 7]}`
+	response, err := app.Test(test.BuildRequest("POST", "/user/geofences", body))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusCreated, response.StatusCode)
+
+	b, _ := io.ReadAll(response.Body)
+	n, err := models.UserDeviceToGeofences(
+		models.UserDeviceToGeofenceWhere.GeofenceID.EQ(gjson.GetBytes(b, "id").String()),
+		models.UserDeviceToGeofenceWhere.UserDeviceID.EQ(ud.ID),
+	).Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Equal(int64(1), n)
+
+	for _, tokenID := range []string{"8", "9"} {
+		body = `{"name": "Work` + tokenID + `", "type": "PrivacyFence", "vehicleTokenIds": [` + tokenID + `]}`
+		response, err = app.Test(test.BuildRequest("POST", "/user/geofences", body))
+		s.Require().NoError(err)
+		s.Equal(fiber.StatusBadRequest, response.StatusCode)
+	}
+
+	_ = producer.Close()
+}
+
+func (s *GeofencesControllerTestSuite) TestPostGeofenceShape() {
+	injectedUserID := ksuid.New().String()
+	producer := saramamocks.NewSyncProducer(s.T(), sarama.NewConfig())
+	c := NewGeofencesController(&config.Settings{Port: "3000"}, s.pdb.DBS, s.logger, producer, s.deviceDefSvc, nil)
+	app := fiber.New()
+	app.Post("/user/geofences", test.AuthInjectorTestHandler(injectedUserID, nil), c.Create)
+
+	body := `{
+		"name": "Block",
+		"type": "TriggerEntry",
+		"shape": {
+			"polygon": {
+				"type": "Polygon",
+				"coordinates": [[[-122.4194, 37.7749], [-122.4094, 37.7749], [-122.4094, 37.7819], [-122.4194, 37.7819], [-122.4194, 37.7749]]]
+			},
+			"resolution": 9
+		}
+	}`
+	response, err := app.Test(test.BuildRequest("POST", "/user/geofences", body))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusCreated, response.StatusCode)
+
+	b, _ := io.ReadAll(response.Body)
+	gf, err := models.FindGeofence(s.ctx, s.pdb.DBS().Reader, gjson.GetBytes(b, "id").String())
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{
+		"8928308280bffff",
+		"8928308280fffff",
+		"89283082847ffff",
+		"89283082863ffff",
+		"89283082873ffff",
+		"89283082877ffff",
+		"8928308287bffff",
+	}, []string(gf.H3Indexes))
+	s.Equal(int64(9), gjson.GetBytes(gf.Shape.JSON, "resolution").Int())
+
+	// Too many cells once compacted.
+	body = `{"name": "City", "type": "TriggerEntry", "shape": {"circle": {"latitude": 37.7749, "longitude": -122.4194, "radiusMeters": 2000}, "resolution": 10}}`
+	response, err = app.Test(test.BuildRequest("POST", "/user/geofences", body))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusBadRequest, response.StatusCode)
+
+	_ = producer.Close()
+}
+
+func TestCreateGeofence_Validate(t *testing.T) {
+	polygon := &GeoJSONPolygon{
+		Type:        "Polygon",
+		Coordinates: [][][]float64{{{-122.4194, 37.7749}, {-122.4094, 37.7749}, {-122.4094, 37.7819}, {-122.4194, 37.7749}}},
+	}
+	circle := &GeofenceCircle{Latitude: 37.7749, Longitude: -122.4194, RadiusMeters: 500}
+	from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := from.AddDate(0, 3, 0)
+
+	tests := []struct {
+		name  string
+		req   CreateGeofence
+		valid bool
+	}{
+		{"indexes", CreateGeofence{Name: "a", Type: "PrivacyFence", H3Indexes: []string{"8928308280fffff"}}, true},
+		{"invalid index", CreateGeofence{Name: "a", Type: "PrivacyFence", H3Indexes: []string{"8928308280fffff", "123"}}, false},
+		{"polygon", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Polygon: polygon, Resolution: 9}}, true},
+		{"circle", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Circle: circle, Resolution: 9}}, true},
+		{"shape and indexes", CreateGeofence{Name: "a", Type: "PrivacyFence", H3Indexes: []string{"8928308280fffff"}, Shape: &GeofenceShape{Circle: circle, Resolution: 9}}, false},
+		{"empty shape", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Resolution: 9}}, false},
+		{"polygon and circle", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Polygon: polygon, Circle: circle, Resolution: 9}}, false},
+		{"bad resolution", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Circle: circle, Resolution: 16}}, false},
+		{"open ring", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Polygon: &GeoJSONPolygon{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{{{-122.4194, 37.7749}, {-122.4094, 37.7749}, {-122.4094, 37.7819}, {-122.4194, 37.7819}}},
+		}, Resolution: 9}}, false},
+		{"no radius", CreateGeofence{Name: "a", Type: "PrivacyFence", Shape: &GeofenceShape{Circle: &GeofenceCircle{Latitude: 1, Longitude: 1}, Resolution: 9}}, false},
+		{"schedule", CreateGeofence{Name: "a", Type: "PrivacyFence", Schedule: &geofencesvc.Schedule{TimeZone: "Europe/Berlin", Windows: []geofencesvc.Window{{Start: "22:00", End: "06:00"}}}}, true},
+		{"bad schedule", CreateGeofence{Name: "a", Type: "PrivacyFence", Schedule: &geofencesvc.Schedule{TimeZone: "Europe/Berlin"}}, false},
+		{"dates", CreateGeofence{Name: "a", Type: "PrivacyFence", ActiveFrom: &from, ActiveUntil: &until}, true},
+		{"dates reversed", CreateGeofence{Name: "a", Type: "PrivacyFence", ActiveFrom: &until, ActiveUntil: &from}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateGeofence_Area(t *testing.T) {
+	req := CreateGeofence{
+		Shape: &GeofenceShape{
+			Circle:     &GeofenceCircle{Latitude: 37.7749, Longitude: -122.4194, RadiusMeters: 300},
+			Resolution: 9,
+		},
+	}
+	indexes, shape, err := req.area()
+	require.NoError(t, err)
+	assert.NotEmpty(t, indexes)
+	assert.LessOrEqual(t, len(indexes), maxFenceTiles)
+	assert.JSONEq(t, `{"circle": {"latitude": 37.7749, "longitude": -122.4194, "radiusMeters": 300}, "resolution": 9}`, string(shape.JSON))
+
+	// Smaller than a cell.
+	req.Shape = &GeofenceShape{
+		Circle:     &GeofenceCircle{Latitude: 37.7749, Longitude: -122.4194, RadiusMeters: 1},
+		Resolution: 5,
+	}
+	_, _, err = req.area()
+	var fe *fiber.Error
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, fiber.StatusBadRequest, fe.Code)
+}
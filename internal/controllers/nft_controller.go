@@ -5,9 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"math/big"
-	"slices"
 	"strings"
 
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/internal/utils"
 	"github.com/DIMO-Network/shared"
@@ -34,32 +34,29 @@ import (
 )
 
 type NFTController struct {
-	Settings         *config.Settings
-	DBS              func() *db.ReaderWriter
-	s3               *s3.Client
-	log              *zerolog.Logger
-	deviceDefSvc     services.DeviceDefinitionService
-	integSvc         services.DeviceDefinitionIntegrationService
-	smartcarTaskSvc  services.SmartcarTaskService
-	teslaTaskService services.TeslaTaskService
+	Settings        *config.Settings
+	DBS             func() *db.ReaderWriter
+	s3              *s3.Client
+	log             *zerolog.Logger
+	deviceDefSvc    services.DeviceDefinitionService
+	integSvc        services.DeviceDefinitionIntegrationService
+	commandRegistry *services.CommandRegistry
 }
 
 // NewNFTController constructor
 func NewNFTController(settings *config.Settings, dbs func() *db.ReaderWriter, logger *zerolog.Logger, s3 *s3.Client,
 	deviceDefSvc services.DeviceDefinitionService,
-	smartcarTaskSvc services.SmartcarTaskService,
-	teslaTaskService services.TeslaTaskService,
+	commandRegistry *services.CommandRegistry,
 	integSvc services.DeviceDefinitionIntegrationService,
 ) NFTController {
 	return NFTController{
-		Settings:         settings,
-		DBS:              dbs,
-		log:              logger,
-		s3:               s3,
-		deviceDefSvc:     deviceDefSvc,
-		smartcarTaskSvc:  smartcarTaskSvc,
-		teslaTaskService: teslaTaskService,
-		integSvc:         integSvc,
+		Settings:        settings,
+		DBS:             dbs,
+		log:             logger,
+		s3:              s3,
+		deviceDefSvc:    deviceDefSvc,
+		commandRegistry: commandRegistry,
+		integSvc:        integSvc,
 	}
 }
 
@@ -210,52 +207,23 @@ func (udc *UserDevicesController) UpdateVINV2(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// UnlockDoors godoc
-// @Summary     Unlock the device's doors
-// @Description Unlock the device's doors.
+// EnqueueCommand godoc
+// @Summary     Send a command to the vehicle
+// @Description Send a command, such as "doors/unlock", to the vehicle. Which commands are available
+// @Description depends on the vehicle's active integration. Commands that take arguments, "charge/limit"
+// @Description and "climate/start", read them from the JSON body.
 // @Tags        device,integration,command
 // @Success 200 {object} controllers.CommandResponse
 // @Produce     json
 // @Param       tokenID  path string true "Token ID"
-// @Router      /vehicle/{tokenID}/commands/doors/unlock [post]
-func (nc *NFTController) UnlockDoors(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.DoorsUnlock)
-}
-
-// LockDoors godoc
-// @Summary     Lock the device's doors
-// @Description Lock the device's doors.
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       tokenID  path string true "Token ID"
-// @Router      /vehicle/{tokenID}/commands/doors/lock [post]
-func (nc *NFTController) LockDoors(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.DoorsLock)
-}
-
-// OpenTrunk godoc
-// @Summary     Open the device's rear trunk
-// @Description Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       tokenID  path string true "Token ID"
-// @Router      /vehicle/{tokenID}/commands/trunk/open [post]
-func (nc *NFTController) OpenTrunk(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.TrunkOpen)
-}
-
-// OpenFrunk godoc
-// @Summary     Open the device's front trunk
-// @Description Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       tokenID  path string true "Token ID"
-// @Router      /vehicle/{tokenID}/commands/frunk/open [post]
-func (nc *NFTController) OpenFrunk(c *fiber.Ctx) error {
-	return nc.handleEnqueueCommand(c, constants.FrunkOpen)
+// @Param       command  path string true "Command path, e.g., doors/unlock"
+// @Param       params   body services.CommandParams false "Arguments for commands such as charge/limit and climate/start"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Router      /vehicle/{tokenID}/commands/{command} [post]
+func (nc *NFTController) EnqueueCommand(commandPath string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return nc.handleEnqueueCommand(c, commandPath)
+	}
 }
 
 // handleEnqueueCommand enqueues the command specified by commandPath with the
@@ -278,6 +246,11 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tokenID))
 	}
 
+	params, err := parseCommandParams(c, commandPath)
+	if err != nil {
+		return err
+	}
+
 	// Checking both that the nft exists and is linked to a device.
 	nft, err := models.UserDevices(
 		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(tokenID)),
@@ -314,43 +287,17 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		return opaqueInternalError
 	}
 
-	// TODO(elffjs): This map is ugly. Surely we interface our way out of this?
-	commandMap := map[string]map[string]func(udai *models.UserDeviceAPIIntegration) (string, error){
-		constants.SmartCarVendor: {
-			"doors/unlock": nc.smartcarTaskSvc.UnlockDoors,
-			"doors/lock":   nc.smartcarTaskSvc.LockDoors,
-		},
-		constants.TeslaVendor: {
-			"doors/unlock": nc.teslaTaskService.UnlockDoors,
-			"doors/lock":   nc.teslaTaskService.LockDoors,
-			"trunk/open":   nc.teslaTaskService.OpenTrunk,
-			"frunk/open":   nc.teslaTaskService.OpenFrunk,
-		},
-	}
-
 	integration, err := nc.deviceDefSvc.GetIntegrationByID(c.Context(), udai.IntegrationID)
 	if err != nil {
 		return shared.GrpcErrorToFiber(err, "deviceDefSvc error getting integration id: "+udai.IntegrationID)
 	}
 
-	vendorCommandMap, ok := commandMap[integration.Vendor]
-	if !ok {
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
-	}
-
-	// This correctly handles md.Commands.Enabled being nil.
-	if !slices.Contains(md.Commands.Enabled, commandPath) {
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command with this device.")
-	}
-
-	commandFunc, ok := vendorCommandMap[commandPath]
-	if !ok {
-		// Should never get here.
-		logger.Error().Msg("Command was enabled for this device, but there is no function to execute it.")
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
+	commandFunc, err := nc.commandRegistry.Executor(integration.Vendor, commandPath, md)
+	if err != nil {
+		return commandExecutorError(err)
 	}
 
-	subTaskID, err := commandFunc(udai)
+	subTaskID, err := commandFunc(udai, params)
 	if err != nil {
 		logger.Err(err).Msg("Failed to start command task.")
 		return opaqueInternalError
@@ -362,6 +309,7 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 		IntegrationID: udai.IntegrationID,
 		Command:       commandPath,
 		Status:        models.DeviceCommandRequestStatusPending,
+		Requester:     null.StringFrom(helpers.GetUserID(c)),
 	}
 
 	if err := comRow.Insert(c.Context(), nc.DBS().Writer, boil.Infer()); err != nil {
@@ -374,6 +322,54 @@ func (nc *NFTController) handleEnqueueCommand(c *fiber.Ctx, commandPath string)
 	return c.JSON(CommandResponse{RequestID: subTaskID})
 }
 
+// ListCommandRequests godoc
+// @Summary     List the vehicle's command history.
+// @Description Lists the commands sent to the vehicle, newest first. Results can be filtered and are paginated.
+// @Tags        device,command
+// @Success 200 {object} controllers.CommandRequestHistoryResp
+// @Produce     json
+// @Param       tokenID       path  int    true  "Token ID"
+// @Param       command       query string false "Only commands with this path, e.g., doors/unlock"
+// @Param       status        query string false "Only commands in this status" Enums(Pending,Complete,Failed,TimedOut,Cancelled)
+// @Param       integrationId query string false "Only commands sent through this integration"
+// @Param       since         query string false "Only commands created at or after this RFC 3339 time"
+// @Param       until         query string false "Only commands created before this RFC 3339 time"
+// @Param       page          query int    false "Page number, starting from 1"
+// @Param       pageSize      query int    false "Page size, at most 100"
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/commands [get]
+func (nc *NFTController) ListCommandRequests(c *fiber.Ctx) error {
+	tokenIDRaw := c.Params("tokenID")
+
+	tokenID, ok := new(decimal.Big).SetString(tokenIDRaw)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tokenIDRaw))
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(tokenID)),
+	).One(c.Context(), nc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "Vehicle NFT not found.")
+		}
+		nc.log.Err(err).Msg("Failed to search for device.")
+		return opaqueInternalError
+	}
+
+	resp, err := listCommandRequests(c, nc.DBS().Reader, ud.ID)
+	if err != nil {
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			return err
+		}
+		nc.log.Err(err).Msg("Failed to list command requests.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(resp)
+}
+
 // GetBurnDevice godoc
 // @Description Returns the data the user must sign in order to burn the device.
 // @Param       tokenID path int true "token id"
@@ -430,6 +426,7 @@ func (udc *UserDevicesController) GetBurnDevice(c *fiber.Ctx) error {
 // @Description Sends a burn device request to the blockchain
 // @Param       tokenID path int true "token id"
 // @Param       burnRequest  body controllers.BurnRequest true "Signature"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /user/vehicle/{tokenID}/commands/burn [post]
@@ -450,6 +447,8 @@ func (udc *UserDevicesController) PostBurnDevice(c *fiber.Ctx) error {
 			Name:    "DIMO",
 			Version: "1",
 		},
+		Idempotency:    udc.idempotencyStore,
+		IdempotencyKey: idempotencymw.Key(c),
 	}
 
 	tx, err := udc.DBS().Reader.BeginTx(c.Context(), nil)
@@ -525,7 +524,7 @@ func (udc *UserDevicesController) PostBurnDevice(c *fiber.Ctx) error {
 	}
 
 	udc.log.Info().Msgf("submitted metatransaction request %s", requestID)
-	return client.BurnVehicleSign(requestID, bvs.TokenID, sigBytes)
+	return registryRequestError(client.BurnVehicleSign(requestID, bvs.TokenID, sigBytes))
 }
 
 func (udc *UserDevicesController) checkDeviceBurn(ctx context.Context, userDevice *models.UserDevice) (registry.BurnVehicleSign, *pb.User, error) {
@@ -558,3 +557,33 @@ type BurnRequest struct {
 	// Signature is the hex encoding of the EIP-712 signature result.
 	Signature string `json:"signature" validate:"required"`
 }
+
+// VehiclePrivilegesResp lists the privileges granted on a vehicle.
+type VehiclePrivilegesResp struct {
+	Grantees []PrivilegeUser `json:"grantees"`
+}
+
+// GetVehiclePrivileges godoc
+// @Summary     List the privileges granted on the vehicle.
+// @Description Lists every address holding an unexpired privilege on the vehicle, along with the privilege ids and their expiries.
+// @Tags        user-devices
+// @Produce     json
+// @Param       tokenID path int true "Token ID"
+// @Success     200 {object} controllers.VehiclePrivilegesResp
+// @Security    BearerAuth
+// @Router      /user/vehicle/{tokenID}/privileges [get]
+func (udc *UserDevicesController) GetVehiclePrivileges(c *fiber.Ctx) error {
+	tis := c.Params("tokenID")
+	ti, ok := new(big.Int).SetString(tis, 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tis))
+	}
+
+	pu, err := vehiclePrivilegeUsers(c.Context(), udc.DBS().Reader, common.HexToAddress(udc.Settings.VehicleNFTAddress), utils.BigToDecimal(ti))
+	if err != nil {
+		udc.log.Err(err).Msg("Failed to list vehicle privileges.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(VehiclePrivilegesResp{Grantees: pu})
+}
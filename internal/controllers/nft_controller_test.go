@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/internal/test"
 	"github.com/DIMO-Network/devices-api/models"
 	pb "github.com/DIMO-Network/shared/api/users"
 	smock "github.com/IBM/sarama/mocks"
+	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -19,6 +21,7 @@ import (
 	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"go.uber.org/mock/gomock"
 )
 
@@ -167,3 +170,32 @@ func (s *UserDevicesControllerTestSuite) TestUpdateVINV2_setCountryAndProtocol()
 		string(userDevice.Metadata.JSON))
 	s.Equal("4Y1SL65848Z411439", userDevice.VinIdentifier.String)
 }
+
+func (s *UserDevicesControllerTestSuite) TestGetVehiclePrivileges() {
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, ksuid.New().String(), nil, "", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(9), null.BytesFrom(s.testUserEthAddr.Bytes()), s.pdb)
+
+	grantee := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	for priv, expiry := range map[int64]time.Time{1: time.Now().Add(time.Hour), 4: time.Now().Add(time.Hour), 2: time.Now().Add(-time.Hour)} {
+		p := models.NFTPrivilege{
+			// The suite doesn't set a vehicle contract address.
+			ContractAddress: common.Address{}.Bytes(),
+			TokenID:         types.NewDecimal(decimal.New(9, 0)),
+			Privilege:       priv,
+			UserAddress:     grantee.Bytes(),
+			Expiry:          expiry,
+		}
+		s.Require().NoError(p.Insert(context.Background(), s.pdb.DBS().Writer, boil.Infer()))
+	}
+
+	response, err := s.app.Test(test.BuildRequest("GET", "/vehicle/9/privileges", ""))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, response.StatusCode)
+
+	var resp VehiclePrivilegesResp
+	s.Require().NoError(json.NewDecoder(response.Body).Decode(&resp))
+	s.Require().Len(resp.Grantees, 1)
+	s.Equal(grantee.Hex(), resp.Grantees[0].Address)
+	s.Len(resp.Grantees[0].Privileges, 2)
+}
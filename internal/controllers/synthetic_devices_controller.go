@@ -13,6 +13,7 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/contracts"
 	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/models"
@@ -198,6 +199,7 @@ func (sdc *SyntheticDevicesController) GetSyntheticDeviceMintingPayload(c *fiber
 // @Param       userDeviceID path int true "user device KSUID, must be minted"
 // @Param       integrationID path int true "integration KSUD, must be software-based"
 // @Param       signed body controllers.MintSyntheticDeviceRequest true "only field is the signed EIP-712"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success     204
 // @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/mint [post]
 func (sdc *SyntheticDevicesController) MintSyntheticDevice(c *fiber.Ctx) error {
@@ -369,8 +371,11 @@ func (sdc *SyntheticDevicesController) MintSyntheticDevice(c *fiber.Ctx) error {
 		SyntheticDeviceSig:  virtSig,
 	}
 
-	if err := sdc.registryClient.MintSyntheticDeviceSign(requestID, mvt); err != nil {
-		return err
+	client := sdc.registryClient
+	client.IdempotencyKey = idempotencymw.Key(c)
+
+	if err := client.MintSyntheticDeviceSign(requestID, mvt); err != nil {
+		return registryRequestError(err)
 	}
 
 	return c.JSON(fiber.Map{"message": "Submitted synthetic device mint request."})
@@ -440,6 +445,7 @@ type BurnSyntheticDeviceRequest struct {
 // @Param       userDeviceID path int true "user device KSUID, must be minted"
 // @Param       integrationID path int true "integration KSUD, must be software-based and active"
 // @Param       signed body controllers.BurnSyntheticDeviceRequest true "only field is the signed EIP-712"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success     200 {array} signer.TypedData
 // @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/burn [post]
 func (sdc *SyntheticDevicesController) BurnSyntheticDevice(c *fiber.Ctx) error {
@@ -553,7 +559,10 @@ func (sdc *SyntheticDevicesController) BurnSyntheticDevice(c *fiber.Ctx) error {
 		return err
 	}
 
-	return sdc.registryClient.BurnSyntheticDeviceSign(reqID, big.NewInt(vehicleNode), big.NewInt(syntheticDeviceNode), ownerSignature)
+	client := sdc.registryClient
+	client.IdempotencyKey = idempotencymw.Key(c)
+
+	return registryRequestError(client.BurnSyntheticDeviceSign(reqID, big.NewInt(vehicleNode), big.NewInt(syntheticDeviceNode), ownerSignature))
 }
 
 func (sdc *SyntheticDevicesController) generateNextChildKeyNumber(ctx context.Context) (int, error) {
@@ -4,12 +4,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/middleware/address"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/services/integration"
+	"github.com/DIMO-Network/devices-api/internal/services/oauth"
 	"github.com/DIMO-Network/devices-api/internal/services/tmpcred"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
@@ -30,7 +30,7 @@ type Controller struct {
 	Tesla       SyntheticTaskManager
 	IntegClient *integration.Client
 	Store       *tmpcred.Store
-	TeslaAPI    services.TeslaFleetAPIService
+	Providers   oauth.Providers
 	Cipher      shared.Cipher
 }
 
@@ -94,52 +94,72 @@ func (co *Controller) PostReauthenticate(c *fiber.Ctx) error {
 		return err
 	}
 
-	// TODO(elffjs): Yeah, yeah, this is bad.
+	var tasks SyntheticTaskManager
 	switch integ.Vendor {
-	// In this case, the client should have redirected the user to [Smartcar Reauthentication]
-	// endpoint. The credentials we already have should start working again.
-	//
-	// [Smartcar Reauthentication]: https://smartcar.com/docs/connect/re-auth/redirect-to-connect.
 	case constants.SmartCarVendor:
+		tasks = co.Smartcar
+	case constants.TeslaVendor:
+		tasks = co.Tesla
+	}
+
+	provider, ok := co.Providers[integ.Vendor]
+	if tasks == nil || !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Integration %d does not support reauthentication.", integTokenID))
+	}
+
+	cred, err := co.Store.Retrieve(c.Context(), userAddr)
+	if err != nil {
+		if !errors.Is(err, tmpcred.ErrNotFound) {
+			return err
+		}
+		// In the Smartcar case, the client may instead have redirected the user to the
+		// [Smartcar Reauthentication] endpoint. The credentials we already have should
+		// start working again.
+		//
+		// [Smartcar Reauthentication]: https://smartcar.com/docs/connect/re-auth/redirect-to-connect.
+		if integ.Vendor != constants.SmartCarVendor {
+			return fiber.NewError(fiber.StatusBadRequest, "No stored credentials found.")
+		}
 		if udai.Status != models.UserDeviceAPIIntegrationStatusAuthenticationFailure {
 			// TODO(elffjs): Can probably still "succeed" in this case.
 			return fiber.NewError(fiber.StatusBadRequest, "Device is not in authentication failure.")
 		}
 
+		fromStatus := udai.Status
 		udai.Status = models.UserDeviceAPIIntegrationStatusPendingFirstData
 		udai.TaskID = null.StringFrom(ksuid.New().String())
+		udai.FailureReason = null.String{}
+		udai.TokenRefreshFailures = 0
 
 		cols := models.UserDeviceAPIIntegrationColumns
-		_, err = udai.Update(c.Context(), co.DBS.DBS().Writer, boil.Whitelist(cols.Status, cols.TaskID, cols.UpdatedAt))
+		_, err = udai.Update(c.Context(), tx, boil.Whitelist(cols.Status, cols.TaskID, cols.FailureReason, cols.TokenRefreshFailures, cols.UpdatedAt))
 		if err != nil {
 			return err
 		}
 
-		err = co.Smartcar.StartPoll(udai, sd)
-		if err != nil {
+		if err := services.RecordIntegrationStatusChange(c.Context(), tx, udai, fromStatus, services.IntegrationStatusSourceReauthentication, ""); err != nil {
 			return err
 		}
-	case constants.TeslaVendor:
-		cred, err := co.Store.Retrieve(c.Context(), userAddr)
-		if err != nil {
-			if errors.Is(err, tmpcred.ErrNotFound) {
-				return fiber.NewError(fiber.StatusBadRequest, "No stored credentials found.")
-			}
+
+		if err := tasks.StartPoll(udai, sd); err != nil {
 			return err
 		}
-
+	} else {
 		if cred.IntegrationID != int(integTokenID) {
-			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Stored credentials are for integration %d, not Tesla.", cred.IntegrationID))
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Stored credentials are for integration %d, not %d.", cred.IntegrationID, integTokenID))
 		}
 
-		teslaID, err := strconv.Atoi(udai.ExternalID.String)
-		if err != nil {
+		var md services.UserDeviceAPIIntegrationsMetadata
+		if err := udai.Metadata.Unmarshal(&md); err != nil {
 			return err
 		}
 
-		// Make sure that these credentials have access to this particular vehicle.
-		_, err = co.TeslaAPI.GetVehicle(c.Context(), cred.AccessToken, teslaID)
-		if err != nil {
+		// This also makes sure that the credentials have access to this particular vehicle.
+		if err := provider.PrepareMetadata(c.Context(), cred.AccessToken, udai.ExternalID.String, &md); err != nil {
+			return err
+		}
+
+		if err := udai.Metadata.Marshal(md); err != nil {
 			return err
 		}
 
@@ -153,44 +173,34 @@ func (co *Controller) PostReauthenticate(c *fiber.Ctx) error {
 		}
 
 		if udai.TaskID.Valid {
-			if err := co.Tesla.StopPoll(udai); err != nil {
+			if err := tasks.StopPoll(udai); err != nil {
 				return err
 			}
 		}
 
-		var md services.UserDeviceAPIIntegrationsMetadata
-		if err := udai.Metadata.Unmarshal(&md); err != nil {
-			return err
-		}
-
-		md.TeslaAPIVersion = constants.TeslaAPIV2
-		md.Commands, err = co.TeslaAPI.GetAvailableCommands(cred.AccessToken)
-		if err != nil {
-			return err
-		}
-
-		if err := udai.Metadata.Marshal(md); err != nil {
-			return err
-		}
-
 		udai.AccessToken = null.StringFrom(encAccess)
 		udai.RefreshToken = null.StringFrom(encRefresh)
 		udai.AccessExpiresAt = null.TimeFrom(cred.Expiry)
 
+		fromStatus := udai.Status
 		udai.Status = models.UserDeviceAPIIntegrationStatusPendingFirstData
 		udai.TaskID = null.StringFrom(ksuid.New().String())
+		udai.FailureReason = null.String{}
+		udai.TokenRefreshFailures = 0
 
 		cols := models.UserDeviceAPIIntegrationColumns
-		_, err = udai.Update(c.Context(), co.DBS.DBS().Writer, boil.Whitelist(cols.Status, cols.TaskID, cols.AccessToken, cols.RefreshToken, cols.AccessExpiresAt, cols.Metadata, cols.UpdatedAt))
+		_, err = udai.Update(c.Context(), tx, boil.Whitelist(cols.Status, cols.TaskID, cols.AccessToken, cols.RefreshToken, cols.AccessExpiresAt, cols.Metadata, cols.FailureReason, cols.TokenRefreshFailures, cols.UpdatedAt))
 		if err != nil {
 			return err
 		}
 
-		if err := co.Tesla.StartPoll(udai, sd); err != nil {
+		if err := services.RecordIntegrationStatusChange(c.Context(), tx, udai, fromStatus, services.IntegrationStatusSourceReauthentication, ""); err != nil {
+			return err
+		}
+
+		if err := tasks.StartPoll(udai, sd); err != nil {
 			return err
 		}
-	default:
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Integration %d does not support reauthentication.", integTokenID))
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -24,7 +24,9 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/contracts"
 	sig2 "github.com/DIMO-Network/devices-api/internal/contracts/signature"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/idempotency"
 	"github.com/DIMO-Network/devices-api/internal/services/ipfs"
 	"github.com/DIMO-Network/devices-api/internal/services/registry"
 	"github.com/DIMO-Network/devices-api/models"
@@ -86,6 +88,8 @@ type UserDevicesController struct {
 	ipfsSvc                   *ipfs.IPFS
 	clickHouseConn            clickhouse.Conn
 	userAddrGetter            helpers.EthAddrGetter
+	commandRegistry           *services.CommandRegistry
+	idempotencyStore          *idempotency.Store
 }
 
 // PrivilegedDevices contains all devices for which a privilege has been shared
@@ -142,6 +146,8 @@ func NewUserDevicesController(settings *config.Settings,
 	teslaFleetAPISvc services.TeslaFleetAPIService,
 	ipfsSvc *ipfs.IPFS,
 	chConn clickhouse.Conn,
+	commandRegistry *services.CommandRegistry,
+	idempotencyStore *idempotency.Store,
 ) UserDevicesController {
 	return UserDevicesController{
 		Settings:                  settings,
@@ -171,6 +177,8 @@ func NewUserDevicesController(settings *config.Settings,
 		ipfsSvc:                   ipfsSvc,
 		userAddrGetter:            helpers.CreateUserAddrGetter(usersClient),
 		clickHouseConn:            chConn,
+		commandRegistry:           commandRegistry,
+		idempotencyStore:          idempotencyStore,
 	}
 }
 
@@ -254,36 +262,10 @@ func (udc *UserDevicesController) dbDevicesToDisplay(ctx context.Context, device
 				nft.OwnerAddress = &addr
 
 				// NFT Privileges
-				udp, err := models.NFTPrivileges(
-					models.NFTPrivilegeWhere.TokenID.EQ(types.Decimal(d.TokenID)),
-					models.NFTPrivilegeWhere.Expiry.GT(time.Now()),
-					models.NFTPrivilegeWhere.ContractAddress.EQ(common.FromHex(udc.Settings.VehicleNFTAddress)),
-					qm.OrderBy(models.NFTPrivilegeColumns.UpdatedAt+" DESC, "+models.NFTPrivilegeColumns.Privilege+" ASC"),
-				).All(ctx, udc.DBS().Reader)
+				pu, err = vehiclePrivilegeUsers(ctx, udc.DBS().Reader, common.HexToAddress(udc.Settings.VehicleNFTAddress), types.Decimal(d.TokenID))
 				if err != nil {
 					return nil, err
 				}
-
-				privByAddr := make(map[string][]Privilege)
-				for _, v := range udp {
-					ua := common.BytesToAddress(v.UserAddress).Hex()
-					privByAddr[ua] = append(privByAddr[ua], Privilege{
-						ID:        v.Privilege,
-						ExpiresAt: v.Expiry,
-						UpdatedAt: v.UpdatedAt,
-					})
-				}
-
-				for k, v := range privByAddr {
-					pu = append(pu, PrivilegeUser{
-						Address:    k,
-						Privileges: v,
-					})
-				}
-
-				slices.SortFunc(pu, func(a, b PrivilegeUser) int {
-					return cmp.Compare(a.Address, b.Address)
-				})
 			}
 
 			if mtr := d.R.MintRequest; mtr != nil {
@@ -536,12 +518,16 @@ func (udc *UserDevicesController) GetUserDevices(c *fiber.Ctx) error {
 
 				for _, udai := range toModify {
 					udc.log.Info().Str("userId", userID).Str("userDeviceId", udai.UserDeviceID).Str("integrationId", udai.IntegrationID).Msg("Setting connection active.")
+					fromStatus := udai.Status
 					udai.Status = models.UserDeviceAPIIntegrationStatusActive
 					udai.UpdatedAt = modTime
 					_, err := udai.Update(c.Context(), tx, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status, models.UserDeviceAPIIntegrationColumns.UpdatedAt))
 					if err != nil {
 						return err
 					}
+					if err := services.RecordIntegrationStatusChange(c.Context(), tx, udai, fromStatus, services.IntegrationStatusSourceActivity, "Received signals."); err != nil {
+						return err
+					}
 				}
 
 				if err := tx.Commit(); err != nil {
@@ -1338,17 +1324,31 @@ var erc1271magicValue = [4]byte{0x16, 0x26, 0xba, 0x7e}
 // @Tags        user-devices
 // @Param       userDeviceID path string                  true "user device ID"
 // @Param       mintRequest  body controllers.VehicleMintRequest true "Signature and NFT data"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success     200
 // @Security    BearerAuth
 // @Router      /user/devices/{userDeviceID}/commands/mint [post]
 func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 	userDeviceID := c.Params("userDeviceID")
 
+	var mr VehicleMintRequest
+	if err := c.BodyParser(&mr); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	_, err := udc.mintVehicle(c, userDeviceID, &mr, idempotencymw.Key(c))
+	return registryRequestError(err)
+}
+
+// mintVehicle checks the owner's signature over the vehicle's mint payload and, if it's
+// good, sends the mint transaction. It returns the id of the meta-transaction request.
+// A non-empty idempotencyKey makes retries of the same mint safe.
+func (udc *UserDevicesController) mintVehicle(c *fiber.Ctx, userDeviceID string, mr *VehicleMintRequest, idempotencyKey string) (string, error) {
 	logger := helpers.GetLogger(c, udc.log)
 
 	tx, err := udc.DBS().Writer.BeginTx(c.Context(), &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer tx.Rollback() //nolint
 
@@ -1359,20 +1359,15 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 	).One(c.Context(), tx)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fiber.NewError(fiber.StatusNotFound, "No device with that id found.")
+			return "", fiber.NewError(fiber.StatusNotFound, "No device with that id found.")
 		}
-		return err
+		return "", err
 	}
 
 	// This actually makes no database calls!
 	mvs, dd, err := udc.checkVehicleMint(c, userDevice)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, errors.Wrapf(err, "failed to checkVehicleMint. user device id: %s", userDeviceID).Error())
-	}
-
-	var mr VehicleMintRequest
-	if err := c.BodyParser(&mr); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+		return "", fiber.NewError(fiber.StatusBadRequest, errors.Wrapf(err, "failed to checkVehicleMint. user device id: %s", userDeviceID).Error())
 	}
 
 	// This may not be there, but if it is we should delete it.
@@ -1380,7 +1375,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 	image, err := base64.StdEncoding.DecodeString(imageData)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Primary image not properly base64-encoded.")
+		return "", fiber.NewError(fiber.StatusBadRequest, "Primary image not properly base64-encoded.")
 	}
 
 	client := registry.Client{
@@ -1392,6 +1387,8 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 			Name:    "DIMO",
 			Version: "1",
 		},
+		Idempotency:    udc.idempotencyStore,
+		IdempotencyKey: idempotencyKey,
 	}
 
 	mvdds := registry.MintVehicleWithDeviceDefinitionSign{
@@ -1410,7 +1407,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 	hash, err := client.Hash(&mvdds)
 	if err != nil {
-		return opaqueInternalError
+		return "", opaqueInternalError
 	}
 
 	sigBytes := common.FromHex(mr.Signature)
@@ -1419,21 +1416,21 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 	if origErr != nil || recAddr != mvs.Owner {
 		ethClient, err := ethclient.Dial(udc.Settings.MainRPCURL)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		sigCon, err := sig2.NewErc1271(mvs.Owner, ethClient)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		ret, err := sigCon.IsValidSignature(nil, common.BytesToHash(hash), sigBytes)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		if ret != erc1271magicValue {
-			return fiber.NewError(fiber.StatusBadRequest, "Could not verify ERC-1271 signature.")
+			return "", fiber.NewError(fiber.StatusBadRequest, "Could not verify ERC-1271 signature.")
 		}
 	}
 
@@ -1441,7 +1438,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 	if len(image) == 0 {
 		if !userDevice.IpfsImageCid.Valid {
-			return fiber.NewError(fiber.StatusBadRequest, "No image in request body and none assigned previously.")
+			return "", fiber.NewError(fiber.StatusBadRequest, "No image in request body and none assigned previously.")
 		}
 	} else {
 		if userDevice.IpfsImageCid.Valid {
@@ -1449,7 +1446,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 		}
 		cid, err := udc.ipfsSvc.UploadImage(c.Context(), imageData)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "Failed to upload image to IPFS.")
+			return "", fiber.NewError(fiber.StatusBadRequest, "Failed to upload image to IPFS.")
 		}
 
 		userDevice.IpfsImageCid = null.StringFrom(cid)
@@ -1460,7 +1457,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 	imageTransp, err := base64.StdEncoding.DecodeString(imageDataTransp)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Transparent image not properly base64-encoded.")
+		return "", fiber.NewError(fiber.StatusBadRequest, "Transparent image not properly base64-encoded.")
 	}
 
 	if len(imageTransp) != 0 {
@@ -1471,7 +1468,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 		})
 		if err != nil {
 			logger.Err(err).Msg("Failed to save transparent image to S3.")
-			return opaqueInternalError
+			return "", opaqueInternalError
 		}
 	}
 
@@ -1480,12 +1477,12 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 		Status: models.MetaTransactionRequestStatusUnsubmitted,
 	}
 	if err = mtr.Insert(c.Context(), tx, boil.Infer()); err != nil {
-		return err
+		return "", err
 	}
 
 	userDevice.MintRequestID = null.StringFrom(requestID)
 	if _, err = userDevice.Update(c.Context(), tx, boil.Infer()); err != nil {
-		return err
+		return "", err
 	}
 
 	if udais := userDevice.R.UserDeviceAPIIntegrations; len(udais) != 0 {
@@ -1493,11 +1490,11 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 		for _, udai := range udais {
 			in, err := udc.DeviceDefSvc.GetIntegrationByID(c.Context(), udai.IntegrationID)
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			if dd.Make.Name == "Peugeot" && dd.Model == "2008" && dd.Year == 2024 {
-				return fiber.NewError(fiber.StatusBadRequest, "Certain Peugeot vehicles cannot be connected through Smartcar at this time.")
+				return "", fiber.NewError(fiber.StatusBadRequest, "Certain Peugeot vehicles cannot be connected through Smartcar at this time.")
 			}
 
 			if in.Vendor == constants.TeslaVendor {
@@ -1516,14 +1513,14 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 			qry := fmt.Sprintf("SELECT nextval('%s.synthetic_devices_serial_sequence');", udc.Settings.DB.Name)
 			err := queries.Raw(qry).Bind(c.Context(), tx, &seq)
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			childNum := seq.NextVal
 
 			addr, err := udc.wallet.GetAddress(c.Context(), uint32(childNum))
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			sd := models.SyntheticDevice{
@@ -1534,7 +1531,7 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 			}
 
 			if err := sd.Insert(c.Context(), tx, boil.Infer()); err != nil {
-				return err
+				return "", err
 			}
 
 			mvss := registry.MintVehicleAndSdSign{
@@ -1543,19 +1540,19 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 
 			hash, err := client.Hash(&mvss)
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			sign, err := udc.wallet.SignHash(c.Context(), uint32(childNum), hash)
 			if err != nil {
-				return err
+				return "", err
 			}
 
 			if err := tx.Commit(); err != nil {
-				return err
+				return "", err
 			}
 
-			return client.MintVehicleAndSdWithDeviceDefinitionSign(requestID, contracts.MintVehicleAndSdWithDdInput{
+			return requestID, client.MintVehicleAndSdWithDeviceDefinitionSign(requestID, contracts.MintVehicleAndSdWithDdInput{
 				ManufacturerNode:     mvs.ManufacturerNode,
 				Owner:                mvs.Owner,
 				DeviceDefinitionId:   dd.Id,
@@ -1570,12 +1567,22 @@ func (udc *UserDevicesController) PostMintDevice(c *fiber.Ctx) error {
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return "", err
 	}
 
 	logger.Info().Msgf("Submitted metatransaction request %s", requestID)
 
-	return client.MintVehicleWithDeviceDefinitionSign(requestID, mvs.ManufacturerNode, mvs.Owner, dd.Id, attrListsToAttrPairs(mvs.Attributes, mvs.Infos), sigBytes)
+	return requestID, client.MintVehicleWithDeviceDefinitionSign(requestID, mvs.ManufacturerNode, mvs.Owner, dd.Id, attrListsToAttrPairs(mvs.Attributes, mvs.Infos), sigBytes)
+}
+
+// registryRequestError turns the error for a transaction that was already sent
+// under the request's idempotency key into a 409. Other errors pass through.
+func registryRequestError(err error) error {
+	var dupErr *registry.DuplicateRequestError
+	if errors.As(err, &dupErr) {
+		return fiber.NewError(fiber.StatusConflict, "A transaction was already submitted for this Idempotency-Key.")
+	}
+	return err
 }
 
 func attrListsToAttrPairs(attrs []string, infos []string) []contracts.AttributeInfoPair {
@@ -1769,6 +1776,44 @@ type PrivilegeUser struct {
 	Privileges []Privilege `json:"privileges"`
 }
 
+// vehiclePrivilegeUsers groups the unexpired privileges granted on the vehicle by
+// grantee, ordered by address.
+func vehiclePrivilegeUsers(ctx context.Context, exec boil.ContextExecutor, contract common.Address, tokenID types.Decimal) ([]PrivilegeUser, error) {
+	udp, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.TokenID.EQ(tokenID),
+		models.NFTPrivilegeWhere.Expiry.GT(time.Now()),
+		models.NFTPrivilegeWhere.ContractAddress.EQ(contract.Bytes()),
+		qm.OrderBy(models.NFTPrivilegeColumns.UpdatedAt+" DESC, "+models.NFTPrivilegeColumns.Privilege+" ASC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	privByAddr := make(map[string][]Privilege)
+	for _, v := range udp {
+		ua := common.BytesToAddress(v.UserAddress).Hex()
+		privByAddr[ua] = append(privByAddr[ua], Privilege{
+			ID:        v.Privilege,
+			ExpiresAt: v.Expiry,
+			UpdatedAt: v.UpdatedAt,
+		})
+	}
+
+	pu := []PrivilegeUser{}
+	for k, v := range privByAddr {
+		pu = append(pu, PrivilegeUser{
+			Address:    k,
+			Privileges: v,
+		})
+	}
+
+	slices.SortFunc(pu, func(a, b PrivilegeUser) int {
+		return cmp.Compare(a.Address, b.Address)
+	})
+
+	return pu, nil
+}
+
 type MyDevicesResp struct {
 	UserDevices   []UserDeviceFull `json:"userDevices"`
 	SharedDevices []UserDeviceFull `json:"sharedDevices"`
@@ -18,6 +18,7 @@ import (
 	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/middleware/owner"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
 	"github.com/DIMO-Network/devices-api/internal/test"
@@ -107,10 +108,14 @@ func (s *UserDevicesControllerTestSuite) SetupSuite() {
 	testUserID2 := "3232451"
 	s.testUserEthAddr = common.HexToAddress("0x1231231231231231231231231231231231231231")
 	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: "prod"}, s.pdb.DBS, logger, s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, teslaSvc, teslaTaskService, new(shared.ROT13Cipher), s.autoPiSvc,
-		autoPiIngest, deviceDefinitionIngest, nil, nil, s.redisClient, nil, s.usersClient, s.deviceDataSvc, s.natsService, nil, s.userDeviceSvc, nil, nil, nil)
+		autoPiIngest, deviceDefinitionIngest, nil, nil, s.redisClient, nil, s.usersClient, s.deviceDataSvc, s.natsService, nil, s.userDeviceSvc, nil, nil, nil, nil, nil)
 	app := test.SetupAppFiber(*logger)
 	app.Post("/user/devices", test.AuthInjectorTestHandler(s.testUserID, nil), c.RegisterDeviceForUser)
 	app.Post("/user/devices/fromvin", test.AuthInjectorTestHandler(s.testUserID, nil), c.RegisterDeviceForUserFromVIN)
+	app.Post("/user/devices/fromvin/batch", test.AuthInjectorTestHandler(s.testUserID, nil), c.RegisterDevicesForUserFromVINs)
+	app.Get("/user/devices/fromvin/batch/:jobID", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetVINRegistrationJob)
+	app.Get("/user/devices/mint/batch", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetMintBatchPayloads)
+	app.Get("/user/devices/mint/batch/:batchID", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetMintBatch)
 	app.Post("/user/devices/fromsmartcar", test.AuthInjectorTestHandler(s.testUserID, nil), c.RegisterDeviceForUserFromSmartcar)
 	app.Post("/user/devices/second", test.AuthInjectorTestHandler(testUserID2, nil), c.RegisterDeviceForUser) // for different test user
 	app.Get("/user/devices/me", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetUserDevices)
@@ -118,7 +123,17 @@ func (s *UserDevicesControllerTestSuite) SetupSuite() {
 	app.Post("/user/devices/:userDeviceID/commands/refresh", test.AuthInjectorTestHandler(s.testUserID, nil), c.RefreshUserDeviceStatus)
 	app.Get("/vehicle/:tokenID/commands/burn", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetBurnDevice)
 	app.Post("/vehicle/:tokenID/commands/burn", test.AuthInjectorTestHandler(s.testUserID, nil), c.PostBurnDevice)
+	app.Get("/vehicle/:tokenID/privileges", test.AuthInjectorTestHandler(s.testUserID, nil), c.GetVehiclePrivileges)
 	app.Delete("/user/devices/:userDeviceID", test.AuthInjectorTestHandler(s.testUserID, nil), c.DeleteUserDevice)
+	// Privilege token routes. Auth done by the middleware.
+	app.Get("/vehicle/:tokenID/integrations/:integrationID", c.GetVehicleIntegration)
+	app.Get("/vehicle/:tokenID/commands/:requestID", c.GetVehicleCommandRequestStatus)
+	app.Get("/vehicle/:tokenID/error-codes", c.GetVehicleErrorCodeQueries)
+	app.Get("/vehicle/:tokenID/aftermarket-device", c.GetVehicleAftermarketDevice)
+	amdOwner := app.Group("/aftermarket/device/by-serial/:serial", test.AuthInjectorTestHandler(s.testUserID, nil), owner.AftermarketDevice(s.pdb, s.usersClient, logger))
+	amdOwner.Get("/commands/claim", c.GetAftermarketDeviceClaimMessage)
+	amdOwner.Get("/commands/pair", c.GetAftermarketDevicePairMessage)
+	amdOwner.Get("/commands/unpair", c.GetAftermarketDeviceUnpairMessage)
 
 	s.controller = &c
 	s.app = app
@@ -207,7 +222,8 @@ func (s *UserDevicesControllerTestSuite) TestPostUserDeviceFromSmartcar() {
 
 	s.scClient.EXPECT().GetUserID(gomock.Any(), scToken.Access).Return("123", nil)
 	s.scClient.EXPECT().GetEndpoints(gomock.Any(), scToken.Access, "123").Return([]string{"https://smartcar.io/api"}, nil)
-	s.scClient.EXPECT().HasDoorControl(gomock.Any(), scToken.Access, "123").Return(false, nil)
+	s.scClient.EXPECT().GetPermissions(gomock.Any(), scToken.Access, "123").Return([]string{"read_vin"}, nil)
+	s.scClient.EXPECT().GetAvailableCommands([]string{"read_vin"}).Return(nil)
 	s.scClient.EXPECT().GetInfo(gomock.Any(), scToken.Access, "123").Times(1).Return(&smartcar.Info{
 		ID:              "1234567",
 		Make:            "FORD",
@@ -3,22 +3,19 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
-	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/middleware/address"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/oauth"
 	"github.com/DIMO-Network/devices-api/internal/services/tmpcred"
-	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -28,13 +25,12 @@ import (
 )
 
 type UserIntegrationAuthController struct {
-	Settings            *config.Settings
-	DBS                 func() *db.ReaderWriter
-	DeviceDefSvc        services.DeviceDefinitionService
-	log                 *zerolog.Logger
-	teslaFleetAPISvc    services.TeslaFleetAPIService
-	store               CredStore
-	teslaRequiredScopes []string
+	Settings     *config.Settings
+	DBS          func() *db.ReaderWriter
+	DeviceDefSvc services.DeviceDefinitionService
+	log          *zerolog.Logger
+	providers    oauth.Providers
+	store        CredStore
 }
 
 //go:generate mockgen -destination=cred_store_mock_test.go -package controllers . CredStore
@@ -47,23 +43,16 @@ func NewUserIntegrationAuthController(
 	dbs func() *db.ReaderWriter,
 	logger *zerolog.Logger,
 	ddSvc services.DeviceDefinitionService,
-	teslaFleetAPISvc services.TeslaFleetAPIService,
+	providers oauth.Providers,
 	credStore CredStore,
 ) UserIntegrationAuthController {
-
-	var teslaRequiredScopes []string
-	if settings.TeslaRequiredScopes != "" {
-		teslaRequiredScopes = strings.Split(settings.TeslaRequiredScopes, ",")
-	}
-
 	return UserIntegrationAuthController{
-		Settings:            settings,
-		DBS:                 dbs,
-		DeviceDefSvc:        ddSvc,
-		log:                 logger,
-		teslaFleetAPISvc:    teslaFleetAPISvc,
-		store:               credStore,
-		teslaRequiredScopes: teslaRequiredScopes,
+		Settings:     settings,
+		DBS:          dbs,
+		DeviceDefSvc: ddSvc,
+		log:          logger,
+		providers:    providers,
+		store:        credStore,
 	}
 }
 
@@ -72,20 +61,20 @@ type CompleteOAuthExchangeResponseWrapper struct {
 	Vehicles []CompleteOAuthExchangeResponse `json:"vehicles"`
 }
 
-// CompleteOAuthExchangeRequest request object for completing tesla OAuth
+// CompleteOAuthExchangeRequest request object for completing OAuth
 type CompleteOAuthExchangeRequest struct {
 	AuthorizationCode string `json:"authorizationCode"`
 	RedirectURI       string `json:"redirectUri"`
 }
 
-// CompleteOAuthExchangeResponse response object for tesla vehicles attached to user account
+// CompleteOAuthExchangeResponse response object for vehicles attached to the user's account
 type CompleteOAuthExchangeResponse struct {
 	ExternalID string           `json:"externalId"`
 	VIN        string           `json:"vin"`
 	Definition DeviceDefinition `json:"definition"`
 }
 
-// DeviceDefinition inner definition object containing meta data for each tesla vehicle
+// DeviceDefinition inner definition object containing meta data for each vehicle
 type DeviceDefinition struct {
 	Make               string `json:"make"`
 	Model              string `json:"model"`
@@ -93,26 +82,19 @@ type DeviceDefinition struct {
 	DeviceDefinitionID string `json:"id"`
 }
 
-type partialTeslaClaims struct {
-	jwt.RegisteredClaims
-	Scopes []string `json:"scp"`
-
-	// For debugging.
-	OUCode string `json:"ou_code"`
-}
-
-var teslaCodeFailureCount = promauto.NewCounterVec(
+var codeExchangeFailureCount = promauto.NewCounterVec(
 	prometheus.CounterOpts{
 		Namespace: "devices_api",
-		Subsystem: "tesla",
+		Subsystem: "oauth",
 		Name:      "code_exchange_failures_total",
-		Help:      "Known strains of failure during Tesla authorization code exchange and ensuing vehicle display.",
+		Help:      "Known strains of failure during authorization code exchange and ensuing vehicle display.",
 	},
-	[]string{"type"},
+	[]string{"vendor", "type"},
 )
 
 // CompleteOAuthExchange godoc
-// @Description Complete Tesla auth and get devices for authenticated user
+// @Description Complete OAuth for an integration and get the vehicles that the user granted access to. The
+// @Description credentials are held briefly so that the next registration or reauthentication call can use them.
 // @Tags        user-devices
 // @Produce     json
 // @Accept      json
@@ -140,87 +122,85 @@ func (u *UserIntegrationAuthController) CompleteOAuthExchange(c *fiber.Ctx) erro
 		return fmt.Errorf("error looking up integration %d: %w", tokenID, err)
 	}
 
-	if intd.Vendor != constants.TeslaVendor {
-		return fiber.NewError(fiber.StatusBadRequest, "Endpoint only valid for the Tesla integration.")
+	vendor := intd.Vendor
+	provider, ok := u.providers[vendor]
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Integration %d does not use OAuth.", tokenID))
 	}
+	vendorLogger := logger.With().Str("vendor", vendor).Logger()
+	logger = &vendorLogger
 
 	var reqBody CompleteOAuthExchangeRequest
 	if err := c.BodyParser(&reqBody); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse JSON request body.")
 	}
 
-	teslaAuth, err := u.teslaFleetAPISvc.CompleteTeslaAuthCodeExchange(c.Context(), reqBody.AuthorizationCode, reqBody.RedirectURI)
+	token, err := provider.ExchangeCode(c.Context(), reqBody.AuthorizationCode, reqBody.RedirectURI)
 	if err != nil {
-		if errors.Is(err, services.ErrInvalidAuthCode) {
-			teslaCodeFailureCount.WithLabelValues("auth_code").Inc()
+		if errors.Is(err, oauth.ErrInvalidCode) {
+			codeExchangeFailureCount.WithLabelValues(vendor, "auth_code").Inc()
 			return fiber.NewError(fiber.StatusBadRequest, "Authorization code invalid, expired, or revoked. Retry login.")
 		}
-		return fiber.NewError(fiber.StatusInternalServerError, "failed to get tesla authCode:"+err.Error())
+		logger.Err(err).Msg("Failed to exchange authorization code.")
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Failed to exchange authorization code with %s.", vendor))
 	}
 
-	if teslaAuth.RefreshToken == "" {
+	if token.RefreshToken == "" {
 		return fiber.NewError(fiber.StatusBadRequest, "Code exchange did not return a refresh token. Make sure you've granted offline_access.")
 	}
 
-	var claims partialTeslaClaims
-	_, _, err = jwt.NewParser().ParseUnverified(teslaAuth.AccessToken, &claims)
+	missingScopes, err := oauth.MissingScopes(c.Context(), provider, token)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Code exchange returned an unparseable access token.")
-	}
-
-	var missingScopes []string
-	for _, scope := range u.teslaRequiredScopes {
-		if !slices.Contains(claims.Scopes, scope) {
-			missingScopes = append(missingScopes, scope)
-		}
+		logger.Err(err).Msg("Couldn't determine granted scopes.")
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't determine the scopes granted by the code exchange.")
 	}
 
 	if len(missingScopes) != 0 {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Missing scopes %s.", strings.Join(missingScopes, ", ")))
 	}
 
-	// Save tesla oauth credentials in cache
+	// Hold on to the credentials until the client registers a vehicle or reauthenticates.
 	if err := u.store.Store(c.Context(), userAddr, &tmpcred.Credential{
 		IntegrationID: int(tokenID),
-		AccessToken:   teslaAuth.AccessToken,
-		RefreshToken:  teslaAuth.RefreshToken,
-		Expiry:        teslaAuth.Expiry,
+		AccessToken:   token.AccessToken,
+		RefreshToken:  token.RefreshToken,
+		Expiry:        token.Expiry,
 	}); err != nil {
 		return fmt.Errorf("error persisting credentials: %w", err)
 	}
 
-	vehicles, err := u.teslaFleetAPISvc.GetVehicles(c.Context(), teslaAuth.AccessToken)
+	vehicles, err := provider.ListVehicles(c.Context(), token.AccessToken)
 	if err != nil {
-		logger.Err(err).Str("subject", claims.Subject).Str("ouCode", claims.OUCode).Interface("audience", claims.Audience).Msg("Error retrieving vehicles.")
+		logger.Err(err).Msg("Error retrieving vehicles.")
 		if errors.Is(err, services.ErrWrongRegion) {
-			teslaCodeFailureCount.WithLabelValues("wrong_region").Inc()
+			codeExchangeFailureCount.WithLabelValues(vendor, "wrong_region").Inc()
 			return fiber.NewError(fiber.StatusInternalServerError, "Region detection failed. Waiting on a fix from Tesla.")
 		}
-		return fiber.NewError(fiber.StatusInternalServerError, "Couldn't fetch vehicles from Tesla.")
+		return fiber.NewError(fiber.StatusInternalServerError, fmt.Sprintf("Couldn't fetch vehicles from %s.", vendor))
 	}
 
 	decodeStart := time.Now()
 	response := make([]CompleteOAuthExchangeResponse, 0, len(vehicles))
 	for _, v := range vehicles {
-		ddRes, err := u.decodeTeslaVIN(c.Context(), v.VIN)
+		dd, err := provider.DecodeVIN(c.Context(), v.VIN)
 		if err != nil {
-			teslaCodeFailureCount.WithLabelValues("vin_decode").Inc()
-			logger.Err(err).Str("vin", v.VIN).Msg("Failed to decode Tesla VIN.")
-			return fiber.NewError(fiber.StatusFailedDependency, "An error occurred completing tesla authorization")
+			codeExchangeFailureCount.WithLabelValues(vendor, "vin_decode").Inc()
+			logger.Err(err).Str("vin", v.VIN).Msg("Failed to decode VIN.")
+			return fiber.NewError(fiber.StatusFailedDependency, fmt.Sprintf("An error occurred completing %s authorization", vendor))
 		}
 
 		response = append(response, CompleteOAuthExchangeResponse{
-			ExternalID: strconv.Itoa(v.ID),
+			ExternalID: v.ExternalID,
 			VIN:        v.VIN,
 			Definition: DeviceDefinition{
-				Make:               ddRes.Make,
-				Model:              ddRes.Model,
-				Year:               ddRes.Year,
-				DeviceDefinitionID: ddRes.ID,
+				Make:               dd.Make,
+				Model:              dd.Model,
+				Year:               dd.Year,
+				DeviceDefinitionID: dd.ID,
 			},
 		})
 	}
-	logger.Info().Msgf("Took %s to \"decode\" %d Tesla VINs.", time.Since(decodeStart), len(vehicles))
+	logger.Info().Msgf("Took %s to decode %d VINs.", time.Since(decodeStart), len(vehicles))
 
 	vehicleResp := &CompleteOAuthExchangeResponseWrapper{
 		Vehicles: response,
@@ -228,23 +208,3 @@ func (u *UserIntegrationAuthController) CompleteOAuthExchange(c *fiber.Ctx) erro
 
 	return c.JSON(vehicleResp)
 }
-
-type decodeResult struct {
-	ID    string
-	Make  string
-	Model string
-	Year  int
-}
-
-func (u *UserIntegrationAuthController) decodeTeslaVIN(ctx context.Context, vin string) (*decodeResult, error) {
-	teslaMake := "Tesla"
-	model := shared.VIN(vin).TeslaModel()
-	year := shared.VIN(vin).Year()
-
-	res, err := u.DeviceDefSvc.FindDeviceDefinitionByMMY(ctx, teslaMake, model, year)
-	if err != nil {
-		return nil, err
-	}
-
-	return &decodeResult{ID: res.DeviceDefinitionId, Make: teslaMake, Model: model, Year: year}, nil
-}
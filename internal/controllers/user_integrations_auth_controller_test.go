@@ -13,12 +13,14 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
+	"github.com/DIMO-Network/devices-api/internal/services/oauth"
 	"github.com/DIMO-Network/devices-api/internal/services/tmpcred"
 	"github.com/DIMO-Network/devices-api/internal/test"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	smartcar "github.com/smartcar/go-sdk"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"go.uber.org/mock/gomock"
@@ -35,6 +37,7 @@ type UserIntegrationAuthControllerTestSuite struct {
 	deviceDefSvc     *mock_services.MockDeviceDefinitionService
 	testUserID       string
 	teslaFleetAPISvc *mock_services.MockTeslaFleetAPIService
+	smartcarClient   *mock_services.MockSmartcarClient
 	userAddr         common.Address
 	credStore        *MockCredStore
 }
@@ -50,12 +53,16 @@ func (s *UserIntegrationAuthControllerTestSuite) SetupSuite() {
 	s.credStore = NewMockCredStore(mockCtrl)
 	s.deviceDefSvc = mock_services.NewMockDeviceDefinitionService(mockCtrl)
 	s.teslaFleetAPISvc = mock_services.NewMockTeslaFleetAPIService(mockCtrl)
+	s.smartcarClient = mock_services.NewMockSmartcarClient(mockCtrl)
 	s.testUserID = "123123"
 	c := NewUserIntegrationAuthController(&config.Settings{
 		Port:                "3000",
 		Environment:         "prod",
 		TeslaRequiredScopes: "vehicle_device_data",
-	}, s.pdb.DBS, logger, s.deviceDefSvc, s.teslaFleetAPISvc, s.credStore)
+	}, s.pdb.DBS, logger, s.deviceDefSvc, oauth.Providers{
+		constants.TeslaVendor:    oauth.NewTeslaProvider(s.teslaFleetAPISvc, s.deviceDefSvc, []string{"vehicle_device_data"}),
+		constants.SmartCarVendor: oauth.NewSmartcarProvider(s.smartcarClient, s.deviceDefSvc, []string{"read_vin"}),
+	}, s.credStore)
 	app := test.SetupAppFiber(*logger)
 	s.userAddr = common.HexToAddress("1")
 	app.Post("/integration/:tokenID/credentials", func(c *fiber.Ctx) error {
@@ -270,7 +277,7 @@ func (s *UserIntegrationAuthControllerTestSuite) TestCompleteOAuthExchange_Unpro
 
 func (s *UserIntegrationAuthControllerTestSuite) TestCompleteOAuthExchange_InvalidTokenID() {
 	s.deviceDefSvc.EXPECT().GetIntegrationByTokenID(gomock.Any(), uint64(1)).Return(&ddgrpc.Integration{
-		Vendor: constants.SmartCarVendor,
+		Vendor: constants.AutoPiVendor,
 	}, nil)
 
 	request := test.BuildRequest("POST", "/integration/1/credentials", fmt.Sprintf(`{
@@ -282,3 +289,57 @@ func (s *UserIntegrationAuthControllerTestSuite) TestCompleteOAuthExchange_Inval
 
 	s.Assert().Equal(fiber.StatusBadRequest, response.StatusCode)
 }
+
+func (s *UserIntegrationAuthControllerTestSuite) TestCompleteOAuthExchange_Smartcar() {
+	expiry := time.Now().Add(2 * time.Hour)
+	s.deviceDefSvc.EXPECT().GetIntegrationByTokenID(gomock.Any(), uint64(1)).Return(&ddgrpc.Integration{
+		Vendor: constants.SmartCarVendor,
+	}, nil)
+	s.smartcarClient.EXPECT().ExchangeCode(gomock.Any(), "code", "https://mock-redirect.test.dimo.zone").Return(&smartcar.Token{
+		Access:       "access",
+		Refresh:      "refresh",
+		AccessExpiry: expiry,
+	}, nil)
+	s.smartcarClient.EXPECT().GetVehicleIDs(gomock.Any(), "access").Return([]string{"sc-1"}, nil).Times(2)
+	s.smartcarClient.EXPECT().GetPermissions(gomock.Any(), "access", "sc-1").Return([]string{"read_vin", "read_odometer"}, nil)
+	s.smartcarClient.EXPECT().GetVIN(gomock.Any(), "access", "sc-1").Return("1FMCU0G62MUA52727", nil)
+	s.deviceDefSvc.EXPECT().DecodeVIN(gomock.Any(), "1FMCU0G62MUA52727", "", 0, "").Return(&ddgrpc.DecodeVinResponse{DefinitionId: "ford_escape_2021"}, nil)
+	s.deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), "ford_escape_2021").Return(&ddgrpc.GetDeviceDefinitionItemResponse{
+		DeviceDefinitionId: "someID-3",
+		Make:               &ddgrpc.DeviceMake{Name: "Ford"},
+		Model:              "Escape",
+		Year:               2021,
+	}, nil)
+	s.credStore.EXPECT().Store(gomock.Any(), s.userAddr, &tmpcred.Credential{
+		IntegrationID: 1,
+		AccessToken:   "access",
+		RefreshToken:  "refresh",
+		Expiry:        expiry,
+	}).Return(nil)
+
+	request := test.BuildRequest("POST", "/integration/1/credentials", `{"authorizationCode": "code", "redirectUri": "https://mock-redirect.test.dimo.zone"}`)
+	response, err := s.app.Test(request)
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+
+	expected, err := json.Marshal(CompleteOAuthExchangeResponseWrapper{
+		Vehicles: []CompleteOAuthExchangeResponse{
+			{
+				ExternalID: "sc-1",
+				VIN:        "1FMCU0G62MUA52727",
+				Definition: DeviceDefinition{
+					Make:               "Ford",
+					Model:              "Escape",
+					Year:               2021,
+					DeviceDefinitionID: "someID-3",
+				},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	s.JSONEq(string(expected), string(body))
+}
@@ -11,13 +11,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	smartcar "github.com/smartcar/go-sdk"
 
 	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
 	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/internal/services/oauth"
 	"github.com/DIMO-Network/devices-api/internal/services/tmpcred"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
@@ -45,8 +45,12 @@ func isTeslaVirtualKeyCapable(model string, year int) bool {
 // @Security    BearerAuth
 // @Router      /user/devices/{userDeviceID}/integrations/{integrationID} [get]
 func (udc *UserDevicesController) GetUserDeviceIntegration(c *fiber.Ctx) error {
-	userDeviceID := c.Params("userDeviceID")
-	integrationID := c.Params("integrationID")
+	return udc.getUserDeviceIntegration(c, c.Params("userDeviceID"), c.Params("integrationID"))
+}
+
+// getUserDeviceIntegration responds with the integration's status and, for Tesla, the
+// state of the virtual key and telemetry subscription.
+func (udc *UserDevicesController) getUserDeviceIntegration(c *fiber.Ctx, userDeviceID, integrationID string) error {
 	deviceExists, err := models.UserDevices(
 		models.UserDeviceWhere.ID.EQ(userDeviceID),
 	).Exists(c.Context(), udc.DBS().Reader)
@@ -112,8 +116,7 @@ func (udc *UserDevicesController) GetUserDeviceIntegration(c *fiber.Ctx) error {
 					return fmt.Errorf("failed to decrypt access token: %w", err)
 				}
 
-				var claims partialTeslaClaims
-				_, _, err = jwt.NewParser().ParseUnverified(accessToken, &claims)
+				scopes, err := oauth.TeslaScopes(accessToken)
 				if err != nil {
 					return fiber.NewError(fiber.StatusInternalServerError, "Couldn't parse access token.")
 				}
@@ -121,7 +124,7 @@ func (udc *UserDevicesController) GetUserDeviceIntegration(c *fiber.Ctx) error {
 				if udc.Settings.TeslaRequiredScopes != "" {
 					// Yes, wasteful Split.
 					for _, scope := range strings.Split(udc.Settings.TeslaRequiredScopes, ",") {
-						if !slices.Contains(claims.Scopes, scope) {
+						if !slices.Contains(scopes, scope) {
 							resp.Tesla.MissingRequiredScopes = append(resp.Tesla.MissingRequiredScopes, scope)
 						}
 					}
@@ -194,6 +197,96 @@ func (udc *UserDevicesController) getTelemetrySubscriptionStatus(ctx context.Con
 	return isSubscribed, nil
 }
 
+// GetUserDeviceIntegrationHistory godoc
+// @Summary     List an integration's status changes.
+// @Description Lists every status the integration has moved through, newest first, along with its current status and how long it has been in it.
+// @ID          get-user-device-integration-history
+// @Tags        integrations
+// @Produce     json
+// @Param       userDeviceID  path  string true  "Device ID"
+// @Param       integrationID path  string true  "Integration ID"
+// @Param       page          query int    false "Page number, starting from 1"
+// @Param       pageSize      query int    false "Page size, at most 100"
+// @Success     200 {object} controllers.IntegrationStatusHistoryResp
+// @Security    BearerAuth
+// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/history [get]
+func (udc *UserDevicesController) GetUserDeviceIntegrationHistory(c *fiber.Ctx) error {
+	return udc.getUserDeviceIntegrationHistory(c, c.Params("userDeviceID"), c.Params("integrationID"))
+}
+
+func (udc *UserDevicesController) getUserDeviceIntegrationHistory(c *fiber.Ctx, userDeviceID, integrationID string) error {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		return fiber.NewError(fiber.StatusBadRequest, "Page must be at least 1.")
+	}
+
+	pageSize := c.QueryInt("pageSize", defaultStatusHistoryPageSize)
+	if pageSize < 1 || pageSize > maxStatusHistoryPageSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Page size must be between 1 and %d.", maxStatusHistoryPageSize))
+	}
+
+	mods := []qm.QueryMod{
+		models.UserDeviceAPIIntegrationStatusHistoryWhere.UserDeviceID.EQ(userDeviceID),
+		models.UserDeviceAPIIntegrationStatusHistoryWhere.IntegrationID.EQ(integrationID),
+	}
+
+	total, err := models.UserDeviceAPIIntegrationStatusHistories(mods...).Count(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	udai, err := models.FindUserDeviceAPIIntegration(c.Context(), udc.DBS().Reader, userDeviceID, integrationID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if total == 0 {
+			return fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("User device %s has never had integration %s.", userDeviceID, integrationID))
+		}
+		// The integration was removed, but we can still show its history.
+	}
+
+	mods = append(mods,
+		qm.OrderBy(models.UserDeviceAPIIntegrationStatusHistoryColumns.CreatedAt+" DESC, "+models.UserDeviceAPIIntegrationStatusHistoryColumns.ID+" DESC"),
+		qm.Limit(pageSize),
+		qm.Offset((page-1)*pageSize),
+	)
+
+	hs, err := models.UserDeviceAPIIntegrationStatusHistories(mods...).All(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		return err
+	}
+
+	resp := IntegrationStatusHistoryResp{
+		Transitions: make([]IntegrationStatusTransition, len(hs)),
+		Page:        page,
+		PageSize:    pageSize,
+		Total:       total,
+	}
+
+	if udai != nil {
+		resp.Status = &udai.Status
+	}
+
+	for i, h := range hs {
+		resp.Transitions[i] = IntegrationStatusTransition{
+			FromStatus: h.FromStatus.Ptr(),
+			ToStatus:   h.ToStatus.Ptr(),
+			Source:     h.Source,
+			Reason:     h.Reason.Ptr(),
+			CreatedAt:  h.CreatedAt,
+		}
+	}
+
+	// The newest transition tells us when the current status started, unless
+	// it's on a later page.
+	if udai != nil && page == 1 && len(hs) != 0 && hs[0].ToStatus.Valid && hs[0].ToStatus.String == udai.Status {
+		resp.StatusSince = &hs[0].CreatedAt
+	}
+
+	return c.JSON(resp)
+}
+
 func (udc *UserDevicesController) deleteDeviceIntegration(ctx context.Context, userID, userDeviceID, integrationID string, dd *ddgrpc.GetDeviceDefinitionItemResponse, tx *sql.Tx) error {
 	apiInt, err := models.UserDeviceAPIIntegrations(
 		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(userDeviceID),
@@ -242,6 +335,10 @@ func (udc *UserDevicesController) deleteDeviceIntegration(ctx context.Context, u
 		return err
 	}
 
+	if err := services.RecordIntegrationRemoval(ctx, tx, apiInt, services.IntegrationStatusSourceUser, ""); err != nil {
+		return err
+	}
+
 	var vin string
 	if apiInt.R.UserDevice.VinConfirmed {
 		vin = apiInt.R.UserDevice.VinIdentifier.String
@@ -393,23 +490,217 @@ func (udc *UserDevicesController) GetCommandRequestStatus(c *fiber.Ctx) error {
 		return opaqueInternalError
 	}
 
-	dcr := CommandRequestStatusResp{
-		ID:        requestID,
-		Command:   cr.Command,
-		Status:    cr.Status,
-		CreatedAt: cr.CreatedAt,
-		UpdatedAt: cr.UpdatedAt,
+	return c.JSON(commandRequestStatusResp(cr))
+}
+
+// CancelCommandRequest godoc
+// @Summary     Cancel a pending command.
+// @Description Cancel a submitted command that has not yet received a status. Only pending commands can be cancelled.
+// @ID          cancel-command-request
+// @Tags        device,integration,command
+// @Success 200 {object} controllers.CommandRequestStatusResp
+// @Produce     json
+// @Param       userDeviceID  path string true "Device ID"
+// @Param       integrationID path string true "Integration ID"
+// @Param       requestID path string true "Command request ID"
+// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/{requestID} [delete]
+func (udc *UserDevicesController) CancelCommandRequest(c *fiber.Ctx) error {
+	userDeviceID := c.Params("userDeviceID")
+	integrationID := c.Params("integrationID")
+	requestID := c.Params("requestID")
+
+	tx, err := udc.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	cr, err := models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.ID.EQ(requestID),
+		models.DeviceCommandRequestWhere.UserDeviceID.EQ(userDeviceID),
+		models.DeviceCommandRequestWhere.IntegrationID.EQ(integrationID),
+		qm.For("UPDATE"),
+	).One(c.Context(), tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No command request with that id found.")
+		}
+		udc.log.Err(err).Msg("Failed to search for command request.")
+		return opaqueInternalError
+	}
+
+	if cr.Status != models.DeviceCommandRequestStatusPending {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Command request is already %s.", cr.Status))
+	}
+
+	cr.Status = models.DeviceCommandRequestStatusCancelled
+	if _, err := cr.Update(c.Context(), tx, boil.Infer()); err != nil {
+		udc.log.Err(err).Msg("Failed to cancel command request.")
+		return opaqueInternalError
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
 	}
 
-	return c.JSON(dcr)
+	return c.JSON(commandRequestStatusResp(cr))
 }
 
 type CommandRequestStatusResp struct {
-	ID        string    `json:"id" example:"2D8LqUHQtaMHH6LYPqznmJMBeZm"`
-	Command   string    `json:"command" example:"doors/unlock"`
-	Status    string    `json:"status" enums:"Pending,Complete,Failed" example:"Complete"`
-	CreatedAt time.Time `json:"createdAt" example:"2022-08-09T19:38:39Z"`
-	UpdatedAt time.Time `json:"updatedAt" example:"2022-08-09T19:39:22Z"`
+	ID            string `json:"id" example:"2D8LqUHQtaMHH6LYPqznmJMBeZm"`
+	IntegrationID string `json:"integrationId" example:"26A5Dk3vvvQutjSyF0Jka2DP5lg"`
+	Command       string `json:"command" example:"doors/unlock"`
+	Status        string `json:"status" enums:"Pending,Complete,Failed,TimedOut,Cancelled" example:"Complete"`
+	// Requester is the subject of the token used to send the command: a user id
+	// or, for privilege tokens, the grantee's address.
+	Requester *string `json:"requester,omitempty" example:"ChFrc3VpZC0xMjM0NTY3ODkwEgRnb29nbGU"`
+	// Result is the integration's response to the command, if it sent one.
+	Result json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	// FailureReason explains why the command failed or timed out.
+	FailureReason *string   `json:"failureReason,omitempty" example:"Vehicle is asleep."`
+	CreatedAt     time.Time `json:"createdAt" example:"2022-08-09T19:38:39Z"`
+	UpdatedAt     time.Time `json:"updatedAt" example:"2022-08-09T19:39:22Z"`
+}
+
+func commandRequestStatusResp(cr *models.DeviceCommandRequest) CommandRequestStatusResp {
+	resp := CommandRequestStatusResp{
+		ID:            cr.ID,
+		IntegrationID: cr.IntegrationID,
+		Command:       cr.Command,
+		Status:        cr.Status,
+		Requester:     cr.Requester.Ptr(),
+		FailureReason: cr.FailureReason.Ptr(),
+		CreatedAt:     cr.CreatedAt,
+		UpdatedAt:     cr.UpdatedAt,
+	}
+	if cr.Result.Valid {
+		resp.Result = json.RawMessage(cr.Result.JSON)
+	}
+	return resp
+}
+
+// CommandRequestHistoryResp is a page of command requests, newest first.
+type CommandRequestHistoryResp struct {
+	Commands []CommandRequestStatusResp `json:"commands"`
+	Page     int                        `json:"page" example:"1"`
+	PageSize int                        `json:"pageSize" example:"20"`
+	// Total is the number of requests matching the filters, across all pages.
+	Total int64 `json:"total" example:"42"`
+}
+
+const (
+	defaultCommandHistoryPageSize = 20
+	maxCommandHistoryPageSize     = 100
+)
+
+// listCommandRequests reads the command history filters and paging from the
+// query string and returns the matching requests for the device.
+func listCommandRequests(c *fiber.Ctx, exec boil.ContextExecutor, userDeviceID string) (*CommandRequestHistoryResp, error) {
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Page must be at least 1.")
+	}
+
+	pageSize := c.QueryInt("pageSize", defaultCommandHistoryPageSize)
+	if pageSize < 1 || pageSize > maxCommandHistoryPageSize {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Page size must be between 1 and %d.", maxCommandHistoryPageSize))
+	}
+
+	mods := []qm.QueryMod{
+		models.DeviceCommandRequestWhere.UserDeviceID.EQ(userDeviceID),
+	}
+
+	if command := c.Query("command"); command != "" {
+		mods = append(mods, models.DeviceCommandRequestWhere.Command.EQ(command))
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !slices.Contains(models.AllDeviceCommandRequestStatus(), status) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Unrecognized status %q.", status))
+		}
+		mods = append(mods, models.DeviceCommandRequestWhere.Status.EQ(status))
+	}
+
+	if integrationID := c.Query("integrationId"); integrationID != "" {
+		mods = append(mods, models.DeviceCommandRequestWhere.IntegrationID.EQ(integrationID))
+	}
+
+	if raw := c.Query("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse since, expected an RFC 3339 timestamp.")
+		}
+		mods = append(mods, models.DeviceCommandRequestWhere.CreatedAt.GTE(since))
+	}
+
+	if raw := c.Query("until"); raw != "" {
+		until, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse until, expected an RFC 3339 timestamp.")
+		}
+		mods = append(mods, models.DeviceCommandRequestWhere.CreatedAt.LT(until))
+	}
+
+	total, err := models.DeviceCommandRequests(mods...).Count(c.Context(), exec)
+	if err != nil {
+		return nil, err
+	}
+
+	mods = append(mods,
+		qm.OrderBy(models.DeviceCommandRequestColumns.CreatedAt+" DESC, "+models.DeviceCommandRequestColumns.ID+" DESC"),
+		qm.Limit(pageSize),
+		qm.Offset((page-1)*pageSize),
+	)
+
+	crs, err := models.DeviceCommandRequests(mods...).All(c.Context(), exec)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &CommandRequestHistoryResp{
+		Commands: make([]CommandRequestStatusResp, len(crs)),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+	for i, cr := range crs {
+		resp.Commands[i] = commandRequestStatusResp(cr)
+	}
+
+	return resp, nil
+}
+
+// ListCommandRequests godoc
+// @Summary     List the device's command history.
+// @Description Lists the commands sent to the device, newest first. Results can be filtered and are paginated.
+// @ID          list-command-requests
+// @Tags        device,command
+// @Success 200 {object} controllers.CommandRequestHistoryResp
+// @Produce     json
+// @Param       userDeviceID  path  string true  "Device ID"
+// @Param       command       query string false "Only commands with this path, e.g., doors/unlock"
+// @Param       status        query string false "Only commands in this status" Enums(Pending,Complete,Failed,TimedOut,Cancelled)
+// @Param       integrationId query string false "Only commands sent through this integration"
+// @Param       since         query string false "Only commands created at or after this RFC 3339 time"
+// @Param       until         query string false "Only commands created before this RFC 3339 time"
+// @Param       page          query int    false "Page number, starting from 1"
+// @Param       pageSize      query int    false "Page size, at most 100"
+// @Security    BearerAuth
+// @Router      /user/devices/{userDeviceID}/commands [get]
+func (udc *UserDevicesController) ListCommandRequests(c *fiber.Ctx) error {
+	userDeviceID := c.Params("userDeviceID")
+
+	resp, err := listCommandRequests(c, udc.DBS().Reader, userDeviceID)
+	if err != nil {
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			return err
+		}
+		udc.log.Err(err).Msg("Failed to list command requests.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(resp)
 }
 
 // handleEnqueueCommand enqueues the command specified by commandPath with the
@@ -424,6 +715,11 @@ func (udc *UserDevicesController) handleEnqueueCommand(c *fiber.Ctx, commandPath
 
 	logger.Info().Msg("Received command request.")
 
+	params, err := parseCommandParams(c, commandPath)
+	if err != nil {
+		return err
+	}
+
 	// Checking both that the device exists and that the user owns it.
 	deviceOK, err := models.UserDevices(
 		models.UserDeviceWhere.ID.EQ(userDeviceID),
@@ -459,44 +755,17 @@ func (udc *UserDevicesController) handleEnqueueCommand(c *fiber.Ctx, commandPath
 		return opaqueInternalError
 	}
 
-	// TODO(elffjs): This map is ugly. Surely we interface our way out of this?
-	commandMap := map[string]map[string]func(udai *models.UserDeviceAPIIntegration) (string, error){
-		constants.SmartCarVendor: {
-			constants.DoorsUnlock: udc.smartcarTaskSvc.UnlockDoors,
-			constants.DoorsLock:   udc.smartcarTaskSvc.LockDoors,
-		},
-		constants.TeslaVendor: {
-			constants.DoorsUnlock: udc.teslaTaskService.UnlockDoors,
-			constants.DoorsLock:   udc.teslaTaskService.LockDoors,
-			constants.TrunkOpen:   udc.teslaTaskService.OpenTrunk,
-			constants.FrunkOpen:   udc.teslaTaskService.OpenFrunk,
-		},
-	}
-
 	integration, err := udc.DeviceDefSvc.GetIntegrationByID(c.Context(), udai.IntegrationID)
-
 	if err != nil {
 		return shared.GrpcErrorToFiber(err, "deviceDefSvc error getting integration id: "+udai.IntegrationID)
 	}
 
-	vendorCommandMap, ok := commandMap[integration.Vendor]
-	if !ok {
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
-	}
-
-	// This correctly handles md.Commands.Enabled being nil.
-	if !slices.Contains(md.Commands.Enabled, commandPath) {
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command with this device.")
-	}
-
-	commandFunc, ok := vendorCommandMap[commandPath]
-	if !ok {
-		// Should never get here.
-		logger.Error().Msg("Command was enabled for this device, but there is no function to execute it.")
-		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
+	commandFunc, err := udc.commandRegistry.Executor(integration.Vendor, commandPath, md)
+	if err != nil {
+		return commandExecutorError(err)
 	}
 
-	subTaskID, err := commandFunc(udai)
+	subTaskID, err := commandFunc(udai, params)
 	if err != nil {
 		logger.Err(err).Msg("Failed to start command task.")
 		return opaqueInternalError
@@ -508,6 +777,7 @@ func (udc *UserDevicesController) handleEnqueueCommand(c *fiber.Ctx, commandPath
 		IntegrationID: integrationID,
 		Command:       commandPath,
 		Status:        models.DeviceCommandRequestStatusPending,
+		Requester:     null.StringFrom(helpers.GetUserID(c)),
 	}
 
 	if err := comRow.Insert(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
@@ -524,60 +794,52 @@ type CommandResponse struct {
 	RequestID string `json:"requestId"`
 }
 
-// UnlockDoors godoc
-// @Summary     Unlock the device's doors
-// @Description Unlock the device's doors.
-// @ID          unlock-doors
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       userDeviceID  path string true "Device ID"
-// @Param       integrationID path string true "Integration ID"
-// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/doors/unlock [post]
-func (udc *UserDevicesController) UnlockDoors(c *fiber.Ctx) error {
-	return udc.handleEnqueueCommand(c, constants.DoorsUnlock)
-}
-
-// LockDoors godoc
-// @Summary     Lock the device's doors
-// @Description Lock the device's doors.
-// @ID          lock-doors
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       userDeviceID  path string true "Device ID"
-// @Param       integrationID path string true "Integration ID"
-// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/doors/lock [post]
-func (udc *UserDevicesController) LockDoors(c *fiber.Ctx) error {
-	return udc.handleEnqueueCommand(c, constants.DoorsLock)
+// parseCommandParams reads the optional JSON body of a command request and
+// checks that it has the arguments the command needs.
+func parseCommandParams(c *fiber.Ctx, commandPath string) (services.CommandParams, error) {
+	var params services.CommandParams
+	if len(c.Body()) != 0 {
+		if err := c.BodyParser(&params); err != nil {
+			return params, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+		}
+	}
+	if err := params.Validate(commandPath); err != nil {
+		return params, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	return params, nil
 }
 
-// OpenTrunk godoc
-// @Summary     Open the device's rear trunk
-// @Description Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.
-// @ID          open-trunk
-// @Tags        device,integration,command
-// @Success 200 {object} controllers.CommandResponse
-// @Produce     json
-// @Param       userDeviceID  path string true "Device ID"
-// @Param       integrationID path string true "Integration ID"
-// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/trunk/open [post]
-func (udc *UserDevicesController) OpenTrunk(c *fiber.Ctx) error {
-	return udc.handleEnqueueCommand(c, constants.TrunkOpen)
+// commandExecutorError translates a failed registry lookup into a response.
+func commandExecutorError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrCommandNotSupported):
+		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command.")
+	case errors.Is(err, services.ErrCommandNotEnabled):
+		return fiber.NewError(fiber.StatusConflict, "Integration is not capable of this command with this device.")
+	default:
+		return err
+	}
 }
 
-// OpenFrunk godoc
-// @Summary     Open the device's front trunk
-// @Description Open the device's front trunk. Currently, this only works for Teslas connected through Tesla.
-// @ID          open-frunk
+// EnqueueCommand godoc
+// @Summary     Send a command to the device
+// @Description Send a command, such as "doors/unlock", through the given integration. Which commands are
+// @Description available depends on the integration and the vehicle. Commands that take arguments,
+// @Description "charge/limit" and "climate/start", read them from the JSON body.
+// @ID          enqueue-command
 // @Tags        device,integration,command
 // @Success 200 {object} controllers.CommandResponse
 // @Produce     json
 // @Param       userDeviceID  path string true "Device ID"
 // @Param       integrationID path string true "Integration ID"
-// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/frunk/open [post]
-func (udc *UserDevicesController) OpenFrunk(c *fiber.Ctx) error {
-	return udc.handleEnqueueCommand(c, constants.FrunkOpen)
+// @Param       command       path string true "Command path, e.g., doors/unlock"
+// @Param       params        body services.CommandParams false "Arguments for commands such as charge/limit and climate/start"
+// @Param       Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Router      /user/devices/{userDeviceID}/integrations/{integrationID}/commands/{command} [post]
+func (udc *UserDevicesController) EnqueueCommand(commandPath string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return udc.handleEnqueueCommand(c, commandPath)
+	}
 }
 
 // TelemetrySubscribe godoc
@@ -1018,6 +1280,46 @@ func (udc *UserDevicesController) registerSmartcarIntegration(c *fiber.Ctx, logg
 			udc.redisCache.Del(c.Context(), buildSmartcarTokenKey(ud.VinIdentifier.String, ud.UserID))
 		}
 	}
+	// When the credentials come from the OAuth exchange endpoint they may cover several
+	// vehicles, so the client has to tell us which one it wants.
+	var externalID string
+	if token == nil && reqBody.Code == "" {
+		userAddr, hasAddr, err := udc.userAddrGetter.GetEthAddr(c)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Couldn't retrieve user Ethereum address.")
+		}
+		if !hasAddr {
+			return fiber.NewError(fiber.StatusBadRequest, "No authorization code given and no Ethereum address for user.")
+		}
+
+		if reqBody.ExternalID == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "Missing externalId field.")
+		}
+
+		store := &tmpcred.Store{
+			Redis:  udc.redisCache,
+			Cipher: udc.cipher,
+		}
+
+		cred, err := store.Retrieve(c.Context(), userAddr)
+		if err != nil {
+			if errors.Is(err, tmpcred.ErrNotFound) {
+				return fiber.NewError(fiber.StatusBadRequest, "No authorization code given and no credentials found for user.")
+			}
+			return err
+		}
+
+		if uint64(cred.IntegrationID) != integ.TokenId {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Stored credentials are for integration %d, not Smartcar.", cred.IntegrationID))
+		}
+
+		token = &smartcar.Token{
+			Access:       cred.AccessToken,
+			Refresh:      cred.RefreshToken,
+			AccessExpiry: cred.Expiry,
+		}
+		externalID = reqBody.ExternalID
+	}
 	if token == nil {
 		// no token found or could be unmarshalled so try exchangecode, assumption is it has not been called before for this code
 		var err error
@@ -1041,23 +1343,24 @@ func (udc *UserDevicesController) registerSmartcarIntegration(c *fiber.Ctx, logg
 		return smartcarCallErr
 	}
 
-	externalID, err := udc.smartcarClient.GetExternalID(c.Context(), token.Access)
-	if err != nil {
-		logger.Err(err).Msg("Failed to retrieve vehicle ID from Smartcar.")
-		return smartcarCallErr
-	}
-	// by default use vin from userdevice, unless if it is not confirmed, in that case pull from SC
-	vin := ud.VinIdentifier.String
-	if !ud.VinConfirmed {
-		vin, err = udc.smartcarClient.GetVIN(c.Context(), token.Access, externalID)
+	if externalID == "" {
+		externalID, err = udc.smartcarClient.GetExternalID(c.Context(), token.Access)
 		if err != nil {
-			logger.Err(err).Msg("Failed to retrieve VIN from Smartcar.")
+			logger.Err(err).Msg("Failed to retrieve vehicle ID from Smartcar.")
 			return smartcarCallErr
 		}
+	}
+	// Always ask Smartcar, so that a stored credential or external id for some other car can't be attached to
+	// a vehicle whose VIN we've already confirmed.
+	vin, err := udc.smartcarClient.GetVIN(c.Context(), token.Access, externalID)
+	if err != nil {
+		logger.Err(err).Msg("Failed to retrieve VIN from Smartcar.")
+		return smartcarCallErr
+	}
 
-		if ud.VinConfirmed && ud.VinIdentifier.String != vin {
-			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Vehicle's confirmed VIN does not match Smartcar's %s.", vin))
-		}
+	if ud.VinConfirmed && !strings.EqualFold(ud.VinIdentifier.String, vin) {
+		logger.Warn().Str("smartcarVin", vin).Msg("Smartcar VIN does not match the vehicle's confirmed VIN.")
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Vehicle's confirmed VIN does not match Smartcar's %s.", vin))
 	}
 	localLog := logger.With().Str("vin", vin).Str("userId", ud.UserID).Logger()
 
@@ -1104,17 +1407,13 @@ func (udc *UserDevicesController) registerSmartcarIntegration(c *fiber.Ctx, logg
 		return smartcarCallErr
 	}
 
-	var commands *services.UserDeviceAPIIntegrationsMetadataCommands
-
-	doorControl, err := udc.smartcarClient.HasDoorControl(c.Context(), token.Access, externalID)
+	perms, err := udc.smartcarClient.GetPermissions(c.Context(), token.Access, externalID)
 	if err != nil {
-		localLog.Err(err).Msg("Failed to retrieve door control permissions from Smartcar.")
+		localLog.Err(err).Msg("Failed to retrieve control permissions from Smartcar.")
 		return smartcarCallErr
 	}
 
-	if doorControl {
-		commands = udc.smartcarClient.GetAvailableCommands()
-	}
+	commands := udc.smartcarClient.GetAvailableCommands(perms)
 
 	meta := services.UserDeviceAPIIntegrationsMetadata{
 		SmartcarUserID:    &scUserID,
@@ -1299,6 +1598,10 @@ func (udc *UserDevicesController) registerDeviceTesla(c *fiber.Ctx, logger *zero
 		return err
 	}
 
+	if err := services.RecordIntegrationStatusChange(c.Context(), tx, &integration, "", services.IntegrationStatusSourceRegistration, ""); err != nil {
+		return err
+	}
+
 	ud.VinIdentifier = null.StringFrom(strings.ToUpper(v.VIN))
 	ud.VinConfirmed = true
 	_, err = ud.Update(c.Context(), tx, boil.Infer())
@@ -1397,7 +1700,8 @@ type UserDeviceIntegrationStatus struct {
 // RegisterDeviceIntegrationRequest carries credentials used to connect the device to a given
 // integration.
 type RegisterDeviceIntegrationRequest struct {
-	// Code is an OAuth authorization code. Not used in all integrations.
+	// Code is an OAuth authorization code. Not used in all integrations. For Smartcar, leave this
+	// empty and set ExternalID to use the credentials from the integration credentials endpoint.
 	Code string `json:"code"`
 	// RedirectURI is the OAuth redirect URI used by the frontend. Not used in all integrations.
 	RedirectURI string `json:"redirectURI"`
@@ -1479,6 +1783,39 @@ type GetUserDeviceIntegrationResponse struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
+const (
+	defaultStatusHistoryPageSize = 20
+	maxStatusHistoryPageSize     = 100
+)
+
+// IntegrationStatusHistoryResp is a page of an integration's status changes,
+// newest first.
+type IntegrationStatusHistoryResp struct {
+	// Status is the integration's current status. It is absent if the
+	// integration has since been removed.
+	Status *string `json:"status,omitempty" example:"AuthenticationFailure"`
+	// StatusSince is when the integration entered its current status.
+	StatusSince *time.Time                    `json:"statusSince,omitempty"`
+	Transitions []IntegrationStatusTransition `json:"transitions"`
+	Page        int                           `json:"page" example:"1"`
+	PageSize    int                           `json:"pageSize" example:"20"`
+	// Total is the number of transitions across all pages.
+	Total int64 `json:"total" example:"3"`
+}
+
+// IntegrationStatusTransition is a single change in an integration's status.
+type IntegrationStatusTransition struct {
+	// FromStatus is absent when the integration was created.
+	FromStatus *string `json:"fromStatus,omitempty" example:"Active"`
+	// ToStatus is absent when the integration was removed.
+	ToStatus *string `json:"toStatus,omitempty" example:"AuthenticationFailure"`
+	// Source is the part of the system that made the change, such as "task" or "reauthentication".
+	Source string `json:"source" example:"task"`
+	// Reason explains the change, if we know why it happened.
+	Reason    *string   `json:"reason,omitempty" example:"Polling task reported an authentication failure."`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type ManufacturerInfo struct {
 	TokenID *big.Int `json:"tokenId"`
 	Name    string   `json:"name"`
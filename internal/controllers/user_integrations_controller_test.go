@@ -118,7 +118,7 @@ func (s *UserIntegrationsControllerTestSuite) SetupSuite() {
 	logger := test.Logger()
 	c := NewUserDevicesController(&config.Settings{Port: "3000"}, s.pdb.DBS, logger, s.deviceDefSvc, s.deviceDefIntSvc, s.eventSvc, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, s.cipher, s.autopiAPISvc,
 		s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, s.redisClient, nil, s.userClient, nil, s.natsSvc, nil, s.userDeviceSvc,
-		s.teslaFleetAPISvc, nil, nil)
+		s.teslaFleetAPISvc, nil, nil, nil, nil)
 
 	app := test.SetupAppFiber(*logger)
 
@@ -127,10 +127,12 @@ func (s *UserIntegrationsControllerTestSuite) SetupSuite() {
 
 	app.Post("/user2/devices/:userDeviceID/integrations/:integrationID", test.AuthInjectorTestHandler(testUser2, nil), c.RegisterDeviceIntegration)
 	app.Get("/user/devices/:userDeviceID/integrations/:integrationID", test.AuthInjectorTestHandler(testUserID, nil), c.GetUserDeviceIntegration)
+	app.Get("/user/devices/:userDeviceID/integrations/:integrationID/history", test.AuthInjectorTestHandler(testUserID, nil), c.GetUserDeviceIntegrationHistory)
 	app.Post("/user/devices/:userDeviceID/integrations/:integrationID/commands/telemetry/subscribe",
 		test.AuthInjectorTestHandler(testUserID, nil),
 		c.TelemetrySubscribe,
 	)
+	app.Get("/user/devices/:userDeviceID/commands", test.AuthInjectorTestHandler(testUserID, nil), c.ListCommandRequests)
 
 	s.app = app
 }
@@ -264,7 +266,8 @@ func (s *UserIntegrationsControllerTestSuite) TestPostSmartCar_SuccessNewToken()
 	s.scClient.EXPECT().GetExternalID(gomock.Any(), "myAccess").Return("smartcar-idx", nil)
 	s.scClient.EXPECT().GetVIN(gomock.Any(), "myAccess", "smartcar-idx").Return(vin, nil)
 	s.scClient.EXPECT().GetEndpoints(gomock.Any(), "myAccess", "smartcar-idx").Return([]string{"/", "/vin"}, nil)
-	s.scClient.EXPECT().HasDoorControl(gomock.Any(), "myAccess", "smartcar-idx").Return(false, nil)
+	s.scClient.EXPECT().GetPermissions(gomock.Any(), "myAccess", "smartcar-idx").Return([]string{"read_vin"}, nil)
+	s.scClient.EXPECT().GetAvailableCommands([]string{"read_vin"}).Return(nil)
 	s.deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), integration.Id).Return(integration, nil)
 
 	rot13 := new(shared.ROT13Cipher)
@@ -319,7 +322,7 @@ func (s *UserIntegrationsControllerTestSuite) TestPostSmartCar_FailureTestVIN()
 
 	logger := test.Logger()
 	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: "prod"}, s.pdb.DBS, logger, s.deviceDefSvc, s.deviceDefIntSvc, s.eventSvc, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), s.autopiAPISvc,
-		s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, s.redisClient, nil, nil, nil, s.natsSvc, nil, s.userDeviceSvc, nil, nil, nil)
+		s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, s.redisClient, nil, nil, nil, s.natsSvc, nil, s.userDeviceSvc, nil, nil, nil, nil, nil)
 
 	app := test.SetupAppFiber(*logger)
 
@@ -388,8 +391,10 @@ func (s *UserIntegrationsControllerTestSuite) TestPostSmartCar_SuccessCachedToke
 
 	s.scClient.EXPECT().GetUserID(gomock.Any(), token.Access).Return(smartCarUserID, nil)
 	s.scClient.EXPECT().GetExternalID(gomock.Any(), token.Access).Return("smartcar-idx", nil)
+	s.scClient.EXPECT().GetVIN(gomock.Any(), token.Access, "smartcar-idx").Return(vin, nil)
 	s.scClient.EXPECT().GetEndpoints(gomock.Any(), token.Access, "smartcar-idx").Return([]string{"/", "/vin"}, nil)
-	s.scClient.EXPECT().HasDoorControl(gomock.Any(), token.Access, "smartcar-idx").Return(false, nil)
+	s.scClient.EXPECT().GetPermissions(gomock.Any(), token.Access, "smartcar-idx").Return([]string{"read_vin"}, nil)
+	s.scClient.EXPECT().GetAvailableCommands([]string{"read_vin"}).Return(nil)
 
 	// original device def
 	s.deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), ud.DefinitionID).Times(1).Return(dd[0], nil)
@@ -417,6 +422,34 @@ func (s *UserIntegrationsControllerTestSuite) TestPostSmartCar_SuccessCachedToke
 	//assert.Equal(s.T(), "fbzr-erserfu-pbqr", apiInt.RefreshToken.String)
 }
 
+func (s *UserIntegrationsControllerTestSuite) TestPostSmartCar_ConfirmedVINMismatch() {
+	integration := test.BuildIntegrationGRPC(smartCarIntegrationID, constants.SmartCarVendor, 10, 0)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "Mach E", 2020, integration)
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, dd[0].Id, nil, "", s.pdb)
+	ud.VinIdentifier = null.StringFrom("CARVIN")
+	ud.VinConfirmed = true
+	_, err := ud.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	require.NoError(s.T(), err)
+
+	req := `{
+			"code": "qxy",
+			"redirectURI": "http://dimo.zone/cb"
+		}`
+	token := &smartcar.Token{Access: "myAccess", Refresh: "myRefresh", AccessExpiry: time.Now().Add(time.Hour)}
+
+	s.redisClient.EXPECT().Get(gomock.Any(), buildSmartcarTokenKey("CARVIN", testUserID)).Return(redis.NewStringResult("", redis.Nil))
+	s.deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), integration.Id).Return(integration, nil)
+	s.scClient.EXPECT().ExchangeCode(gomock.Any(), "qxy", "http://dimo.zone/cb").Return(token, nil)
+	s.scClient.EXPECT().GetUserID(gomock.Any(), "myAccess").Return("smartCarUserId", nil)
+	s.scClient.EXPECT().GetExternalID(gomock.Any(), "myAccess").Return("smartcar-idx", nil)
+	s.scClient.EXPECT().GetVIN(gomock.Any(), "myAccess", "smartcar-idx").Return("OTHERVIN", nil)
+
+	request := test.BuildRequest("POST", "/user/devices/"+ud.ID+"/integrations/"+integration.Id, req)
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusConflict, response.StatusCode)
+}
+
 func (s *UserIntegrationsControllerTestSuite) TestPostUnknownDevice() {
 	req := `{
 			"code": "qxy",
@@ -519,7 +552,7 @@ func (s *UserIntegrationsControllerTestSuite) TestGetAutoPiInfoNoUDAI_ShouldUpda
 	const environment = "prod" // shouldUpdate only applies in prod
 	// specific dependency and controller
 	autopiAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
-	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	logger := zerolog.Nop()
 	app.Get("/aftermarket/device/by-serial/:serial", test.AuthInjectorTestHandler(testUserID, nil), owner.AftermarketDevice(s.pdb, s.userClient, &logger), c.GetAftermarketDeviceInfo)
@@ -559,7 +592,7 @@ func (s *UserIntegrationsControllerTestSuite) TestGetAutoPiInfoNoUDAI_UpToDate()
 	const environment = "prod" // shouldUpdate only applies in prod
 	// specific dependency and controller
 	autopiAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
-	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	logger := zerolog.Nop()
 	app.Get("/aftermarket/device/by-serial/:serial", test.AuthInjectorTestHandler(testUserID, nil), owner.AftermarketDevice(s.pdb, s.userClient, &logger), c.GetAftermarketDeviceInfo)
@@ -596,7 +629,7 @@ func (s *UserIntegrationsControllerTestSuite) TestGetAutoPiInfoNoUDAI_FutureUpda
 	const environment = "prod" // shouldUpdate only applies in prod
 	// specific dependency and controller
 	autopiAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
-	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	logger := zerolog.Nop()
 	app.Get("/aftermarket/device/by-serial/:serial", test.AuthInjectorTestHandler(testUserID, nil), owner.AftermarketDevice(s.pdb, s.userClient, &logger), c.GetAftermarketDeviceInfo)
@@ -635,7 +668,7 @@ func (s *UserIntegrationsControllerTestSuite) TestGetAutoPiInfoNoUDAI_ShouldUpda
 	const environment = "prod" // shouldUpdate only applies in prod
 	// specific dependency and controller
 	autopiAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
-	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	c := NewUserDevicesController(&config.Settings{Port: "3000", Environment: environment}, s.pdb.DBS, test.Logger(), s.deviceDefSvc, s.deviceDefIntSvc, &fakeEventService{}, s.scClient, s.scTaskSvc, s.teslaSvc, s.teslaTaskService, new(shared.ROT13Cipher), autopiAPISvc, s.autoPiIngest, s.deviceDefinitionRegistrar, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	app := fiber.New()
 	logger := zerolog.Nop()
 	app.Get("/aftermarket/device/by-serial/:serial", test.AuthInjectorTestHandler(testUserID, nil), owner.AftermarketDevice(s.pdb, s.userClient, &logger), c.GetAftermarketDeviceInfo)
@@ -996,3 +1029,96 @@ func (s *UserIntegrationsControllerTestSuite) Test_TelemetrySubscribe_NotCapable
 
 	s.Assert().True(res.StatusCode == fiber.StatusBadRequest)
 }
+
+func (s *UserIntegrationsControllerTestSuite) TestListCommandRequests() {
+	integration := test.BuildIntegrationGRPC(teslaIntegrationID, constants.TeslaVendor, 10, 0)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Tesla", "Model S", 2012, integration)
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, dd[0].Id, nil, "5YJSA1CN0CFP02439", s.pdb)
+
+	start := time.Now().Add(-time.Hour)
+	for i, cmd := range []string{constants.DoorsUnlock, constants.DoorsLock, constants.DoorsUnlock} {
+		dcr := models.DeviceCommandRequest{
+			ID:            ksuid.New().String(),
+			UserDeviceID:  ud.ID,
+			IntegrationID: integration.Id,
+			Command:       cmd,
+			Status:        models.DeviceCommandRequestStatusComplete,
+			Requester:     null.StringFrom(testUserID),
+			CreatedAt:     start.Add(time.Duration(i) * time.Minute),
+		}
+		s.Require().NoError(dcr.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	}
+
+	request := test.BuildRequest(http.MethodGet, fmt.Sprintf("/user/devices/%s/commands?command=%s&pageSize=1", ud.ID, constants.DoorsUnlock), "")
+	res, err := s.app.Test(request, 60*1000)
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	body, _ := io.ReadAll(res.Body)
+	defer res.Body.Close()
+
+	actual := CommandRequestHistoryResp{}
+	s.Require().NoError(json.Unmarshal(body, &actual))
+
+	s.Assert().EqualValues(2, actual.Total)
+	s.Require().Len(actual.Commands, 1)
+	s.Assert().Equal(constants.DoorsUnlock, actual.Commands[0].Command)
+	s.Assert().Equal(testUserID, *actual.Commands[0].Requester)
+	s.Assert().WithinDuration(start.Add(2*time.Minute), actual.Commands[0].CreatedAt, time.Second)
+
+	request = test.BuildRequest(http.MethodGet, fmt.Sprintf("/user/devices/%s/commands?status=Bogus", ud.ID), "")
+	res, err = s.app.Test(request, 60*1000)
+	s.Require().NoError(err)
+	s.Assert().Equal(fiber.StatusBadRequest, res.StatusCode)
+}
+
+func (s *UserIntegrationsControllerTestSuite) TestGetUserDeviceIntegrationHistory() {
+	integration := test.BuildIntegrationGRPC(teslaIntegrationID, constants.TeslaVendor, 10, 0)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Tesla", "Model S", 2012, integration)
+	ud := test.SetupCreateUserDevice(s.T(), testUserID, dd[0].Id, nil, "5YJSA1CN0CFP02439", s.pdb)
+	udai := test.SetupCreateUserDeviceAPIIntegration(s.T(), "", "xyz", ud.ID, integration.Id, s.pdb)
+
+	start := time.Now().Add(-time.Hour)
+	for i, to := range []string{models.UserDeviceAPIIntegrationStatusActive, models.UserDeviceAPIIntegrationStatusAuthenticationFailure} {
+		h := models.UserDeviceAPIIntegrationStatusHistory{
+			ID:            ksuid.New().String(),
+			UserDeviceID:  ud.ID,
+			IntegrationID: integration.Id,
+			ToStatus:      null.StringFrom(to),
+			Source:        services.IntegrationStatusSourceTask,
+			CreatedAt:     start.Add(time.Duration(i) * time.Minute),
+		}
+		if i != 0 {
+			h.FromStatus = null.StringFrom(models.UserDeviceAPIIntegrationStatusActive)
+		}
+		s.Require().NoError(h.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	}
+
+	udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+	_, err := udai.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status))
+	s.Require().NoError(err)
+
+	request := test.BuildRequest(http.MethodGet, fmt.Sprintf("/user/devices/%s/integrations/%s/history", ud.ID, integration.Id), "")
+	res, err := s.app.Test(request, 60*1000)
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	body, _ := io.ReadAll(res.Body)
+	defer res.Body.Close()
+
+	actual := IntegrationStatusHistoryResp{}
+	s.Require().NoError(json.Unmarshal(body, &actual))
+
+	s.Assert().EqualValues(2, actual.Total)
+	s.Require().Len(actual.Transitions, 2)
+	s.Assert().Equal(models.UserDeviceAPIIntegrationStatusAuthenticationFailure, *actual.Status)
+	s.Require().NotNil(actual.StatusSince)
+	s.Assert().WithinDuration(start.Add(time.Minute), *actual.StatusSince, time.Second)
+	s.Assert().Equal(models.UserDeviceAPIIntegrationStatusActive, *actual.Transitions[0].FromStatus)
+	s.Assert().Nil(actual.Transitions[1].FromStatus)
+
+	request = test.BuildRequest(http.MethodGet, fmt.Sprintf("/user/devices/%s/integrations/%s/history", ud.ID, ksuid.New().String()), "")
+	res, err = s.app.Test(request, 60*1000)
+	s.Require().NoError(err)
+	s.Assert().Equal(fiber.StatusNotFound, res.StatusCode)
+}
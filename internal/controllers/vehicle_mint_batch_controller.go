@@ -0,0 +1,364 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	idempotencymw "github.com/DIMO-Network/devices-api/internal/middleware/idempotency"
+	"github.com/DIMO-Network/devices-api/internal/services/registry"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	signer "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// maxMintBatchSize caps the number of vehicles minted in one request.
+const maxMintBatchSize = 100
+
+// mintBatchStatusRejected is the status of a vehicle in a mint batch for which no
+// transaction was sent.
+const mintBatchStatusRejected = "Rejected"
+
+// MintBatchPayloadsResp holds the data to sign for each vehicle in a bulk mint.
+type MintBatchPayloadsResp struct {
+	Vehicles []MintBatchPayload `json:"vehicles"`
+	// Skipped lists the requested vehicles that can't be minted, and why.
+	Skipped []MintBatchSkip `json:"skipped"`
+}
+
+// MintBatchPayload is the EIP-712 data that the owner must sign to mint one vehicle.
+type MintBatchPayload struct {
+	UserDeviceID string            `json:"userDeviceId"`
+	TypedData    *signer.TypedData `json:"typedData"`
+}
+
+// MintBatchSkip is a vehicle left out of a bulk mint.
+type MintBatchSkip struct {
+	UserDeviceID string `json:"userDeviceId"`
+	Reason       string `json:"reason"`
+}
+
+// MintBatchRequest carries the signatures for a bulk mint.
+type MintBatchRequest struct {
+	Vehicles []MintBatchVehicle `json:"vehicles"`
+}
+
+// MintBatchVehicle is the signature, and optionally the images, for one vehicle in a
+// bulk mint. As with the single-vehicle mint, the image may be left out if one was
+// assigned before.
+type MintBatchVehicle struct {
+	UserDeviceID string `json:"userDeviceId"`
+	VehicleMintRequest
+}
+
+// MintBatchStatusResp shows the progress of the transactions sent for a bulk mint.
+type MintBatchStatusResp struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Counts gives the number of vehicles in each status.
+	Counts   map[string]int           `json:"counts"`
+	Vehicles []MintBatchVehicleStatus `json:"vehicles"`
+}
+
+// MintBatchVehicleStatus is the state of one vehicle's mint.
+type MintBatchVehicleStatus struct {
+	UserDeviceID string `json:"userDeviceId"`
+	// RequestID is the id of the meta-transaction request. Absent if the vehicle was
+	// rejected.
+	RequestID *string `json:"requestId"`
+	// Status is that of the transaction or, if none was sent, "Rejected".
+	Status string `json:"status" enums:"Rejected,Unsubmitted,Submitted,Mined,Confirmed,Failed"`
+	// Hash is the hash of the transaction, once submitted.
+	Hash *hexutil.Bytes `json:"hash,omitempty" swaggertype:"string"`
+	// TokenID is the id of the vehicle NFT, once minted.
+	TokenID       *big.Int `json:"tokenId,omitempty" swaggertype:"number"`
+	FailureReason *string  `json:"failureReason"`
+}
+
+// GetMintBatchPayloads godoc
+// @Description Returns the data the user must sign to mint each of their unminted vehicles, up to 100 at a time.
+// @Description Vehicles that can't be minted yet, for example because their VIN isn't confirmed, are listed
+// @Description separately with the reason.
+// @Tags        user-devices
+// @Produce     json
+// @Param       userDeviceIds query string false "Comma-separated user device ids to restrict the batch to"
+// @Success     200 {object} controllers.MintBatchPayloadsResp
+// @Security    BearerAuth
+// @Router      /user/devices/mint/batch [get]
+func (udc *UserDevicesController) GetMintBatchPayloads(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+
+	mods := []qm.QueryMod{
+		models.UserDeviceWhere.UserID.EQ(userID),
+		models.UserDeviceWhere.TokenID.IsNull(),
+		qm.Load(models.UserDeviceRels.MintRequest),
+		qm.OrderBy(models.UserDeviceColumns.CreatedAt),
+	}
+
+	var ids []string
+	if raw := c.Query("userDeviceIds"); raw != "" {
+		ids = strings.Split(raw, ",")
+		if len(ids) > maxMintBatchSize {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("At most %d vehicles may be minted at once.", maxMintBatchSize))
+		}
+		mods = append(mods, models.UserDeviceWhere.ID.IN(ids))
+	}
+
+	uds, err := models.UserDevices(mods...).All(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		udc.log.Err(err).Msg("Failed to search for unminted vehicles.")
+		return opaqueInternalError
+	}
+
+	client := registry.Client{
+		Producer:     udc.producer,
+		RequestTopic: "topic.transaction.request.send",
+		Contract: registry.Contract{
+			ChainID: big.NewInt(udc.Settings.DIMORegistryChainID),
+			Address: common.HexToAddress(udc.Settings.DIMORegistryAddr),
+			Name:    "DIMO",
+			Version: "1",
+		},
+	}
+
+	resp := MintBatchPayloadsResp{
+		Vehicles: []MintBatchPayload{},
+		Skipped:  []MintBatchSkip{},
+	}
+
+	found := make(map[string]bool, len(uds))
+
+	for _, ud := range uds {
+		found[ud.ID] = true
+
+		if len(resp.Vehicles) == maxMintBatchSize {
+			resp.Skipped = append(resp.Skipped, MintBatchSkip{UserDeviceID: ud.ID, Reason: "Batch is full."})
+			continue
+		}
+
+		mvs, dd, err := udc.checkVehicleMint(c, ud)
+		if err != nil {
+			resp.Skipped = append(resp.Skipped, MintBatchSkip{UserDeviceID: ud.ID, Reason: err.Error()})
+			continue
+		}
+
+		resp.Vehicles = append(resp.Vehicles, MintBatchPayload{
+			UserDeviceID: ud.ID,
+			TypedData: client.GetPayload(&registry.MintVehicleWithDeviceDefinitionSign{
+				ManufacturerNode:   mvs.ManufacturerNode,
+				Owner:              mvs.Owner,
+				Attributes:         mvs.Attributes,
+				Infos:              mvs.Infos,
+				DeviceDefinitionID: dd.Id,
+			}),
+		})
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			resp.Skipped = append(resp.Skipped, MintBatchSkip{UserDeviceID: id, Reason: "No unminted vehicle with that id found."})
+		}
+	}
+
+	return c.JSON(resp)
+}
+
+// PostMintBatch godoc
+// @Description Sends a mint request to the blockchain for each signed vehicle. Each signature is checked
+// @Description as for the single-vehicle mint; vehicles that fail are rejected without affecting the rest.
+// @Tags        user-devices
+// @Accept      json
+// @Produce     json
+// @Param       mintRequest     body   controllers.MintBatchRequest true  "Signatures and NFT data, one per vehicle"
+// @Param       Idempotency-Key header string                       false "Key that makes retries of this request safe"
+// @Success     201 {object} controllers.MintBatchStatusResp
+// @Security    BearerAuth
+// @Router      /user/devices/mint/batch [post]
+func (udc *UserDevicesController) PostMintBatch(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+	logger := helpers.GetLogger(c, udc.log)
+
+	var mbr MintBatchRequest
+	if err := c.BodyParser(&mbr); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	if len(mbr.Vehicles) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "No vehicles to mint.")
+	}
+	if len(mbr.Vehicles) > maxMintBatchSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("At most %d vehicles may be minted at once.", maxMintBatchSize))
+	}
+
+	ids := make([]string, len(mbr.Vehicles))
+	seen := make(map[string]bool, len(mbr.Vehicles))
+	for i, v := range mbr.Vehicles {
+		if seen[v.UserDeviceID] {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Vehicle %s appears more than once.", v.UserDeviceID))
+		}
+		seen[v.UserDeviceID] = true
+		ids[i] = v.UserDeviceID
+	}
+
+	// The owner middleware doesn't cover these routes, so check every vehicle up front.
+	owned, err := models.UserDevices(
+		models.UserDeviceWhere.ID.IN(ids),
+		models.UserDeviceWhere.UserID.EQ(userID),
+	).Count(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		return err
+	}
+	if int(owned) != len(ids) {
+		return fiber.NewError(fiber.StatusNotFound, "Not all of the vehicles were found.")
+	}
+
+	batch := models.VehicleMintBatch{
+		ID:     ksuid.New().String(),
+		UserID: userID,
+	}
+	if err := batch.Insert(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
+		return err
+	}
+
+	idemKey := idempotencymw.Key(c)
+
+	for _, v := range mbr.Vehicles {
+		mbv := models.VehicleMintBatchVehicle{
+			BatchID:      batch.ID,
+			UserDeviceID: v.UserDeviceID,
+		}
+
+		// Each mint gets its own key, so that a retry of the batch resends only what
+		// didn't go out the first time.
+		key := ""
+		if idemKey != "" {
+			key = idemKey + "/" + v.UserDeviceID
+		}
+
+		// A request id comes back with an error if the request was saved but sending
+		// it failed.
+		requestID, err := udc.mintVehicle(c, v.UserDeviceID, &v.VehicleMintRequest, key)
+		if err != nil {
+			var dupErr *registry.DuplicateRequestError
+			var fibErr *fiber.Error
+			switch {
+			case errors.As(err, &dupErr):
+				requestID = dupErr.RequestID
+				if requestID == "" {
+					mbv.FailureReason = null.StringFrom("A transaction for this vehicle is already being sent.")
+				}
+			case errors.As(err, &fibErr) && fibErr.Code < fiber.StatusInternalServerError:
+				mbv.FailureReason = null.StringFrom(fibErr.Message)
+			default:
+				logger.Err(err).Str("userDeviceId", v.UserDeviceID).Msg("Failed to mint vehicle in batch.")
+				mbv.FailureReason = null.StringFrom("Internal error.")
+			}
+		}
+		if requestID != "" {
+			mbv.MintRequestID = null.StringFrom(requestID)
+		}
+
+		if err := mbv.Insert(c.Context(), udc.DBS().Writer, boil.Infer()); err != nil {
+			return err
+		}
+	}
+
+	logger.Info().Str("batchId", batch.ID).Int("vehicles", len(mbr.Vehicles)).Msg("Submitted mint batch.")
+
+	resp, err := udc.mintBatchStatus(c, &batch)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetMintBatch godoc
+// @Description Shows the status of the transactions sent for a bulk mint.
+// @Tags        user-devices
+// @Produce     json
+// @Param       batchID path string true "Batch ID"
+// @Success     200 {object} controllers.MintBatchStatusResp
+// @Security    BearerAuth
+// @Router      /user/devices/mint/batch/{batchID} [get]
+func (udc *UserDevicesController) GetMintBatch(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+
+	batch, err := models.VehicleMintBatches(
+		models.VehicleMintBatchWhere.ID.EQ(c.Params("batchID")),
+		models.VehicleMintBatchWhere.UserID.EQ(userID),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No mint batch with that id found.")
+		}
+		udc.log.Err(err).Msg("Failed to search for mint batch.")
+		return opaqueInternalError
+	}
+
+	resp, err := udc.mintBatchStatus(c, batch)
+	if err != nil {
+		udc.log.Err(err).Msg("Failed to retrieve mint batch vehicles.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(resp)
+}
+
+func (udc *UserDevicesController) mintBatchStatus(c *fiber.Ctx, batch *models.VehicleMintBatch) (*MintBatchStatusResp, error) {
+	mbvs, err := models.VehicleMintBatchVehicles(
+		models.VehicleMintBatchVehicleWhere.BatchID.EQ(batch.ID),
+		qm.Load(models.VehicleMintBatchVehicleRels.MintRequest),
+		qm.Load(models.VehicleMintBatchVehicleRels.UserDevice),
+		qm.OrderBy(models.VehicleMintBatchVehicleColumns.UserDeviceID),
+	).All(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &MintBatchStatusResp{
+		ID:        batch.ID,
+		CreatedAt: batch.CreatedAt,
+		Counts:    make(map[string]int),
+		Vehicles:  make([]MintBatchVehicleStatus, len(mbvs)),
+	}
+
+	for i, mbv := range mbvs {
+		vs := MintBatchVehicleStatus{
+			UserDeviceID:  mbv.UserDeviceID,
+			RequestID:     mbv.MintRequestID.Ptr(),
+			Status:        mintBatchStatusRejected,
+			FailureReason: mbv.FailureReason.Ptr(),
+		}
+
+		if mtr := mbv.R.MintRequest; mtr != nil {
+			vs.Status = mtr.Status
+			if mtr.Hash.Valid {
+				hash := hexutil.Bytes(mtr.Hash.Bytes)
+				vs.Hash = &hash
+			}
+			if mtr.FailureReason.Valid {
+				vs.FailureReason = mtr.FailureReason.Ptr()
+			}
+		}
+
+		if ud := mbv.R.UserDevice; ud != nil && !ud.TokenID.IsZero() {
+			vs.TokenID = ud.TokenID.Int(nil)
+		}
+
+		resp.Counts[vs.Status]++
+		resp.Vehicles[i] = vs
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	pb "github.com/DIMO-Network/shared/api/users"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *UserDevicesControllerTestSuite) TestGetMintBatchPayloads() {
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "Escape", 2021, nil)
+	dd[0].Make.TokenId = 42
+
+	ready := test.SetupCreateUserDevice(s.T(), s.testUserID, dd[0].Id, nil, "1FMCU0G6XMUA00021", s.pdb)
+	unconfirmed := test.SetupCreateUserDevice(s.T(), s.testUserID, dd[0].Id, nil, "", s.pdb)
+	minted := test.SetupCreateUserDevice(s.T(), s.testUserID, dd[0].Id, nil, "1FMCU0G6XMUA00022", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), minted, big.NewInt(21), null.BytesFrom(s.testUserEthAddr.Bytes()), s.pdb)
+
+	addr := s.testUserEthAddr.Hex()
+	s.usersClient.EXPECT().GetUser(gomock.Any(), &pb.GetUserRequest{Id: s.testUserID}).Return(&pb.User{Id: s.testUserID, EthereumAddress: &addr}, nil)
+	s.deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), dd[0].Id).Return(dd[0], nil)
+
+	missing := ksuid.New().String()
+	res, err := s.app.Test(test.BuildRequest("GET", "/user/devices/mint/batch?userDeviceIds="+ready.ID+","+unconfirmed.ID+","+minted.ID+","+missing, ""))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	var resp MintBatchPayloadsResp
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&resp))
+
+	s.Require().Len(resp.Vehicles, 1)
+	s.Equal(ready.ID, resp.Vehicles[0].UserDeviceID)
+	s.Equal("MintVehicleWithDeviceDefinitionSign", resp.Vehicles[0].TypedData.PrimaryType)
+	s.Equal(dd[0].Id, resp.Vehicles[0].TypedData.Message["deviceDefinitionId"])
+
+	s.Require().Len(resp.Skipped, 3)
+	s.Equal(MintBatchSkip{UserDeviceID: unconfirmed.ID, Reason: "VIN not confirmed"}, resp.Skipped[0])
+	s.Equal(minted.ID, resp.Skipped[1].UserDeviceID)
+	s.Equal(missing, resp.Skipped[2].UserDeviceID)
+}
+
+func (s *UserDevicesControllerTestSuite) TestGetMintBatch() {
+	sent := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "1FMCU0G6XMUA00023", s.pdb)
+	rejected := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "1FMCU0G6XMUA00024", s.pdb)
+
+	mtr := models.MetaTransactionRequest{
+		ID:     ksuid.New().String(),
+		Status: models.MetaTransactionRequestStatusSubmitted,
+		Hash:   null.BytesFrom(common.HexToHash("0xabc").Bytes()),
+	}
+	s.Require().NoError(mtr.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	batch := models.VehicleMintBatch{ID: ksuid.New().String(), UserID: s.testUserID}
+	s.Require().NoError(batch.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	for _, mbv := range []models.VehicleMintBatchVehicle{
+		{BatchID: batch.ID, UserDeviceID: sent.ID, MintRequestID: null.StringFrom(mtr.ID)},
+		{BatchID: batch.ID, UserDeviceID: rejected.ID, FailureReason: null.StringFrom("Could not verify ERC-1271 signature.")},
+	} {
+		s.Require().NoError(mbv.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	}
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/user/devices/mint/batch/"+batch.ID, ""))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	var resp MintBatchStatusResp
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&resp))
+	s.Equal(batch.ID, resp.ID)
+	s.Equal(map[string]int{models.MetaTransactionRequestStatusSubmitted: 1, mintBatchStatusRejected: 1}, resp.Counts)
+	s.Require().Len(resp.Vehicles, 2)
+
+	for _, v := range resp.Vehicles {
+		switch v.UserDeviceID {
+		case sent.ID:
+			s.Equal(models.MetaTransactionRequestStatusSubmitted, v.Status)
+			s.Equal(&mtr.ID, v.RequestID)
+			s.Require().NotNil(v.Hash)
+			s.Equal(mtr.Hash.Bytes, []byte(*v.Hash))
+		case rejected.ID:
+			s.Equal(mintBatchStatusRejected, v.Status)
+			s.Nil(v.RequestID)
+			s.Equal("Could not verify ERC-1271 signature.", *v.FailureReason)
+		default:
+			s.Failf("unexpected vehicle", "id %s", v.UserDeviceID)
+		}
+	}
+
+	res, err = s.app.Test(test.BuildRequest("GET", "/user/devices/mint/batch/"+ksuid.New().String(), ""))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// The handlers in this file serve the token-keyed, privilege-token routes under
+// /v1/vehicle/:tokenID. They look the vehicle up by token id and then defer to the
+// same logic as the owner's /v1/user/devices/:userDeviceID routes.
+
+// vehicleByTokenID looks up the vehicle named by the tokenID path parameter.
+func (udc *UserDevicesController) vehicleByTokenID(c *fiber.Ctx) (*models.UserDevice, error) {
+	tokenIDRaw := c.Params("tokenID")
+
+	tokenID, ok := new(decimal.Big).SetString(tokenIDRaw)
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Couldn't parse token id %q.", tokenIDRaw))
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(tokenID)),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Vehicle NFT not found.")
+		}
+		udc.log.Err(err).Msg("Failed to search for device.")
+		return nil, opaqueInternalError
+	}
+
+	return ud, nil
+}
+
+// GetVehicleIntegration godoc
+// @Summary     Get the status of one of the vehicle's integrations.
+// @Description Same as the user device route, for holders of a privilege token.
+// @Tags        integrations
+// @Produce     json
+// @Param       tokenID       path int    true "Token ID"
+// @Param       integrationID path string true "Integration ID"
+// @Success     200 {object} controllers.GetUserDeviceIntegrationResponse
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/integrations/{integrationID} [get]
+func (udc *UserDevicesController) GetVehicleIntegration(c *fiber.Ctx) error {
+	ud, err := udc.vehicleByTokenID(c)
+	if err != nil {
+		return err
+	}
+
+	return udc.getUserDeviceIntegration(c, ud.ID, c.Params("integrationID"))
+}
+
+// GetVehicleIntegrationHistory godoc
+// @Summary     List an integration's status changes.
+// @Description Same as the user device route, for holders of a privilege token.
+// @Tags        integrations
+// @Produce     json
+// @Param       tokenID       path  int    true  "Token ID"
+// @Param       integrationID path  string true  "Integration ID"
+// @Param       page          query int    false "Page number, starting from 1"
+// @Param       pageSize      query int    false "Page size, at most 100"
+// @Success     200 {object} controllers.IntegrationStatusHistoryResp
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/integrations/{integrationID}/history [get]
+func (udc *UserDevicesController) GetVehicleIntegrationHistory(c *fiber.Ctx) error {
+	ud, err := udc.vehicleByTokenID(c)
+	if err != nil {
+		return err
+	}
+
+	return udc.getUserDeviceIntegrationHistory(c, ud.ID, c.Params("integrationID"))
+}
+
+// GetVehicleCommandRequestStatus godoc
+// @Summary     Get the status of a submitted command.
+// @Description Get the status of a command sent to the vehicle, by request id.
+// @Tags        device,command
+// @Produce     json
+// @Param       tokenID   path int    true "Token ID"
+// @Param       requestID path string true "Command request ID"
+// @Success     200 {object} controllers.CommandRequestStatusResp
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/commands/{requestID} [get]
+func (udc *UserDevicesController) GetVehicleCommandRequestStatus(c *fiber.Ctx) error {
+	ud, err := udc.vehicleByTokenID(c)
+	if err != nil {
+		return err
+	}
+
+	// Unlike the owner route, the privilege only covers this vehicle.
+	cr, err := models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.ID.EQ(c.Params("requestID")),
+		models.DeviceCommandRequestWhere.UserDeviceID.EQ(ud.ID),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No command request with that id found.")
+		}
+		udc.log.Err(err).Msg("Failed to search for command status.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(commandRequestStatusResp(cr))
+}
+
+// GetVehicleErrorCodeQueries godoc
+// @Summary     List all error code queries made for this vehicle.
+// @Description Same as the user device route, for holders of a privilege token.
+// @Tags        error-codes
+// @Produce     json
+// @Param       tokenID path int true "Token ID"
+// @Success     200 {object} controllers.GetUserDeviceErrorCodeQueriesResponse
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/error-codes [get]
+func (udc *UserDevicesController) GetVehicleErrorCodeQueries(c *fiber.Ctx) error {
+	ud, err := udc.vehicleByTokenID(c)
+	if err != nil {
+		return err
+	}
+
+	return udc.getUserDeviceErrorCodeQueries(c, ud.ID)
+}
+
+// GetVehicleAftermarketDevice godoc
+// @Summary     Get the aftermarket device paired with the vehicle.
+// @Description Same as the by-serial route, for holders of a privilege token.
+// @Tags        integrations
+// @Produce     json
+// @Param       tokenID path int true "Token ID"
+// @Success     200 {object} controllers.AutoPiDeviceInfo
+// @Security    BearerAuth
+// @Router      /vehicle/{tokenID}/aftermarket-device [get]
+func (udc *UserDevicesController) GetVehicleAftermarketDevice(c *fiber.Ctx) error {
+	ud, err := udc.vehicleByTokenID(c)
+	if err != nil {
+		return err
+	}
+
+	ad, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.VehicleTokenID.EQ(ud.TokenID),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No aftermarket device paired with this vehicle.")
+		}
+		udc.log.Err(err).Msg("Failed to search for paired aftermarket device.")
+		return opaqueInternalError
+	}
+
+	c.Locals("serial", ad.Serial)
+
+	return udc.GetAftermarketDeviceInfo(c)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+
+	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *UserDevicesControllerTestSuite) TestGetVehicleCommandRequestStatus() {
+	ud := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(5), null.BytesFrom(s.testUserEthAddr.Bytes()), s.pdb)
+	other := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "", s.pdb)
+
+	dcr := models.DeviceCommandRequest{
+		ID:            ksuid.New().String(),
+		UserDeviceID:  ud.ID,
+		IntegrationID: ksuid.New().String(),
+		Command:       "doors/unlock",
+		Status:        models.DeviceCommandRequestStatusComplete,
+	}
+	s.Require().NoError(dcr.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	otherDCR := models.DeviceCommandRequest{
+		ID:            ksuid.New().String(),
+		UserDeviceID:  other.ID,
+		IntegrationID: dcr.IntegrationID,
+		Command:       "doors/unlock",
+		Status:        models.DeviceCommandRequestStatusPending,
+	}
+	s.Require().NoError(otherDCR.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/vehicle/5/commands/"+dcr.ID, ""))
+	s.Require().NoError(err)
+	s.Equal(200, res.StatusCode)
+
+	var status CommandRequestStatusResp
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&status))
+	s.Equal(dcr.ID, status.ID)
+	s.Equal(models.DeviceCommandRequestStatusComplete, status.Status)
+
+	// The privilege on vehicle 5 doesn't reach another vehicle's commands.
+	res, err = s.app.Test(test.BuildRequest("GET", "/vehicle/5/commands/"+otherDCR.ID, ""))
+	s.Require().NoError(err)
+	s.Equal(404, res.StatusCode)
+}
+
+func (s *UserDevicesControllerTestSuite) TestGetVehicleErrorCodeQueries() {
+	ud := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(6), null.BytesFrom(s.testUserEthAddr.Bytes()), s.pdb)
+
+	ecq := models.ErrorCodeQuery{
+		ID:                 ksuid.New().String(),
+		UserDeviceID:       ud.ID,
+		CodesQueryResponse: null.JSONFrom([]byte(`[{"code": "P0113", "description": "Intake air temperature sensor circuit high."}]`)),
+	}
+	s.Require().NoError(ecq.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/vehicle/6/error-codes", ""))
+	s.Require().NoError(err)
+	s.Equal(200, res.StatusCode)
+
+	var resp GetUserDeviceErrorCodeQueriesResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&resp))
+	s.Require().Len(resp.Queries, 1)
+	s.Require().Len(resp.Queries[0].ErrorCodes, 1)
+	s.Equal("P0113", resp.Queries[0].ErrorCodes[0].Code)
+
+	res, err = s.app.Test(test.BuildRequest("GET", "/vehicle/7/error-codes", ""))
+	s.Require().NoError(err)
+	s.Equal(404, res.StatusCode)
+}
+
+func (s *UserDevicesControllerTestSuite) TestGetVehicleAftermarketDevice() {
+	ud := test.SetupCreateUserDevice(s.T(), s.testUserID, ksuid.New().String(), nil, "", s.pdb)
+	_ = test.SetupCreateVehicleNFT(s.T(), ud, big.NewInt(8), null.BytesFrom(s.testUserEthAddr.Bytes()), s.pdb)
+
+	res, err := s.app.Test(test.BuildRequest("GET", "/vehicle/8/aftermarket-device", ""))
+	s.Require().NoError(err)
+	s.Equal(404, res.StatusCode)
+
+	ad := test.SetupCreateMintedAftermarketDevice(s.T(), s.testUserID, "macaron-serial", big.NewInt(13), common.HexToAddress("0x1"), nil, s.pdb)
+	ad.VehicleTokenID = types.NewNullDecimal(decimal.New(8, 0))
+	ad.DeviceManufacturerTokenID = types.NewDecimal(decimal.New(144, 0))
+	_, err = ad.Update(context.Background(), s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	s.deviceDefSvc.EXPECT().GetMakeByTokenID(gomock.Any(), big.NewInt(144)).Return(&ddgrpc.DeviceMake{Name: "Hashdog"}, nil)
+
+	res, err = s.app.Test(test.BuildRequest("GET", "/vehicle/8/aftermarket-device", ""))
+	s.Require().NoError(err)
+	body, _ := io.ReadAll(res.Body)
+	s.Require().Equal(200, res.StatusCode, string(body))
+
+	var info AutoPiDeviceInfo
+	s.Require().NoError(json.Unmarshal(body, &info))
+	s.Equal("macaron-serial", info.UnitID)
+	s.Equal(big.NewInt(13), info.TokenID)
+	s.Equal("Hashdog", info.Manufacturer.Name)
+}
@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// maxVINRegistrationRows caps the size of a single batch registration.
+const maxVINRegistrationRows = 1000
+
+// RegisterUserDevicesVINBatch is the JSON form of a batch VIN registration.
+type RegisterUserDevicesVINBatch struct {
+	Vehicles []RegisterUserDeviceVIN `json:"vehicles"`
+}
+
+// VINRegistrationJobResp describes a batch VIN registration and the outcome of
+// each of its rows.
+type VINRegistrationJobResp struct {
+	ID string `json:"id"`
+	// Status is "Pending" while rows remain to be processed, then "Complete".
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	// Counts gives the number of rows in each row status.
+	Counts map[string]int           `json:"counts"`
+	Rows   []VINRegistrationRowResp `json:"rows"`
+}
+
+// VINRegistrationRowResp is the outcome of one row of a batch VIN registration.
+type VINRegistrationRowResp struct {
+	// Row is the zero-based position of the row in the submitted list.
+	Row         int    `json:"row"`
+	VIN         string `json:"vin"`
+	CountryCode string `json:"countryCode"`
+	// Status is one of "Pending", "Created", "Duplicate", "DecodeFailed" and "Failed".
+	Status string `json:"status" enums:"Pending,Created,Duplicate,DecodeFailed,Failed"`
+	// UserDeviceID is the created vehicle or, for a duplicate, the caller's
+	// existing vehicle with the same VIN.
+	UserDeviceID  *string `json:"userDeviceId"`
+	FailureReason *string `json:"failureReason"`
+}
+
+// RegisterDevicesForUserFromVINs godoc
+// @Description Adds many devices to a user by decoding their VINs. The body is either JSON or a CSV with
+// @Description a header row naming the columns vin, countryCode and, optionally, canProtocol. Rows are
+// @Description processed in the background; poll the returned job for the outcome of each one.
+// @Tags        user-devices
+// @Accept      json,text/csv
+// @Produce     json
+// @Param       vehicles body controllers.RegisterUserDevicesVINBatch true "VINs and countries to register"
+// @Success     202 {object} controllers.VINRegistrationJobResp
+// @Failure     400 "validation failure"
+// @Security    BearerAuth
+// @Router      /user/devices/fromvin/batch [post]
+func (udc *UserDevicesController) RegisterDevicesForUserFromVINs(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+
+	var regs []RegisterUserDeviceVIN
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), "text/csv") {
+		var err error
+		regs, err = parseVINRegistrationCSV(c.Body())
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else {
+		var batch RegisterUserDevicesVINBatch
+		if err := c.BodyParser(&batch); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		regs = batch.Vehicles
+	}
+
+	if len(regs) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "No vehicles to register.")
+	}
+	if len(regs) > maxVINRegistrationRows {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("At most %d vehicles may be registered at once.", maxVINRegistrationRows))
+	}
+
+	job := models.VinRegistrationJob{
+		ID:     ksuid.New().String(),
+		UserID: userID,
+	}
+
+	rows := make(models.VinRegistrationJobRowSlice, len(regs))
+	seen := make(map[string]int, len(regs))
+
+	for i := range regs {
+		reg := &regs[i]
+		reg.VIN = strings.ToUpper(strings.TrimSpace(reg.VIN))
+		reg.CountryCode = strings.ToUpper(strings.TrimSpace(reg.CountryCode))
+		if err := reg.Validate(); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Row %d: %s", i, err))
+		}
+		if constants.FindCountry(reg.CountryCode) == nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Row %d: unsupported or invalid country: %s", i, reg.CountryCode))
+		}
+
+		row := &models.VinRegistrationJobRow{
+			JobID:       job.ID,
+			RowNumber:   i,
+			Vin:         reg.VIN,
+			CountryCode: reg.CountryCode,
+			Status:      models.VinRegistrationRowStatusPending,
+		}
+		if reg.CANProtocol != "" {
+			row.CanProtocol = null.StringFrom(reg.CANProtocol)
+		}
+
+		// Repeats within the list are settled here. The worker could otherwise pick
+		// up both copies at once.
+		if first, ok := seen[reg.VIN]; ok {
+			row.Status = models.VinRegistrationRowStatusDuplicate
+			row.FailureReason = null.StringFrom(fmt.Sprintf("Repeats row %d.", first))
+		} else {
+			seen[reg.VIN] = i
+		}
+
+		rows[i] = row
+	}
+
+	tx, err := udc.DBS().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := job.Insert(c.Context(), tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := row.Insert(c.Context(), tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(vinRegistrationJobResp(&job, rows))
+}
+
+// GetVINRegistrationJob godoc
+// @Description Gets the progress of a batch VIN registration, with the outcome of each row so far.
+// @Tags        user-devices
+// @Produce     json
+// @Param       jobID path string true "Job ID"
+// @Success     200 {object} controllers.VINRegistrationJobResp
+// @Failure     404 "no job with that id found"
+// @Security    BearerAuth
+// @Router      /user/devices/fromvin/batch/{jobID} [get]
+func (udc *UserDevicesController) GetVINRegistrationJob(c *fiber.Ctx) error {
+	userID := helpers.GetUserID(c)
+
+	job, err := models.VinRegistrationJobs(
+		models.VinRegistrationJobWhere.ID.EQ(c.Params("jobID")),
+		models.VinRegistrationJobWhere.UserID.EQ(userID),
+	).One(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No registration job with that id found.")
+		}
+		udc.log.Err(err).Msg("Failed to search for VIN registration job.")
+		return opaqueInternalError
+	}
+
+	rows, err := models.VinRegistrationJobRows(
+		models.VinRegistrationJobRowWhere.JobID.EQ(job.ID),
+		qm.OrderBy(models.VinRegistrationJobRowColumns.RowNumber),
+	).All(c.Context(), udc.DBS().Reader)
+	if err != nil {
+		udc.log.Err(err).Msg("Failed to retrieve VIN registration rows.")
+		return opaqueInternalError
+	}
+
+	return c.JSON(vinRegistrationJobResp(job, rows))
+}
+
+func vinRegistrationJobResp(job *models.VinRegistrationJob, rows models.VinRegistrationJobRowSlice) VINRegistrationJobResp {
+	resp := VINRegistrationJobResp{
+		ID:          job.ID,
+		Status:      "Complete",
+		CreatedAt:   job.CreatedAt,
+		CompletedAt: job.CompletedAt.Ptr(),
+		Counts:      make(map[string]int),
+		Rows:        make([]VINRegistrationRowResp, len(rows)),
+	}
+
+	for i, row := range rows {
+		if row.Status == models.VinRegistrationRowStatusPending {
+			resp.Status = "Pending"
+		}
+		resp.Counts[row.Status]++
+		resp.Rows[i] = VINRegistrationRowResp{
+			Row:           row.RowNumber,
+			VIN:           row.Vin,
+			CountryCode:   row.CountryCode,
+			Status:        row.Status,
+			UserDeviceID:  row.UserDeviceID.Ptr(),
+			FailureReason: row.FailureReason.Ptr(),
+		}
+	}
+
+	return resp
+}
+
+// parseVINRegistrationCSV reads a CSV with a header row. The vin and countryCode
+// columns are required, canProtocol is optional and anything else is ignored.
+func parseVINRegistrationCSV(body []byte) ([]RegisterUserDeviceVIN, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cols := map[string]int{"vin": -1, "countrycode": -1, "canprotocol": -1}
+	for i, name := range header {
+		if _, ok := cols[strings.ToLower(strings.TrimSpace(name))]; ok {
+			cols[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+	}
+	if cols["vin"] == -1 || cols["countrycode"] == -1 {
+		return nil, errors.New("the CSV header must name vin and countryCode columns")
+	}
+
+	var regs []RegisterUserDeviceVIN
+	for {
+		rec, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return regs, nil
+			}
+			return nil, err
+		}
+
+		reg := RegisterUserDeviceVIN{
+			VIN:         rec[cols["vin"]],
+			CountryCode: rec[cols["countrycode"]],
+		}
+		if i := cols["canprotocol"]; i != -1 {
+			reg.CANProtocol = rec[i]
+		}
+		regs = append(regs, reg)
+	}
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVINRegistrationCSV(t *testing.T) {
+	regs, err := parseVINRegistrationCSV([]byte("fleetId,VIN,countryCode\n7,1fmcu0g6xmua00001,usa\n8, 1FMCU0G6XMUA00002,CAN\n"))
+	require.NoError(t, err)
+	require.Len(t, regs, 2)
+	assert.Equal(t, RegisterUserDeviceVIN{VIN: "1fmcu0g6xmua00001", CountryCode: "usa"}, regs[0])
+	assert.Equal(t, RegisterUserDeviceVIN{VIN: "1FMCU0G6XMUA00002", CountryCode: "CAN"}, regs[1])
+
+	regs, err = parseVINRegistrationCSV([]byte("vin,countryCode,canProtocol\n1FMCU0G6XMUA00001,USA,6\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []RegisterUserDeviceVIN{{VIN: "1FMCU0G6XMUA00001", CountryCode: "USA", CANProtocol: "6"}}, regs)
+
+	_, err = parseVINRegistrationCSV([]byte("vin\n1FMCU0G6XMUA00001\n"))
+	assert.Error(t, err)
+
+	_, err = parseVINRegistrationCSV([]byte("vin,countryCode\n1FMCU0G6XMUA00001\n"))
+	assert.Error(t, err)
+}
+
+func (s *UserDevicesControllerTestSuite) TestRegisterDevicesForUserFromVINs() {
+	body := `{"vehicles": [
+		{"vin": "1fmcu0g6xmua00011", "countryCode": "usa"},
+		{"vin": "1FMCU0G6XMUA00012", "countryCode": "CAN"},
+		{"vin": "1FMCU0G6XMUA00011", "countryCode": "USA"}
+	]}`
+	res, err := s.app.Test(test.BuildRequest("POST", "/user/devices/fromvin/batch", body))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusAccepted, res.StatusCode)
+
+	var job VINRegistrationJobResp
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&job))
+	s.Equal("Pending", job.Status)
+	s.Require().Len(job.Rows, 3)
+	s.Equal("1FMCU0G6XMUA00011", job.Rows[0].VIN)
+	s.Equal("USA", job.Rows[0].CountryCode)
+	s.Equal(models.VinRegistrationRowStatusPending, job.Rows[0].Status)
+	s.Equal(models.VinRegistrationRowStatusDuplicate, job.Rows[2].Status)
+	s.Equal(map[string]int{models.VinRegistrationRowStatusPending: 2, models.VinRegistrationRowStatusDuplicate: 1}, job.Counts)
+
+	res, err = s.app.Test(test.BuildRequest("GET", "/user/devices/fromvin/batch/"+job.ID, ""))
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+
+	var polled VINRegistrationJobResp
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&polled))
+	s.Equal(job.ID, polled.ID)
+	s.Len(polled.Rows, 3)
+
+	req := test.BuildRequest("POST", "/user/devices/fromvin/batch", "vin,countryCode\n1FMCU0G6XMUA00013,XYZ\n")
+	req.Header.Set(fiber.HeaderContentType, "text/csv")
+	res, err = s.app.Test(req)
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusBadRequest, res.StatusCode)
+
+	res, err = s.app.Test(test.BuildRequest("GET", "/user/devices/fromvin/batch/"+ksuid.New().String(), ""))
+	s.Require().NoError(err)
+	s.Equal(fiber.StatusNotFound, res.StatusCode)
+}
@@ -3,11 +3,16 @@ package controllers
 import (
 	"crypto/hmac"
 	"crypto/sha256"
+	"database/sql"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
 	"github.com/gofiber/fiber/v2"
 	"github.com/tidwall/gjson"
 	"github.com/volatiletech/null/v8"
@@ -26,15 +31,22 @@ type WebhooksController struct {
 	log             *zerolog.Logger
 	autoPiSvc       services.AutoPiAPIService
 	deviceDefIntSvc services.DeviceDefinitionIntegrationService
+	deviceDefSvc    services.DeviceDefinitionService
+	smartcarTaskSvc services.SmartcarTaskService
+	eventService    services.EventService
 }
 
-func NewWebhooksController(settings *config.Settings, dbs func() *db.ReaderWriter, log *zerolog.Logger, autoPiSvc services.AutoPiAPIService, deviceDefIntSvc services.DeviceDefinitionIntegrationService) WebhooksController {
+func NewWebhooksController(settings *config.Settings, dbs func() *db.ReaderWriter, log *zerolog.Logger, autoPiSvc services.AutoPiAPIService, deviceDefIntSvc services.DeviceDefinitionIntegrationService,
+	deviceDefSvc services.DeviceDefinitionService, smartcarTaskSvc services.SmartcarTaskService, eventService services.EventService) WebhooksController {
 	return WebhooksController{
 		dbs:             dbs,
 		settings:        settings,
 		log:             log,
 		autoPiSvc:       autoPiSvc,
 		deviceDefIntSvc: deviceDefIntSvc,
+		deviceDefSvc:    deviceDefSvc,
+		smartcarTaskSvc: smartcarTaskSvc,
+		eventService:    eventService,
 	}
 }
 
@@ -105,6 +117,7 @@ func (wc *WebhooksController) ProcessCommand(c *fiber.Ctx) error {
 
 		if apiIntegration.Status != models.UserDeviceAPIIntegrationStatusActive {
 			// update the integration state, Pending first data means we are succesfully paired and template applied, just waiting for data to stream
+			fromStatus := apiIntegration.Status
 			apiIntegration.Status = models.UserDeviceAPIIntegrationStatusPendingFirstData
 			ss := constants.TemplateConfirmed.String()
 			udMetadata.AutoPiSubStatus = &ss
@@ -114,13 +127,27 @@ func (wc *WebhooksController) ProcessCommand(c *fiber.Ctx) error {
 				logger.Err(err).Msg("failed to marshal user device api integration metadata json from autopi webhook")
 				return c.SendStatus(fiber.StatusNoContent)
 			}
-			_, err = apiIntegration.Update(c.Context(), wc.dbs().Writer, boil.Whitelist(
+			tx, err := wc.dbs().Writer.BeginTx(c.Context(), nil)
+			if err != nil {
+				logger.Err(err).Msg("failed to start transaction")
+				return c.SendStatus(fiber.StatusNoContent)
+			}
+			defer tx.Rollback() //nolint
+			_, err = apiIntegration.Update(c.Context(), tx, boil.Whitelist(
 				models.UserDeviceAPIIntegrationColumns.Metadata, models.UserDeviceAPIIntegrationColumns.Status,
 				models.UserDeviceAPIIntegrationColumns.UpdatedAt))
 			if err != nil {
 				logger.Err(err).Msg("failed to save user device integration changes")
 				return c.SendStatus(fiber.StatusNoContent)
 			}
+			if err := services.RecordIntegrationStatusChange(c.Context(), tx, apiIntegration, fromStatus, services.IntegrationStatusSourceWebhook, "AutoPi template applied."); err != nil {
+				logger.Err(err).Msg("failed to record integration status change")
+				return c.SendStatus(fiber.StatusNoContent)
+			}
+			if err := tx.Commit(); err != nil {
+				logger.Err(err).Msg("failed to save user device integration changes")
+				return c.SendStatus(fiber.StatusNoContent)
+			}
 
 			reg := ""
 			ci := constants.FindCountry(apiIntegration.R.UserDevice.CountryCode.String)
@@ -140,13 +167,212 @@ func (wc *WebhooksController) ProcessCommand(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// Smartcar webhook event types that we act on.
+const (
+	smartcarWebhookVerify              = "VERIFY"
+	smartcarWebhookVehicleError        = "VEHICLE_ERROR"
+	smartcarWebhookVehicleDisconnected = "VEHICLE_DISCONNECTED"
+)
+
+// Smartcar error types that mean we no longer have access to the vehicle.
+var smartcarRevocationErrorTypes = map[string]bool{
+	"AUTHENTICATION": true,
+	"PERMISSION":     true,
+}
+
+type smartcarWebhook struct {
+	EventID   string `json:"eventId"`
+	EventType string `json:"eventType"`
+	Data      struct {
+		Challenge string `json:"challenge"`
+		Vehicle   struct {
+			ID string `json:"id"`
+		} `json:"vehicle"`
+		Errors []smartcarWebhookError `json:"errors"`
+	} `json:"data"`
+}
+
+type smartcarWebhookError struct {
+	Type        string `json:"type"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// ProcessSmartcarWebhook handles vehicle error and disconnect notifications from Smartcar. Requests must carry an
+// SC-Signature header with the hex HMAC-SHA256 of the body, keyed by the Smartcar management token. Revoked access
+// puts the integration into AuthenticationFailure and stops polling; other vehicle errors mark it Failed.
+// Notifications for vehicles we don't know about are acknowledged and ignored so that Smartcar doesn't retry them.
+func (wc *WebhooksController) ProcessSmartcarWebhook(c *fiber.Ctx) error {
+	logger := wc.log.With().
+		Str("integration", "smartcar").
+		Str("handler", "webhooks.ProcessSmartcarWebhook").
+		Logger()
+
+	if wc.settings.SmartcarManagementToken == "" {
+		// Anyone could sign with an empty key.
+		logger.Error().Msg("SMARTCAR_MANAGEMENT_TOKEN is not set, rejecting webhook")
+		return fiber.NewError(fiber.StatusServiceUnavailable, "smartcar webhooks are not configured")
+	}
+
+	if !validateSignature(wc.settings.SmartcarManagementToken, string(c.Body()), c.Get("SC-Signature")) {
+		logger.Error().Msg("invalid webhook signature")
+		return fiber.NewError(fiber.StatusUnauthorized, "invalid smartcar webhook signature")
+	}
+
+	var hook smartcarWebhook
+	if err := json.Unmarshal(c.Body(), &hook); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid smartcar webhook request payload")
+	}
+	logger = logger.With().Str("eventId", hook.EventID).Str("eventType", hook.EventType).Logger()
+
+	if hook.EventType == smartcarWebhookVerify {
+		// Smartcar checks that we hold the management token by asking us to sign a challenge.
+		h := hmac.New(sha256.New, []byte(wc.settings.SmartcarManagementToken))
+		h.Write([]byte(hook.Data.Challenge))
+		return c.JSON(fiber.Map{"challenge": hex.EncodeToString(h.Sum(nil))})
+	}
+
+	if hook.EventType != smartcarWebhookVehicleError && hook.EventType != smartcarWebhookVehicleDisconnected {
+		logger.Debug().Msg("ignoring smartcar webhook event type")
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+
+	if hook.Data.Vehicle.ID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "no vehicle id found in smartcar webhook payload")
+	}
+	logger = logger.With().Str("smartcarVehicleId", hook.Data.Vehicle.ID).Logger()
+
+	integ, err := wc.deviceDefSvc.GetIntegrationByVendor(c.Context(), constants.SmartCarVendor)
+	if err != nil {
+		logger.Err(err).Msg("failed to look up smartcar integration")
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to look up smartcar integration")
+	}
+
+	udai, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(integ.Id),
+		models.UserDeviceAPIIntegrationWhere.ExternalID.EQ(null.StringFrom(hook.Data.Vehicle.ID)),
+		qm.Load(models.UserDeviceAPIIntegrationRels.UserDevice),
+	).One(c.Context(), wc.dbs().Writer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Info().Msg("no integration found for smartcar vehicle")
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+		logger.Err(err).Msg("failed to look up integration for smartcar vehicle")
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to look up integration")
+	}
+	logger = logger.With().Str("userDeviceId", udai.UserDeviceID).Logger()
+
+	revoked := hook.EventType == smartcarWebhookVehicleDisconnected
+	var reasons []string
+	for _, e := range hook.Data.Errors {
+		if smartcarRevocationErrorTypes[e.Type] {
+			revoked = true
+		}
+		reason := e.Type
+		if e.Code != "" {
+			reason += ":" + e.Code
+		}
+		if e.Description != "" {
+			reason += " " + e.Description
+		}
+		reasons = append(reasons, reason)
+	}
+
+	fromStatus := udai.Status
+	cols := models.UserDeviceAPIIntegrationColumns
+	update := []string{cols.Status, cols.FailureReason, cols.UpdatedAt}
+	eventType := "com.dimo.zone.device.integration.error"
+
+	if revoked {
+		eventType = "com.dimo.zone.device.integration.revoke"
+		if len(reasons) == 0 {
+			reasons = append(reasons, "Access to the vehicle was revoked in Smartcar.")
+		}
+		if udai.TaskID.Valid {
+			if err := wc.smartcarTaskSvc.StopPoll(udai); err != nil {
+				logger.Err(err).Msg("failed to stop smartcar polling")
+				return fiber.NewError(fiber.StatusInternalServerError, "failed to stop polling")
+			}
+			udai.TaskID = null.String{}
+			update = append(update, cols.TaskID)
+		}
+		udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+	} else {
+		if len(reasons) == 0 {
+			reasons = append(reasons, "Smartcar reported a vehicle error.")
+		}
+		udai.Status = models.UserDeviceAPIIntegrationStatusFailed
+	}
+	udai.FailureReason = null.StringFrom(strings.Join(reasons, "; "))
+
+	tx, err := wc.dbs().Writer.BeginTx(c.Context(), nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := udai.Update(c.Context(), tx, boil.Whitelist(update...)); err != nil {
+		logger.Err(err).Msg("failed to save integration status")
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to save integration status")
+	}
+	if err := services.RecordIntegrationStatusChange(c.Context(), tx, udai, fromStatus, services.IntegrationStatusSourceWebhook, udai.FailureReason.String); err != nil {
+		logger.Err(err).Msg("failed to record integration status change")
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to save integration status")
+	}
+	if err := tx.Commit(); err != nil {
+		logger.Err(err).Msg("failed to save integration status")
+		return fiber.NewError(fiber.StatusInternalServerError, "failed to save integration status")
+	}
+
+	ud := udai.R.UserDevice
+	device := services.UserDeviceEventDevice{ID: ud.ID, VIN: ud.VinIdentifier.String}
+	if def, err := wc.deviceDefSvc.GetDeviceDefinitionBySlug(c.Context(), ud.DefinitionID); err != nil {
+		logger.Err(err).Msg("failed to look up device definition for event")
+	} else {
+		device.DeviceDefinitionID = def.DeviceDefinitionId
+		device.Make = def.Make.Name
+		device.Model = def.Model
+		device.Year = int(def.Year)
+	}
+
+	err = wc.eventService.Emit(&shared.CloudEvent[any]{
+		Type:    eventType,
+		Source:  "devices-api",
+		Subject: ud.ID,
+		Data: services.UserDeviceIntegrationStatusEvent{
+			Timestamp: time.Now(),
+			UserID:    ud.UserID,
+			Device:    device,
+			Integration: services.UserDeviceEventIntegration{
+				ID:     integ.Id,
+				Type:   integ.Type,
+				Style:  integ.Style,
+				Vendor: integ.Vendor,
+			},
+			Status: udai.Status,
+			Reason: udai.FailureReason.String,
+		},
+	})
+	if err != nil {
+		logger.Err(err).Msg("failed to emit integration status event")
+	}
+
+	logger.Info().Str("status", udai.Status).Msg("processed smartcar webhook")
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 func validateSignature(secret, data, expectedSignature string) bool {
 	// Create a new HMAC by defining the hash type and the key (as byte array)
 	h := hmac.New(sha256.New, []byte(secret))
 	// Write Data to it
 	h.Write([]byte(data))
-	// Get result and encode as hexadecimal string
-	sha := hex.EncodeToString(h.Sum(nil))
+	// Compare against the hex-decoded signature in constant time
+	sig, err := hex.DecodeString(expectedSignature)
+	if err != nil {
+		return false
+	}
 
-	return sha == expectedSignature
+	return hmac.Equal(h.Sum(nil), sig)
 }
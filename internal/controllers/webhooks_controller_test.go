@@ -2,10 +2,16 @@ package controllers
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"testing"
 
+	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
+	"github.com/tidwall/gjson"
 
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
@@ -66,7 +72,7 @@ func (s *WebHooksControllerTestSuite) TestPostWebhook401InvalidSignature() {
 	autoAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
 
 	token := "BobbyHarry"
-	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc)
+	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc, nil, nil, nil)
 	app := fiber.New()
 	app.Post(constants.AutoPiWebhookPath, c.ProcessCommand)
 
@@ -89,7 +95,7 @@ func (s *WebHooksControllerTestSuite) TestPostWebhook400BadPayload() {
 	autoAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
 
 	token := "BobbyHarry"
-	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc)
+	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc, nil, nil, nil)
 	app := fiber.New()
 	app.Post(constants.AutoPiWebhookPath, c.ProcessCommand)
 
@@ -107,7 +113,7 @@ func (s *WebHooksControllerTestSuite) TestPostWebhookSyncCommand() {
 	autoAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
 
 	token := "BobbyHarry"
-	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc)
+	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc, nil, nil, nil)
 	app := fiber.New()
 	app.Post(constants.AutoPiWebhookPath, c.ProcessCommand)
 
@@ -176,7 +182,7 @@ func (s *WebHooksControllerTestSuite) TestPostWebhookRawCommand() {
 	autoAPISvc := mock_services.NewMockAutoPiAPIService(s.mockCtrl)
 
 	token := "BobbyHarry"
-	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc)
+	c := NewWebhooksController(&config.Settings{AutoPiAPIToken: token}, s.pdb.DBS, test.Logger(), autoAPISvc, ddDefIntSvc, nil, nil, nil)
 	app := fiber.New()
 	app.Post(constants.AutoPiWebhookPath, c.ProcessCommand)
 
@@ -238,3 +244,107 @@ func (s *WebHooksControllerTestSuite) TestPostWebhookRawCommand() {
 	assert.Equal(s.T(), "123", cmdResult.Value)
 	assert.Equal(s.T(), "vin", cmdResult.Type)
 }
+
+func signSmartcarWebhook(token, body string) string {
+	h := hmac.New(sha256.New, []byte(token))
+	h.Write([]byte(body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (s *WebHooksControllerTestSuite) TestPostSmartcarWebhookVerify() {
+	token := "BobbyHarry"
+	c := NewWebhooksController(&config.Settings{SmartcarManagementToken: token}, s.pdb.DBS, test.Logger(), nil, nil, nil, nil, nil)
+	app := fiber.New()
+	app.Post(constants.SmartcarWebhookPath, c.ProcessSmartcarWebhook)
+
+	body := `{"eventId": "e1", "eventType": "VERIFY", "data": {"challenge": "abc123"}}`
+
+	request := test.BuildRequest("POST", constants.SmartcarWebhookPath, body)
+	response, _ := app.Test(request)
+	s.Equal(401, response.StatusCode)
+
+	request = test.BuildRequest("POST", constants.SmartcarWebhookPath, body)
+	request.Header.Set("SC-Signature", signSmartcarWebhook(token, body))
+	response, _ = app.Test(request)
+	s.Require().Equal(200, response.StatusCode)
+
+	respBody, _ := io.ReadAll(response.Body)
+	s.Equal(signSmartcarWebhook(token, "abc123"), gjson.GetBytes(respBody, "challenge").String())
+}
+
+func (s *WebHooksControllerTestSuite) TestPostSmartcarWebhookNotConfigured() {
+	c := NewWebhooksController(&config.Settings{}, s.pdb.DBS, test.Logger(), nil, nil, nil, nil, nil)
+	app := fiber.New()
+	app.Post(constants.SmartcarWebhookPath, c.ProcessSmartcarWebhook)
+
+	body := `{"eventId": "e1", "eventType": "VERIFY", "data": {"challenge": "abc123"}}`
+
+	// Signed with the empty key.
+	request := test.BuildRequest("POST", constants.SmartcarWebhookPath, body)
+	request.Header.Set("SC-Signature", signSmartcarWebhook("", body))
+	response, _ := app.Test(request)
+	s.Equal(503, response.StatusCode)
+}
+
+func (s *WebHooksControllerTestSuite) TestPostSmartcarWebhookDisconnected() {
+	ddSvc := mock_services.NewMockDeviceDefinitionService(s.mockCtrl)
+	scTaskSvc := mock_services.NewMockSmartcarTaskService(s.mockCtrl)
+	eventSvc := mock_services.NewMockEventService(s.mockCtrl)
+
+	token := "BobbyHarry"
+	c := NewWebhooksController(&config.Settings{SmartcarManagementToken: token}, s.pdb.DBS, test.Logger(), nil, nil, ddSvc, scTaskSvc, eventSvc)
+	app := fiber.New()
+	app.Post(constants.SmartcarWebhookPath, c.ProcessSmartcarWebhook)
+
+	integ := test.BuildIntegrationGRPC(smartCarIntegrationID, constants.SmartCarVendor, 0, 0)
+	dd := test.BuildDeviceDefinitionGRPC(ksuid.New().String(), "Ford", "Mach E", 2022, integ)
+	ud := test.SetupCreateUserDevice(s.T(), ksuid.New().String(), dd[0].Id, nil, "", s.pdb)
+	udai := test.SetupCreateUserDeviceAPIIntegration(s.T(), "", "smartcar-vehicle-1", ud.ID, integ.Id, s.pdb)
+	udai.TaskID = null.StringFrom(ksuid.New().String())
+	_, err := udai.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	ddSvc.EXPECT().GetIntegrationByVendor(gomock.Any(), constants.SmartCarVendor).Return(integ, nil)
+	ddSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), ud.DefinitionID).Return(dd[0], nil)
+	scTaskSvc.EXPECT().StopPoll(gomock.Any()).Return(nil)
+	eventSvc.EXPECT().Emit(gomock.Any()).Do(func(event *shared.CloudEvent[any]) {
+		s.Equal("com.dimo.zone.device.integration.revoke", event.Type)
+		s.Equal(ud.ID, event.Subject)
+	}).Return(nil)
+
+	body := `{"eventId": "e2", "eventType": "VEHICLE_DISCONNECTED", "data": {"vehicle": {"id": "smartcar-vehicle-1"}}}`
+	request := test.BuildRequest("POST", constants.SmartcarWebhookPath, body)
+	request.Header.Set("SC-Signature", signSmartcarWebhook(token, body))
+	response, _ := app.Test(request)
+	s.Require().Equal(204, response.StatusCode)
+
+	s.Require().NoError(udai.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Equal(models.UserDeviceAPIIntegrationStatusAuthenticationFailure, udai.Status)
+	s.False(udai.TaskID.Valid)
+	s.True(udai.FailureReason.Valid)
+
+	history, err := models.UserDeviceAPIIntegrationStatusHistories(
+		models.UserDeviceAPIIntegrationStatusHistoryWhere.UserDeviceID.EQ(ud.ID),
+	).All(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Require().Len(history, 1)
+	s.Equal(services.IntegrationStatusSourceWebhook, history[0].Source)
+}
+
+func (s *WebHooksControllerTestSuite) TestPostSmartcarWebhookUnknownVehicle() {
+	ddSvc := mock_services.NewMockDeviceDefinitionService(s.mockCtrl)
+
+	token := "BobbyHarry"
+	c := NewWebhooksController(&config.Settings{SmartcarManagementToken: token}, s.pdb.DBS, test.Logger(), nil, nil, ddSvc, nil, nil)
+	app := fiber.New()
+	app.Post(constants.SmartcarWebhookPath, c.ProcessSmartcarWebhook)
+
+	integ := test.BuildIntegrationGRPC(smartCarIntegrationID, constants.SmartCarVendor, 0, 0)
+	ddSvc.EXPECT().GetIntegrationByVendor(gomock.Any(), constants.SmartCarVendor).Return(integ, nil)
+
+	body := `{"eventId": "e3", "eventType": "VEHICLE_ERROR", "data": {"vehicle": {"id": "nope"}, "errors": [{"type": "VEHICLE_STATE", "code": "ASLEEP"}]}}`
+	request := test.BuildRequest("POST", constants.SmartcarWebhookPath, body)
+	request.Header.Set("SC-Signature", signSmartcarWebhook(token, body))
+	response, _ := app.Test(request)
+	s.Equal(204, response.StatusCode)
+}
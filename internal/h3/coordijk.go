@@ -0,0 +1,287 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Ported to Go from the H3 C library (src/h3lib/lib/coordijk.c and vec2d.c), with changes.
+
+package h3
+
+import "math"
+
+// coordIJK is a position in one of the hexagonal ijk grids. Most functions
+// here expect it normalized to ijk+ form: no negative components and at least
+// one zero component.
+type coordIJK struct {
+	i, j, k int
+}
+
+// vec2d is a point in a face-centered 2D cartesian system.
+type vec2d struct {
+	x, y float64
+}
+
+// Indexing digits, which double as directions in the ijk grid.
+const (
+	centerDigit  = 0
+	kAxesDigit   = 1
+	jAxesDigit   = 2
+	jkAxesDigit  = 3
+	iAxesDigit   = 4
+	ikAxesDigit  = 5
+	ijAxesDigit  = 6
+	invalidDigit = 7
+	numDigits    = invalidDigit
+)
+
+// unitVecs are the ijk+ unit vectors for each digit.
+var unitVecs = [numDigits]coordIJK{
+	{0, 0, 0},
+	{0, 0, 1},
+	{0, 1, 0},
+	{0, 1, 1},
+	{1, 0, 0},
+	{1, 0, 1},
+	{1, 1, 0},
+}
+
+func (c coordIJK) add(o coordIJK) coordIJK {
+	return coordIJK{c.i + o.i, c.j + o.j, c.k + o.k}
+}
+
+func (c coordIJK) sub(o coordIJK) coordIJK {
+	return coordIJK{c.i - o.i, c.j - o.j, c.k - o.k}
+}
+
+func (c coordIJK) scale(f int) coordIJK {
+	return coordIJK{c.i * f, c.j * f, c.k * f}
+}
+
+func (c *coordIJK) normalize() {
+	if c.i < 0 {
+		c.j -= c.i
+		c.k -= c.i
+		c.i = 0
+	}
+
+	if c.j < 0 {
+		c.i -= c.j
+		c.k -= c.j
+		c.j = 0
+	}
+
+	if c.k < 0 {
+		c.i -= c.k
+		c.j -= c.k
+		c.k = 0
+	}
+
+	m := min(c.i, c.j, c.k)
+	if m > 0 {
+		c.i -= m
+		c.j -= m
+		c.k -= m
+	}
+}
+
+// hex2dToCoordIJK finds the hex containing a point of the 2D grid.
+func hex2dToCoordIJK(v vec2d) coordIJK {
+	var h coordIJK
+
+	a1 := math.Abs(v.x)
+	a2 := math.Abs(v.y)
+
+	// Do a reverse conversion.
+	x2 := a2 / sin60
+	x1 := a1 + x2/2
+
+	m1 := int(x1)
+	m2 := int(x2)
+
+	r1 := x1 - float64(m1)
+	r2 := x2 - float64(m2)
+
+	if r1 < 0.5 {
+		if r1 < 1.0/3.0 {
+			if r2 < (1+r1)/2 {
+				h.i, h.j = m1, m2
+			} else {
+				h.i, h.j = m1, m2+1
+			}
+		} else {
+			if r2 < 1-r1 {
+				h.j = m2
+			} else {
+				h.j = m2 + 1
+			}
+
+			if 1-r1 <= r2 && r2 < 2*r1 {
+				h.i = m1 + 1
+			} else {
+				h.i = m1
+			}
+		}
+	} else {
+		if r1 < 2.0/3.0 {
+			if r2 < 1-r1 {
+				h.j = m2
+			} else {
+				h.j = m2 + 1
+			}
+
+			if 2*r1-1 < r2 && r2 < 1-r1 {
+				h.i = m1
+			} else {
+				h.i = m1 + 1
+			}
+		} else {
+			if r2 < r1/2 {
+				h.i, h.j = m1+1, m2
+			} else {
+				h.i, h.j = m1+1, m2+1
+			}
+		}
+	}
+
+	// Fold across the axes if necessary.
+	if v.x < 0 {
+		if h.j%2 == 0 {
+			axisi := h.j / 2
+			diff := h.i - axisi
+			h.i -= 2 * diff
+		} else {
+			axisi := (h.j + 1) / 2
+			diff := h.i - axisi
+			h.i -= 2*diff + 1
+		}
+	}
+
+	if v.y < 0 {
+		h.i -= (2*h.j + 1) / 2
+		h.j = -h.j
+	}
+
+	h.normalize()
+	return h
+}
+
+// toHex2d gives the center of the hex in the 2D grid.
+func (c coordIJK) toHex2d() vec2d {
+	i := c.i - c.k
+	j := c.j - c.k
+
+	return vec2d{
+		x: float64(i) - 0.5*float64(j),
+		y: float64(j) * sqrt3Over2,
+	}
+}
+
+// unitIJKToDigit gives the digit for a unit or zero vector, or invalidDigit.
+func unitIJKToDigit(c coordIJK) int {
+	c.normalize()
+	for d := centerDigit; d < numDigits; d++ {
+		if c == unitVecs[d] {
+			return d
+		}
+	}
+	return invalidDigit
+}
+
+// upAp7 moves to the indexing parent in a ccw aperture 7 grid.
+func (c *coordIJK) upAp7() {
+	i := c.i - c.k
+	j := c.j - c.k
+
+	c.i = int(math.Round(float64(3*i-j) / 7))
+	c.j = int(math.Round(float64(i+2*j) / 7))
+	c.k = 0
+	c.normalize()
+}
+
+// upAp7r moves to the indexing parent in a cw aperture 7 grid.
+func (c *coordIJK) upAp7r() {
+	i := c.i - c.k
+	j := c.j - c.k
+
+	c.i = int(math.Round(float64(2*i+j) / 7))
+	c.j = int(math.Round(float64(3*j-i) / 7))
+	c.k = 0
+	c.normalize()
+}
+
+// downAp7 moves to the center child in the next finer ccw aperture 7 grid.
+func (c *coordIJK) downAp7() {
+	*c = coordIJK{3, 0, 1}.scale(c.i).add(coordIJK{1, 3, 0}.scale(c.j)).add(coordIJK{0, 1, 3}.scale(c.k))
+	c.normalize()
+}
+
+// downAp7r moves to the center child in the next finer cw aperture 7 grid.
+func (c *coordIJK) downAp7r() {
+	*c = coordIJK{3, 1, 0}.scale(c.i).add(coordIJK{0, 3, 1}.scale(c.j)).add(coordIJK{1, 0, 3}.scale(c.k))
+	c.normalize()
+}
+
+// neighbor moves one step in the direction of the digit.
+func (c *coordIJK) neighbor(digit int) {
+	if digit > centerDigit && digit < numDigits {
+		*c = c.add(unitVecs[digit])
+		c.normalize()
+	}
+}
+
+func (c *coordIJK) rotate60ccw() {
+	*c = coordIJK{1, 1, 0}.scale(c.i).add(coordIJK{0, 1, 1}.scale(c.j)).add(coordIJK{1, 0, 1}.scale(c.k))
+	c.normalize()
+}
+
+func (c *coordIJK) rotate60cw() {
+	*c = coordIJK{1, 0, 1}.scale(c.i).add(coordIJK{1, 1, 0}.scale(c.j)).add(coordIJK{0, 1, 1}.scale(c.k))
+	c.normalize()
+}
+
+func rotateDigit60ccw(digit int) int {
+	switch digit {
+	case kAxesDigit:
+		return ikAxesDigit
+	case ikAxesDigit:
+		return iAxesDigit
+	case iAxesDigit:
+		return ijAxesDigit
+	case ijAxesDigit:
+		return jAxesDigit
+	case jAxesDigit:
+		return jkAxesDigit
+	case jkAxesDigit:
+		return kAxesDigit
+	default:
+		return digit
+	}
+}
+
+func rotateDigit60cw(digit int) int {
+	switch digit {
+	case kAxesDigit:
+		return jkAxesDigit
+	case jkAxesDigit:
+		return jAxesDigit
+	case jAxesDigit:
+		return ijAxesDigit
+	case ijAxesDigit:
+		return iAxesDigit
+	case iAxesDigit:
+		return ikAxesDigit
+	case ikAxesDigit:
+		return kAxesDigit
+	default:
+		return digit
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Ported to Go from the H3 C library (src/h3lib/lib/faceijk.c and latLng.c), with changes.
+
+package h3
+
+import "math"
+
+const (
+	numIcosaFaces = 20
+
+	// sqrt3Over2 is sin(60°).
+	sqrt3Over2 = 0.8660254037844386467637231707529361834714
+	sin60      = sqrt3Over2
+	sqrt7      = 2.6457513110645905905016157536392604257102
+	// ap7RotRads is the rotation between Class II and Class III grids.
+	ap7RotRads = 0.333473172251832115336090755351601070065900389
+	// res0UGnomonic is the scaling factor from the res 0 unit length to
+	// gnomonic unit length.
+	res0UGnomonic = 0.38196601125010500003
+	epsilon       = 0.0000000000000001
+)
+
+// Quadrants of a face, used as indexes into faceNeighbors.
+const (
+	ij = 1
+	ki = 2
+	jk = 3
+)
+
+// faceIJK is a position in the ijk grid centered on an icosahedron face.
+type faceIJK struct {
+	face  int
+	coord coordIJK
+}
+
+// faceOrientIJK describes how to move into a neighboring face's coordinates.
+type faceOrientIJK struct {
+	face      int
+	translate coordIJK
+	ccwRot60  int
+}
+
+type latLng struct {
+	lat, lng float64
+}
+
+type vec3d struct {
+	x, y, z float64
+}
+
+// maxDimByCIIres is the largest coordinate sum that stays on a face, by Class
+// II resolution. Odd entries are unused.
+var maxDimByCIIres = [...]int{2, -1, 14, -1, 98, -1, 686, -1, 4802, -1, 33614, -1, 235298, -1, 1647086, -1, 11529602}
+
+// unitScaleByCIIres is the length of a res 0 unit in cells of each Class II
+// resolution. Odd entries are unused.
+var unitScaleByCIIres = [...]int{1, -1, 7, -1, 49, -1, 343, -1, 2401, -1, 16807, -1, 117649, -1, 823543, -1, 5764801}
+
+func isResClassIII(res int) bool {
+	return res%2 == 1
+}
+
+// posAngleRads normalizes an angle to [0, 2π).
+func posAngleRads(rads float64) float64 {
+	tmp := rads
+	if rads < 0 {
+		tmp = rads + 2*math.Pi
+	}
+	if rads >= 2*math.Pi {
+		tmp -= 2 * math.Pi
+	}
+	return tmp
+}
+
+func constrainLng(lng float64) float64 {
+	for lng > math.Pi {
+		lng -= 2 * math.Pi
+	}
+	for lng < -math.Pi {
+		lng += 2 * math.Pi
+	}
+	return lng
+}
+
+func geoAzimuthRads(p1, p2 latLng) float64 {
+	return math.Atan2(math.Cos(p2.lat)*math.Sin(p2.lng-p1.lng),
+		math.Cos(p1.lat)*math.Sin(p2.lat)-math.Sin(p1.lat)*math.Cos(p2.lat)*math.Cos(p2.lng-p1.lng))
+}
+
+// geoAzDistanceRads finds the point at the given azimuth and distance from p1.
+func geoAzDistanceRads(p1 latLng, az, distance float64) latLng {
+	if distance < epsilon {
+		return p1
+	}
+
+	var p2 latLng
+
+	az = posAngleRads(az)
+
+	if az < epsilon || math.Abs(az-math.Pi) < epsilon {
+		// Due north or south.
+		if az < epsilon {
+			p2.lat = p1.lat + distance
+		} else {
+			p2.lat = p1.lat - distance
+		}
+
+		if math.Abs(p2.lat-math.Pi/2) < epsilon {
+			p2.lat, p2.lng = math.Pi/2, 0
+		} else if math.Abs(p2.lat+math.Pi/2) < epsilon {
+			p2.lat, p2.lng = -math.Pi/2, 0
+		} else {
+			p2.lng = constrainLng(p1.lng)
+		}
+		return p2
+	}
+
+	sinlat := math.Sin(p1.lat)*math.Cos(distance) + math.Cos(p1.lat)*math.Sin(distance)*math.Cos(az)
+	sinlat = max(-1, min(1, sinlat))
+	p2.lat = math.Asin(sinlat)
+
+	if math.Abs(p2.lat-math.Pi/2) < epsilon {
+		p2.lat, p2.lng = math.Pi/2, 0
+	} else if math.Abs(p2.lat+math.Pi/2) < epsilon {
+		p2.lat, p2.lng = -math.Pi/2, 0
+	} else {
+		sinlng := math.Sin(az) * math.Sin(distance) / math.Cos(p2.lat)
+		coslng := (math.Cos(distance) - math.Sin(p1.lat)*math.Sin(p2.lat)) / math.Cos(p1.lat) / math.Cos(p2.lat)
+		sinlng = max(-1, min(1, sinlng))
+		coslng = max(-1, min(1, coslng))
+		p2.lng = constrainLng(p1.lng + math.Atan2(sinlng, coslng))
+	}
+
+	return p2
+}
+
+// geoToClosestFace finds the face whose center is closest to g, along with the
+// squared euclidean distance to that center.
+func geoToClosestFace(g latLng) (int, float64) {
+	r := math.Cos(g.lat)
+	v := vec3d{
+		x: math.Cos(g.lng) * r,
+		y: math.Sin(g.lng) * r,
+		z: math.Sin(g.lat),
+	}
+
+	face := 0
+	sqd := 5.0
+	for f := range numIcosaFaces {
+		c := faceCenterPoint[f]
+		d := (c.x-v.x)*(c.x-v.x) + (c.y-v.y)*(c.y-v.y) + (c.z-v.z)*(c.z-v.z)
+		if d < sqd {
+			face, sqd = f, d
+		}
+	}
+
+	return face, sqd
+}
+
+// geoToFaceIJK finds the face and cell containing g at the resolution.
+func geoToFaceIJK(g latLng, res int) faceIJK {
+	face, v := geoToHex2d(g, res)
+	return faceIJK{face: face, coord: hex2dToCoordIJK(v)}
+}
+
+// geoToHex2d finds the face containing g and its position in that face's 2D
+// grid at the resolution.
+func geoToHex2d(g latLng, res int) (int, vec2d) {
+	face, sqd := geoToClosestFace(g)
+
+	// cos(r) = 1 - 2 * sin^2(r/2) = 1 - 2 * (sqd / 4) = 1 - sqd/2
+	r := math.Acos(1 - sqd/2)
+
+	if r < epsilon {
+		return face, vec2d{}
+	}
+
+	// Find the ccw angle from the Class II i-axis.
+	theta := posAngleRads(faceAxesAzRadsCII[face][0] - posAngleRads(geoAzimuthRads(faceCenterGeo[face], g)))
+
+	if isResClassIII(res) {
+		theta = posAngleRads(theta - ap7RotRads)
+	}
+
+	// Gnomonic scaling, then scaling for the resolution.
+	r = math.Tan(r)
+	r /= res0UGnomonic
+	for range res {
+		r *= sqrt7
+	}
+
+	return face, vec2d{x: r * math.Cos(theta), y: r * math.Sin(theta)}
+}
+
+// hex2dToGeo finds the point for a position in a face's 2D grid.
+func hex2dToGeo(v vec2d, face, res int) latLng {
+	r := math.Hypot(v.x, v.y)
+
+	if r < epsilon {
+		return faceCenterGeo[face]
+	}
+
+	theta := math.Atan2(v.y, v.x)
+
+	for range res {
+		r /= sqrt7
+	}
+
+	r *= res0UGnomonic
+	r = math.Atan(r)
+
+	if isResClassIII(res) {
+		theta = posAngleRads(theta + ap7RotRads)
+	}
+
+	theta = posAngleRads(faceAxesAzRadsCII[face][0] - theta)
+
+	return geoAzDistanceRads(faceCenterGeo[face], theta, r)
+}
+
+// toGeo gives the center of the cell.
+func (f faceIJK) toGeo(res int) latLng {
+	return hex2dToGeo(f.coord.toHex2d(), f.face, res)
+}
+
+// adjustOverageClassII moves a Class II position that has run off its face
+// onto the neighboring face. It reports whether it did.
+func (f *faceIJK) adjustOverageClassII(res int, pentLeading4 bool) bool {
+	c := &f.coord
+	maxDim := maxDimByCIIres[res]
+
+	if c.i+c.j+c.k <= maxDim {
+		return false
+	}
+
+	var orient faceOrientIJK
+	if c.k > 0 {
+		if c.j > 0 {
+			orient = faceNeighbors[f.face][jk]
+		} else {
+			orient = faceNeighbors[f.face][ki]
+
+			// Adjust for the pentagonal missing sequence.
+			if pentLeading4 {
+				origin := coordIJK{maxDim, 0, 0}
+				tmp := c.sub(origin)
+				tmp.rotate60cw()
+				*c = tmp.add(origin)
+			}
+		}
+	} else {
+		orient = faceNeighbors[f.face][ij]
+	}
+
+	f.face = orient.face
+
+	for range orient.ccwRot60 {
+		c.rotate60ccw()
+	}
+
+	*c = c.add(orient.translate.scale(unitScaleByCIIres[res]))
+	c.normalize()
+
+	return true
+}
+
+// faceCenterGeo are the icosahedron face centers in radians.
+var faceCenterGeo = [numIcosaFaces]latLng{
+	{0.803582649718989942, 1.248397419617396099},   // face 0
+	{1.307747883455638156, 2.536945009877921159},   // face 1
+	{1.054751253523952054, -1.347517358900396623},  // face 2
+	{0.600191595538186799, -0.450603909469755746},  // face 3
+	{0.491715428198773866, 0.401988202911306943},   // face 4
+	{0.172745327415618701, 1.678146885280433686},   // face 5
+	{0.605929321571350690, 2.953923329812411617},   // face 6
+	{0.427370518328979641, -1.888876200336285401},  // face 7
+	{-0.079066118549212831, -0.733429513380867741}, // face 8
+	{-0.230961644455383637, 0.506495587332349035},  // face 9
+	{0.079066118549212831, 2.408163140208925497},   // face 10
+	{0.230961644455383637, -2.635097066257444203},  // face 11
+	{-0.172745327415618701, -1.463445768309359553}, // face 12
+	{-0.605929321571350690, -0.187669323777381622}, // face 13
+	{-0.427370518328979641, 1.252716453253507838},  // face 14
+	{-0.600191595538186799, 2.690988744120037492},  // face 15
+	{-0.491715428198773866, -2.739604450678486295}, // face 16
+	{-0.803582649718989942, -1.893195233972397139}, // face 17
+	{-1.307747883455638156, -0.604647643711872080}, // face 18
+	{-1.054751253523952054, 1.794075294689396615},  // face 19
+}
+
+// faceCenterPoint are the icosahedron face centers on the unit sphere.
+var faceCenterPoint = [numIcosaFaces]vec3d{
+	{0.2199307791404606, 0.6583691780274996, 0.7198475378926182},    // face 0
+	{-0.2139234834501421, 0.1478171829550703, 0.9656017935214205},   // face 1
+	{0.1092625278784797, -0.4811951572873210, 0.8697775121287253},   // face 2
+	{0.7428567301586791, -0.3593941678278028, 0.5648005936517033},   // face 3
+	{0.8112534709140969, 0.3448953237639384, 0.4721387736413930},    // face 4
+	{-0.1055498149613921, 0.9794457296411413, 0.1718874610009365},   // face 5
+	{-0.8075407579970092, 0.1533552485898818, 0.5695261994882688},   // face 6
+	{-0.2846148069787907, -0.8644080972654206, 0.4144792552473539},  // face 7
+	{0.7405621473854482, -0.6673299564565524, -0.0789837646326737},  // face 8
+	{0.8512303986474293, 0.4722343788582681, -0.2289137388687808},   // face 9
+	{-0.7405621473854481, 0.6673299564565524, 0.0789837646326737},   // face 10
+	{-0.8512303986474292, -0.4722343788582682, 0.2289137388687808},  // face 11
+	{0.1055498149613919, -0.9794457296411413, -0.1718874610009365},  // face 12
+	{0.8075407579970092, -0.1533552485898819, -0.5695261994882688},  // face 13
+	{0.2846148069787908, 0.8644080972654204, -0.4144792552473539},   // face 14
+	{-0.7428567301586791, 0.3593941678278027, -0.5648005936517033},  // face 15
+	{-0.8112534709140971, -0.3448953237639382, -0.4721387736413930}, // face 16
+	{-0.2199307791404607, -0.6583691780274996, -0.7198475378926182}, // face 17
+	{0.2139234834501420, -0.1478171829550704, -0.9656017935214205},  // face 18
+	{-0.1092625278784796, 0.4811951572873210, -0.8697775121287253},  // face 19
+}
+
+// faceAxesAzRadsCII are the azimuths in radians from each face center to its
+// vertices 0, 1 and 2, which are the directions of the Class II i, j and k axes.
+var faceAxesAzRadsCII = [numIcosaFaces][3]float64{
+	{5.619958268523939882, 3.525563166130744542, 1.431168063737548730}, // face 0
+	{5.760339081714187279, 3.665943979320991689, 1.571548876927796127}, // face 1
+	{0.780213654393430055, 4.969003859179821079, 2.874608756786625655}, // face 2
+	{0.430469363979999913, 4.619259568766391033, 2.524864466373195467}, // face 3
+	{6.130269123335111400, 4.035874020941915804, 1.941478918548720291}, // face 4
+	{2.692877706530642877, 0.598482604137447119, 4.787272808923838195}, // face 5
+	{2.982963003477243874, 0.888567901084048369, 5.077358105870439581}, // face 6
+	{3.532912002790141181, 1.438516900396945656, 5.627307105183336758}, // face 7
+	{3.494305004259568154, 1.399909901866372864, 5.588700106652763840}, // face 8
+	{3.003214169499538391, 0.908819067106342928, 5.097609271892733906}, // face 9
+	{5.930472956509811562, 3.836077854116615875, 1.741682751723420374}, // face 10
+	{0.138378484090254847, 4.327168688876645809, 2.232773586483450311}, // face 11
+	{0.448714947059150361, 4.637505151845541521, 2.543110049452346120}, // face 12
+	{0.158629650112549365, 4.347419854898940135, 2.253024752505744869}, // face 13
+	{5.891865957979238535, 3.797470855586042958, 1.703075753192847583}, // face 14
+	{2.711123289609793325, 0.616728187216597771, 4.805518392002988683}, // face 15
+	{3.294508837434268316, 1.200113735041072948, 5.388903939827463911}, // face 16
+	{3.804819692245439833, 1.710424589852244509, 5.899214794638635174}, // face 17
+	{3.664438879055192436, 1.570043776661997111, 5.758833981448388027}, // face 18
+	{2.361378999196363184, 0.266983896803167583, 4.455774101589558636}, // face 19
+}
+
+// faceNeighbors gives, for each face, the face itself followed by its
+// neighbors across the ij, ki and jk edges, with the translation and rotation
+// into each neighbor's coordinate system.
+var faceNeighbors = [numIcosaFaces][4]faceOrientIJK{
+	{ // face 0
+		{0, coordIJK{0, 0, 0}, 0},
+		{4, coordIJK{2, 0, 2}, 1},
+		{1, coordIJK{2, 2, 0}, 5},
+		{5, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 1
+		{1, coordIJK{0, 0, 0}, 0},
+		{0, coordIJK{2, 0, 2}, 1},
+		{2, coordIJK{2, 2, 0}, 5},
+		{6, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 2
+		{2, coordIJK{0, 0, 0}, 0},
+		{1, coordIJK{2, 0, 2}, 1},
+		{3, coordIJK{2, 2, 0}, 5},
+		{7, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 3
+		{3, coordIJK{0, 0, 0}, 0},
+		{2, coordIJK{2, 0, 2}, 1},
+		{4, coordIJK{2, 2, 0}, 5},
+		{8, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 4
+		{4, coordIJK{0, 0, 0}, 0},
+		{3, coordIJK{2, 0, 2}, 1},
+		{0, coordIJK{2, 2, 0}, 5},
+		{9, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 5
+		{5, coordIJK{0, 0, 0}, 0},
+		{10, coordIJK{2, 2, 0}, 3},
+		{14, coordIJK{2, 0, 2}, 3},
+		{0, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 6
+		{6, coordIJK{0, 0, 0}, 0},
+		{11, coordIJK{2, 2, 0}, 3},
+		{10, coordIJK{2, 0, 2}, 3},
+		{1, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 7
+		{7, coordIJK{0, 0, 0}, 0},
+		{12, coordIJK{2, 2, 0}, 3},
+		{11, coordIJK{2, 0, 2}, 3},
+		{2, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 8
+		{8, coordIJK{0, 0, 0}, 0},
+		{13, coordIJK{2, 2, 0}, 3},
+		{12, coordIJK{2, 0, 2}, 3},
+		{3, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 9
+		{9, coordIJK{0, 0, 0}, 0},
+		{14, coordIJK{2, 2, 0}, 3},
+		{13, coordIJK{2, 0, 2}, 3},
+		{4, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 10
+		{10, coordIJK{0, 0, 0}, 0},
+		{5, coordIJK{2, 2, 0}, 3},
+		{6, coordIJK{2, 0, 2}, 3},
+		{15, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 11
+		{11, coordIJK{0, 0, 0}, 0},
+		{6, coordIJK{2, 2, 0}, 3},
+		{7, coordIJK{2, 0, 2}, 3},
+		{16, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 12
+		{12, coordIJK{0, 0, 0}, 0},
+		{7, coordIJK{2, 2, 0}, 3},
+		{8, coordIJK{2, 0, 2}, 3},
+		{17, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 13
+		{13, coordIJK{0, 0, 0}, 0},
+		{8, coordIJK{2, 2, 0}, 3},
+		{9, coordIJK{2, 0, 2}, 3},
+		{18, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 14
+		{14, coordIJK{0, 0, 0}, 0},
+		{9, coordIJK{2, 2, 0}, 3},
+		{5, coordIJK{2, 0, 2}, 3},
+		{19, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 15
+		{15, coordIJK{0, 0, 0}, 0},
+		{16, coordIJK{2, 0, 2}, 1},
+		{19, coordIJK{2, 2, 0}, 5},
+		{10, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 16
+		{16, coordIJK{0, 0, 0}, 0},
+		{17, coordIJK{2, 0, 2}, 1},
+		{15, coordIJK{2, 2, 0}, 5},
+		{11, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 17
+		{17, coordIJK{0, 0, 0}, 0},
+		{18, coordIJK{2, 0, 2}, 1},
+		{16, coordIJK{2, 2, 0}, 5},
+		{12, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 18
+		{18, coordIJK{0, 0, 0}, 0},
+		{19, coordIJK{2, 0, 2}, 1},
+		{17, coordIJK{2, 2, 0}, 5},
+		{13, coordIJK{0, 2, 2}, 3},
+	},
+	{ // face 19
+		{19, coordIJK{0, 0, 0}, 0},
+		{15, coordIJK{2, 0, 2}, 1},
+		{18, coordIJK{2, 2, 0}, 5},
+		{14, coordIJK{0, 2, 2}, 3},
+	},
+}
@@ -0,0 +1,358 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Ported to Go from the H3 C library (src/h3lib/lib/h3Index.c), with changes.
+
+// Package h3 is a pure Go implementation of the parts of Uber's H3 grid system
+// (https://h3geo.org) that we need for geofencing: finding the cell that
+// contains a point, finding a cell's center, and walking up the hierarchy.
+//
+// It is a port of the H3 C library, which is licensed under the Apache
+// License, Version 2.0, and produces identical cell indexes. We carry our own
+// copy because the H3 bindings for Go require cgo.
+package h3
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MaxResolution is the finest H3 resolution.
+const MaxResolution = 15
+
+const (
+	cellMode = 1
+
+	maxOffset      = 63
+	modeOffset     = 59
+	baseCellOffset = 45
+	resOffset      = 52
+	reservedOffset = 56
+	perDigitOffset = 3
+
+	highBitMask  = uint64(1) << maxOffset
+	modeMask     = uint64(15) << modeOffset
+	baseCellMask = uint64(127) << baseCellOffset
+	resMask      = uint64(15) << resOffset
+	reservedMask = uint64(7) << reservedOffset
+	digitMask    = uint64(7)
+
+	// initIndex has mode 0, resolution 0, base cell 0 and every digit set to 7.
+	initIndex = uint64(35184372088831)
+)
+
+var (
+	// ErrInvalidResolution is returned for resolutions outside of 0–15.
+	ErrInvalidResolution = errors.New("h3: resolution out of range")
+	// ErrInvalidLatLng is returned for coordinates that aren't finite numbers.
+	ErrInvalidLatLng = errors.New("h3: invalid latitude or longitude")
+	// ErrInvalidCell is returned when parsing something that isn't a cell index.
+	ErrInvalidCell = errors.New("h3: invalid cell index")
+)
+
+// Cell is an H3 cell index.
+type Cell uint64
+
+// ParseCell parses the hexadecimal form of a cell index, such as
+// "8928308280fffff".
+func ParseCell(s string) (Cell, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidCell, s)
+	}
+	c := Cell(v)
+	if !c.IsValid() {
+		return 0, fmt.Errorf("%w %q", ErrInvalidCell, s)
+	}
+	return c, nil
+}
+
+// LatLngToCell returns the cell at the given resolution that contains the
+// point. Coordinates are in degrees.
+func LatLngToCell(lat, lng float64, res int) (Cell, error) {
+	if res < 0 || res > MaxResolution {
+		return 0, ErrInvalidResolution
+	}
+	if math.IsNaN(lat) || math.IsInf(lat, 0) || math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return 0, ErrInvalidLatLng
+	}
+
+	g := latLng{lat: lat * math.Pi / 180, lng: lng * math.Pi / 180}
+	c := faceIJKToCell(geoToFaceIJK(g, res), res)
+	if c == 0 {
+		return 0, ErrInvalidLatLng
+	}
+	return c, nil
+}
+
+// String returns the usual hexadecimal form of the index.
+func (c Cell) String() string {
+	return strconv.FormatUint(uint64(c), 16)
+}
+
+// Resolution returns the resolution of the cell.
+func (c Cell) Resolution() int {
+	return int((uint64(c) & resMask) >> resOffset)
+}
+
+// IsValid reports whether c is a well-formed cell index.
+func (c Cell) IsValid() bool {
+	h := uint64(c)
+	if h&highBitMask != 0 {
+		return false
+	}
+	if (h&modeMask)>>modeOffset != cellMode {
+		return false
+	}
+	if h&reservedMask != 0 {
+		return false
+	}
+
+	bc := c.baseCell()
+	if bc >= numBaseCells {
+		return false
+	}
+
+	res := c.Resolution()
+
+	foundFirstNonZero := false
+	for r := 1; r <= res; r++ {
+		digit := c.digit(r)
+
+		if !foundFirstNonZero && digit != centerDigit {
+			foundFirstNonZero = true
+			if isBaseCellPentagon(bc) && digit == kAxesDigit {
+				return false
+			}
+		}
+
+		if digit >= numDigits {
+			return false
+		}
+	}
+
+	for r := res + 1; r <= MaxResolution; r++ {
+		if c.digit(r) != invalidDigit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Parent returns the cell's ancestor at the given resolution. It returns the
+// cell itself if res is its own resolution, and 0 if res is finer.
+func (c Cell) Parent(res int) Cell {
+	childRes := c.Resolution()
+	if res < 0 || res > childRes {
+		return 0
+	}
+	p := c.withResolution(res)
+	for r := res + 1; r <= childRes; r++ {
+		p = p.withDigit(r, invalidDigit)
+	}
+	return p
+}
+
+// LatLng returns the center of the cell in degrees.
+func (c Cell) LatLng() (lat, lng float64) {
+	g := c.toFaceIJK().toGeo(c.Resolution())
+	return g.lat * 180 / math.Pi, g.lng * 180 / math.Pi
+}
+
+func (c Cell) baseCell() int {
+	return int((uint64(c) & baseCellMask) >> baseCellOffset)
+}
+
+func (c Cell) withBaseCell(bc int) Cell {
+	return Cell(uint64(c)&^baseCellMask | uint64(bc)<<baseCellOffset)
+}
+
+func (c Cell) withResolution(res int) Cell {
+	return Cell(uint64(c)&^resMask | uint64(res)<<resOffset)
+}
+
+func (c Cell) digit(res int) int {
+	return int((uint64(c) >> ((MaxResolution - res) * perDigitOffset)) & digitMask)
+}
+
+func (c Cell) withDigit(res, digit int) Cell {
+	shift := (MaxResolution - res) * perDigitOffset
+	return Cell(uint64(c)&^(digitMask<<shift) | uint64(digit)<<shift)
+}
+
+func (c Cell) leadingNonZeroDigit() int {
+	for r := 1; r <= c.Resolution(); r++ {
+		if d := c.digit(r); d != centerDigit {
+			return d
+		}
+	}
+	return centerDigit
+}
+
+func (c Cell) rotate60ccw() Cell {
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.withDigit(r, rotateDigit60ccw(c.digit(r)))
+	}
+	return c
+}
+
+func (c Cell) rotate60cw() Cell {
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.withDigit(r, rotateDigit60cw(c.digit(r)))
+	}
+	return c
+}
+
+// rotatePent60ccw rotates about a pentagonal center, skipping the deleted
+// k-axes subsequence.
+func (c Cell) rotatePent60ccw() Cell {
+	foundFirstNonZero := false
+	for r := 1; r <= c.Resolution(); r++ {
+		c = c.withDigit(r, rotateDigit60ccw(c.digit(r)))
+
+		if !foundFirstNonZero && c.digit(r) != 0 {
+			foundFirstNonZero = true
+
+			if c.leadingNonZeroDigit() == kAxesDigit {
+				c = c.rotate60ccw()
+			}
+		}
+	}
+	return c
+}
+
+// faceIJKToCell encodes a position on a face. It returns 0 if the position is
+// too far from the face to be valid.
+func faceIJKToCell(fijk faceIJK, res int) Cell {
+	h := Cell(initIndex)
+	h = Cell(uint64(h)&^modeMask | cellMode<<modeOffset)
+	h = h.withResolution(res)
+
+	if res == 0 {
+		if fijk.coord.i > 2 || fijk.coord.j > 2 || fijk.coord.k > 2 {
+			return 0
+		}
+		return h.withBaseCell(faceIJKBaseCells[fijk.face][fijk.coord.i][fijk.coord.j][fijk.coord.k].baseCell)
+	}
+
+	// Build the index from the finest resolution up, leaving the base cell's
+	// position in the face's coordinate system.
+	ijk := &fijk.coord
+	for r := res - 1; r >= 0; r-- {
+		last := *ijk
+		var lastCenter coordIJK
+		if isResClassIII(r + 1) {
+			ijk.upAp7()
+			lastCenter = *ijk
+			lastCenter.downAp7()
+		} else {
+			ijk.upAp7r()
+			lastCenter = *ijk
+			lastCenter.downAp7r()
+		}
+
+		diff := last.sub(lastCenter)
+		diff.normalize()
+
+		h = h.withDigit(r+1, unitIJKToDigit(diff))
+	}
+
+	if ijk.i > 2 || ijk.j > 2 || ijk.k > 2 {
+		return 0
+	}
+
+	bcr := faceIJKBaseCells[fijk.face][ijk.i][ijk.j][ijk.k]
+	h = h.withBaseCell(bcr.baseCell)
+
+	// Rotate into the base cell's canonical orientation.
+	if isBaseCellPentagon(bcr.baseCell) {
+		// Force rotation out of the missing k-axes subsequence.
+		if h.leadingNonZeroDigit() == kAxesDigit {
+			if baseCellIsCwOffset(bcr.baseCell, fijk.face) {
+				h = h.rotate60cw()
+			} else {
+				h = h.rotate60ccw()
+			}
+		}
+
+		for range bcr.ccwRot60 {
+			h = h.rotatePent60ccw()
+		}
+	} else {
+		for range bcr.ccwRot60 {
+			h = h.rotate60ccw()
+		}
+	}
+
+	return h
+}
+
+// toFaceIJK finds the face and position of the cell.
+func (c Cell) toFaceIJK() faceIJK {
+	bc := c.baseCell()
+
+	// Adjust for the pentagonal missing sequence. All of subsequence 5 needs
+	// to be adjusted, and some of subsequence 4 below.
+	if isBaseCellPentagon(bc) && c.leadingNonZeroDigit() == ikAxesDigit {
+		c = c.rotate60cw()
+	}
+
+	fijk := baseCellData[bc].homeFIJK
+	res := c.Resolution()
+
+	// A hexagon base cell's center subtree stays on its home face.
+	possibleOverage := isBaseCellPentagon(bc) || (res != 0 && fijk.coord != coordIJK{})
+
+	for r := 1; r <= res; r++ {
+		if isResClassIII(r) {
+			fijk.coord.downAp7()
+		} else {
+			fijk.coord.downAp7r()
+		}
+		fijk.coord.neighbor(c.digit(r))
+	}
+
+	if !possibleOverage {
+		return fijk
+	}
+
+	orig := fijk.coord
+
+	// Work in the next finer Class II grid if this is Class III.
+	adjRes := res
+	if isResClassIII(res) {
+		fijk.coord.downAp7r()
+		adjRes++
+	}
+
+	pentLeading4 := isBaseCellPentagon(bc) && c.leadingNonZeroDigit() == iAxesDigit
+	if fijk.adjustOverageClassII(adjRes, pentLeading4) {
+		// Pentagon base cells may have secondary overages.
+		if isBaseCellPentagon(bc) {
+			for fijk.adjustOverageClassII(adjRes, false) {
+			}
+		}
+
+		if adjRes != res {
+			fijk.coord.upAp7r()
+		}
+	} else if adjRes != res {
+		fijk.coord = orig
+	}
+
+	return fijk
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package h3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Expected values come from the reference H3 library.
+func TestLatLngToCell(t *testing.T) {
+	tests := []struct {
+		lat, lng   float64
+		res        int
+		cell       string
+		centerLat  float64
+		centerLng  float64
+		parentRes3 string
+	}{
+		{37.775938728915946, -122.41795063018799, 9, "8928308280fffff", 37.7767023494, -122.4184593232, "832830fffffffff"},
+		{37.3615593, -122.0553238, 7, "87283472bffffff", 37.3517182018, -122.0503256526, "832834fffffffff"},
+		{40.7128, -74.006, 12, "8c2a107289061ff", 40.7127470097, -74.0059626949, "832a10fffffffff"},
+		{-33.8688, 151.2093, 5, "85be0e37fffffff", -33.9015862022, 151.2234814726, "83be0efffffffff"},
+		// Pentagon.
+		{64.7, 10.54, 3, "830800fffffffff", 64.7000001279, 10.5361990755, "830800fffffffff"},
+		{90, 0, 0, "8001fffffffffff", 79.2423985098, 38.0234070080, "8001fffffffffff"},
+		{0, 180, 8, "887eb57221fffff", 0.0018093229, 179.9972896202, "837eb5fffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cell, func(t *testing.T) {
+			c, err := LatLngToCell(tt.lat, tt.lng, tt.res)
+			require.NoError(t, err)
+			assert.Equal(t, tt.cell, c.String())
+			assert.Equal(t, tt.res, c.Resolution())
+			assert.True(t, c.IsValid())
+
+			lat, lng := c.LatLng()
+			assert.InDelta(t, tt.centerLat, lat, 1e-9)
+			assert.InDelta(t, tt.centerLng, lng, 1e-9)
+
+			assert.Equal(t, tt.parentRes3, c.Parent(min(3, tt.res)).String())
+		})
+	}
+}
+
+func TestLatLngToCell_Errors(t *testing.T) {
+	_, err := LatLngToCell(0, 0, 16)
+	assert.ErrorIs(t, err, ErrInvalidResolution)
+
+	_, err = LatLngToCell(0, 0, -1)
+	assert.ErrorIs(t, err, ErrInvalidResolution)
+}
+
+func TestParseCell(t *testing.T) {
+	c, err := ParseCell("8928308280fffff")
+	require.NoError(t, err)
+	assert.Equal(t, Cell(0x8928308280fffff), c)
+
+	// Pentagon child along the deleted k-axis.
+	_, err = ParseCell("8109fffffffffff")
+	assert.ErrorIs(t, err, ErrInvalidCell)
+
+	_, err = ParseCell("8108fffffffffff")
+	assert.NoError(t, err)
+
+	for _, s := range []string{"", "0", "zzz", "8928308280ffff0", "ffffffffffffffff"} {
+		_, err = ParseCell(s)
+		assert.ErrorIs(t, err, ErrInvalidCell, s)
+	}
+}
+
+func TestCell_Parent(t *testing.T) {
+	c := Cell(0x8928308280fffff)
+	assert.Equal(t, c, c.Parent(9))
+	assert.Equal(t, Cell(0), c.Parent(10))
+	assert.Equal(t, "8029fffffffffff", c.Parent(0).String())
+}
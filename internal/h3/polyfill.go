@@ -0,0 +1,269 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Ported to Go from the H3 C library (src/h3lib/lib/latLng.c), with changes.
+
+package h3
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// earthRadiusMeters is the authalic radius of the earth used by H3.
+const earthRadiusMeters = 6371007.180918475
+
+// hexEdgeLengthAvgMeters is the average hexagon edge length at each resolution.
+var hexEdgeLengthAvgMeters = [MaxResolution + 1]float64{
+	1281256.011,
+	483056.8391,
+	182512.9565,
+	68979.22179,
+	26071.75968,
+	9854.090990,
+	3724.532667,
+	1406.475763,
+	531.4140101,
+	200.7861476,
+	75.86378287,
+	28.66389748,
+	10.83018784,
+	4.092010473,
+	1.546099657,
+	0.584168630,
+}
+
+// maxSamples bounds the work done for a single shape.
+const maxSamples = 1 << 20
+
+var (
+	// ErrInvalidShape is returned for polygons and circles that can't be
+	// turned into cells.
+	ErrInvalidShape = errors.New("h3: invalid shape")
+	// ErrShapeTooLarge is returned when a shape would need too many cells at
+	// the requested resolution.
+	ErrShapeTooLarge = errors.New("h3: shape too large for resolution")
+)
+
+// LatLng is a point in degrees.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// PolygonToCells returns the cells at the given resolution whose centers lie
+// inside the polygon. The first loop is the outer boundary and any others are
+// holes. Edges are treated as straight lines in latitude and longitude, and
+// polygons may not cross the antimeridian.
+//
+// Unlike H3's polygonToCells, which walks the grid, the cells are found by
+// sampling the bounding box, so a cell that is much smaller than average
+// could in principle be missed. parity_test.go checks the two agree on a
+// set of reference polygons.
+func PolygonToCells(loops [][]LatLng, res int) ([]Cell, error) {
+	if res < 0 || res > MaxResolution {
+		return nil, ErrInvalidResolution
+	}
+	if len(loops) == 0 {
+		return nil, ErrInvalidShape
+	}
+	for _, loop := range loops {
+		if len(loop) < 3 {
+			return nil, ErrInvalidShape
+		}
+		for _, p := range loop {
+			if !validLatLng(p) {
+				return nil, ErrInvalidLatLng
+			}
+		}
+	}
+
+	south, north := loops[0][0].Lat, loops[0][0].Lat
+	west, east := loops[0][0].Lng, loops[0][0].Lng
+	for _, p := range loops[0] {
+		south, north = min(south, p.Lat), max(north, p.Lat)
+		west, east = min(west, p.Lng), max(east, p.Lng)
+	}
+	if east-west > 180 {
+		return nil, ErrInvalidShape
+	}
+
+	inside := func(p LatLng) bool {
+		if !loopContains(loops[0], p) {
+			return false
+		}
+		for _, hole := range loops[1:] {
+			if loopContains(hole, p) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return cellsIn(south, north, west, east, res, inside)
+}
+
+// CircleToCells returns the cells at the given resolution whose centers lie
+// within radius meters of the center.
+func CircleToCells(center LatLng, radius float64, res int) ([]Cell, error) {
+	if res < 0 || res > MaxResolution {
+		return nil, ErrInvalidResolution
+	}
+	if !validLatLng(center) {
+		return nil, ErrInvalidLatLng
+	}
+	if !(radius > 0) || math.IsInf(radius, 0) {
+		return nil, ErrInvalidShape
+	}
+
+	dLat := radius / earthRadiusMeters * 180 / math.Pi
+	south, north := center.Lat-dLat, center.Lat+dLat
+	if south < -90 || north > 90 {
+		return nil, ErrInvalidShape
+	}
+	cosLat := math.Cos(max(math.Abs(south), math.Abs(north)) * math.Pi / 180)
+	dLng := min(dLat/max(cosLat, 1e-9), 180)
+
+	inside := func(p LatLng) bool {
+		return DistanceMeters(center, p) <= radius
+	}
+
+	return cellsIn(south, north, center.Lng-dLng, center.Lng+dLng, res, inside)
+}
+
+// DistanceMeters is the great circle distance between two points.
+func DistanceMeters(a, b LatLng) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLng := math.Sin((b.Lng - a.Lng) * math.Pi / 180 / 2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLng*sinLng
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// cellsIn samples the bounding box finely enough to hit every cell that
+// overlaps it, and keeps the cells whose centers satisfy inside.
+func cellsIn(south, north, west, east float64, res int, inside func(LatLng) bool) ([]Cell, error) {
+	// Cells vary in size, so sample well below the average edge length.
+	step := hexEdgeLengthAvgMeters[res] / 6 / earthRadiusMeters * 180 / math.Pi
+
+	cosLat := math.Cos(max(math.Abs(south), math.Abs(north)) * math.Pi / 180)
+	latSteps := math.Ceil((north-south)/step) + 1
+	lngStep := step / max(cosLat, 1e-9)
+	lngSteps := math.Ceil((east-west)/lngStep) + 1
+	if latSteps*lngSteps > maxSamples {
+		return nil, ErrShapeTooLarge
+	}
+
+	seen := make(map[Cell]bool)
+	var out []Cell
+	for i := range int(latSteps) {
+		lat := min(south+float64(i)*step, north)
+		for j := range int(lngSteps) {
+			lng := min(west+float64(j)*lngStep, east)
+
+			c, err := LatLngToCell(lat, lng, res)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[c]; ok {
+				continue
+			}
+
+			centerLat, centerLng := c.LatLng()
+			in := inside(LatLng{Lat: centerLat, Lng: centerLng})
+			seen[c] = in
+			if in {
+				out = append(out, c)
+			}
+		}
+	}
+
+	slices.Sort(out)
+	return out, nil
+}
+
+// loopContains is the even-odd rule, with longitude as x and latitude as y.
+func loopContains(loop []LatLng, p LatLng) bool {
+	in := false
+	for i, a := range loop {
+		b := loop[(i+1)%len(loop)]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) {
+			x := a.Lng + (p.Lat-a.Lat)/(b.Lat-a.Lat)*(b.Lng-a.Lng)
+			if p.Lng < x {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+func validLatLng(p LatLng) bool {
+	return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lng) &&
+		p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// IsPentagon reports whether the cell is one of the twelve pentagons at its
+// resolution.
+func (c Cell) IsPentagon() bool {
+	return isBaseCellPentagon(c.baseCell()) && c.leadingNonZeroDigit() == centerDigit
+}
+
+// Compact replaces every complete set of siblings with their parent, as many
+// times as possible, and removes duplicates and cells covered by another cell
+// in the input. The result is sorted.
+func Compact(cells []Cell) []Cell {
+	set := make(map[Cell]struct{}, len(cells))
+	for _, c := range cells {
+		set[c] = struct{}{}
+	}
+
+	for res := MaxResolution; res > 0; res-- {
+		children := make(map[Cell]int)
+		for c := range set {
+			if c.Resolution() == res {
+				children[c.Parent(res-1)]++
+			}
+		}
+
+		for parent, n := range children {
+			want := 7
+			if parent.IsPentagon() {
+				want = 6
+			}
+			if n < want {
+				continue
+			}
+			set[parent] = struct{}{}
+		}
+	}
+
+	out := make([]Cell, 0, len(set))
+	for c := range set {
+		if !coveredByAncestor(c, set) {
+			out = append(out, c)
+		}
+	}
+
+	slices.Sort(out)
+	return out
+}
+
+func coveredByAncestor(c Cell, set map[Cell]struct{}) bool {
+	for res := c.Resolution() - 1; res >= 0; res-- {
+		if _, ok := set[c.Parent(res)]; ok {
+			return true
+		}
+	}
+	return false
+}
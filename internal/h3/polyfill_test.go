@@ -0,0 +1,134 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package h3
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var square = []LatLng{
+	{37.7749, -122.4194},
+	{37.7749, -122.4094},
+	{37.7819, -122.4094},
+	{37.7819, -122.4194},
+}
+
+func cellStrings(cells []Cell) []string {
+	out := make([]string, len(cells))
+	for i, c := range cells {
+		out[i] = c.String()
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Expected values come from the reference H3 library.
+func TestPolygonToCells(t *testing.T) {
+	cells, err := PolygonToCells([][]LatLng{square}, 9)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"8928308280bffff",
+		"8928308280fffff",
+		"89283082847ffff",
+		"89283082863ffff",
+		"89283082873ffff",
+		"89283082877ffff",
+		"8928308287bffff",
+	}, cellStrings(cells))
+
+	hole := []LatLng{
+		{37.7769, -122.4174},
+		{37.7769, -122.4114},
+		{37.7799, -122.4114},
+		{37.7799, -122.4174},
+	}
+	cells, err = PolygonToCells([][]LatLng{square, hole}, 9)
+	require.NoError(t, err)
+	assert.Len(t, cells, 5)
+}
+
+func TestPolygonToCells_Errors(t *testing.T) {
+	_, err := PolygonToCells([][]LatLng{square[:2]}, 9)
+	assert.ErrorIs(t, err, ErrInvalidShape)
+
+	_, err = PolygonToCells([][]LatLng{{{0, -170}, {0, 170}, {10, 170}}}, 5)
+	assert.ErrorIs(t, err, ErrInvalidShape)
+
+	_, err = PolygonToCells([][]LatLng{{{0, 0}, {0, 100}, {91, 100}}}, 5)
+	assert.ErrorIs(t, err, ErrInvalidLatLng)
+
+	_, err = PolygonToCells([][]LatLng{square}, 16)
+	assert.ErrorIs(t, err, ErrInvalidResolution)
+
+	_, err = PolygonToCells([][]LatLng{{{0, 0}, {0, 10}, {10, 10}}}, 15)
+	assert.ErrorIs(t, err, ErrShapeTooLarge)
+}
+
+func TestCircleToCells(t *testing.T) {
+	center := LatLng{37.7749, -122.4194}
+	cells, err := CircleToCells(center, 500, 9)
+	require.NoError(t, err)
+
+	centerCell, err := LatLngToCell(center.Lat, center.Lng, 9)
+	require.NoError(t, err)
+	assert.Contains(t, cells, centerCell)
+
+	// A res 9 cell has an area of about 0.1 km², so expect roughly 7.
+	assert.InDelta(t, 7, len(cells), 3)
+	for _, c := range cells {
+		lat, lng := c.LatLng()
+		assert.LessOrEqual(t, DistanceMeters(center, LatLng{lat, lng}), 500.0)
+	}
+
+	_, err = CircleToCells(center, 0, 9)
+	assert.ErrorIs(t, err, ErrInvalidShape)
+}
+
+func children(c Cell) []Cell {
+	res := c.Resolution() + 1
+	var out []Cell
+	for d := centerDigit; d < numDigits; d++ {
+		child := c.withResolution(res).withDigit(res, d)
+		if child.IsValid() {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+func TestCompact(t *testing.T) {
+	parent := Cell(0x8828308281fffff)
+
+	var grandchildren []Cell
+	for _, child := range children(parent) {
+		grandchildren = append(grandchildren, children(child)...)
+	}
+	require.Len(t, grandchildren, 49)
+	assert.Equal(t, []Cell{parent}, Compact(grandchildren))
+
+	// An incomplete set stays as it is, without duplicates or covered cells.
+	partial := append(slices.Clone(grandchildren[1:]), grandchildren[1], parent.Parent(7))
+	assert.Equal(t, []Cell{parent.Parent(7)}, Compact(partial))
+	assert.Len(t, Compact(grandchildren[1:]), 6+6)
+
+	pentagon := Cell(0x830800fffffffff)
+	require.True(t, pentagon.IsPentagon())
+	require.Len(t, children(pentagon), 6)
+	assert.Equal(t, []Cell{pentagon}, Compact(children(pentagon)))
+}
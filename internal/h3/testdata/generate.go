@@ -0,0 +1,164 @@
+// Copyright 2016-2022 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//         http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program writes the reference files in this directory using the H3 C library,
+// through github.com/uber/h3-go/v4 v4.1.2 (H3 4.1.0). It lives under testdata so that
+// the build never sees it or its cgo dependency. To regenerate, copy it into a module
+// that requires h3-go and run it from a directory containing an out/ folder.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/uber/h3-go/v4"
+)
+
+func f(x float64) string { return strconv.FormatFloat(x, 'g', -1, 64) }
+
+func main() {
+	r := rand.New(rand.NewSource(20241018))
+
+	cells, _ := os.Create("out/cells.csv")
+	fmt.Fprintln(cells, "# lat,lng,res,cell,center lat,center lng,parent at res/2")
+	write := func(lat, lng float64, res int) {
+		c := h3.LatLngToCell(h3.LatLng{Lat: lat, Lng: lng}, res)
+		ll := c.LatLng()
+		fmt.Fprintf(cells, "%s,%s,%d,%s,%.12f,%.12f,%s\n", f(lat), f(lng), res, c, ll.Lat, ll.Lng, c.Parent(res/2))
+	}
+
+	// Uniform over the sphere, each at every resolution.
+	for i := 0; i < 120; i++ {
+		lat := math.Asin(2*r.Float64()-1) * 180 / math.Pi
+		lng := r.Float64()*360 - 180
+		for res := 0; res <= 15; res++ {
+			write(lat, lng, res)
+		}
+	}
+
+	// Around each pentagon, where the port has the most special cases.
+	for _, p := range h3.Pentagons(0) {
+		ll := p.LatLng()
+		for i := 0; i < 8; i++ {
+			res := r.Intn(16)
+			d := 0.5 / math.Pow(2.6, float64(res))
+			write(ll.Lat+(r.Float64()*2-1)*d, ll.Lng+(r.Float64()*2-1)*d, res)
+		}
+	}
+
+	// Poles, the antimeridian and the equator.
+	for _, p := range [][2]float64{{90, 0}, {-90, 0}, {89.9999, 45}, {-89.9999, -135}, {0, 180}, {0, -180}, {12.3, 179.99999}, {-45.6, -179.99999}, {0, 0}, {1e-12, -1e-12}} {
+		for res := 0; res <= 15; res += 3 {
+			write(p[0], p[1], res)
+		}
+	}
+	cells.Close()
+
+	// Validity of well-formed-looking indexes with random base cells and digits.
+	valid, _ := os.Create("out/validity.csv")
+	fmt.Fprintln(valid, "# cell,valid")
+	for i := 0; i < 400; i++ {
+		res := r.Intn(16)
+		bc := r.Intn(124)
+		if i%7 == 0 {
+			bc = []int{4, 14, 24, 38, 49, 58, 63, 72, 83, 97, 107, 117}[r.Intn(12)]
+		}
+		h := uint64(1)<<59 | uint64(res)<<52 | uint64(bc)<<45
+		for d := 1; d <= 15; d++ {
+			digit := uint64(7)
+			if d <= res {
+				digit = uint64(r.Intn(7))
+				if r.Intn(40) == 0 {
+					digit = 7
+				}
+				if i%7 == 0 && d <= 2 && r.Intn(2) == 0 {
+					digit = 0
+				}
+			} else if r.Intn(60) == 0 {
+				digit = uint64(r.Intn(7))
+			}
+			h |= digit << (3 * (15 - d))
+		}
+		fmt.Fprintf(valid, "%x,%t\n", h, h3.Cell(h).IsValid())
+	}
+	valid.Close()
+
+	type poly struct {
+		Name  string         `json:"name"`
+		Res   int            `json:"res"`
+		Loops [][][2]float64 `json:"loops"`
+		Cells []string       `json:"cells"`
+	}
+	var polys []poly
+	add := func(name string, res int, loops ...[][2]float64) {
+		gp := h3.GeoPolygon{}
+		for i, l := range loops {
+			var gl h3.GeoLoop
+			for _, p := range l {
+				gl = append(gl, h3.LatLng{Lat: p[0], Lng: p[1]})
+			}
+			if i == 0 {
+				gp.GeoLoop = gl
+			} else {
+				gp.Holes = append(gp.Holes, gl)
+			}
+		}
+		out := []string{}
+		for _, c := range h3.PolygonToCells(gp, res) {
+			out = append(out, c.String())
+		}
+		sort.Strings(out)
+		polys = append(polys, poly{Name: name, Res: res, Loops: loops, Cells: out})
+	}
+
+	sf := [][2]float64{{37.7749, -122.4194}, {37.7749, -122.4094}, {37.7819, -122.4094}, {37.7819, -122.4194}}
+	for _, res := range []int{7, 8, 9, 10} {
+		add(fmt.Sprintf("san francisco square res %d", res), res, sf)
+	}
+	add("square with hole", 10, sf, [][2]float64{{37.7769, -122.4174}, {37.7769, -122.4114}, {37.7799, -122.4114}, {37.7799, -122.4174}})
+	add("thin sliver", 10, [][2]float64{{40.7, -74.02}, {40.7005, -74.02}, {40.75, -73.95}, {40.7495, -73.95}})
+	add("concave", 9, [][2]float64{{51.5, -0.2}, {51.5, 0.0}, {51.52, 0.0}, {51.51, -0.1}, {51.52, -0.2}})
+	add("southern hemisphere", 8, [][2]float64{{-33.95, 151.1}, {-33.85, 151.1}, {-33.8, 151.25}, {-33.95, 151.3}})
+	add("high latitude", 6, [][2]float64{{69.5, 18.5}, {70.2, 18.5}, {70.2, 20.5}, {69.5, 20.5}})
+	add("near pentagon 4", 5, [][2]float64{{63.5, 8.5}, {65.8, 8.5}, {65.8, 12.5}, {63.5, 12.5}})
+	add("triangle res 11", 11, [][2]float64{{48.8566, 2.3522}, {48.8600, 2.3600}, {48.8530, 2.3610}})
+	add("city res 7", 7, [][2]float64{{34.0, -118.5}, {34.0, -118.1}, {34.3, -118.1}, {34.3, -118.5}})
+	for i := 0; i < 8; i++ {
+		lat := math.Asin(2*r.Float64()*0.9-0.9) * 180 / math.Pi
+		lng := r.Float64()*340 - 170
+		res := 6 + r.Intn(5)
+		size := 4 * hexEdge(res) / 111000
+		var loop [][2]float64
+		n := 3 + r.Intn(6)
+		for k := 0; k < n; k++ {
+			a := 2 * math.Pi * float64(k) / float64(n)
+			rad := size * (0.5 + r.Float64())
+			loop = append(loop, [2]float64{lat + rad*math.Sin(a), lng + rad*math.Cos(a)/math.Cos(lat*math.Pi/180)})
+		}
+		add(fmt.Sprintf("random %d res %d", i, res), res, loop)
+	}
+
+	b, _ := json.MarshalIndent(polys, "", "\t")
+	os.WriteFile("out/polygons.json", append(b, '\n'), 0o644)
+}
+
+func hexEdge(res int) float64 {
+	return []float64{1281256, 483056, 182512, 68979, 26071, 9854, 3724, 1406, 531, 200, 75, 28, 10, 4, 1.5, 0.58}[res]
+}
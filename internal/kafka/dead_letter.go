@@ -0,0 +1,336 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	wm_kafka "github.com/ThreeDotsLabs/watermill-kafka/v3/pkg/kafka"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/rs/zerolog"
+)
+
+// Names of the consumers that send failures to a dead-letter topic. These are appended to
+// DEAD_LETTER_TOPIC_PREFIX and are what replay-dlq takes to pick a handler.
+const (
+	ConsumerContractEvents    = "contract-events"
+	ConsumerDeviceFingerprint = "device-fingerprint"
+	ConsumerTaskStatus        = "task-status"
+	ConsumerTaskCredentials   = "task-credentials"
+)
+
+// Headers added to a message when it is sent to a dead-letter topic. The original headers
+// are kept alongside these.
+const (
+	deadLetterHeaderPrefix = "dlq-"
+
+	DeadLetterHeaderConsumer  = deadLetterHeaderPrefix + "consumer"
+	DeadLetterHeaderError     = deadLetterHeaderPrefix + "error"
+	DeadLetterHeaderAttempts  = deadLetterHeaderPrefix + "attempts"
+	DeadLetterHeaderFailedAt  = deadLetterHeaderPrefix + "failed-at"
+	DeadLetterHeaderTopic     = deadLetterHeaderPrefix + "topic"
+	DeadLetterHeaderPartition = deadLetterHeaderPrefix + "partition"
+	DeadLetterHeaderOffset    = deadLetterHeaderPrefix + "offset"
+)
+
+// Message is a Kafka message as seen by a handler, independent of the client library that
+// read it.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// Handler processes a single message.
+type Handler func(ctx context.Context, msg *Message) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying won't fix, such as a payload that can't be
+// parsed. The message goes to the dead-letter topic without further attempts.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// DeadLetterTopic is the topic that a consumer's failed messages are sent to.
+func DeadLetterTopic(prefix, consumer string) string {
+	return prefix + "." + consumer
+}
+
+type DeadLetterConfig struct {
+	// Consumer is the name of the consumer, one of the Consumer constants.
+	Consumer string
+	// Topic is the topic the consumer reads from.
+	Topic string
+	// DeadLetterTopic is where messages go once they have failed MaxAttempts times.
+	DeadLetterTopic string
+	MaxAttempts     int
+	// Backoff is the wait before the second attempt. It doubles after each attempt.
+	Backoff time.Duration
+}
+
+// DeadLetterQueue retries failed messages and, when they keep failing, publishes them to a
+// dead-letter topic so that they can be replayed later. A nil queue runs the handler once
+// and returns its error, which is what the consumers did before.
+type DeadLetterQueue struct {
+	producer sarama.SyncProducer
+	config   DeadLetterConfig
+	logger   *zerolog.Logger
+}
+
+func NewDeadLetterQueue(producer sarama.SyncProducer, config DeadLetterConfig, logger *zerolog.Logger) *DeadLetterQueue {
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	return &DeadLetterQueue{producer: producer, config: config, logger: logger}
+}
+
+// Handle runs the handler on the message until it succeeds, returns a permanent error or
+// runs out of attempts. In the last two cases the message is sent to the dead-letter topic.
+// An error is returned only if the context is canceled or the message couldn't be sent.
+func (d *DeadLetterQueue) Handle(ctx context.Context, msg *Message, handler Handler) error {
+	if d == nil {
+		return handler(ctx, msg)
+	}
+
+	backoff := d.config.Backoff
+	attempts := 0
+
+	var err error
+	for {
+		attempts++
+
+		err = handler(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		var perm *permanentError
+		if errors.As(err, &perm) || attempts >= d.config.MaxAttempts {
+			break
+		}
+
+		d.logger.Warn().Err(err).Str("consumer", d.config.Consumer).Int32("partition", msg.Partition).Int64("offset", msg.Offset).Int("attempt", attempts).Msg("Message failed, retrying.")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	if err := d.send(msg, err, attempts); err != nil {
+		return fmt.Errorf("failed to send message to dead-letter topic %s: %w", d.config.DeadLetterTopic, err)
+	}
+
+	return nil
+}
+
+// HandleWatermill is Handle for consumers built on watermill, which keeps the Kafka
+// coordinates of a message in its context.
+func (d *DeadLetterQueue) HandleWatermill(msg *message.Message, handler Handler) error {
+	km := &Message{
+		Value:   msg.Payload,
+		Headers: make(map[string]string, len(msg.Metadata)),
+	}
+
+	if d != nil {
+		km.Topic = d.config.Topic
+	}
+
+	ctx := msg.Context()
+	km.Partition, _ = wm_kafka.MessagePartitionFromCtx(ctx)
+	km.Offset, _ = wm_kafka.MessagePartitionOffsetFromCtx(ctx)
+	km.Key, _ = wm_kafka.MessageKeyFromCtx(ctx)
+	km.Timestamp, _ = wm_kafka.MessageTimestampFromCtx(ctx)
+
+	for k, v := range msg.Metadata {
+		km.Headers[k] = v
+	}
+
+	return d.Handle(ctx, km, handler)
+}
+
+func (d *DeadLetterQueue) send(msg *Message, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers)+7)
+	for k, v := range msg.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+
+	for k, v := range map[string]string{
+		DeadLetterHeaderConsumer:  d.config.Consumer,
+		DeadLetterHeaderError:     cause.Error(),
+		DeadLetterHeaderAttempts:  strconv.Itoa(attempts),
+		DeadLetterHeaderFailedAt:  time.Now().UTC().Format(time.RFC3339Nano),
+		DeadLetterHeaderTopic:     msg.Topic,
+		DeadLetterHeaderPartition: strconv.FormatInt(int64(msg.Partition), 10),
+		DeadLetterHeaderOffset:    strconv.FormatInt(msg.Offset, 10),
+	} {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+
+	pm := &sarama.ProducerMessage{
+		Topic:   d.config.DeadLetterTopic,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	}
+	if msg.Key != nil {
+		pm.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	if _, _, err := d.producer.SendMessage(pm); err != nil {
+		return err
+	}
+
+	d.logger.Error().Err(cause).Str("consumer", d.config.Consumer).Str("topic", msg.Topic).Int32("partition", msg.Partition).Int64("offset", msg.Offset).Int("attempts", attempts).Msgf("Sent message to dead-letter topic %s.", d.config.DeadLetterTopic)
+
+	return nil
+}
+
+// DeadLetter is a message read back from a dead-letter topic.
+type DeadLetter struct {
+	// Message is the original message, with the dead-letter headers removed.
+	Message  *Message
+	Consumer string
+	Error    string
+	Attempts int
+	FailedAt time.Time
+}
+
+// ParseDeadLetter recovers the original message and the reason it failed from a message
+// on a dead-letter topic.
+func ParseDeadLetter(m *sarama.ConsumerMessage) *DeadLetter {
+	dl := &DeadLetter{
+		Message: &Message{
+			Key:       m.Key,
+			Value:     m.Value,
+			Headers:   make(map[string]string),
+			Timestamp: m.Timestamp,
+		},
+		FailedAt: m.Timestamp,
+	}
+
+	for _, h := range m.Headers {
+		k, v := string(h.Key), string(h.Value)
+
+		switch k {
+		case DeadLetterHeaderConsumer:
+			dl.Consumer = v
+		case DeadLetterHeaderError:
+			dl.Error = v
+		case DeadLetterHeaderAttempts:
+			dl.Attempts, _ = strconv.Atoi(v)
+		case DeadLetterHeaderFailedAt:
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				dl.FailedAt = t
+			}
+		case DeadLetterHeaderTopic:
+			dl.Message.Topic = v
+		case DeadLetterHeaderPartition:
+			p, _ := strconv.ParseInt(v, 10, 32)
+			dl.Message.Partition = int32(p)
+		case DeadLetterHeaderOffset:
+			dl.Message.Offset, _ = strconv.ParseInt(v, 10, 64)
+		default:
+			if !strings.HasPrefix(k, deadLetterHeaderPrefix) {
+				dl.Message.Headers[k] = v
+			}
+		}
+	}
+
+	return dl
+}
+
+type deadLetterGroupHandler struct {
+	dlq     *DeadLetterQueue
+	handler Handler
+	logger  *zerolog.Logger
+}
+
+func (*deadLetterGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (*deadLetterGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h *deadLetterGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case cm, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			msg := &Message{
+				Topic:     cm.Topic,
+				Partition: cm.Partition,
+				Offset:    cm.Offset,
+				Key:       cm.Key,
+				Value:     cm.Value,
+				Headers:   make(map[string]string, len(cm.Headers)),
+				Timestamp: cm.Timestamp,
+			}
+			for _, rh := range cm.Headers {
+				msg.Headers[string(rh.Key)] = string(rh.Value)
+			}
+
+			if err := h.dlq.Handle(session.Context(), msg, h.handler); err != nil {
+				h.logger.Err(err).Int32("partition", cm.Partition).Int64("offset", cm.Offset).Msg("Error processing message.")
+				if session.Context().Err() != nil {
+					// Leave the message for whoever picks up the partition.
+					return nil
+				}
+				if h.dlq != nil {
+					// The message couldn't be dead-lettered either. Ending the claim cancels the
+					// session without marking it, so it's read again once the group rejoins.
+					return err
+				}
+			}
+			session.MarkMessage(cm, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// ConsumeWithDeadLetters starts a consumer group on the topic, sending messages that the
+// handler can't process to the dead-letter queue. It returns once the group is created.
+func ConsumeWithDeadLetters(ctx context.Context, brokers []string, topic, group string, dlq *DeadLetterQueue, handler Handler, logger *zerolog.Logger) error {
+	kconf := sarama.NewConfig()
+	kconf.Version = sarama.V3_6_0_0
+
+	g, err := sarama.NewConsumerGroup(brokers, group, kconf)
+	if err != nil {
+		return err
+	}
+
+	h := &deadLetterGroupHandler{dlq: dlq, handler: handler, logger: logger}
+
+	go func() {
+		for {
+			if err := g.Consume(ctx, []string{topic}, h); err != nil {
+				logger.Err(err).Msg("Consumer group session ended with an error.")
+			}
+			if ctx.Err() != nil {
+				logger.Info().Msg("Context canceled, shutting down.")
+				if err := g.Close(); err != nil {
+					logger.Err(err).Msg("Error closing consumer group.")
+				}
+				return
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	smock "github.com/IBM/sarama/mocks"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterQueue(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	msg := &Message{
+		Topic:     "topic.contract.event",
+		Partition: 2,
+		Offset:    41,
+		Key:       []byte("key"),
+		Value:     []byte(`{"subject":"x"}`),
+		Headers:   map[string]string{"ce_type": "zone.dimo.contract.event"},
+	}
+
+	t.Run("retries until success", func(t *testing.T) {
+		prod := smock.NewSyncProducer(t, nil)
+		defer prod.Close() //nolint
+
+		dlq := NewDeadLetterQueue(prod, DeadLetterConfig{Consumer: ConsumerContractEvents, DeadLetterTopic: "dlq", MaxAttempts: 3}, &logger)
+
+		calls := 0
+		err := dlq.Handle(ctx, msg, func(context.Context, *Message) error {
+			calls++
+			if calls < 3 {
+				return errors.New("database is down")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("dead-letters after the last attempt", func(t *testing.T) {
+		prod := smock.NewSyncProducer(t, nil)
+		defer prod.Close() //nolint
+
+		var sent *sarama.ProducerMessage
+		prod.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(pm *sarama.ProducerMessage) error {
+			sent = pm
+			return nil
+		})
+
+		dlq := NewDeadLetterQueue(prod, DeadLetterConfig{Consumer: ConsumerContractEvents, DeadLetterTopic: "dlq", MaxAttempts: 2}, &logger)
+
+		calls := 0
+		err := dlq.Handle(ctx, msg, func(context.Context, *Message) error {
+			calls++
+			return errors.New("database is down")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		require.NotNil(t, sent)
+		assert.Equal(t, "dlq", sent.Topic)
+
+		cm := &sarama.ConsumerMessage{Topic: sent.Topic}
+		cm.Key, _ = sent.Key.Encode()
+		cm.Value, _ = sent.Value.Encode()
+		for i := range sent.Headers {
+			cm.Headers = append(cm.Headers, &sent.Headers[i])
+		}
+
+		dl := ParseDeadLetter(cm)
+		assert.Equal(t, ConsumerContractEvents, dl.Consumer)
+		assert.Equal(t, "database is down", dl.Error)
+		assert.Equal(t, 2, dl.Attempts)
+		assert.False(t, dl.FailedAt.IsZero())
+		assert.Equal(t, msg, dl.Message)
+	})
+
+	t.Run("permanent errors skip retries", func(t *testing.T) {
+		prod := smock.NewSyncProducer(t, nil)
+		defer prod.Close() //nolint
+		prod.ExpectSendMessageAndSucceed()
+
+		dlq := NewDeadLetterQueue(prod, DeadLetterConfig{Consumer: ConsumerTaskStatus, DeadLetterTopic: "dlq", MaxAttempts: 5}, &logger)
+
+		calls := 0
+		err := dlq.Handle(ctx, msg, func(context.Context, *Message) error {
+			calls++
+			return Permanent(errors.New("bad payload"))
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("returns an error when the dead-letter topic is unavailable", func(t *testing.T) {
+		prod := smock.NewSyncProducer(t, nil)
+		defer prod.Close() //nolint
+		prod.ExpectSendMessageAndFail(sarama.ErrLeaderNotAvailable)
+
+		dlq := NewDeadLetterQueue(prod, DeadLetterConfig{Consumer: ConsumerTaskStatus, DeadLetterTopic: "dlq", MaxAttempts: 1}, &logger)
+
+		err := dlq.Handle(ctx, msg, func(context.Context, *Message) error {
+			return errors.New("database is down")
+		})
+		assert.ErrorIs(t, err, sarama.ErrLeaderNotAvailable)
+	})
+
+	t.Run("nil queue returns the error", func(t *testing.T) {
+		var dlq *DeadLetterQueue
+		err := dlq.Handle(ctx, msg, func(context.Context, *Message) error {
+			return errors.New("database is down")
+		})
+		assert.EqualError(t, err, "database is down")
+	})
+}
+
+type fakeGroupSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *fakeGroupSession) Context() context.Context { return s.ctx }
+func (s *fakeGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type fakeGroupClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeGroupClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestDeadLetterGroupHandler(t *testing.T) {
+	logger := zerolog.Nop()
+
+	prod := smock.NewSyncProducer(t, nil)
+	defer prod.Close() //nolint
+	prod.ExpectSendMessageAndSucceed()
+	prod.ExpectSendMessageAndFail(sarama.ErrLeaderNotAvailable)
+
+	dlq := NewDeadLetterQueue(prod, DeadLetterConfig{Consumer: ConsumerContractEvents, DeadLetterTopic: "dlq", MaxAttempts: 1}, &logger)
+
+	claim := &fakeGroupClaim{messages: make(chan *sarama.ConsumerMessage, 4)}
+	for i := int64(0); i < 4; i++ {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "topic.contract.event", Offset: i}
+	}
+	close(claim.messages)
+
+	session := &fakeGroupSession{ctx: context.Background()}
+
+	h := &deadLetterGroupHandler{dlq: dlq, logger: &logger, handler: func(_ context.Context, msg *Message) error {
+		if msg.Offset == 0 {
+			return nil
+		}
+		return errors.New("database is down")
+	}}
+
+	err := h.ConsumeClaim(session, claim)
+	assert.ErrorIs(t, err, sarama.ErrLeaderNotAvailable)
+
+	// The second message was dead-lettered, the third couldn't be and the fourth was never read.
+	assert.Equal(t, []int64{0, 1}, session.marked)
+}
@@ -0,0 +1,94 @@
+// Package idempotency provides a Fiber middleware that honors the
+// Idempotency-Key request header.
+package idempotency
+
+import (
+	"errors"
+
+	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	idem "github.com/DIMO-Network/devices-api/internal/services/idempotency"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// KeyHeader is the request header carrying the client's key.
+	KeyHeader = "Idempotency-Key"
+	// ReplayedHeader is set on responses that were served from a stored result.
+	ReplayedHeader = "Idempotent-Replayed"
+
+	keyLocal     = "idempotencyKey"
+	maxKeyLength = 255
+)
+
+// New creates a middleware that runs each request with a given Idempotency-Key
+// at most once. The first successful response is stored and replayed for any
+// retry with the same key, route and caller within the store's window. Failed
+// requests are not stored, so they may be retried with the same key.
+//
+// Must run after authentication, since keys are scoped to the token subject.
+func New(store *idem.Store, logger *zerolog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(KeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		if len(key) > maxKeyLength {
+			return fiber.NewError(fiber.StatusBadRequest, "Idempotency-Key header is too long.")
+		}
+
+		scope := helpers.GetUserID(c) + " " + c.Method() + " " + c.Path()
+
+		rec, err := store.Claim(c.Context(), scope, key, idem.Hash(c.Body()))
+		if err != nil {
+			switch {
+			case errors.Is(err, idem.ErrInProgress):
+				return fiber.NewError(fiber.StatusConflict, "A request with this Idempotency-Key is still being processed.")
+			case errors.Is(err, idem.ErrKeyReused):
+				return fiber.NewError(fiber.StatusUnprocessableEntity, "This Idempotency-Key was already used with a different request body.")
+			default:
+				return err
+			}
+		}
+
+		if rec != nil {
+			c.Set(ReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(rec.StatusCode.Int).Send(rec.ResponseBody.Bytes)
+		}
+
+		c.Locals(keyLocal, scope+" "+key)
+
+		if err := c.Next(); err != nil {
+			if rerr := store.Release(c.Context(), scope, key); rerr != nil {
+				logger.Err(rerr).Str("scope", scope).Msg("Failed to release idempotency key.")
+			}
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status < 200 || status >= 300 {
+			if err := store.Release(c.Context(), scope, key); err != nil {
+				logger.Err(err).Str("scope", scope).Msg("Failed to release idempotency key.")
+			}
+			return nil
+		}
+
+		if err := store.Complete(c.Context(), scope, key, status, c.Response().Body()); err != nil {
+			// The request went through, so don't fail it. A retry will see the key
+			// as in progress until it expires.
+			logger.Err(err).Str("scope", scope).Msg("Failed to store idempotent response.")
+		}
+
+		return nil
+	}
+}
+
+// Key returns the idempotency key of the current request, qualified by the
+// caller and route so that it is unique across the service. It is empty if the
+// request has no key.
+func Key(c *fiber.Ctx) string {
+	key, _ := c.Locals(keyLocal).(string)
+	return key
+}
@@ -0,0 +1,78 @@
+package idempotency
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	idem "github.com/DIMO-Network/devices-api/internal/services/idempotency"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, "../../../migrations")
+	logger := test.Logger()
+
+	store := idem.NewStore(pdb.DBS, time.Hour)
+
+	calls := 0
+	fail := false
+
+	app := test.SetupAppFiber(*logger)
+	app.Post("/commands", test.AuthInjectorTestHandler("louxUser", nil), New(store, logger), func(c *fiber.Ctx) error {
+		calls++
+		if fail {
+			return fiber.NewError(fiber.StatusBadGateway, "Vendor unavailable.")
+		}
+		return c.JSON(fiber.Map{"call": calls, "key": Key(c)})
+	})
+
+	send := func(key, body string) (int, string, string) {
+		req := test.BuildRequest("POST", "/commands", body)
+		if key != "" {
+			req.Header.Set(KeyHeader, key)
+		}
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(b), res.Header.Get(ReplayedHeader)
+	}
+
+	code, first, replayed := send("key-1", `{"a": 1}`)
+	assert.Equal(t, 200, code)
+	assert.Empty(t, replayed)
+	assert.Contains(t, first, `"key":"louxUser POST /commands key-1"`)
+
+	code, again, replayed := send("key-1", `{"a": 1}`)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, "true", replayed)
+	assert.Equal(t, first, again)
+	assert.Equal(t, 1, calls)
+
+	code, _, _ = send("key-1", `{"a": 2}`)
+	assert.Equal(t, 422, code)
+	assert.Equal(t, 1, calls)
+
+	code, _, _ = send("", `{"a": 1}`)
+	assert.Equal(t, 200, code)
+	assert.Equal(t, 2, calls)
+
+	// Failures aren't stored, so the same key can be retried.
+	fail = true
+	code, _, _ = send("key-2", `{}`)
+	assert.Equal(t, 502, code)
+
+	fail = false
+	code, _, replayed = send("key-2", `{}`)
+	assert.Equal(t, 200, code)
+	assert.Empty(t, replayed)
+	assert.Equal(t, 4, calls)
+
+	require.NoError(t, container.Terminate(ctx))
+}
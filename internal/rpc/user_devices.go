@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	mtpgrpc "github.com/DIMO-Network/meta-transaction-processor/pkg/grpc"
 	"github.com/ethereum/go-ethereum/common"
@@ -309,11 +310,26 @@ func (s *userDeviceRPCServer) UpdateDeviceIntegrationStatus(ctx context.Context,
 	}
 
 	if req.Status != apiIntegration.Status {
+		tx, err := s.dbs().Writer.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Internal error.")
+		}
+		defer tx.Rollback() //nolint
+
+		fromStatus := apiIntegration.Status
 		apiIntegration.Status = req.Status
-		if _, err := apiIntegration.Update(ctx, s.dbs().Writer, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status)); err != nil {
+		if _, err := apiIntegration.Update(ctx, tx, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status)); err != nil {
 			logger.Info().Msgf("Failed to update integration status to %s.", req.Status)
 			return nil, status.Error(codes.Internal, "failed to update API integration")
 		}
+		if err := services.RecordIntegrationStatusChange(ctx, tx, apiIntegration, fromStatus, services.IntegrationStatusSourceRPC, ""); err != nil {
+			logger.Err(err).Msg("Failed to record integration status change.")
+			return nil, status.Error(codes.Internal, "failed to update API integration")
+		}
+		if err := tx.Commit(); err != nil {
+			logger.Err(err).Msgf("Failed to update integration status to %s.", req.Status)
+			return nil, status.Error(codes.Internal, "failed to update API integration")
+		}
 		logger.Info().Msgf("Updated integration status to %s.", req.Status)
 	}
 
@@ -736,11 +752,25 @@ func (s *userDeviceRPCServer) StopUserDeviceIntegration(ctx context.Context, req
 		return nil, fmt.Errorf("integration authentication status is already %s", models.UserDeviceAPIIntegrationStatusAuthenticationFailure)
 	}
 
+	tx, err := s.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	fromStatus := apiInt.Status
 	apiInt.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
-	if _, err := apiInt.Update(ctx, s.dbs().Writer, boil.Infer()); err != nil {
+	if _, err := apiInt.Update(ctx, tx, boil.Infer()); err != nil {
 		log.Err(err).Msgf("failed to update integration table; task id: %s", apiInt.TaskID.String)
 		return nil, fmt.Errorf("failed to update integration table; task id: %s; %w", apiInt.TaskID.String, err)
 	}
+	if err := services.RecordIntegrationStatusChange(ctx, tx, apiInt, fromStatus, services.IntegrationStatusSourceRPC, "Polling stopped."); err != nil {
+		log.Err(err).Msg("failed to record integration status change")
+		return nil, fmt.Errorf("failed to record integration status change: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update integration table; task id: %s; %w", apiInt.TaskID.String, err)
+	}
 
 	log.Info().Msg("integration polling stopped")
 	return &emptypb.Empty{}, nil
@@ -819,3 +849,160 @@ func (s *userDeviceRPCServer) DeleteUnMintedUserDevice(ctx context.Context, req
 	log.Info().Msg("deleted unminted user device")
 	return &emptypb.Empty{}, nil
 }
+
+// GetIntegrationHealthSummary counts integrations by integration and current
+// status, along with how many times integrations moved into each status since
+// the requested time.
+func (s *userDeviceRPCServer) GetIntegrationHealthSummary(ctx context.Context, req *pb.GetIntegrationHealthSummaryRequest) (*pb.GetIntegrationHealthSummaryResponse, error) {
+	since := time.Now().Add(-24 * time.Hour)
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+
+	var current []struct {
+		IntegrationID string `boil:"integration_id"`
+		Status        string `boil:"status"`
+		Count         int64  `boil:"count"`
+	}
+
+	err := models.UserDeviceAPIIntegrations(
+		qm.Select(models.UserDeviceAPIIntegrationColumns.IntegrationID, models.UserDeviceAPIIntegrationColumns.Status, "count(*) AS count"),
+		qm.GroupBy(models.UserDeviceAPIIntegrationColumns.IntegrationID+", "+models.UserDeviceAPIIntegrationColumns.Status),
+	).Bind(ctx, s.dbs().Reader, &current)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to count integrations by status.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	var moves []struct {
+		IntegrationID string `boil:"integration_id"`
+		ToStatus      string `boil:"to_status"`
+		Count         int64  `boil:"count"`
+	}
+
+	err = models.UserDeviceAPIIntegrationStatusHistories(
+		qm.Select(models.UserDeviceAPIIntegrationStatusHistoryColumns.IntegrationID, models.UserDeviceAPIIntegrationStatusHistoryColumns.ToStatus, "count(*) AS count"),
+		models.UserDeviceAPIIntegrationStatusHistoryWhere.ToStatus.IsNotNull(),
+		models.UserDeviceAPIIntegrationStatusHistoryWhere.CreatedAt.GTE(since),
+		qm.GroupBy(models.UserDeviceAPIIntegrationStatusHistoryColumns.IntegrationID+", "+models.UserDeviceAPIIntegrationStatusHistoryColumns.ToStatus),
+	).Bind(ctx, s.dbs().Reader, &moves)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to count integration status transitions.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	type key struct{ integrationID, status string }
+	summaries := make(map[key]*pb.IntegrationStatusSummary)
+	out := &pb.GetIntegrationHealthSummaryResponse{}
+
+	get := func(integrationID, status string) *pb.IntegrationStatusSummary {
+		k := key{integrationID, status}
+		if sum, ok := summaries[k]; ok {
+			return sum
+		}
+		sum := &pb.IntegrationStatusSummary{IntegrationId: integrationID, Status: status}
+		summaries[k] = sum
+		out.Statuses = append(out.Statuses, sum)
+		return sum
+	}
+
+	for _, c := range current {
+		get(c.IntegrationID, c.Status).Count = c.Count
+	}
+	for _, m := range moves {
+		get(m.IntegrationID, m.ToStatus).Transitions = m.Count
+	}
+
+	return out, nil
+}
+
+func vehiclePrivilegeToPB(p *models.NFTPrivilege) *pb.VehiclePrivilege {
+	return &pb.VehiclePrivilege{
+		TokenId:     p.TokenID.Int(nil).Int64(),
+		UserAddress: p.UserAddress,
+		PrivilegeId: p.Privilege,
+		ExpiresAt:   timestamppb.New(p.Expiry),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+	}
+}
+
+// ListPrivilegesForVehicle lists the unexpired privileges granted on the vehicle,
+// ordered by grantee and privilege id.
+func (s *userDeviceRPCServer) ListPrivilegesForVehicle(ctx context.Context, req *pb.ListPrivilegesForVehicleRequest) (*pb.ListPrivilegesForVehicleResponse, error) {
+	privs, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.ContractAddress.EQ(common.HexToAddress(s.settings.VehicleNFTAddress).Bytes()),
+		models.NFTPrivilegeWhere.TokenID.EQ(types.NewDecimal(decimal.New(req.TokenId, 0))),
+		models.NFTPrivilegeWhere.Expiry.GT(time.Now()),
+		qm.OrderBy(models.NFTPrivilegeColumns.UserAddress+", "+models.NFTPrivilegeColumns.Privilege),
+	).All(ctx, s.dbs().Reader)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list vehicle privileges.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	out := &pb.ListPrivilegesForVehicleResponse{
+		Privileges: make([]*pb.VehiclePrivilege, len(privs)),
+	}
+
+	for i, p := range privs {
+		out.Privileges[i] = vehiclePrivilegeToPB(p)
+	}
+
+	return out, nil
+}
+
+// ListVehiclesSharedWithAddress lists the vehicles on which the address holds
+// unexpired privileges, ordered by token id.
+func (s *userDeviceRPCServer) ListVehiclesSharedWithAddress(ctx context.Context, req *pb.ListVehiclesSharedWithAddressRequest) (*pb.ListVehiclesSharedWithAddressResponse, error) {
+	if len(req.UserAddress) != common.AddressLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Address must be %d bytes long.", common.AddressLength)
+	}
+
+	privs, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.ContractAddress.EQ(common.HexToAddress(s.settings.VehicleNFTAddress).Bytes()),
+		models.NFTPrivilegeWhere.UserAddress.EQ(req.UserAddress),
+		models.NFTPrivilegeWhere.Expiry.GT(time.Now()),
+		qm.OrderBy(models.NFTPrivilegeColumns.TokenID+", "+models.NFTPrivilegeColumns.Privilege),
+	).All(ctx, s.dbs().Reader)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to list privileges for address.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	out := &pb.ListVehiclesSharedWithAddressResponse{}
+	if len(privs) == 0 {
+		return out, nil
+	}
+
+	vehicles := make(map[int64]*pb.SharedVehicle)
+	var tokenIDs []any
+
+	for _, p := range privs {
+		pp := vehiclePrivilegeToPB(p)
+		v, ok := vehicles[pp.TokenId]
+		if !ok {
+			v = &pb.SharedVehicle{TokenId: pp.TokenId}
+			vehicles[pp.TokenId] = v
+			out.Vehicles = append(out.Vehicles, v)
+			tokenIDs = append(tokenIDs, p.TokenID)
+		}
+		v.Privileges = append(v.Privileges, pp)
+	}
+
+	uds, err := models.UserDevices(
+		qm.WhereIn(models.UserDeviceColumns.TokenID+" IN ?", tokenIDs...),
+	).All(ctx, s.dbs().Reader)
+	if err != nil {
+		s.logger.Err(err).Msg("Failed to look up shared vehicles.")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	for _, ud := range uds {
+		if v, ok := vehicles[ud.TokenID.Int(nil).Int64()]; ok {
+			v.UserDeviceId = ud.ID
+			v.OwnerAddress = ud.OwnerAddress.Bytes
+		}
+	}
+
+	return out, nil
+}
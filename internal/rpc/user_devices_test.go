@@ -18,7 +18,11 @@ import (
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/test"
@@ -243,3 +247,112 @@ func TestGetUserDevice_NoSyntheticDeviceFields_WhenNoTokenID(t *testing.T) {
 
 	assert.Nil(udResult.SyntheticDevice)
 }
+
+func TestGetIntegrationHealthSummary(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	integrationID := ksuid.New().String()
+
+	for i, status := range []string{models.UserDeviceAPIIntegrationStatusActive, models.UserDeviceAPIIntegrationStatusActive, models.UserDeviceAPIIntegrationStatusAuthenticationFailure} {
+		ud := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+		udai := test.SetupCreateUserDeviceAPIIntegration(t, "", ksuid.New().String(), ud.ID, integrationID, pdb)
+		assert.NoError(services.RecordIntegrationStatusChange(ctx, pdb.DBS().Writer, &udai, "", services.IntegrationStatusSourceRegistration, ""))
+
+		if status != udai.Status {
+			from := udai.Status
+			udai.Status = status
+			_, err := udai.Update(ctx, pdb.DBS().Writer, boil.Whitelist(models.UserDeviceAPIIntegrationColumns.Status))
+			assert.NoError(err)
+			assert.NoError(services.RecordIntegrationStatusChange(ctx, pdb.DBS().Writer, &udai, from, services.IntegrationStatusSourceTask, ""), "device %d", i)
+		}
+	}
+
+	udService := NewUserDeviceRPCService(pdb.DBS, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	resp, err := udService.GetIntegrationHealthSummary(ctx, &pb_devices.GetIntegrationHealthSummaryRequest{})
+	assert.NoError(err)
+
+	got := make(map[string]*pb_devices.IntegrationStatusSummary)
+	for _, s := range resp.Statuses {
+		assert.Equal(integrationID, s.IntegrationId)
+		got[s.Status] = s
+	}
+
+	if assert.Contains(got, models.UserDeviceAPIIntegrationStatusActive) {
+		assert.EqualValues(2, got[models.UserDeviceAPIIntegrationStatusActive].Count)
+		assert.EqualValues(3, got[models.UserDeviceAPIIntegrationStatusActive].Transitions)
+	}
+	if assert.Contains(got, models.UserDeviceAPIIntegrationStatusAuthenticationFailure) {
+		assert.EqualValues(1, got[models.UserDeviceAPIIntegrationStatusAuthenticationFailure].Count)
+		assert.EqualValues(1, got[models.UserDeviceAPIIntegrationStatusAuthenticationFailure].Transitions)
+	}
+
+	resp, err = udService.GetIntegrationHealthSummary(ctx, &pb_devices.GetIntegrationHealthSummaryRequest{Since: timestamppb.New(time.Now().Add(time.Hour))})
+	assert.NoError(err)
+	for _, s := range resp.Statuses {
+		assert.Zero(s.Transitions)
+	}
+}
+
+func TestListVehiclePrivileges(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	settings := &config.Settings{VehicleNFTAddress: "0x881d40237659c251811cec9c364ef91dc08d300c"}
+	vehicleAddr := common.HexToAddress(settings.VehicleNFTAddress)
+	owner := common.BigToAddress(big.NewInt(7))
+	grantee := common.BigToAddress(big.NewInt(8))
+
+	ud := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	_ = test.SetupCreateVehicleNFT(t, ud, big.NewInt(5), null.BytesFrom(owner.Bytes()), pdb)
+
+	for _, p := range []models.NFTPrivilege{
+		{TokenID: types.NewDecimal(decimal.New(5, 0)), Privilege: 1, Expiry: time.Now().Add(time.Hour)},
+		{TokenID: types.NewDecimal(decimal.New(5, 0)), Privilege: 4, Expiry: time.Now().Add(time.Hour)},
+		{TokenID: types.NewDecimal(decimal.New(5, 0)), Privilege: 2, Expiry: time.Now().Add(-time.Hour)},
+		// No record of this vehicle.
+		{TokenID: types.NewDecimal(decimal.New(6, 0)), Privilege: 1, Expiry: time.Now().Add(time.Hour)},
+	} {
+		p.ContractAddress = vehicleAddr.Bytes()
+		p.UserAddress = grantee.Bytes()
+		assert.NoError(p.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+	}
+
+	udService := NewUserDeviceRPCService(pdb.DBS, settings, nil, nil, nil, nil, nil, nil, nil)
+
+	privs, err := udService.ListPrivilegesForVehicle(ctx, &pb_devices.ListPrivilegesForVehicleRequest{TokenId: 5})
+	assert.NoError(err)
+	if assert.Len(privs.Privileges, 2) {
+		assert.EqualValues(1, privs.Privileges[0].PrivilegeId)
+		assert.EqualValues(4, privs.Privileges[1].PrivilegeId)
+		assert.Equal(grantee.Bytes(), privs.Privileges[0].UserAddress)
+	}
+
+	shared, err := udService.ListVehiclesSharedWithAddress(ctx, &pb_devices.ListVehiclesSharedWithAddressRequest{UserAddress: grantee.Bytes()})
+	assert.NoError(err)
+	if assert.Len(shared.Vehicles, 2) {
+		assert.EqualValues(5, shared.Vehicles[0].TokenId)
+		assert.Equal(ud.ID, shared.Vehicles[0].UserDeviceId)
+		assert.Equal(owner.Bytes(), shared.Vehicles[0].OwnerAddress)
+		assert.Len(shared.Vehicles[0].Privileges, 2)
+
+		assert.EqualValues(6, shared.Vehicles[1].TokenId)
+		assert.Empty(shared.Vehicles[1].UserDeviceId)
+	}
+
+	_, err = udService.ListVehiclesSharedWithAddress(ctx, &pb_devices.ListVehiclesSharedWithAddressRequest{UserAddress: []byte{1}})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
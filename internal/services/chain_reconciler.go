@@ -0,0 +1,497 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/shared/dbtypes"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// Entities compared by the ChainReconciler. These are also the values of the entity
+// column in the audit table.
+const (
+	ChainEntityVehicle           = "vehicle"
+	ChainEntityAftermarketDevice = "aftermarket_device"
+	ChainEntitySyntheticDevice   = "synthetic_device"
+)
+
+// chainReconcilePageSize is the number of rows read from the database at a time.
+const chainReconcilePageSize = 500
+
+// ChainReader reads the on-chain state that we mirror. Owners are the zero address
+// for tokens that don't exist, and linked token ids are zero when there is no link.
+type ChainReader interface {
+	VehicleOwner(ctx context.Context, vehicleNode *big.Int) (common.Address, error)
+	// AftermarketDeviceOwner returns the zero address for devices that have not been
+	// claimed, even though the manufacturer technically owns them.
+	AftermarketDeviceOwner(ctx context.Context, adNode *big.Int) (common.Address, error)
+	AftermarketDeviceVehicle(ctx context.Context, adNode *big.Int) (*big.Int, error)
+	SyntheticDeviceVehicle(ctx context.Context, sdNode *big.Int) (*big.Int, error)
+}
+
+// RegistryChainReader is a ChainReader backed by the generated contract bindings. Any
+// bind.ContractCaller works, including a simulated backend or a local node.
+type RegistryChainReader struct {
+	registry      *contracts.RegistryCaller
+	vehicles      *contracts.MultiPrivilegeCaller
+	aftermarket   *contracts.AftermarketDeviceIdCaller
+	vehicleAddr   common.Address
+	aftermarketID common.Address
+	syntheticAddr common.Address
+}
+
+// NewRegistryChainReader creates a reader for the given registry and NFT contracts.
+func NewRegistryChainReader(caller bind.ContractCaller, registryAddr, vehicleAddr, aftermarketAddr, syntheticAddr common.Address) (*RegistryChainReader, error) {
+	registry, err := contracts.NewRegistryCaller(registryAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := contracts.NewMultiPrivilegeCaller(vehicleAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	aftermarket, err := contracts.NewAftermarketDeviceIdCaller(aftermarketAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistryChainReader{
+		registry:      registry,
+		vehicles:      vehicles,
+		aftermarket:   aftermarket,
+		vehicleAddr:   vehicleAddr,
+		aftermarketID: aftermarketAddr,
+		syntheticAddr: syntheticAddr,
+	}, nil
+}
+
+func (r *RegistryChainReader) VehicleOwner(ctx context.Context, vehicleNode *big.Int) (common.Address, error) {
+	owner, err := r.vehicles.OwnerOf(&bind.CallOpts{Context: ctx}, vehicleNode)
+	if isRevert(err) {
+		// ownerOf reverts for burned or never-minted tokens.
+		return common.Address{}, nil
+	}
+	return owner, err
+}
+
+func (r *RegistryChainReader) AftermarketDeviceOwner(ctx context.Context, adNode *big.Int) (common.Address, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	claimed, err := r.registry.IsAftermarketDeviceClaimed(opts, adNode)
+	if err != nil || !claimed {
+		return common.Address{}, err
+	}
+
+	owner, err := r.aftermarket.OwnerOf(opts, adNode)
+	if isRevert(err) {
+		return common.Address{}, nil
+	}
+	return owner, err
+}
+
+func (r *RegistryChainReader) AftermarketDeviceVehicle(ctx context.Context, adNode *big.Int) (*big.Int, error) {
+	return r.registry.GetLink(&bind.CallOpts{Context: ctx}, r.aftermarketID, adNode)
+}
+
+func (r *RegistryChainReader) SyntheticDeviceVehicle(ctx context.Context, sdNode *big.Int) (*big.Int, error) {
+	return r.registry.GetNodeLink(&bind.CallOpts{Context: ctx}, r.syntheticAddr, r.vehicleAddr, sdNode)
+}
+
+// isRevert distinguishes a call that the contract rejected from a failure to reach it or
+// any other error the node returns. Reverts with a reason carry it as error data; bare
+// ones only have the message.
+func isRevert(err error) bool {
+	var de rpc.DataError
+	if !errors.As(err, &de) {
+		return false
+	}
+	return de.ErrorData() != nil || strings.Contains(de.Error(), "execution reverted")
+}
+
+// ChainDiscrepancy is a field that differs between the database and the chain.
+type ChainDiscrepancy struct {
+	Entity  string   `json:"entity"`
+	TokenID *big.Int `json:"tokenId"`
+	Field   string   `json:"field"`
+	DB      string   `json:"db"`
+	Chain   string   `json:"chain"`
+	// Repairable is false when the database can't be brought in line: the chain links a
+	// device to a vehicle that we don't have, or a synthetic device has been burned.
+	// Vehicle transfers and burns and aftermarket device pairings are repaired with the
+	// contract events consumer's handlers, so they carry the same side effects.
+	Repairable bool `json:"repairable"`
+	// Repaired is set once the database has been updated to match the chain.
+	Repaired bool `json:"repaired"`
+}
+
+// ChainReconciler compares the token ids, owners and pairings in the database with the
+// chain and, if asked to, overwrites the database with what it finds there.
+type ChainReconciler struct {
+	dbs    func() *db.ReaderWriter
+	log    *zerolog.Logger
+	chain  ChainReader
+	events *ContractsEventsConsumer
+}
+
+// NewChainReconciler creates a reconciler reading from the given chain. Repairs that
+// need more than a column update go through the handlers of events.
+func NewChainReconciler(dbs func() *db.ReaderWriter, log *zerolog.Logger, chain ChainReader, events *ContractsEventsConsumer) *ChainReconciler {
+	return &ChainReconciler{dbs: dbs, log: log, chain: chain, events: events}
+}
+
+// Reconcile checks every minted vehicle, aftermarket device and synthetic device. With
+// repair set, each repairable discrepancy is fixed in its own transaction along with a
+// row in chain_reconciliation_changes. The returned run id ties those rows together.
+func (r *ChainReconciler) Reconcile(ctx context.Context, entities []string, repair bool) (string, []*ChainDiscrepancy, error) {
+	runID := ksuid.New().String()
+
+	var out []*ChainDiscrepancy
+
+	for _, entity := range entities {
+		var ds []*ChainDiscrepancy
+		var err error
+
+		switch entity {
+		case ChainEntityVehicle:
+			ds, err = r.vehicles(ctx, runID, repair)
+		case ChainEntityAftermarketDevice:
+			ds, err = r.aftermarketDevices(ctx, runID, repair)
+		case ChainEntitySyntheticDevice:
+			ds, err = r.syntheticDevices(ctx, runID, repair)
+		default:
+			err = fmt.Errorf("unrecognized entity %q", entity)
+		}
+
+		out = append(out, ds...)
+		if err != nil {
+			return runID, out, err
+		}
+	}
+
+	return runID, out, nil
+}
+
+func (r *ChainReconciler) vehicles(ctx context.Context, runID string, repair bool) ([]*ChainDiscrepancy, error) {
+	var out []*ChainDiscrepancy
+	after := types.NewNullDecimal(nil)
+
+	for {
+		mods := []qm.QueryMod{
+			models.UserDeviceWhere.TokenID.IsNotNull(),
+			qm.OrderBy(models.UserDeviceColumns.TokenID),
+			qm.Limit(chainReconcilePageSize),
+		}
+		if !after.IsZero() {
+			mods = append(mods, models.UserDeviceWhere.TokenID.GT(after))
+		}
+
+		uds, err := models.UserDevices(mods...).All(ctx, r.dbs().Reader)
+		if err != nil {
+			return out, err
+		}
+
+		for _, ud := range uds {
+			tokenID := ud.TokenID.Int(nil)
+
+			owner, err := r.chain.VehicleOwner(ctx, tokenID)
+			if err != nil {
+				return out, fmt.Errorf("failed to read owner of vehicle %d: %w", tokenID, err)
+			}
+
+			dbOwner := common.BytesToAddress(ud.OwnerAddress.Bytes)
+			if owner == dbOwner {
+				continue
+			}
+
+			d := &ChainDiscrepancy{
+				Entity:     ChainEntityVehicle,
+				TokenID:    tokenID,
+				Field:      models.UserDeviceColumns.OwnerAddress,
+				DB:         addrString(ud.OwnerAddress),
+				Chain:      addrString(nullAddr(owner)),
+				Repairable: true,
+			}
+			out = append(out, d)
+
+			if repair {
+				// Applied as the missed Transfer would have been, clearing privileges,
+				// geofences and integrations along with the owner.
+				if err := r.apply(ctx, runID, d, func(tx *EventTx) error {
+					if IsZeroAddress(owner) {
+						return r.events.burnVehicle(ctx, tx, tokenID, dbOwner)
+					}
+					return r.events.transferVehicle(ctx, tx, tokenID, dbOwner, owner, common.Hash{})
+				}); err != nil {
+					return out, err
+				}
+			}
+		}
+
+		if len(uds) < chainReconcilePageSize {
+			return out, nil
+		}
+		after = uds[len(uds)-1].TokenID
+	}
+}
+
+func (r *ChainReconciler) aftermarketDevices(ctx context.Context, runID string, repair bool) ([]*ChainDiscrepancy, error) {
+	var out []*ChainDiscrepancy
+	var after *big.Int
+
+	for {
+		mods := []qm.QueryMod{
+			qm.OrderBy(models.AftermarketDeviceColumns.TokenID),
+			qm.Limit(chainReconcilePageSize),
+		}
+		if after != nil {
+			mods = append(mods, models.AftermarketDeviceWhere.TokenID.GT(dbtypes.IntToDecimal(after)))
+		}
+
+		ads, err := models.AftermarketDevices(mods...).All(ctx, r.dbs().Reader)
+		if err != nil {
+			return out, err
+		}
+
+		for _, ad := range ads {
+			tokenID := ad.TokenID.Int(nil)
+			cols := models.AftermarketDeviceColumns
+
+			owner, err := r.chain.AftermarketDeviceOwner(ctx, tokenID)
+			if err != nil {
+				return out, fmt.Errorf("failed to read owner of aftermarket device %d: %w", tokenID, err)
+			}
+
+			if dbOwner := common.BytesToAddress(ad.OwnerAddress.Bytes); owner != dbOwner {
+				d := &ChainDiscrepancy{
+					Entity:     ChainEntityAftermarketDevice,
+					TokenID:    tokenID,
+					Field:      cols.OwnerAddress,
+					DB:         addrString(ad.OwnerAddress),
+					Chain:      addrString(nullAddr(owner)),
+					Repairable: true,
+				}
+				out = append(out, d)
+
+				if repair {
+					// As with a transfer, the web2 owner and beneficiary belonged to the
+					// previous owner.
+					ad.OwnerAddress = nullAddr(owner)
+					ad.UserID = null.String{}
+					ad.Beneficiary = null.Bytes{}
+
+					if err := r.apply(ctx, runID, d, func(tx *EventTx) error {
+						_, err := ad.Update(ctx, tx, boil.Whitelist(cols.OwnerAddress, cols.UserID, cols.Beneficiary, cols.UpdatedAt))
+						return err
+					}); err != nil {
+						return out, err
+					}
+				}
+			}
+
+			vehicleNode, err := r.chain.AftermarketDeviceVehicle(ctx, tokenID)
+			if err != nil {
+				return out, fmt.Errorf("failed to read pairing of aftermarket device %d: %w", tokenID, err)
+			}
+
+			dbVehicle := bigOrZero(ad.VehicleTokenID.Int(nil))
+			if dbVehicle.Cmp(vehicleNode) == 0 {
+				continue
+			}
+
+			d := &ChainDiscrepancy{
+				Entity:     ChainEntityAftermarketDevice,
+				TokenID:    tokenID,
+				Field:      cols.VehicleTokenID,
+				DB:         nodeString(dbVehicle),
+				Chain:      nodeString(vehicleNode),
+				Repairable: true,
+			}
+
+			if vehicleNode.Sign() != 0 {
+				exists, err := models.UserDevices(models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(vehicleNode))).Exists(ctx, r.dbs().Reader)
+				if err != nil {
+					return out, err
+				}
+				d.Repairable = exists
+			}
+			out = append(out, d)
+
+			if repair && d.Repairable {
+				// Pairing and unpairing also set up and tear down the device's integration.
+				if err := r.apply(ctx, runID, d, func(tx *EventTx) error {
+					if dbVehicle.Sign() != 0 {
+						if err := r.events.unpairAftermarketDevice(ctx, tx, tokenID, dbVehicle); err != nil {
+							return err
+						}
+					}
+					if vehicleNode.Sign() != 0 {
+						return r.events.pairAftermarketDevice(ctx, tx, tokenID, vehicleNode)
+					}
+					return nil
+				}); err != nil {
+					return out, err
+				}
+			}
+		}
+
+		if len(ads) < chainReconcilePageSize {
+			return out, nil
+		}
+		after = ads[len(ads)-1].TokenID.Int(nil)
+	}
+}
+
+func (r *ChainReconciler) syntheticDevices(ctx context.Context, runID string, repair bool) ([]*ChainDiscrepancy, error) {
+	var out []*ChainDiscrepancy
+	after := types.NewNullDecimal(nil)
+
+	for {
+		mods := []qm.QueryMod{
+			models.SyntheticDeviceWhere.TokenID.IsNotNull(),
+			qm.OrderBy(models.SyntheticDeviceColumns.TokenID),
+			qm.Limit(chainReconcilePageSize),
+		}
+		if !after.IsZero() {
+			mods = append(mods, models.SyntheticDeviceWhere.TokenID.GT(after))
+		}
+
+		sds, err := models.SyntheticDevices(mods...).All(ctx, r.dbs().Reader)
+		if err != nil {
+			return out, err
+		}
+
+		for _, sd := range sds {
+			tokenID := sd.TokenID.Int(nil)
+
+			vehicleNode, err := r.chain.SyntheticDeviceVehicle(ctx, tokenID)
+			if err != nil {
+				return out, fmt.Errorf("failed to read vehicle of synthetic device %d: %w", tokenID, err)
+			}
+
+			dbVehicle := sd.VehicleTokenID.Int(nil)
+			if bigOrZero(dbVehicle).Cmp(vehicleNode) == 0 {
+				continue
+			}
+
+			// An unlinked synthetic device has been burned, which takes more than an
+			// update to clean up.
+			d := &ChainDiscrepancy{
+				Entity:  ChainEntitySyntheticDevice,
+				TokenID: tokenID,
+				Field:   models.SyntheticDeviceColumns.VehicleTokenID,
+				DB:      nodeString(dbVehicle),
+				Chain:   nodeString(vehicleNode),
+			}
+
+			if vehicleNode.Sign() != 0 {
+				exists, err := models.UserDevices(models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(vehicleNode))).Exists(ctx, r.dbs().Reader)
+				if err != nil {
+					return out, err
+				}
+				d.Repairable = exists
+			}
+			out = append(out, d)
+
+			if repair && d.Repairable {
+				sd.VehicleTokenID = dbtypes.NullIntToDecimal(vehicleNode)
+
+				if err := r.apply(ctx, runID, d, func(tx *EventTx) error {
+					_, err := sd.Update(ctx, tx, boil.Whitelist(models.SyntheticDeviceColumns.VehicleTokenID))
+					return err
+				}); err != nil {
+					return out, err
+				}
+			}
+		}
+
+		if len(sds) < chainReconcilePageSize {
+			return out, nil
+		}
+		after = sds[len(sds)-1].TokenID
+	}
+}
+
+// apply runs the update and records it in the audit table, in one transaction. Messages
+// queued by the update go out once it commits.
+func (r *ChainReconciler) apply(ctx context.Context, runID string, d *ChainDiscrepancy, update func(tx *EventTx) error) error {
+	tx, err := BeginEventTx(ctx, r.dbs())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := update(tx); err != nil {
+		return fmt.Errorf("failed to repair %s %d %s: %w", d.Entity, d.TokenID, d.Field, err)
+	}
+
+	change := models.ChainReconciliationChange{
+		ID:       ksuid.New().String(),
+		RunID:    runID,
+		Entity:   d.Entity,
+		TokenID:  dbtypes.IntToDecimal(d.TokenID),
+		Field:    d.Field,
+		OldValue: null.StringFrom(d.DB),
+		NewValue: null.StringFrom(d.Chain),
+	}
+	if d.DB == "" {
+		change.OldValue = null.String{}
+	}
+
+	if err := change.Insert(ctx, tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	d.Repaired = true
+	r.log.Info().Str("entity", d.Entity).Str("tokenId", d.TokenID.String()).Str("field", d.Field).Str("old", d.DB).Str("new", d.Chain).Msg("Repaired chain discrepancy.")
+
+	return nil
+}
+
+func addrString(b null.Bytes) string {
+	if !b.Valid {
+		return ""
+	}
+	return common.BytesToAddress(b.Bytes).Hex()
+}
+
+func nullAddr(a common.Address) null.Bytes {
+	if IsZeroAddress(a) {
+		return null.Bytes{}
+	}
+	return null.BytesFrom(a.Bytes())
+}
+
+func nodeString(n *big.Int) string {
+	if n == nil || n.Sign() == 0 {
+		return ""
+	}
+	return n.String()
+}
+
+func bigOrZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"go.uber.org/mock/gomock"
+
+	smock "github.com/IBM/sarama/mocks"
+)
+
+type fakeChainReader struct {
+	vehicleOwners map[int64]common.Address
+}
+
+func (f *fakeChainReader) VehicleOwner(_ context.Context, vehicleNode *big.Int) (common.Address, error) {
+	return f.vehicleOwners[vehicleNode.Int64()], nil
+}
+
+func (f *fakeChainReader) AftermarketDeviceOwner(context.Context, *big.Int) (common.Address, error) {
+	return common.Address{}, nil
+}
+
+func (f *fakeChainReader) AftermarketDeviceVehicle(context.Context, *big.Int) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func (f *fakeChainReader) SyntheticDeviceVehicle(context.Context, *big.Int) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func TestChainReconcilerVehicleOwners(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+
+	stale := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	current := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	ud1 := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud1, big.NewInt(1), null.BytesFrom(stale.Bytes()), pdb)
+	ud2 := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud2, big.NewInt(2), null.BytesFrom(current.Bytes()), pdb)
+	ud3 := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud3, big.NewInt(3), null.BytesFrom(stale.Bytes()), pdb)
+
+	// The stale owner's privilege should go with the transfer.
+	priv := models.NFTPrivilege{
+		ContractAddress: common.HexToAddress("0x881d40237659c251811cec9c364ef91dc08d300c").Bytes(),
+		TokenID:         types.NewDecimal(decimal.New(1, 0)),
+		Privilege:       1,
+		UserAddress:     common.HexToAddress("0x8ba1f109551bd432803012645ac136ddd64dba72").Bytes(),
+		Expiry:          time.Now().Add(time.Hour),
+	}
+	require.NoError(t, priv.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	mockCtrl := gomock.NewController(t)
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+
+	settings := &config.Settings{}
+	kprod := smock.NewSyncProducer(t, nil)
+	events := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, NewEventService(&logger, settings, kprod), nil, nil, nil)
+
+	chain := &fakeChainReader{vehicleOwners: map[int64]common.Address{1: current, 2: current}}
+
+	rec := NewChainReconciler(pdb.DBS, &logger, chain, events)
+
+	_, ds, err := rec.Reconcile(ctx, []string{ChainEntityVehicle}, false)
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+	assert.True(t, ds[0].Repairable)
+	assert.False(t, ds[0].Repaired)
+
+	count, err := models.ChainReconciliationChanges().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.Zero(t, count, "a dry run should not write audit rows")
+
+	// One transfer event and one deletion event.
+	kprod.ExpectSendMessageAndSucceed()
+	kprod.ExpectSendMessageAndSucceed()
+	deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), ud3.DefinitionID).Return(&ddgrpc.GetDeviceDefinitionItemResponse{
+		Id:    ud3.DefinitionID,
+		Make:  &ddgrpc.DeviceMake{Name: "Ford"},
+		Model: "Escape",
+		Year:  2021,
+	}, nil)
+
+	runID, ds, err := rec.Reconcile(ctx, []string{ChainEntityVehicle}, true)
+	require.NoError(t, err)
+	require.Len(t, ds, 2)
+
+	assert.Equal(t, int64(1), ds[0].TokenID.Int64())
+	assert.Equal(t, current.Hex(), ds[0].Chain)
+	assert.Equal(t, int64(3), ds[1].TokenID.Int64())
+	assert.Empty(t, ds[1].Chain)
+
+	for _, d := range ds {
+		assert.True(t, d.Repairable)
+		assert.True(t, d.Repaired)
+	}
+
+	require.NoError(t, ud1.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, current, common.BytesToAddress(ud1.OwnerAddress.Bytes))
+
+	privs, err := models.NFTPrivileges().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.Zero(t, privs, "the transfer should clear privileges")
+
+	burned, err := models.UserDeviceExists(ctx, pdb.DBS().Reader, ud3.ID)
+	require.NoError(t, err)
+	assert.False(t, burned, "the burned vehicle should be removed")
+
+	changes, err := models.ChainReconciliationChanges(qm.OrderBy(models.ChainReconciliationChangeColumns.TokenID)).All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	for _, c := range changes {
+		assert.Equal(t, runID, c.RunID)
+		assert.Equal(t, ChainEntityVehicle, c.Entity)
+		assert.Equal(t, null.StringFrom(stale.Hex()), c.OldValue)
+	}
+	assert.Equal(t, null.StringFrom(current.Hex()), changes[0].NewValue)
+	assert.Equal(t, null.StringFrom(""), changes[1].NewValue)
+}
+
+// stubContractCode answers every call with the word stored under the hash of its calldata
+// and reverts, with no reason, when there is none. The top bit of a stored word only
+// marks it as set and is cleared before returning, so that zero can be an answer too.
+const stubContractCode = "0x36600060003736600020548015601f57600160ff1b191660005260206000f35b600080fd"
+
+// stubContract lines up the answers of a stubContractCode account to the calls of a
+// generated binding.
+type stubContract struct {
+	t       *testing.T
+	abi     *abi.ABI
+	storage map[common.Hash]common.Hash
+}
+
+func newStubContract(t *testing.T, md *bind.MetaData) *stubContract {
+	a, err := md.GetAbi()
+	require.NoError(t, err)
+	return &stubContract{t: t, abi: a, storage: make(map[common.Hash]common.Hash)}
+}
+
+func (s *stubContract) returns(out common.Hash, method string, args ...any) {
+	in, err := s.abi.Pack(method, args...)
+	require.NoError(s.t, err)
+	out[0] |= 0x80
+	s.storage[crypto.Keccak256Hash(in)] = out
+}
+
+func (s *stubContract) account() ethtypes.Account {
+	return ethtypes.Account{Code: common.FromHex(stubContractCode), Storage: s.storage, Balance: new(big.Int)}
+}
+
+// chainFixture is a simulated chain with stub registry, vehicle and aftermarket device
+// contracts.
+type chainFixture struct {
+	backend *simulated.Backend
+	reader  *RegistryChainReader
+
+	registry, vehicles, aftermarket *stubContract
+
+	registryAddr, vehicleAddr, aftermarketAddr, syntheticAddr common.Address
+}
+
+func newChainFixture(t *testing.T) *chainFixture {
+	return &chainFixture{
+		registry:        newStubContract(t, contracts.RegistryMetaData),
+		vehicles:        newStubContract(t, contracts.MultiPrivilegeMetaData),
+		aftermarket:     newStubContract(t, contracts.AftermarketDeviceIdMetaData),
+		registryAddr:    common.HexToAddress("0x0000000000000000000000000000000000001001"),
+		vehicleAddr:     common.HexToAddress("0x0000000000000000000000000000000000001002"),
+		aftermarketAddr: common.HexToAddress("0x0000000000000000000000000000000000001003"),
+		syntheticAddr:   common.HexToAddress("0x0000000000000000000000000000000000001004"),
+	}
+}
+
+// start launches the chain. The answers have to be set up before this.
+func (f *chainFixture) start(t *testing.T) {
+	f.backend = simulated.NewBackend(ethtypes.GenesisAlloc{
+		f.registryAddr:    f.registry.account(),
+		f.vehicleAddr:     f.vehicles.account(),
+		f.aftermarketAddr: f.aftermarket.account(),
+	})
+	t.Cleanup(func() { _ = f.backend.Close() })
+
+	var err error
+	f.reader, err = NewRegistryChainReader(f.backend.Client(), f.registryAddr, f.vehicleAddr, f.aftermarketAddr, f.syntheticAddr)
+	require.NoError(t, err)
+}
+
+func (f *chainFixture) vehicleOwner(tokenID int64, owner common.Address) {
+	f.vehicles.returns(common.BytesToHash(owner.Bytes()), "ownerOf", big.NewInt(tokenID))
+}
+
+func (f *chainFixture) aftermarketDeviceOwner(tokenID int64, owner common.Address) {
+	f.registry.returns(common.BigToHash(big.NewInt(1)), "isAftermarketDeviceClaimed", big.NewInt(tokenID))
+	f.aftermarket.returns(common.BytesToHash(owner.Bytes()), "ownerOf", big.NewInt(tokenID))
+}
+
+func (f *chainFixture) aftermarketDeviceVehicle(tokenID, vehicleID int64) {
+	f.registry.returns(common.BigToHash(big.NewInt(vehicleID)), "getLink", f.aftermarketAddr, big.NewInt(tokenID))
+}
+
+func (f *chainFixture) syntheticDeviceVehicle(tokenID, vehicleID int64) {
+	f.registry.returns(common.BigToHash(big.NewInt(vehicleID)), "getNodeLink", f.syntheticAddr, f.vehicleAddr, big.NewInt(tokenID))
+}
+
+func TestRegistryChainReader(t *testing.T) {
+	ctx := context.Background()
+
+	owner := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	f := newChainFixture(t)
+	f.vehicleOwner(1, owner)
+	f.aftermarketDeviceOwner(10, owner)
+	f.aftermarketDeviceVehicle(10, 1)
+	// Claimed, but ownerOf reverts.
+	f.registry.returns(common.BigToHash(big.NewInt(1)), "isAftermarketDeviceClaimed", big.NewInt(11))
+	// Minted but unclaimed.
+	f.registry.returns(common.Hash{}, "isAftermarketDeviceClaimed", big.NewInt(12))
+	f.aftermarket.returns(common.BytesToHash(owner.Bytes()), "ownerOf", big.NewInt(12))
+	f.syntheticDeviceVehicle(20, 1)
+	f.syntheticDeviceVehicle(21, 0)
+	f.start(t)
+
+	r := f.reader
+
+	addr, err := r.VehicleOwner(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, owner, addr)
+
+	addr, err = r.VehicleOwner(ctx, big.NewInt(2))
+	require.NoError(t, err, "a burned vehicle should read as unowned")
+	assert.Equal(t, common.Address{}, addr)
+
+	addr, err = r.AftermarketDeviceOwner(ctx, big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, owner, addr)
+
+	addr, err = r.AftermarketDeviceOwner(ctx, big.NewInt(11))
+	require.NoError(t, err)
+	assert.Equal(t, common.Address{}, addr)
+
+	addr, err = r.AftermarketDeviceOwner(ctx, big.NewInt(12))
+	require.NoError(t, err)
+	assert.Equal(t, common.Address{}, addr, "an unclaimed device should read as unowned")
+
+	// The registry itself reverts for this one; only ownerOf reverts are expected.
+	_, err = r.AftermarketDeviceOwner(ctx, big.NewInt(13))
+	require.Error(t, err)
+	assert.True(t, isRevert(err))
+
+	node, err := r.AftermarketDeviceVehicle(ctx, big.NewInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), node.Int64())
+
+	node, err = r.SyntheticDeviceVehicle(ctx, big.NewInt(20))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), node.Int64())
+
+	node, err = r.SyntheticDeviceVehicle(ctx, big.NewInt(21))
+	require.NoError(t, err)
+	assert.Zero(t, node.Sign())
+
+	// Errors other than reverts must not pass for a missing token.
+	_, err = f.backend.Client().CallContract(ctx, ethereum.CallMsg{To: &f.vehicleAddr}, big.NewInt(1000))
+	require.Error(t, err)
+	assert.False(t, isRevert(err), "a missing block is not a revert: %v", err)
+
+	require.NoError(t, f.backend.Close())
+	_, err = r.VehicleOwner(ctx, big.NewInt(1))
+	require.Error(t, err)
+	assert.False(t, isRevert(err), "an unreachable node is not a revert: %v", err)
+}
+
+func TestChainReconcilerDevices(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+
+	stale := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	current := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	ud1 := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud1, big.NewInt(1), null.BytesFrom(current.Bytes()), pdb)
+	ud2 := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	test.SetupCreateVehicleNFT(t, ud2, big.NewInt(2), null.BytesFrom(current.Bytes()), pdb)
+
+	// Transferred on chain.
+	ad1 := test.SetupCreateMintedAftermarketDevice(t, "testUser", ksuid.New().String(), big.NewInt(10), common.HexToAddress("0x10"), nil, pdb)
+	ad1.OwnerAddress = null.BytesFrom(stale.Bytes())
+	ad1.Beneficiary = null.BytesFrom(stale.Bytes())
+	// Unpaired on chain.
+	ad2 := test.SetupCreateMintedAftermarketDevice(t, "testUser", ksuid.New().String(), big.NewInt(11), common.HexToAddress("0x11"), nil, pdb)
+	ad2.VehicleTokenID = types.NewNullDecimal(decimal.New(1, 0))
+	// Paired on chain, to a vehicle we have and to one we don't.
+	ad3 := test.SetupCreateMintedAftermarketDevice(t, "testUser", ksuid.New().String(), big.NewInt(12), common.HexToAddress("0x12"), nil, pdb)
+	ad4 := test.SetupCreateMintedAftermarketDevice(t, "testUser", ksuid.New().String(), big.NewInt(13), common.HexToAddress("0x13"), nil, pdb)
+	for _, ad := range []*models.AftermarketDevice{ad1, ad2} {
+		_, err := ad.Update(ctx, pdb.DBS().Writer, boil.Infer())
+		require.NoError(t, err)
+	}
+
+	syntheticDevice := func(tokenID int64) *models.SyntheticDevice {
+		mtr := models.MetaTransactionRequest{ID: ksuid.New().String()}
+		require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+		sd := &models.SyntheticDevice{
+			VehicleTokenID:     types.NewNullDecimal(decimal.New(1, 0)),
+			IntegrationTokenID: types.NewDecimal(decimal.New(2, 0)),
+			MintRequestID:      mtr.ID,
+			WalletChildNumber:  int(tokenID),
+			WalletAddress:      common.BigToAddress(big.NewInt(tokenID)).Bytes(),
+			TokenID:            types.NewNullDecimal(decimal.New(tokenID, 0)),
+		}
+		require.NoError(t, sd.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return sd
+	}
+
+	// Moved to another vehicle, and burned.
+	sd1 := syntheticDevice(20)
+	sd2 := syntheticDevice(21)
+
+	f := newChainFixture(t)
+	f.aftermarketDeviceOwner(10, current)
+	f.aftermarketDeviceVehicle(10, 0)
+	f.registry.returns(common.Hash{}, "isAftermarketDeviceClaimed", big.NewInt(11))
+	f.aftermarketDeviceVehicle(11, 0)
+	f.registry.returns(common.Hash{}, "isAftermarketDeviceClaimed", big.NewInt(12))
+	f.aftermarketDeviceVehicle(12, 2)
+	f.registry.returns(common.Hash{}, "isAftermarketDeviceClaimed", big.NewInt(13))
+	f.aftermarketDeviceVehicle(13, 99)
+	f.syntheticDeviceVehicle(20, 2)
+	f.syntheticDeviceVehicle(21, 0)
+	f.start(t)
+
+	// Pairings go through the consumer, and so through the integration.
+	mockCtrl := gomock.NewController(t)
+	integ := NewMockIntegration(mockCtrl)
+	integ.EXPECT().Unpair(gomock.Any(), gomock.Any(), big.NewInt(11), big.NewInt(1)).Return(nil)
+	integ.EXPECT().Pair(gomock.Any(), gomock.Any(), big.NewInt(12), big.NewInt(2)).Return(nil)
+
+	events := NewContractsEventsConsumer(pdb, &logger, &config.Settings{}, integ, nil, nil, nil, nil, nil)
+
+	rec := NewChainReconciler(pdb.DBS, &logger, f.reader, events)
+
+	runID, ds, err := rec.Reconcile(ctx, []string{ChainEntityAftermarketDevice, ChainEntitySyntheticDevice}, true)
+	require.NoError(t, err)
+	require.Len(t, ds, 6)
+
+	cols := models.AftermarketDeviceColumns
+
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntityAftermarketDevice, TokenID: big.NewInt(10), Field: cols.OwnerAddress, DB: stale.Hex(), Chain: current.Hex(), Repairable: true, Repaired: true}, *ds[0])
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntityAftermarketDevice, TokenID: big.NewInt(11), Field: cols.VehicleTokenID, DB: "1", Repairable: true, Repaired: true}, *ds[1])
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntityAftermarketDevice, TokenID: big.NewInt(12), Field: cols.VehicleTokenID, Chain: "2", Repairable: true, Repaired: true}, *ds[2])
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntityAftermarketDevice, TokenID: big.NewInt(13), Field: cols.VehicleTokenID, Chain: "99"}, *ds[3])
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntitySyntheticDevice, TokenID: big.NewInt(20), Field: models.SyntheticDeviceColumns.VehicleTokenID, DB: "1", Chain: "2", Repairable: true, Repaired: true}, *ds[4])
+	assert.Equal(t, ChainDiscrepancy{Entity: ChainEntitySyntheticDevice, TokenID: big.NewInt(21), Field: models.SyntheticDeviceColumns.VehicleTokenID, DB: "1"}, *ds[5])
+
+	require.NoError(t, ad1.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, current, common.BytesToAddress(ad1.OwnerAddress.Bytes))
+	assert.False(t, ad1.UserID.Valid, "the previous owner's user should be cleared")
+	assert.False(t, ad1.Beneficiary.Valid, "the previous owner's beneficiary should be cleared")
+
+	require.NoError(t, ad2.Reload(ctx, pdb.DBS().Reader))
+	assert.True(t, ad2.VehicleTokenID.IsZero(), "the device should be unpaired")
+
+	require.NoError(t, ad3.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, int64(2), ad3.VehicleTokenID.Int(nil).Int64())
+
+	require.NoError(t, ad4.Reload(ctx, pdb.DBS().Reader))
+	assert.True(t, ad4.VehicleTokenID.IsZero())
+
+	require.NoError(t, sd1.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, int64(2), sd1.VehicleTokenID.Int(nil).Int64())
+
+	require.NoError(t, sd2.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, int64(1), sd2.VehicleTokenID.Int(nil).Int64())
+
+	changes, err := models.ChainReconciliationChanges(qm.OrderBy(models.ChainReconciliationChangeColumns.Entity+", "+models.ChainReconciliationChangeColumns.TokenID)).All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	for _, c := range changes {
+		assert.Equal(t, runID, c.RunID)
+	}
+	assert.Equal(t, ChainEntityAftermarketDevice, changes[0].Entity)
+	assert.Equal(t, null.StringFrom(current.Hex()), changes[0].NewValue)
+	assert.Equal(t, cols.VehicleTokenID, changes[1].Field)
+	assert.Equal(t, null.StringFrom("1"), changes[1].OldValue)
+	assert.Equal(t, null.StringFrom(""), changes[1].NewValue)
+	assert.Equal(t, null.String{}, changes[2].OldValue)
+	assert.Equal(t, null.StringFrom("2"), changes[2].NewValue)
+	assert.Equal(t, ChainEntitySyntheticDevice, changes[3].Entity)
+	assert.Equal(t, null.StringFrom("1"), changes[3].OldValue)
+	assert.Equal(t, null.StringFrom("2"), changes[3].NewValue)
+}
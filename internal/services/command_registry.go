@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/models"
+)
+
+// CommandFunc enqueues a command for the vehicle behind the given integration
+// and returns the id of the sub-task that will carry it out.
+type CommandFunc func(udai *models.UserDeviceAPIIntegration, params CommandParams) (string, error)
+
+// CommandParams holds the arguments for the commands that take them. Commands
+// ignore the fields that don't apply to them.
+type CommandParams struct {
+	// ChargeLimit is the target state of charge, in percent, for charge/limit.
+	ChargeLimit *float64 `json:"chargeLimit,omitempty" example:"80"`
+	// Temperature is the target cabin temperature, in degrees Celsius, for
+	// climate/start. If omitted, the vehicle's last setting is used.
+	Temperature *float64 `json:"temperature,omitempty" example:"21.5"`
+}
+
+// Accepted ranges for command parameters. These are the limits that both Tesla
+// and Smartcar enforce.
+const (
+	minChargeLimit = 50
+	maxChargeLimit = 100
+	minTemperature = 15
+	maxTemperature = 28
+)
+
+// Validate checks that params contains what the command needs. Failures wrap
+// ErrInvalidCommandParams.
+func (p CommandParams) Validate(command string) error {
+	switch command {
+	case constants.ChargeLimit:
+		if p.ChargeLimit == nil {
+			return fmt.Errorf("%w: chargeLimit is required", ErrInvalidCommandParams)
+		}
+		if *p.ChargeLimit < minChargeLimit || *p.ChargeLimit > maxChargeLimit {
+			return fmt.Errorf("%w: chargeLimit must be between %d and %d", ErrInvalidCommandParams, minChargeLimit, maxChargeLimit)
+		}
+	case constants.ClimateStart:
+		if p.Temperature != nil && (*p.Temperature < minTemperature || *p.Temperature > maxTemperature) {
+			return fmt.Errorf("%w: temperature must be between %d and %d", ErrInvalidCommandParams, minTemperature, maxTemperature)
+		}
+	}
+	return nil
+}
+
+// withoutParams adapts executors for commands that take no arguments.
+func withoutParams(fn func(udai *models.UserDeviceAPIIntegration) (string, error)) CommandFunc {
+	return func(udai *models.UserDeviceAPIIntegration, _ CommandParams) (string, error) {
+		return fn(udai)
+	}
+}
+
+var (
+	// ErrCommandNotSupported is returned when the integration's vendor has not
+	// registered an executor for the command.
+	ErrCommandNotSupported = errors.New("integration is not capable of this command")
+	// ErrCommandNotEnabled is returned when the vendor supports the command but
+	// it is not enabled for this particular vehicle.
+	ErrCommandNotEnabled = errors.New("command is not enabled for this device integration")
+	// ErrInvalidCommandParams is returned when a command's parameters are
+	// missing or out of range.
+	ErrInvalidCommandParams = errors.New("invalid command parameters")
+)
+
+// CommandRegistry keeps track of which commands each integration vendor can
+// execute. Commands are identified by paths like "doors/unlock".
+type CommandRegistry struct {
+	executors map[string]map[string]CommandFunc
+}
+
+// NewCommandRegistry returns an empty registry. Integrations add their commands
+// with Register.
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{executors: make(map[string]map[string]CommandFunc)}
+}
+
+// Register makes fn the executor for the command on integrations from the
+// given vendor. A second registration for the same pair replaces the first.
+func (r *CommandRegistry) Register(vendor, command string, fn CommandFunc) {
+	if r.executors[vendor] == nil {
+		r.executors[vendor] = make(map[string]CommandFunc)
+	}
+	r.executors[vendor][command] = fn
+}
+
+// Commands returns every command that at least one vendor can execute, sorted.
+func (r *CommandRegistry) Commands() []string {
+	var out []string
+	for _, commands := range r.executors {
+		for command := range commands {
+			if !slices.Contains(out, command) {
+				out = append(out, command)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Executor looks up the function that runs the command for the vendor, checking
+// that the command is enabled in the integration metadata md.
+func (r *CommandRegistry) Executor(vendor, command string, md *UserDeviceAPIIntegrationsMetadata) (CommandFunc, error) {
+	commands, ok := r.executors[vendor]
+	if !ok {
+		return nil, ErrCommandNotSupported
+	}
+
+	if md.Commands == nil || !slices.Contains(md.Commands.Enabled, command) {
+		return nil, ErrCommandNotEnabled
+	}
+
+	fn, ok := commands[command]
+	if !ok {
+		return nil, ErrCommandNotSupported
+	}
+
+	return fn, nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	smock "github.com/IBM/sarama/mocks"
+)
+
+func TestCommandRegistry_Commands(t *testing.T) {
+	r := NewCommandRegistry()
+	noop := func(*models.UserDeviceAPIIntegration, CommandParams) (string, error) { return "", nil }
+
+	r.Register(constants.TeslaVendor, constants.FrunkOpen, noop)
+	r.Register(constants.TeslaVendor, constants.DoorsLock, noop)
+	r.Register(constants.SmartCarVendor, constants.DoorsLock, noop)
+
+	assert.Equal(t, []string{constants.DoorsLock, constants.FrunkOpen}, r.Commands())
+}
+
+func TestCommandRegistry_Executor(t *testing.T) {
+	r := NewCommandRegistry()
+	r.Register(constants.TeslaVendor, constants.DoorsLock, func(*models.UserDeviceAPIIntegration, CommandParams) (string, error) {
+		return "lock-task", nil
+	})
+
+	tests := []struct {
+		name    string
+		vendor  string
+		command string
+		md      *UserDeviceAPIIntegrationsMetadata
+		wantErr error
+	}{
+		{
+			name:    "enabled and registered",
+			vendor:  constants.TeslaVendor,
+			command: constants.DoorsLock,
+			md:      &UserDeviceAPIIntegrationsMetadata{Commands: &UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.DoorsLock}}},
+		},
+		{
+			name:    "unknown vendor",
+			vendor:  constants.SmartCarVendor,
+			command: constants.DoorsLock,
+			md:      &UserDeviceAPIIntegrationsMetadata{Commands: &UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.DoorsLock}}},
+			wantErr: ErrCommandNotSupported,
+		},
+		{
+			name:    "no commands in metadata",
+			vendor:  constants.TeslaVendor,
+			command: constants.DoorsLock,
+			md:      &UserDeviceAPIIntegrationsMetadata{},
+			wantErr: ErrCommandNotEnabled,
+		},
+		{
+			name:    "command not enabled",
+			vendor:  constants.TeslaVendor,
+			command: constants.DoorsLock,
+			md:      &UserDeviceAPIIntegrationsMetadata{Commands: &UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.DoorsUnlock}}},
+			wantErr: ErrCommandNotEnabled,
+		},
+		{
+			name:    "enabled but not registered",
+			vendor:  constants.TeslaVendor,
+			command: constants.TrunkOpen,
+			md:      &UserDeviceAPIIntegrationsMetadata{Commands: &UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.TrunkOpen}}},
+			wantErr: ErrCommandNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := r.Executor(tt.vendor, tt.command, tt.md)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			subTaskID, err := fn(&models.UserDeviceAPIIntegration{}, CommandParams{})
+			require.NoError(t, err)
+			assert.Equal(t, "lock-task", subTaskID)
+		})
+	}
+}
+
+func TestCommandParams_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		params  CommandParams
+		wantErr bool
+	}{
+		{name: "no params needed", command: constants.DoorsLock},
+		{name: "charge limit in range", command: constants.ChargeLimit, params: CommandParams{ChargeLimit: floatPtr(80.0)}},
+		{name: "charge limit missing", command: constants.ChargeLimit, wantErr: true},
+		{name: "charge limit too low", command: constants.ChargeLimit, params: CommandParams{ChargeLimit: floatPtr(20.0)}, wantErr: true},
+		{name: "charge limit too high", command: constants.ChargeLimit, params: CommandParams{ChargeLimit: floatPtr(101.0)}, wantErr: true},
+		{name: "climate without temperature", command: constants.ClimateStart},
+		{name: "climate temperature in range", command: constants.ClimateStart, params: CommandParams{Temperature: floatPtr(21.5)}},
+		{name: "climate temperature out of range", command: constants.ClimateStart, params: CommandParams{Temperature: floatPtr(40.0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(tt.command)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCommandParams)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClimateStartValidatesTemperature(t *testing.T) {
+	// Nothing should reach Kafka.
+	producer := smock.NewSyncProducer(t, nil)
+	defer producer.Close() //nolint
+
+	r := NewCommandRegistry()
+	RegisterSmartcarCommands(r, NewSmartcarTaskService(&config.Settings{}, producer, nil))
+	RegisterTeslaCommands(r, NewTeslaTaskService(&config.Settings{}, producer, nil))
+
+	md := &UserDeviceAPIIntegrationsMetadata{Commands: &UserDeviceAPIIntegrationsMetadataCommands{Enabled: []string{constants.ClimateStart}}}
+
+	for _, vendor := range []string{constants.SmartCarVendor, constants.TeslaVendor} {
+		t.Run(vendor, func(t *testing.T) {
+			fn, err := r.Executor(vendor, constants.ClimateStart, md)
+			require.NoError(t, err)
+
+			_, err = fn(&models.UserDeviceAPIIntegration{}, CommandParams{Temperature: floatPtr(40.0)})
+			assert.ErrorIs(t, err, ErrInvalidCommandParams)
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
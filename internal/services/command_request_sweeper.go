@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+)
+
+// CommandRequestSweeper moves command requests that never got a status back
+// from the task workers into the TimedOut state, so that clients polling them
+// can give up.
+type CommandRequestSweeper struct {
+	dbs      func() *db.ReaderWriter
+	log      *zerolog.Logger
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// NewCommandRequestSweeper creates a sweeper that expires requests which have
+// been pending for longer than timeout. It checks every interval.
+func NewCommandRequestSweeper(dbs func() *db.ReaderWriter, log *zerolog.Logger, timeout, interval time.Duration) *CommandRequestSweeper {
+	return &CommandRequestSweeper{dbs: dbs, log: log, timeout: timeout, interval: interval}
+}
+
+// Run sweeps on a timer until the context is cancelled.
+func (s *CommandRequestSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Sweep(ctx)
+			if err != nil {
+				s.log.Err(err).Msg("Failed to expire stale command requests.")
+				continue
+			}
+			if n != 0 {
+				s.log.Info().Int64("count", n).Msg("Expired stale command requests.")
+			}
+		}
+	}
+}
+
+// Sweep marks every request that has been pending for longer than the timeout
+// as TimedOut and returns the number of requests it changed.
+func (s *CommandRequestSweeper) Sweep(ctx context.Context) (int64, error) {
+	now := time.Now()
+
+	return models.DeviceCommandRequests(
+		models.DeviceCommandRequestWhere.Status.EQ(models.DeviceCommandRequestStatusPending),
+		models.DeviceCommandRequestWhere.CreatedAt.LT(now.Add(-s.timeout)),
+	).UpdateAll(ctx, s.dbs().Writer, models.M{
+		models.DeviceCommandRequestColumns.Status:        models.DeviceCommandRequestStatusTimedOut,
+		models.DeviceCommandRequestColumns.FailureReason: "No status received from the integration within " + s.timeout.String() + ".",
+		models.DeviceCommandRequestColumns.UpdatedAt:     now,
+	})
+}
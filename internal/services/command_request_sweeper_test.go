@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestCommandRequestSweeper(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+
+	ud := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+
+	insert := func(status string, age time.Duration) *models.DeviceCommandRequest {
+		dcr := &models.DeviceCommandRequest{
+			ID:            ksuid.New().String(),
+			UserDeviceID:  ud.ID,
+			IntegrationID: ksuid.New().String(),
+			Command:       "doors/unlock",
+			Status:        status,
+			CreatedAt:     time.Now().Add(-age),
+		}
+		require.NoError(t, dcr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return dcr
+	}
+
+	stale := insert(models.DeviceCommandRequestStatusPending, 10*time.Minute)
+	fresh := insert(models.DeviceCommandRequestStatusPending, 10*time.Second)
+	done := insert(models.DeviceCommandRequestStatusComplete, 10*time.Minute)
+
+	sweeper := NewCommandRequestSweeper(pdb.DBS, &logger, 2*time.Minute, time.Minute)
+
+	n, err := sweeper.Sweep(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	require.NoError(t, stale.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, models.DeviceCommandRequestStatusTimedOut, stale.Status)
+	require.True(t, stale.FailureReason.Valid)
+
+	require.NoError(t, fresh.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, models.DeviceCommandRequestStatusPending, fresh.Status)
+
+	require.NoError(t, done.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, models.DeviceCommandRequestStatusComplete, done.Status)
+}
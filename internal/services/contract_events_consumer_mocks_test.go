@@ -42,31 +42,31 @@ func (m *MockIntegration) EXPECT() *MockIntegrationMockRecorder {
 }
 
 // Pair mocks base method.
-func (m *MockIntegration) Pair(ctx context.Context, autoPiTokenID, vehicleTokenID *big.Int) error {
+func (m *MockIntegration) Pair(ctx context.Context, tx *EventTx, autoPiTokenID, vehicleTokenID *big.Int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Pair", ctx, autoPiTokenID, vehicleTokenID)
+	ret := m.ctrl.Call(m, "Pair", ctx, tx, autoPiTokenID, vehicleTokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Pair indicates an expected call of Pair.
-func (mr *MockIntegrationMockRecorder) Pair(ctx, autoPiTokenID, vehicleTokenID any) *gomock.Call {
+func (mr *MockIntegrationMockRecorder) Pair(ctx, tx, autoPiTokenID, vehicleTokenID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pair", reflect.TypeOf((*MockIntegration)(nil).Pair), ctx, autoPiTokenID, vehicleTokenID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pair", reflect.TypeOf((*MockIntegration)(nil).Pair), ctx, tx, autoPiTokenID, vehicleTokenID)
 }
 
 // Unpair mocks base method.
-func (m *MockIntegration) Unpair(ctx context.Context, autoPiTokenID, vehicleTokenID *big.Int) error {
+func (m *MockIntegration) Unpair(ctx context.Context, tx *EventTx, autoPiTokenID, vehicleTokenID *big.Int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Unpair", ctx, autoPiTokenID, vehicleTokenID)
+	ret := m.ctrl.Call(m, "Unpair", ctx, tx, autoPiTokenID, vehicleTokenID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Unpair indicates an expected call of Unpair.
-func (mr *MockIntegrationMockRecorder) Unpair(ctx, autoPiTokenID, vehicleTokenID any) *gomock.Call {
+func (mr *MockIntegrationMockRecorder) Unpair(ctx, tx, autoPiTokenID, vehicleTokenID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpair", reflect.TypeOf((*MockIntegration)(nil).Unpair), ctx, autoPiTokenID, vehicleTokenID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpair", reflect.TypeOf((*MockIntegration)(nil).Unpair), ctx, tx, autoPiTokenID, vehicleTokenID)
 }
 
 // MockSyntheticTaskService is a mock of SyntheticTaskService interface.
@@ -0,0 +1,495 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/dbtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Burns show up twice: once as a Transfer to the zero address from the NFT contract and
+// once as a *NodeBurned event from the registry. Admin burns may only produce the latter.
+// Whichever arrives second finds nothing left to do, so the handlers below treat a
+// missing row as success.
+
+// checkRegistrySource makes sure that an event came from the registry on our chain.
+func (c *ContractsEventsConsumer) checkRegistrySource(e *ContractEventData) error {
+	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
+		return fmt.Errorf("%s from unexpected source %d/%s", e.EventName, e.ChainID, e.Contract)
+	}
+	return nil
+}
+
+// vehicleNodeBurned handles VehicleNodeBurned and VehicleNodeBurnedDevAdmin, which have
+// the same arguments.
+func (c *ContractsEventsConsumer) vehicleNodeBurned(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryVehicleNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.burnVehicle(ctx, tx, args.VehicleNode, args.Owner)
+}
+
+// syntheticDeviceNodeBurned handles SyntheticDeviceNodeBurned and
+// SyntheticDeviceNodeBurnedDevAdmin, which have the same arguments.
+func (c *ContractsEventsConsumer) syntheticDeviceNodeBurned(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistrySyntheticDeviceNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.burnSyntheticDevice(ctx, tx, args.SyntheticDeviceNode, args.Owner)
+}
+
+func (c *ContractsEventsConsumer) aftermarketDeviceNodeBurned(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryAftermarketDeviceNodeBurned
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.burnAftermarketDevice(ctx, tx, args.TokenId)
+}
+
+func (c *ContractsEventsConsumer) aftermarketDeviceNodeBurnedDevAdmin(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryAftermarketDeviceNodeBurnedDevAdmin
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.burnAftermarketDevice(ctx, tx, args.AdNode)
+}
+
+// burnVehicle removes a burned vehicle along with its synthetic device, integrations,
+// privileges and geofence links. Paired aftermarket devices are unpaired first.
+func (c *ContractsEventsConsumer) burnVehicle(ctx context.Context, tx *EventTx, vehicleNode *big.Int, owner common.Address) error {
+	logger := c.log.With().Int64("vehicleTokenId", vehicleNode.Int64()).Str("owner", owner.Hex()).Logger()
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(vehicleNode)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Debug().Msg("Burned vehicle already removed.")
+			return nil
+		}
+		return err
+	}
+
+	// The synthetic device table holds a foreign key to the vehicle, and the synthetic
+	// device's own burn may not have been processed yet.
+	sd, err := models.SyntheticDevices(
+		models.SyntheticDeviceWhere.VehicleTokenID.EQ(ud.TokenID),
+	).One(ctx, tx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if sd != nil && !sd.TokenID.IsZero() {
+		if err := c.burnSyntheticDevice(ctx, tx, sd.TokenID.Int(nil), owner); err != nil {
+			return err
+		}
+	}
+
+	ads, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.VehicleTokenID.EQ(ud.TokenID),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, ad := range ads {
+		if err := c.unpairAftermarketDevice(ctx, tx, ad.TokenID.Int(nil), vehicleNode); err != nil {
+			return fmt.Errorf("failed to unpair aftermarket device %d from burned vehicle: %w", ad.TokenID, err)
+		}
+	}
+
+	if _, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(vehicleNode)),
+	).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	cleanup, err := PlanVehicleTransferCleanup(ctx, tx, ud.ID, VehicleTransferPolicyKeep)
+	if err != nil {
+		return err
+	}
+
+	// Under the keep policy nothing is wiped, so there are no tasks to stop yet.
+	if _, err := cleanup.Apply(ctx, tx); err != nil {
+		return err
+	}
+
+	udais, err := models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(ud.ID),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	var running models.UserDeviceAPIIntegrationSlice
+
+	for _, udai := range udais {
+		if udai.TaskID.Valid {
+			running = append(running, udai)
+		}
+
+		if _, err := udai.Delete(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	// Rows for synthetic devices whose mint never went through.
+	if _, err := models.SyntheticDevices(
+		models.SyntheticDeviceWhere.VehicleTokenID.EQ(ud.TokenID),
+	).DeleteAll(ctx, tx); err != nil {
+		return err
+	}
+
+	if _, err := ud.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	tx.AfterCommit(func() {
+		logger.Info().Msgf("Burned vehicle, removing %d integrations.", len(udais))
+
+		c.stopPolls(ctx, running)
+		if len(cleanup.Geofences) != 0 {
+			c.emitFences(ctx, ud.ID, ud.TokenID)
+		}
+
+		dd, err := c.ddSvc.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
+		if err != nil {
+			logger.Err(err).Msg("Couldn't retrieve definition for vehicle deletion events.")
+			return
+		}
+
+		for _, udai := range udais {
+			integ, err := c.ddSvc.GetIntegrationByID(ctx, udai.IntegrationID)
+			if err != nil {
+				logger.Err(err).Str("integrationId", udai.IntegrationID).Msg("Couldn't retrieve integration for deletion event.")
+				continue
+			}
+			c.emitIntegrationDeleted(ud, dd, integ)
+		}
+
+		if err := c.evtSvc.Emit(&shared.CloudEvent[any]{
+			Type:    "com.dimo.zone.device.delete",
+			Source:  "devices-api",
+			Subject: ud.UserID,
+			Data: UserDeviceEvent{
+				Timestamp: time.Now(),
+				UserID:    ud.UserID,
+				Device: UserDeviceEventDevice{
+					ID:           ud.ID,
+					Make:         dd.Make.Name,
+					Model:        dd.Model,
+					Year:         int(dd.Year),
+					VIN:          ud.VinIdentifier.String,
+					DefinitionID: dd.Id,
+				},
+			},
+		}); err != nil {
+			logger.Err(err).Msg("Couldn't send out vehicle deletion event.")
+		}
+	})
+
+	return nil
+}
+
+// burnSyntheticDevice removes a burned synthetic device and the integration behind it,
+// stopping any polling. Both rows go in one transaction so that a failure leaves the
+// synthetic device in place for the redelivery to find.
+func (c *ContractsEventsConsumer) burnSyntheticDevice(ctx context.Context, tx *EventTx, tokenID *big.Int, owner common.Address) error {
+	sd, err := models.SyntheticDevices(
+		models.SyntheticDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+		qm.Load(models.SyntheticDeviceRels.VehicleToken),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Debug().Int64("syntheticDeviceTokenId", tokenID.Int64()).Msg("Burned synthetic device already removed.")
+			return nil
+		}
+		return fmt.Errorf("couldn't find synthetic device %d to burn: %w", tokenID, err)
+	}
+
+	ud := sd.R.VehicleToken
+	if ud == nil {
+		return fmt.Errorf("burning synthetic device %d with no paired vehicle", sd.TokenID)
+	}
+
+	intID, _ := sd.IntegrationTokenID.Uint64()
+	integ, err := c.ddSvc.GetIntegrationByTokenID(ctx, intID)
+	if err != nil {
+		return err
+	}
+
+	udai, err := models.FindUserDeviceAPIIntegration(ctx, tx, ud.ID, integ.Id)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find job backing burned synthetic device %d: %w", sd.TokenID, err)
+		}
+		udai = nil
+	}
+
+	if _, err := sd.Delete(ctx, tx); err != nil {
+		return fmt.Errorf("failed to delete synthetic device %d row: %w", sd.TokenID, err)
+	}
+
+	if udai != nil {
+		if _, err := udai.Delete(ctx, tx); err != nil {
+			return fmt.Errorf("failed to delete job backing synthetic device %d: %w", sd.TokenID, err)
+		}
+
+		if err := RecordIntegrationRemoval(ctx, tx, udai, IntegrationStatusSourceContractEvent, "Synthetic device burned."); err != nil {
+			return err
+		}
+	}
+
+	tx.AfterCommit(func() {
+		c.log.Info().Int64("syntheticDeviceTokenId", tokenID.Int64()).Str("owner", owner.Hex()).Msg("Burned synthetic device.")
+
+		if udai == nil {
+			c.log.Warn().Int64("syntheticDeviceTokenId", tokenID.Int64()).Msg("No integration behind burned synthetic device.")
+			return
+		}
+
+		// The rows are gone, so a failure from here on can't be retried. Log it instead.
+		if udai.TaskID.Valid {
+			var err error
+			switch integ.Vendor {
+			case constants.SmartCarVendor:
+				err = c.scTask.StopPoll(udai)
+			case constants.TeslaVendor:
+				err = c.teslaTask.StopPoll(udai)
+			default:
+				c.log.Warn().Msgf("Unexpected integration %s.", integ.Vendor)
+			}
+			if err != nil {
+				c.log.Err(err).Int64("syntheticDeviceTokenId", tokenID.Int64()).Str("taskId", udai.TaskID.String).Msg("Failed to stop polling for burned synthetic device.")
+			}
+		}
+
+		// Need this for the event.
+		dd, err := c.ddSvc.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
+		if err != nil {
+			c.log.Err(err).Int64("syntheticDeviceTokenId", tokenID.Int64()).Msg("Couldn't retrieve definition for integration deletion event.")
+			return
+		}
+
+		c.emitIntegrationDeleted(ud, dd, integ)
+	})
+
+	return nil
+}
+
+// burnAftermarketDevice removes a burned aftermarket device, unpairing it first if need be.
+func (c *ContractsEventsConsumer) burnAftermarketDevice(ctx context.Context, tx *EventTx, tokenID *big.Int) error {
+	tkID := utils.BigToDecimal(tokenID)
+
+	apUnit, err := models.AftermarketDevices(models.AftermarketDeviceWhere.TokenID.EQ(tkID)).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Debug().Str("tokenID", tkID.String()).Msg("Burned aftermarket device already removed.")
+			return nil
+		}
+		return err
+	}
+
+	if !apUnit.VehicleTokenID.IsZero() {
+		if err := c.unpairAftermarketDevice(ctx, tx, tokenID, apUnit.VehicleTokenID.Int(nil)); err != nil {
+			return err
+		}
+	}
+
+	c.log.Info().Msgf("Burning aftermarket device %d.", tkID)
+	_, err = models.AutopiJobs(models.AutopiJobWhere.AutopiUnitID.EQ(null.StringFrom(apUnit.Serial))).DeleteAll(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("error deleting jobs associated with aftermarket device: %w", err)
+	}
+
+	_, err = apUnit.Delete(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("error deleting aftermarket device: %w", err)
+	}
+
+	return nil
+}
+
+// aftermarketDeviceTransferredDevAdmin handles an admin moving a claimed device to a new
+// owner. As with an ordinary transfer, the old owner's web2 account and beneficiary go.
+func (c *ContractsEventsConsumer) aftermarketDeviceTransferredDevAdmin(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryAftermarketDeviceTransferredDevAdmin
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	am, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Debug().Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msg("Transferred aftermarket device not found.")
+			return nil
+		}
+		return err
+	}
+
+	c.log.Info().Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msgf("Admin transferred aftermarket device from %s to %s.", args.OldOwner, args.NewOwner)
+
+	am.UserID = null.String{}
+	am.OwnerAddress = null.BytesFrom(args.NewOwner.Bytes())
+	am.Beneficiary = null.Bytes{}
+
+	cols := models.AftermarketDeviceColumns
+
+	_, err = am.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress, cols.Beneficiary, cols.UpdatedAt))
+	return err
+}
+
+// aftermarketDeviceUnclaimedDevAdmin handles an admin releasing a device, which can then
+// be claimed again.
+func (c *ContractsEventsConsumer) aftermarketDeviceUnclaimedDevAdmin(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryAftermarketDeviceUnclaimedDevAdmin
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	am, err := models.AftermarketDevices(
+		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Debug().Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msg("Unclaimed aftermarket device not found.")
+			return nil
+		}
+		return err
+	}
+
+	c.log.Info().Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msg("Admin unclaimed aftermarket device.")
+
+	am.UserID = null.String{}
+	am.OwnerAddress = null.Bytes{}
+	am.Beneficiary = null.Bytes{}
+	am.ClaimMetaTransactionRequestID = null.String{}
+
+	cols := models.AftermarketDeviceColumns
+
+	_, err = am.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress, cols.Beneficiary, cols.ClaimMetaTransactionRequestID, cols.UpdatedAt))
+	return err
+}
+
+func (c *ContractsEventsConsumer) aftermarketDeviceUnpairedDevAdmin(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryAftermarketDeviceUnpairedDevAdmin
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	return c.unpairAftermarketDevice(ctx, tx, args.AftermarketDeviceNode, args.VehicleNode)
+}
+
+// vehicleAttributeSetDevAdmin picks up admin corrections to a vehicle's VIN. Other
+// attributes come from the device definition and aren't stored per vehicle.
+func (c *ContractsEventsConsumer) vehicleAttributeSetDevAdmin(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	if err := c.checkRegistrySource(e); err != nil {
+		return err
+	}
+
+	var args contracts.RegistryVehicleAttributeSetDevAdmin
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return err
+	}
+
+	if args.Attribute != "VIN" {
+		return nil
+	}
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.log.Debug().Int64("vehicleTokenId", args.TokenId.Int64()).Msg("Vehicle with new VIN not found.")
+			return nil
+		}
+		return err
+	}
+
+	c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Msgf("Admin set VIN to %s.", args.Info)
+
+	ud.VinIdentifier = null.StringFrom(args.Info)
+
+	_, err = ud.Update(ctx, tx, boil.Whitelist(models.UserDeviceColumns.VinIdentifier, models.UserDeviceColumns.UpdatedAt))
+	return err
+}
+
+func (c *ContractsEventsConsumer) emitIntegrationDeleted(ud *models.UserDevice, dd *ddgrpc.GetDeviceDefinitionItemResponse, integ *ddgrpc.Integration) {
+	err := c.evtSvc.Emit(&shared.CloudEvent[any]{
+		Type:    "com.dimo.zone.device.integration.delete",
+		Source:  "devices-api",
+		Subject: ud.ID,
+		Data: UserDeviceIntegrationEvent{
+			Timestamp: time.Now(),
+			UserID:    ud.UserID,
+			Device: UserDeviceEventDevice{
+				ID:           ud.ID,
+				Make:         dd.Make.Name,
+				Model:        dd.Model,
+				Year:         int(dd.Year),
+				VIN:          ud.VinIdentifier.String,
+				DefinitionID: dd.Id,
+			},
+			Integration: UserDeviceEventIntegration{
+				ID:     integ.Id,
+				Type:   integ.Type,
+				Style:  integ.Style,
+				Vendor: integ.Vendor,
+			},
+		},
+	})
+	if err != nil {
+		c.log.Err(err).Str("userDeviceId", ud.ID).Str("integrationId", integ.Id).Msg("Couldn't send out integration deletion event.")
+	}
+}
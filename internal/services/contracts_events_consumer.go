@@ -8,32 +8,34 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/internal/services/dex"
 	"github.com/DIMO-Network/devices-api/internal/utils"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/shared/dbtypes"
-	"github.com/DIMO-Network/shared/kafka"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
-	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/types"
 	"google.golang.org/protobuf/proto"
 )
 
+// Integration sets up and tears down the integration behind an aftermarket device
+// pairing. Its writes go in the caller's transaction.
 type Integration interface {
-	Pair(ctx context.Context, autoPiTokenID, vehicleTokenID *big.Int) error
-	Unpair(ctx context.Context, autoPiTokenID, vehicleTokenID *big.Int) error
+	Pair(ctx context.Context, tx *EventTx, autoPiTokenID, vehicleTokenID *big.Int) error
+	Unpair(ctx context.Context, tx *EventTx, autoPiTokenID, vehicleTokenID *big.Int) error
 }
 
 //go:generate mockgen -source=./contracts_events_consumer.go -destination=./contract_events_consumer_mocks_test.go -package=services
@@ -41,6 +43,9 @@ type SyntheticTaskService interface {
 	StopPoll(udai *models.UserDeviceAPIIntegration) error
 }
 
+// PrivacyFenceEmitter republishes the privacy fences that apply to a vehicle.
+type PrivacyFenceEmitter func(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, tokenID types.NullDecimal) error
+
 type ContractsEventsConsumer struct {
 	db           db.Store
 	log          *zerolog.Logger
@@ -52,6 +57,11 @@ type ContractsEventsConsumer struct {
 
 	scTask    SyntheticTaskService
 	teslaTask SyntheticTaskService
+
+	fenceEmitter PrivacyFenceEmitter
+
+	lastBlockMu sync.Mutex
+	lastBlock   map[common.Address]int64
 }
 
 type EventName string
@@ -70,6 +80,20 @@ const (
 	AftermarketDeviceAttributeSet         EventName = "AftermarketDeviceAttributeSet"
 	AftermarketDeviceAddressReset         EventName = "AftermarketDeviceAddressReset"
 	VehicleNodeMintedWithDeviceDefinition EventName = "VehicleNodeMintedWithDeviceDefinition"
+	VehicleNodeBurned                     EventName = "VehicleNodeBurned"
+	SyntheticDeviceNodeBurned             EventName = "SyntheticDeviceNodeBurned"
+	AftermarketDeviceNodeBurned           EventName = "AftermarketDeviceNodeBurned"
+
+	// Events emitted when a registry admin acts on a node directly.
+	VehicleNodeBurnedDevAdmin             EventName = "VehicleNodeBurnedDevAdmin"
+	VehicleAttributeSetDevAdmin           EventName = "VehicleAttributeSetDevAdmin"
+	SyntheticDeviceNodeBurnedDevAdmin     EventName = "SyntheticDeviceNodeBurnedDevAdmin"
+	SyntheticDeviceAttributeSetDevAdmin   EventName = "SyntheticDeviceAttributeSetDevAdmin"
+	AftermarketDeviceNodeBurnedDevAdmin   EventName = "AftermarketDeviceNodeBurnedDevAdmin"
+	AftermarketDeviceTransferredDevAdmin  EventName = "AftermarketDeviceTransferredDevAdmin"
+	AftermarketDeviceUnclaimedDevAdmin    EventName = "AftermarketDeviceUnclaimedDevAdmin"
+	AftermarketDeviceUnpairedDevAdmin     EventName = "AftermarketDeviceUnpairedDevAdmin"
+	AftermarketDeviceAttributeSetDevAdmin EventName = "AftermarketDeviceAttributeSetDevAdmin"
 )
 
 func (r EventName) String() string {
@@ -79,13 +103,15 @@ func (r EventName) String() string {
 const contractEventCEType = "zone.dimo.contract.event"
 
 type ContractEventData struct {
-	ChainID         int64           `json:"chainId"`
-	EventName       string          `json:"eventName"`
-	Block           Block           `json:"block,omitempty"`
-	Contract        common.Address  `json:"contract"`
-	TransactionHash common.Hash     `json:"transactionHash"`
-	EventSignature  common.Hash     `json:"eventSignature"`
-	Arguments       json.RawMessage `json:"arguments"`
+	ChainID         int64          `json:"chainId"`
+	EventName       string         `json:"eventName"`
+	Block           Block          `json:"block,omitempty"`
+	Contract        common.Address `json:"contract"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	// Index is the position of the log in its block. Older producers don't send it.
+	Index          *uint           `json:"index"`
+	EventSignature common.Hash     `json:"eventSignature"`
+	Arguments      json.RawMessage `json:"arguments"`
 	// TODO(elffjs): chainID. Don't repeat this struct everywhere.
 }
 
@@ -95,7 +121,7 @@ type Block struct {
 	Time   time.Time   `json:"time,omitempty"`
 }
 
-func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *config.Settings, genericInt Integration, ddSvc DeviceDefinitionService, evtSvc EventService, scTask SyntheticTaskService, teslaTask SyntheticTaskService) *ContractsEventsConsumer {
+func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *config.Settings, genericInt Integration, ddSvc DeviceDefinitionService, evtSvc EventService, scTask SyntheticTaskService, teslaTask SyntheticTaskService, fenceEmitter PrivacyFenceEmitter) *ContractsEventsConsumer {
 	return &ContractsEventsConsumer{
 		db:           pdb,
 		log:          log,
@@ -106,17 +132,14 @@ func NewContractsEventsConsumer(pdb db.Store, log *zerolog.Logger, settings *con
 		evtSvc:       evtSvc,
 		scTask:       scTask,
 		teslaTask:    teslaTask,
+		fenceEmitter: fenceEmitter,
 	}
 }
 
-func (c *ContractsEventsConsumer) RunConsumer() error {
+func (c *ContractsEventsConsumer) RunConsumer(dlq *kafka.DeadLetterQueue) error {
 	ctx := context.Background()
 
-	if err := kafka.Consume[*shared.CloudEvent[json.RawMessage]](ctx, kafka.Config{
-		Brokers: strings.Split(c.settings.KafkaBrokers, ","),
-		Topic:   c.settings.ContractsEventTopic,
-		Group:   "user-devices",
-	}, c.processEvent, c.log); err != nil {
+	if err := kafka.ConsumeWithDeadLetters(ctx, strings.Split(c.settings.KafkaBrokers, ","), c.settings.ContractsEventTopic, "user-devices", dlq, c.HandleMessage, c.log); err != nil {
 		c.log.Error().Err(err).Msg("error starting contracts events consumer")
 		return err
 	}
@@ -126,6 +149,16 @@ func (c *ContractsEventsConsumer) RunConsumer() error {
 	return nil
 }
 
+// HandleMessage processes a single contract event message. It is also what replay-dlq uses.
+func (c *ContractsEventsConsumer) HandleMessage(ctx context.Context, msg *kafka.Message) error {
+	var event shared.CloudEvent[json.RawMessage]
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		return kafka.Permanent(err)
+	}
+
+	return c.processEvent(ctx, &event)
+}
+
 func (c *ContractsEventsConsumer) processEvent(ctx context.Context, event *shared.CloudEvent[json.RawMessage]) error {
 	if event == nil || event.Type != contractEventCEType {
 		return nil
@@ -141,43 +174,76 @@ func (c *ContractsEventsConsumer) processEvent(ctx context.Context, event *share
 		return nil
 	}
 
+	// Older producers didn't send the log position, so there is nothing to key the
+	// ledger on.
+	if data.TransactionHash == (common.Hash{}) || data.Block.Number == nil || data.Index == nil {
+		return c.processUnledgered(ctx, &data)
+	}
+
+	return c.processOnce(ctx, &data)
+}
+
+// handleEvent applies the event in the transaction.
+func (c *ContractsEventsConsumer) handleEvent(ctx context.Context, tx *EventTx, data *ContractEventData) error {
 	switch data.EventName {
 	case PrivilegeSet.String():
 		c.log.Info().Str("event", data.EventName).Msg("Event received")
-		return c.setPrivilegeHandler(&data)
+		return c.setPrivilegeHandler(ctx, tx, data)
 	case Transfer.String():
-		return c.routeTransferEvent(ctx, &data)
+		return c.routeTransferEvent(ctx, tx, data)
 	case AftermarketDeviceNodeMinted.String():
 		if data.Contract == c.registryAddr {
 			c.log.Info().Str("event", data.EventName).Msg("Event received")
-			return c.setMintedAfterMarketDevice(&data)
+			return c.setMintedAfterMarketDevice(ctx, tx, data)
 		}
 	case BeneficiarySet.String():
 		if data.Contract == c.registryAddr {
 			c.log.Info().Str("event", data.EventName).Msg("Event received")
-			return c.beneficiarySet(&data)
+			return c.beneficiarySet(ctx, tx, data)
 		}
 	case DCNNameChanged.String():
 		c.log.Info().Str("event", data.EventName).Msg("Event received")
-		return c.dcnNameChanged(&data)
+		return c.dcnNameChanged(ctx, tx, data)
 	case DCNNewNode.String():
 		c.log.Info().Str("event", data.EventName).Msg("Event received")
-		return c.dcnNewNode(&data)
+		return c.dcnNewNode(ctx, tx, data)
 	case DCNNewExpiration.String():
 		c.log.Info().Str("event", data.EventName).Msg("Event received")
-		return c.dcnNewExpiration(&data)
+		return c.dcnNewExpiration(ctx, tx, data)
 	case AftermarketDeviceClaimed.String():
-		return c.aftermarketDeviceClaimed(&data)
+		return c.aftermarketDeviceClaimed(ctx, tx, data)
 	case AftermarketDevicePaired.String():
-		return c.aftermarketDevicePaired(&data)
+		return c.aftermarketDevicePaired(ctx, tx, data)
 	case AftermarketDeviceUnpaired.String():
-		return c.aftermarketDeviceUnpaired(&data)
+		return c.aftermarketDeviceUnpaired(ctx, tx, data)
 	case AftermarketDeviceAttributeSet.String():
-		return c.aftermarketDeviceAttributeSet(&data)
+		return c.aftermarketDeviceAttributeSet(ctx, tx, data)
 	case AftermarketDeviceAddressReset.String():
-		return c.aftermarketDeviceAddressReset(&data)
+		return c.aftermarketDeviceAddressReset(ctx, tx, data)
 	case VehicleNodeMintedWithDeviceDefinition.String():
-		return c.vehicleNodeMintedWithDeviceDefinition(&data)
+		return c.vehicleNodeMintedWithDeviceDefinition(ctx, tx, data)
+	case VehicleNodeBurned.String(), VehicleNodeBurnedDevAdmin.String():
+		return c.vehicleNodeBurned(ctx, tx, data)
+	case SyntheticDeviceNodeBurned.String(), SyntheticDeviceNodeBurnedDevAdmin.String():
+		return c.syntheticDeviceNodeBurned(ctx, tx, data)
+	case AftermarketDeviceNodeBurned.String():
+		return c.aftermarketDeviceNodeBurned(ctx, tx, data)
+	case AftermarketDeviceNodeBurnedDevAdmin.String():
+		return c.aftermarketDeviceNodeBurnedDevAdmin(ctx, tx, data)
+	case AftermarketDeviceTransferredDevAdmin.String():
+		return c.aftermarketDeviceTransferredDevAdmin(ctx, tx, data)
+	case AftermarketDeviceUnclaimedDevAdmin.String():
+		return c.aftermarketDeviceUnclaimedDevAdmin(ctx, tx, data)
+	case AftermarketDeviceUnpairedDevAdmin.String():
+		return c.aftermarketDeviceUnpairedDevAdmin(ctx, tx, data)
+	case AftermarketDeviceAttributeSetDevAdmin.String():
+		// Same arguments as the non-admin event.
+		return c.aftermarketDeviceAttributeSet(ctx, tx, data)
+	case VehicleAttributeSetDevAdmin.String():
+		return c.vehicleAttributeSetDevAdmin(ctx, tx, data)
+	case SyntheticDeviceAttributeSetDevAdmin.String():
+		// We don't store any synthetic device attributes.
+		c.log.Debug().Str("event", data.EventName).Msg("Ignoring synthetic device attribute.")
 	default:
 		c.log.Debug().Str("event", data.EventName).Msg("Handler not provided for event.")
 	}
@@ -185,17 +251,17 @@ func (c *ContractsEventsConsumer) processEvent(ctx context.Context, event *share
 	return nil
 }
 
-func (c *ContractsEventsConsumer) routeTransferEvent(ctx context.Context, e *ContractEventData) error {
+func (c *ContractsEventsConsumer) routeTransferEvent(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	switch e.Contract {
 	case common.HexToAddress(c.settings.AftermarketDeviceContractAddress):
 		c.log.Info().Str("event", e.EventName).Msg("Event received")
-		return c.handleAfterMarketTransferEvent(e)
+		return c.handleAfterMarketTransferEvent(ctx, tx, e)
 	case common.HexToAddress(c.settings.VehicleNFTAddress):
 		c.log.Info().Str("event", e.EventName).Msg("Event received")
-		return c.handleVehicleTransfer(ctx, e)
+		return c.handleVehicleTransfer(ctx, tx, e)
 	case common.HexToAddress(c.settings.SyntheticDeviceNFTAddress):
 		c.log.Info().Str("event", e.EventName).Msg("Event received")
-		return c.handleSyntheticTransfer(ctx, e)
+		return c.handleSyntheticTransfer(ctx, tx, e)
 	default:
 		c.log.Debug().Str("event", e.EventName).Interface("fullEventData", e).Msg("Handler not provided for contract")
 	}
@@ -203,7 +269,7 @@ func (c *ContractsEventsConsumer) routeTransferEvent(ctx context.Context, e *Con
 	return nil
 }
 
-func (c *ContractsEventsConsumer) handleSyntheticTransfer(ctx context.Context, e *ContractEventData) error {
+func (c *ContractsEventsConsumer) handleSyntheticTransfer(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.MultiPrivilegeTransfer
 	err := json.Unmarshal(e.Arguments, &args)
 	if err != nil {
@@ -216,162 +282,158 @@ func (c *ContractsEventsConsumer) handleSyntheticTransfer(ctx context.Context, e
 		return nil
 	}
 
-	sd, err := models.SyntheticDevices(
-		models.SyntheticDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
-		qm.Load(models.SyntheticDeviceRels.VehicleToken),
-	).One(ctx, c.db.DBS().Writer)
-	if err != nil {
-		return fmt.Errorf("couldn't find synthetic device %d to burn: %w", args.TokenId, err)
-	}
+	return c.burnSyntheticDevice(ctx, tx, args.TokenId, args.From)
+}
 
-	// The most important thing is to delete the database rows to free things up.
-	_, err = sd.Delete(ctx, c.db.DBS().Writer)
+func (c *ContractsEventsConsumer) handleVehicleTransfer(ctx context.Context, tx *EventTx, e *ContractEventData) error {
+	var args contracts.MultiPrivilegeTransfer
+	err := json.Unmarshal(e.Arguments, &args)
 	if err != nil {
-		return fmt.Errorf("failed to delete synthetic device %d row: %w", sd.TokenID, err)
+		return err
 	}
 
-	ud := sd.R.VehicleToken
-	if ud == nil {
-		return fmt.Errorf("burning synthetic device %d with no paired vehicle", sd.TokenID)
+	// Handle mints in the meta-transaction handler.
+	if IsZeroAddress(args.From) {
+		return nil
 	}
 
-	intID, _ := sd.IntegrationTokenID.Uint64()
-	integ, err := c.ddSvc.GetIntegrationByTokenID(ctx, intID)
-	if err != nil {
-		return err
+	if IsZeroAddress(args.To) {
+		return c.burnVehicle(ctx, tx, args.TokenId, args.From)
 	}
 
-	udai, err := models.FindUserDeviceAPIIntegration(ctx, c.db.DBS().Reader.DB, ud.ID, integ.Id)
+	return c.transferVehicle(ctx, tx, args.TokenId, args.From, args.To, e.TransactionHash)
+}
+
+// transferVehicle moves the vehicle to its new owner, clearing privileges, the previous
+// owner's geofences and, depending on the policy, their integrations.
+func (c *ContractsEventsConsumer) transferVehicle(ctx context.Context, tx *EventTx, tokenID *big.Int, from, to common.Address, txHash common.Hash) error {
+	rowsAff, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(tokenID)),
+	).DeleteAll(ctx, tx)
 	if err != nil {
-		return fmt.Errorf("failed to find job backing burned synthetic device %d: %w", sd.TokenID, err)
+		return err
 	}
 
-	_, err = udai.Delete(ctx, c.db.DBS().Writer)
-	if err != nil {
-		return fmt.Errorf("failed to delete job backing synthetic device %d: %w", sd.TokenID, err)
-	}
-
-	if udai.TaskID.Valid {
-		switch integ.Vendor {
-		case constants.SmartCarVendor:
-			err := c.scTask.StopPoll(udai)
-			if err != nil {
-				return err
-			}
-		case constants.TeslaVendor:
-			err := c.teslaTask.StopPoll(udai)
-			if err != nil {
-				return err
-			}
-		default:
-			c.log.Warn().Msgf("Unexpected integration %s.", integ.Vendor)
-		}
+	if rowsAff != 0 {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Cleared %d privileges upon vehicle transfer.", rowsAff)
 	}
 
-	// Need this for the event.
-	dd, err := c.ddSvc.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(tokenID)),
+	).One(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	err = c.evtSvc.Emit(&shared.CloudEvent[any]{
-		Type:    "com.dimo.zone.device.integration.delete",
-		Source:  "devices-api",
-		Subject: ud.ID,
-		Data: UserDeviceIntegrationEvent{
-			Timestamp: time.Now(),
-			UserID:    ud.UserID,
-			Device: UserDeviceEventDevice{
-				ID:           ud.ID,
-				Make:         dd.Make.Name,
-				Model:        dd.Model,
-				Year:         int(dd.Year),
-				VIN:          ud.VinIdentifier.String,
-				DefinitionID: dd.Id,
-			},
-			Integration: UserDeviceEventIntegration{
-				ID:     integ.Id,
-				Type:   integ.Type,
-				Style:  integ.Style,
-				Vendor: integ.Vendor,
-			},
-		},
-	})
+	// The previous owner's geofences no longer apply to the vehicle and, depending on
+	// the policy, neither do the integrations they authorized.
+	cleanup, err := PlanVehicleTransferCleanup(ctx, tx, ud.ID, c.settings.VehicleTransferPolicy)
 	if err != nil {
-		c.log.Info().Int64("syntheticDeviceTokenId", args.TokenId.Int64()).Str("owner", args.From.Hex()).Msg("Couldn't send out integration deletion event.")
+		return err
 	}
 
-	c.log.Info().Int64("syntheticDeviceTokenId", args.TokenId.Int64()).Str("owner", args.From.Hex()).Msg("Burned synthetic device.")
-
-	return nil
-}
-
-func (c *ContractsEventsConsumer) handleVehicleTransfer(ctx context.Context, e *ContractEventData) error {
-	var args contracts.MultiPrivilegeTransfer
-	err := json.Unmarshal(e.Arguments, &args)
+	running, err := cleanup.Apply(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	// Handle mints in the meta-transaction handler.
-	if IsZeroAddress(args.From) {
-		return nil
+	if len(cleanup.Geofences) != 0 {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Detached %d geofences upon vehicle transfer.", len(cleanup.Geofences))
 	}
 
-	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
-	if err != nil {
-		return err
+	if len(cleanup.Integrations) != 0 {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Wiped %d integrations upon vehicle transfer.", len(cleanup.Integrations))
 	}
-	defer tx.Rollback() //nolint
 
-	rowsAff, err := models.NFTPrivileges(
-		models.NFTPrivilegeWhere.TokenID.EQ(dbtypes.IntToDecimal(args.TokenId)),
-	).DeleteAll(ctx, tx)
+	// Faking a user id for a web3 user with the new owner address.
+	userID, err := addressToUserID(to)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to convert address to user id: %w", err)
 	}
 
-	if rowsAff != 0 {
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Msgf("Cleared %d privileges upon vehicle transfer.", rowsAff)
-	}
+	cols := models.UserDeviceColumns
+	ud.UserID = userID
+	ud.OwnerAddress = null.BytesFrom(to.Bytes())
 
-	ud, err := models.UserDevices(
-		models.UserDeviceWhere.TokenID.EQ(dbtypes.NullIntToDecimal(args.TokenId)),
-	).One(ctx, tx)
-	if err != nil {
+	if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress)); err != nil {
 		return err
 	}
 
-	if IsZeroAddress(args.To) {
-		_, err = ud.Delete(ctx, tx)
-		if err != nil {
-			return err
+	tx.AfterCommit(func() {
+		c.log.Info().Int64("vehicleTokenId", tokenID.Int64()).Msgf("Transferred vehicle from %s to %s.", from, to)
+
+		c.stopPolls(ctx, running)
+		if len(cleanup.Geofences) != 0 {
+			c.emitFences(ctx, ud.ID, ud.TokenID)
 		}
 
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Str("owner", args.From.Hex()).Msg("Burned vehicle.")
-	} else {
-		// Faking a user id for a web3 user with the new owner address.
-		userID, err := addressToUserID(args.To)
+		err := c.evtSvc.Emit(&shared.CloudEvent[any]{
+			Type:    "com.dimo.zone.device.transfer",
+			Source:  "devices-api",
+			Subject: ud.ID,
+			Data: UserDeviceTransferEvent{
+				Timestamp:    time.Now(),
+				UserID:       userID,
+				UserDeviceID: ud.ID,
+				NFT: UserDeviceEventNFT{
+					TokenID: tokenID,
+					Owner:   to,
+					TxHash:  txHash,
+				},
+				From:         from,
+				Policy:       cleanup.Policy,
+				Geofences:    cleanup.Geofences,
+				Integrations: cleanup.IntegrationIDs(),
+			},
+		})
 		if err != nil {
-			return fmt.Errorf("failed to convert address to user id: %w", err)
+			c.log.Err(err).Int64("vehicleTokenId", tokenID.Int64()).Msg("Couldn't send out vehicle transfer event.")
 		}
+	})
 
-		cols := models.UserDeviceColumns
-		ud.UserID = userID
-		ud.OwnerAddress = null.BytesFrom(args.To.Bytes())
+	return nil
+}
 
-		if _, err := ud.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress)); err != nil {
-			return err
+// stopPolls stops the tasks behind integrations that a committed transaction has wiped or
+// removed. Nothing is left in the database to retry from, so failures are only logged.
+func (c *ContractsEventsConsumer) stopPolls(ctx context.Context, udais models.UserDeviceAPIIntegrationSlice) {
+	for _, udai := range udais {
+		if err := c.stopPoll(ctx, udai); err != nil {
+			c.log.Err(err).Str("userDeviceId", udai.UserDeviceID).Str("integrationId", udai.IntegrationID).Str("taskId", udai.TaskID.String).Msg("Failed to stop polling.")
 		}
+	}
+}
+
+// emitFences republishes a vehicle's privacy fences after a committed change to its
+// geofences. As with stopPolls, failures are only logged.
+func (c *ContractsEventsConsumer) emitFences(ctx context.Context, userDeviceID string, tokenID types.NullDecimal) {
+	if c.fenceEmitter == nil {
+		return
+	}
+	if err := c.fenceEmitter(ctx, c.db.DBS().Writer, userDeviceID, tokenID); err != nil {
+		c.log.Err(err).Str("userDeviceId", userDeviceID).Msg("Failed to update privacy fences.")
+	}
+}
 
-		c.log.Info().Int64("vehicleTokenId", args.TokenId.Int64()).Msgf("Transferred vehicle from %s to %s.", args.From, args.To)
+// stopPoll stops the polling task behind a software integration.
+func (c *ContractsEventsConsumer) stopPoll(ctx context.Context, udai *models.UserDeviceAPIIntegration) error {
+	integ, err := c.ddSvc.GetIntegrationByID(ctx, udai.IntegrationID)
+	if err != nil {
+		return err
 	}
 
-	return tx.Commit()
+	switch integ.Vendor {
+	case constants.SmartCarVendor:
+		return c.scTask.StopPoll(udai)
+	case constants.TeslaVendor:
+		return c.teslaTask.StopPoll(udai)
+	default:
+		c.log.Warn().Msgf("Unexpected integration %s.", integ.Vendor)
+		return nil
+	}
 }
 
-func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEventData) error {
-	ctx := context.Background()
+func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.AftermarketDeviceIdTransfer
 	err := json.Unmarshal(e.Arguments, &args)
 	if err != nil {
@@ -386,7 +448,11 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 		return nil
 	}
 
-	apUnit, err := models.AftermarketDevices(models.AftermarketDeviceWhere.TokenID.EQ(tkID)).One(context.Background(), c.db.DBS().Reader)
+	if IsZeroAddress(args.To) {
+		return c.burnAftermarketDevice(ctx, tx, args.TokenId)
+	}
+
+	apUnit, err := models.AftermarketDevices(models.AftermarketDeviceWhere.TokenID.EQ(tkID)).One(ctx, tx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			c.log.Err(err).Str("tokenID", tkID.String()).Msg("record not found as this might be a newly minted device")
@@ -396,22 +462,6 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 		return errors.New("error occurred transferring device")
 	}
 
-	if IsZeroAddress(args.To) {
-		// Burn.
-		c.log.Info().Msgf("Burning aftermarket device %d.", tkID)
-		_, err := models.AutopiJobs(models.AutopiJobWhere.AutopiUnitID.EQ(null.StringFrom(apUnit.Serial))).DeleteAll(ctx, c.db.DBS().Writer)
-		if err != nil {
-			return fmt.Errorf("error deleting jobs associated with aftermarket device: %w", err)
-		}
-
-		_, err = apUnit.Delete(ctx, c.db.DBS().Writer)
-		if err != nil {
-			return fmt.Errorf("error deleting aftermarket device: %w", err)
-		}
-
-		return nil
-	}
-
 	if !apUnit.OwnerAddress.Valid {
 		c.log.Debug().Str("tokenID", tkID.String()).Msg("device has not been claimed yet")
 		return nil
@@ -423,7 +473,7 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 
 	cols := models.AftermarketDeviceColumns
 
-	if _, err = apUnit.Update(ctx, c.db.DBS().Writer, boil.Whitelist(cols.UserID, cols.OwnerAddress, cols.Beneficiary, cols.UpdatedAt)); err != nil {
+	if _, err = apUnit.Update(ctx, tx, boil.Whitelist(cols.UserID, cols.OwnerAddress, cols.Beneficiary, cols.UpdatedAt)); err != nil {
 		c.log.Err(err).Str("tokenID", tkID.String()).Msg("error occurred transferring device")
 		return errors.New("error occurred transferring device")
 	}
@@ -431,7 +481,7 @@ func (c *ContractsEventsConsumer) handleAfterMarketTransferEvent(e *ContractEven
 	return nil
 }
 
-func (c *ContractsEventsConsumer) setPrivilegeHandler(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) setPrivilegeHandler(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.MultiPrivilegeSetPrivilegeData
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return err
@@ -447,10 +497,11 @@ func (c *ContractsEventsConsumer) setPrivilegeHandler(e *ContractEventData) erro
 
 	cols := models.NFTPrivilegeColumns
 
-	return udp.Upsert(context.Background(), c.db.DBS().Writer, true, []string{cols.ContractAddress, cols.TokenID, cols.Privilege, cols.UserAddress}, boil.Whitelist(cols.Expiry, cols.UpdatedAt), boil.Infer())
+	// A new expiry deserves a new warning.
+	return udp.Upsert(ctx, tx, true, []string{cols.ContractAddress, cols.TokenID, cols.Privilege, cols.UserAddress}, boil.Whitelist(cols.Expiry, cols.ExpiryNotifiedAt, cols.UpdatedAt), boil.Infer())
 }
 
-func (c *ContractsEventsConsumer) setMintedAfterMarketDevice(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) setMintedAfterMarketDevice(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.RegistryAftermarketDeviceNodeMinted
 	err := json.Unmarshal(e.Arguments, &args)
 	if err != nil {
@@ -464,7 +515,7 @@ func (c *ContractsEventsConsumer) setMintedAfterMarketDevice(e *ContractEventDat
 		ManufacturerTokenID: utils.BigToDecimal(args.ManufacturerId),
 	}
 
-	if err := pad.Upsert(context.TODO(), c.db.DBS().Writer, false, []string{models.PartialAftermarketDeviceColumns.TokenID}, boil.Infer(), boil.Infer()); err != nil {
+	if err := pad.Upsert(ctx, tx, false, []string{models.PartialAftermarketDeviceColumns.TokenID}, boil.Infer(), boil.Infer()); err != nil {
 		return err
 	}
 
@@ -473,7 +524,7 @@ func (c *ContractsEventsConsumer) setMintedAfterMarketDevice(e *ContractEventDat
 	return nil
 }
 
-func (c *ContractsEventsConsumer) aftermarketDeviceClaimed(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) aftermarketDeviceClaimed(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket claim from unexpected source %d/%s", e.ChainID, e.Contract)
 	}
@@ -485,7 +536,7 @@ func (c *ContractsEventsConsumer) aftermarketDeviceClaimed(e *ContractEventData)
 
 	am, err := models.AftermarketDevices(
 		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
-	).One(context.TODO(), c.db.DBS().Reader)
+	).One(ctx, tx)
 	if err != nil {
 		return err
 	}
@@ -493,12 +544,12 @@ func (c *ContractsEventsConsumer) aftermarketDeviceClaimed(e *ContractEventData)
 	c.log.Info().Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Str("owner", args.Owner.Hex()).Msg("Claiming aftermarket device.")
 
 	am.OwnerAddress = null.BytesFrom(args.Owner.Bytes())
-	_, err = am.Update(context.TODO(), c.db.DBS().Writer, boil.Whitelist(models.AftermarketDeviceColumns.OwnerAddress))
+	_, err = am.Update(ctx, tx, boil.Whitelist(models.AftermarketDeviceColumns.OwnerAddress))
 
 	return err
 }
 
-func (c *ContractsEventsConsumer) aftermarketDevicePaired(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) aftermarketDevicePaired(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket claim from unexpected source %d/%s", e.ChainID, e.Contract)
 	}
@@ -508,30 +559,36 @@ func (c *ContractsEventsConsumer) aftermarketDevicePaired(e *ContractEventData)
 		return err
 	}
 
-	log := c.log.With().Int64("vehicleNode", args.VehicleNode.Int64()).Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Logger()
+	return c.pairAftermarketDevice(ctx, tx, args.AftermarketDeviceNode, args.VehicleNode)
+}
+
+// pairAftermarketDevice records the pairing in the database and sets up the integration
+// behind it.
+func (c *ContractsEventsConsumer) pairAftermarketDevice(ctx context.Context, tx *EventTx, aftermarketDeviceNode, vehicleNode *big.Int) error {
+	log := c.log.With().Int64("vehicleNode", vehicleNode.Int64()).Int64("aftermarketDeviceNode", aftermarketDeviceNode.Int64()).Logger()
 	log.Info().Msg("Pairing aftermarket device and vehicle.")
 
 	am, err := models.AftermarketDevices(
-		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
-	).One(context.TODO(), c.db.DBS().Reader)
+		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(aftermarketDeviceNode)),
+	).One(ctx, tx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve aftermarket device: %w", err)
 	}
 
 	cols := models.AftermarketDeviceColumns
 
-	am.VehicleTokenID = types.NewNullDecimal(utils.BigToDecimal(args.VehicleNode).Big)
-	_, err = am.Update(context.TODO(), c.db.DBS().Writer, boil.Whitelist(cols.VehicleTokenID, cols.UpdatedAt))
+	am.VehicleTokenID = types.NewNullDecimal(utils.BigToDecimal(vehicleNode).Big)
+	_, err = am.Update(ctx, tx, boil.Whitelist(cols.VehicleTokenID, cols.UpdatedAt))
 	if err != nil {
 		return fmt.Errorf("failed to update aftermarket device: %w", err)
 	}
 
-	return c.genericInt.Pair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode)
+	return c.genericInt.Pair(ctx, tx, aftermarketDeviceNode, vehicleNode)
 }
 
 // aftermarketDeviceAttributeSet handles the event of the same name from the registry contract.
 // At present this is only used to grab the serial number for Macarons AND AutoPi's
-func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	// TODO(elffjs): Stop repeating the next eight lines in every handler.
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket claim from unexpected source %d/%s", e.ChainID, e.Contract)
@@ -546,15 +603,9 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEvent
 		return nil
 	}
 
-	tx, err := c.db.DBS().Writer.BeginTx(context.TODO(), nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback() //nolint
-
 	pad, err := models.PartialAftermarketDevices(
 		models.PartialAftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.TokenId)),
-	).One(context.TODO(), tx)
+	).One(ctx, tx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
@@ -569,22 +620,22 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAttributeSet(e *ContractEvent
 		DeviceManufacturerTokenID: pad.ManufacturerTokenID,
 	}
 
-	err = ad.Upsert(context.TODO(), tx, false, []string{models.AftermarketDeviceColumns.EthereumAddress}, boil.Infer(), boil.Infer())
+	err = ad.Upsert(ctx, tx, false, []string{models.AftermarketDeviceColumns.EthereumAddress}, boil.Infer(), boil.Infer())
 	if err != nil {
 		return err
 	}
 
 	c.log.Info().Str("address", common.BytesToAddress(ad.EthereumAddress).Hex()).Msgf("Aftermarket device serial set to %s.", args.Info)
 
-	_, err = pad.Delete(context.TODO(), tx)
+	_, err = pad.Delete(ctx, tx)
 	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket claim from unexpected source %d/%s", e.ChainID, e.Contract)
 	}
@@ -594,11 +645,17 @@ func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData
 		return err
 	}
 
-	c.log.Info().Int64("vehicleNode", args.VehicleNode.Int64()).Int64("aftermarketDeviceNode", args.AftermarketDeviceNode.Int64()).Msg("Unpairing aftermarket device and vehicle.")
+	return c.unpairAftermarketDevice(ctx, tx, args.AftermarketDeviceNode, args.VehicleNode)
+}
+
+// unpairAftermarketDevice clears the pairing in the database and tears down the
+// integration that it created.
+func (c *ContractsEventsConsumer) unpairAftermarketDevice(ctx context.Context, tx *EventTx, aftermarketDeviceNode, vehicleNode *big.Int) error {
+	c.log.Info().Int64("vehicleNode", vehicleNode.Int64()).Int64("aftermarketDeviceNode", aftermarketDeviceNode.Int64()).Msg("Unpairing aftermarket device and vehicle.")
 
 	am, err := models.AftermarketDevices(
-		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.AftermarketDeviceNode)),
-	).One(context.TODO(), c.db.DBS().Reader)
+		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(aftermarketDeviceNode)),
+	).One(ctx, tx)
 	if err != nil {
 		return err
 	}
@@ -606,14 +663,14 @@ func (c *ContractsEventsConsumer) aftermarketDeviceUnpaired(e *ContractEventData
 	am.VehicleTokenID = types.NullDecimal{}
 	am.PairRequestID = null.String{}
 
-	if _, err := am.Update(context.TODO(), c.db.DBS().Writer, boil.Whitelist(models.AftermarketDeviceColumns.VehicleTokenID, models.AftermarketDeviceColumns.PairRequestID)); err != nil {
+	if _, err := am.Update(ctx, tx, boil.Whitelist(models.AftermarketDeviceColumns.VehicleTokenID, models.AftermarketDeviceColumns.PairRequestID)); err != nil {
 		return err
 	}
 
-	return c.genericInt.Unpair(context.TODO(), args.AftermarketDeviceNode, args.VehicleNode)
+	return c.genericInt.Unpair(ctx, tx, aftermarketDeviceNode, vehicleNode)
 }
 
-func (c *ContractsEventsConsumer) beneficiarySet(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) beneficiarySet(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.RegistryBeneficiarySet
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return err
@@ -628,7 +685,7 @@ func (c *ContractsEventsConsumer) beneficiarySet(e *ContractEventData) error {
 
 	device, err := models.AftermarketDevices(
 		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(args.NodeId)),
-	).One(context.Background(), c.db.DBS().Reader)
+	).One(ctx, tx)
 	if err != nil {
 		return err
 	}
@@ -641,7 +698,7 @@ func (c *ContractsEventsConsumer) beneficiarySet(e *ContractEventData) error {
 		device.Beneficiary = null.BytesFrom(args.Beneficiary[:])
 	}
 
-	if _, err = device.Update(context.Background(), c.db.DBS().Writer, boil.Whitelist(cols.Beneficiary, cols.UpdatedAt)); err != nil {
+	if _, err = device.Update(ctx, tx, boil.Whitelist(cols.Beneficiary, cols.UpdatedAt)); err != nil {
 		c.log.Error().Err(err).Msg("Failed to set beneficiary.")
 		return err
 	}
@@ -650,13 +707,13 @@ func (c *ContractsEventsConsumer) beneficiarySet(e *ContractEventData) error {
 }
 
 // dcnNameChanged processes an event of type NameChanged. Upserts DCN record, setting the Name
-func (c *ContractsEventsConsumer) dcnNameChanged(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) dcnNameChanged(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args DCNNameChangedContract
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return err
 	}
 	// see if it exists first
-	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(context.Background(), c.db.DBS().Reader)
+	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(ctx, tx)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return errors.Wrap(err, "failed to query for existing dcn")
 	}
@@ -671,7 +728,7 @@ func (c *ContractsEventsConsumer) dcnNameChanged(e *ContractEventData) error {
 	}
 	dcn.Name = null.StringFrom(args.Name)
 
-	err = dcn.Upsert(context.Background(), c.db.DBS().Writer, true, []string{models.DCNColumns.NFTNodeID},
+	err = dcn.Upsert(ctx, tx, true, []string{models.DCNColumns.NFTNodeID},
 		boil.Whitelist(models.DCNColumns.Name, models.DCNColumns.UpdatedAt), boil.Infer())
 	if err != nil {
 		return errors.Wrapf(err, "failed to upsert dcn with name: %s", args.Name)
@@ -681,13 +738,13 @@ func (c *ContractsEventsConsumer) dcnNameChanged(e *ContractEventData) error {
 }
 
 // dcnNewNode processes an event of type NewNode. Upserts DCN record, setting the Owner Address and Block creation time
-func (c *ContractsEventsConsumer) dcnNewNode(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) dcnNewNode(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.DcnRegistryNewNode
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return err
 	}
 	//question: should this be an insert always?
-	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(context.Background(), c.db.DBS().Reader)
+	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(ctx, tx)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return errors.Wrap(err, "failed to query for existing dcn")
 	}
@@ -699,7 +756,7 @@ func (c *ContractsEventsConsumer) dcnNewNode(e *ContractEventData) error {
 	dcn.OwnerAddress = null.BytesFrom(args.Owner.Bytes())
 	dcn.NFTNodeBlockCreateTime = null.TimeFrom(e.Block.Time)
 
-	err = dcn.Upsert(context.Background(), c.db.DBS().Writer, true, []string{models.DCNColumns.NFTNodeID},
+	err = dcn.Upsert(ctx, tx, true, []string{models.DCNColumns.NFTNodeID},
 		boil.Whitelist(models.DCNColumns.OwnerAddress, models.DCNColumns.NFTNodeBlockCreateTime, models.DCNColumns.UpdatedAt), boil.Infer())
 	if err != nil {
 		return errors.Wrapf(err, "failed to upsert dcn with node: %s", args.Node)
@@ -709,12 +766,12 @@ func (c *ContractsEventsConsumer) dcnNewNode(e *ContractEventData) error {
 }
 
 // dcnNewExpiration processes an event of type NewExpiration. Upserts DCN record, setting the Expiration
-func (c *ContractsEventsConsumer) dcnNewExpiration(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) dcnNewExpiration(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	var args contracts.DcnRegistryNewExpiration
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return err
 	}
-	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(context.Background(), c.db.DBS().Reader)
+	dcn, err := models.DCNS(models.DCNWhere.NFTNodeID.EQ(args.Node[:])).One(ctx, tx)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return errors.Wrap(err, "failed to query for existing dcn")
 	}
@@ -726,7 +783,7 @@ func (c *ContractsEventsConsumer) dcnNewExpiration(e *ContractEventData) error {
 	t := time.Unix(args.Expiration.Int64(), 0)
 	dcn.Expiration = null.TimeFrom(t)
 
-	err = dcn.Upsert(context.Background(), c.db.DBS().Writer, true, []string{models.DCNColumns.NFTNodeID},
+	err = dcn.Upsert(ctx, tx, true, []string{models.DCNColumns.NFTNodeID},
 		boil.Whitelist(models.DCNColumns.Expiration, models.DCNColumns.UpdatedAt), boil.Infer())
 	if err != nil {
 		return errors.Wrapf(err, "failed to upsert dcn with node: %s", args.Node)
@@ -736,7 +793,7 @@ func (c *ContractsEventsConsumer) dcnNewExpiration(e *ContractEventData) error {
 }
 
 // aftermarketDeviceAddressReset handles the event of the same name from the registry contract.
-func (c *ContractsEventsConsumer) aftermarketDeviceAddressReset(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) aftermarketDeviceAddressReset(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("aftermarket device address reset from unexpected source %d/%s", e.ChainID, e.Contract)
 	}
@@ -746,7 +803,7 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAddressReset(e *ContractEvent
 		return err
 	}
 
-	_, err := c.db.DBS().Writer.Exec(
+	_, err := tx.ExecContext(ctx,
 		`UPDATE devices_api.aftermarket_devices 
 		SET ethereum_address = decode($1, 'hex')
 		WHERE token_id = $2;`,
@@ -755,12 +812,11 @@ func (c *ContractsEventsConsumer) aftermarketDeviceAddressReset(e *ContractEvent
 	return err
 }
 
-func (c *ContractsEventsConsumer) vehicleNodeMintedWithDeviceDefinition(e *ContractEventData) error {
+func (c *ContractsEventsConsumer) vehicleNodeMintedWithDeviceDefinition(ctx context.Context, tx *EventTx, e *ContractEventData) error {
 	if e.ChainID != c.settings.DIMORegistryChainID || e.Contract != common.HexToAddress(c.settings.DIMORegistryAddr) {
 		return fmt.Errorf("vehicle mint from unexpected source %d/%s", e.ChainID, e.Contract)
 	}
 
-	ctx := context.Background()
 	var args contracts.RegistryVehicleNodeMintedWithDeviceDefinition
 	if err := json.Unmarshal(e.Arguments, &args); err != nil {
 		return fmt.Errorf("failed to unmarshal arguments from mint event: %w", err)
@@ -769,12 +825,6 @@ func (c *ContractsEventsConsumer) vehicleNodeMintedWithDeviceDefinition(e *Contr
 	log := c.log.With().Int64("vehicleNode", args.VehicleId.Int64()).Int64("manufacturerId", args.ManufacturerId.Int64()).Str("deviceDefinitionId", args.DeviceDefinitionId).Logger()
 	log.Info().Msg("Minting vehicle with device definition")
 
-	tx, err := c.db.DBS().Writer.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to start transaction: %w", err)
-	}
-	defer tx.Rollback() //nolint
-
 	if check, err := models.MetaTransactionRequests(
 		models.MetaTransactionRequestWhere.Hash.EQ(null.BytesFrom(e.TransactionHash.Bytes())),
 	).Exists(ctx, tx); err != nil {
@@ -816,29 +866,30 @@ func (c *ContractsEventsConsumer) vehicleNodeMintedWithDeviceDefinition(e *Contr
 		return fmt.Errorf("failed to insert new user device: %w", err)
 	}
 
-	c.evtSvc.Emit(&shared.CloudEvent[any]{ //nolint
-		Type:    "com.dimo.zone.device.mint",
-		Subject: ud.ID,
-		Source:  "devices-api",
-		Data: UserDeviceMintEvent{
-			Timestamp: time.Now(),
-			UserID:    ud.UserID,
-			Device: UserDeviceEventDevice{
-				ID:                 ud.ID,
-				VIN:                ud.VinIdentifier.String,
-				DeviceDefinitionID: ud.DeviceDefinitionID,
-				DefinitionID:       dDef.Id,
+	tx.AfterCommit(func() {
+		c.evtSvc.Emit(&shared.CloudEvent[any]{ //nolint
+			Type:    "com.dimo.zone.device.mint",
+			Subject: ud.ID,
+			Source:  "devices-api",
+			Data: UserDeviceMintEvent{
+				Timestamp: time.Now(),
+				UserID:    ud.UserID,
+				Device: UserDeviceEventDevice{
+					ID:                 ud.ID,
+					VIN:                ud.VinIdentifier.String,
+					DeviceDefinitionID: ud.DeviceDefinitionID,
+					DefinitionID:       dDef.Id,
+				},
+				NFT: UserDeviceEventNFT{
+					TokenID: args.VehicleId,
+					Owner:   args.Owner,
+					TxHash:  e.TransactionHash,
+				},
 			},
-			NFT: UserDeviceEventNFT{
-				TokenID: args.VehicleId,
-				Owner:   args.Owner,
-				TxHash:  e.TransactionHash,
-			},
-		},
+		})
 	})
 
-	return tx.Commit()
-
+	return nil
 }
 
 // DCNNameChangedContract represents a NameChanged event raised by the FullAbi contract.
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -90,7 +91,7 @@ func TestProcessContractsEventsMessages(t *testing.T) {
 	e := privilegeEventsPayloadFactory(1, 1, "", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	s.require.NoError(err)
@@ -133,7 +134,7 @@ func TestIgnoreWrongEventNames(t *testing.T) {
 	e := privilegeEventsPayloadFactory(2, 2, "SomeEvent", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -160,7 +161,7 @@ func TestUpdatedTimestamp(t *testing.T) {
 	e := privilegeEventsPayloadFactory(3, 3, "", 0, s.settings.DIMORegistryChainID)
 	factoryResp := e[0]
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -235,7 +236,7 @@ func Test_Transfer_Event_Handled_Correctly(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
 
@@ -274,7 +275,7 @@ func Test_Ignore_Transfer_Mint_Event(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -309,7 +310,7 @@ func Test_Ignore_Transfer_Claims_Event(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
 
@@ -343,7 +344,7 @@ func Test_Ignore_Transfer_Wrong_Contract(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 	event, err := marshalMockPayload(factoryResp.payload)
 	require.NoError(t, err)
@@ -376,7 +377,7 @@ func Test_Ignore_Transfer_Unit_Not_Found(t *testing.T) {
 	err := autopiUnit.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+	c := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 	event, err := marshalMockPayload(factoryResp.payload)
 	s.require.NoError(err)
 
@@ -486,7 +487,7 @@ func TestSetBeneficiary(t *testing.T) {
 		"source": "chain/%d"
 		}`, c.Address.Hex(), abi.Events["BeneficiarySet"].ID, c.Event.NodeId, c.Event.Beneficiary.Hex(), c.Event.IdProxyAddress.Hex(), s.settings.DIMORegistryChainID)
 
-		consumer := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil)
+		consumer := NewContractsEventsConsumer(s.pdb, &s.logger, s.settings, nil, nil, nil, nil, nil, nil)
 
 		event, err := marshalMockPayload(payload)
 		require.NoError(t, err)
@@ -523,7 +524,10 @@ func TestVehicleTransfer(t *testing.T) {
 	}
 	_ = ud.Insert(ctx, pdb.DBS().Writer, boil.Infer())
 
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil, nil)
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageAndSucceed()
+	evt := NewEventService(&logger, settings, kprod)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, evt, nil, nil, nil)
 	event, err := marshalMockPayload(`
 	{
 		"type": "zone.dimo.contract.event",
@@ -589,7 +593,10 @@ func Test_NFTPrivileges_Cleared_On_Vehicle_Transfer(t *testing.T) {
 	}
 	_ = ud.Insert(ctx, pdb.DBS().Writer, boil.Infer())
 
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil, nil)
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageAndSucceed()
+	evt := NewEventService(&logger, settings, kprod)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, evt, nil, nil, nil)
 	event, err := marshalMockPayload(`
 	{
 		"type": "zone.dimo.contract.event",
@@ -626,6 +633,179 @@ func Test_NFTPrivileges_Cleared_On_Vehicle_Transfer(t *testing.T) {
 	require.Equal(0, len(nftPrivileges))
 }
 
+func Test_Geofences_Detached_On_Vehicle_Transfer(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	require := require.New(t)
+	logger := zerolog.Nop()
+	settings := &config.Settings{DIMORegistryChainID: 1, VehicleNFTAddress: "0x881d40237659c251811cec9c364ef91dc08d300c"}
+
+	ud := models.UserDevice{
+		ID:                 ksuid.New().String(),
+		UserID:             "oldOwner",
+		OwnerAddress:       null.BytesFrom(common.FromHex("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")),
+		TokenID:            types.NewNullDecimal(decimal.New(5, 0)),
+		DeviceDefinitionID: ksuid.New().String(),
+	}
+	require.NoError(ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	gf := test.SetupCreateGeofence(t, ud.UserID, "Home", &ud, pdb)
+
+	var cleared []string
+	emitter := func(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, tokenID types.NullDecimal) error {
+		// The emitter should only run once the fences are detached for good.
+		n, err := models.UserDeviceToGeofences(models.UserDeviceToGeofenceWhere.UserDeviceID.EQ(userDeviceID)).Count(ctx, pdb.DBS().Reader)
+		require.NoError(err)
+		require.Zero(n)
+		cleared = append(cleared, userDeviceID, tokenID.String())
+		return nil
+	}
+
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageAndSucceed()
+	evt := NewEventService(&logger, settings, kprod)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, evt, nil, nil, emitter)
+	event, err := marshalMockPayload(`
+	{
+		"type": "zone.dimo.contract.event",
+		"source": "chain/1",
+		"data": {
+			"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+			"eventName": "Transfer",
+			"arguments": {
+				"from": "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+				"to": "0x4675c7e5baafbffbca748158becba61ef3b0a263",
+				"tokenId": 5
+			}
+		}
+	}
+	`)
+	require.NoError(err)
+
+	require.NoError(consumer.processEvent(ctx, event))
+	require.Equal([]string{ud.ID, "5"}, cleared)
+
+	// The fence still belongs to the previous owner.
+	exists, err := models.GeofenceExists(ctx, pdb.DBS().Reader, gf.ID)
+	require.NoError(err)
+	require.True(exists)
+
+	n, err := models.UserDeviceToGeofences().Count(ctx, pdb.DBS().Reader)
+	require.NoError(err)
+	require.Zero(n)
+}
+
+func Test_Integrations_Wiped_On_Vehicle_Transfer(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	require := require.New(t)
+	logger := zerolog.Nop()
+	settings := &config.Settings{
+		DIMORegistryChainID:   1,
+		VehicleNFTAddress:     "0x881d40237659c251811cec9c364ef91dc08d300c",
+		VehicleTransferPolicy: VehicleTransferPolicyWipe,
+	}
+
+	ud := models.UserDevice{
+		ID:                 ksuid.New().String(),
+		UserID:             "oldOwner",
+		OwnerAddress:       null.BytesFrom(common.FromHex("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")),
+		TokenID:            types.NewNullDecimal(decimal.New(5, 0)),
+		DeviceDefinitionID: ksuid.New().String(),
+	}
+	require.NoError(ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	scInteg := &ddgrpc.Integration{Id: ksuid.New().String(), Vendor: constants.SmartCarVendor}
+	scUDAI := models.UserDeviceAPIIntegration{
+		UserDeviceID:  ud.ID,
+		IntegrationID: scInteg.Id,
+		Status:        models.UserDeviceAPIIntegrationStatusActive,
+		AccessToken:   null.StringFrom("access"),
+		RefreshToken:  null.StringFrom("refresh"),
+		TaskID:        null.StringFrom(ksuid.New().String()),
+		ExternalID:    null.StringFrom("smartcar-vehicle"),
+	}
+	require.NoError(scUDAI.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	// No credentials, so this one stays with the vehicle.
+	apUDAI := test.SetupCreateUserDeviceAPIIntegration(t, "", "", ud.ID, ksuid.New().String(), pdb)
+
+	ecq := models.ErrorCodeQuery{
+		ID:           ksuid.New().String(),
+		UserDeviceID: ud.ID,
+	}
+	require.NoError(ecq.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+	scTask := NewMockSyntheticTaskService(mockCtrl)
+
+	deviceDefSvc.EXPECT().GetIntegrationByID(gomock.Any(), scInteg.Id).Return(scInteg, nil)
+	scTask.EXPECT().StopPoll(gomock.Any()).DoAndReturn(func(udai *models.UserDeviceAPIIntegration) error {
+		require.Equal(scUDAI.TaskID, udai.TaskID)
+		// Polling stops only after the wipe has committed.
+		stored, err := models.FindUserDeviceAPIIntegration(ctx, pdb.DBS().Reader, udai.UserDeviceID, udai.IntegrationID)
+		require.NoError(err)
+		require.False(stored.TaskID.Valid)
+		return nil
+	})
+
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageWithCheckerFunctionAndSucceed(func(val []byte) error {
+		var ce shared.CloudEvent[UserDeviceTransferEvent]
+		if err := json.Unmarshal(val, &ce); err != nil {
+			return err
+		}
+		if ce.Type != "com.dimo.zone.device.transfer" {
+			return fmt.Errorf("unexpected event type %s", ce.Type)
+		}
+		if ce.Data.Policy != VehicleTransferPolicyWipe || len(ce.Data.Integrations) != 1 || ce.Data.Integrations[0] != scInteg.Id {
+			return fmt.Errorf("unexpected event data %+v", ce.Data)
+		}
+		return nil
+	})
+	evt := NewEventService(&logger, settings, kprod)
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, scTask, nil, nil)
+	event, err := marshalMockPayload(`
+	{
+		"type": "zone.dimo.contract.event",
+		"source": "chain/1",
+		"data": {
+			"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+			"eventName": "Transfer",
+			"arguments": {
+				"from": "0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5",
+				"to": "0x4675c7e5baafbffbca748158becba61ef3b0a263",
+				"tokenId": 5
+			}
+		}
+	}
+	`)
+	require.NoError(err)
+
+	require.NoError(consumer.processEvent(ctx, event))
+
+	require.NoError(scUDAI.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(models.UserDeviceAPIIntegrationStatusAuthenticationFailure, scUDAI.Status)
+	require.False(scUDAI.AccessToken.Valid)
+	require.False(scUDAI.RefreshToken.Valid)
+	require.False(scUDAI.TaskID.Valid)
+
+	require.NoError(apUDAI.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(models.UserDeviceAPIIntegrationStatusActive, apUDAI.Status)
+
+	n, err := models.ErrorCodeQueries().Count(ctx, pdb.DBS().Reader)
+	require.NoError(err)
+	require.Zero(n)
+}
+
 func Test_RegistryAftermarketDeviceAddressReset(t *testing.T) {
 	ctx := context.Background()
 
@@ -668,7 +848,7 @@ func Test_RegistryAftermarketDeviceAddressReset(t *testing.T) {
 	err := amd.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer())
 	s.require.NoError(err)
 
-	consumer := NewContractsEventsConsumer(s.pdb, &logger, s.settings, nil, nil, nil, nil, nil)
+	consumer := NewContractsEventsConsumer(s.pdb, &logger, s.settings, nil, nil, nil, nil, nil, nil)
 	event, err := marshalMockPayload(payload)
 	require.NoError(t, err)
 
@@ -798,7 +978,7 @@ func Test_VehicleNodeMintedWithDeviceDefinition_NoMtx(t *testing.T) {
 	kprod := smock.NewSyncProducer(t, nil)
 	evt := NewEventService(&logger, settings, kprod)
 	kprod.ExpectSendMessageAndSucceed()
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, nil, nil)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, nil, nil, nil)
 
 	owner := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
 	ddSlug := "jeep_wrangler_2013"
@@ -930,7 +1110,7 @@ func TestBurnSyntheticDevice(t *testing.T) {
 	kprod := smock.NewSyncProducer(t, nil)
 	evt := NewEventService(&logger, settings, kprod)
 	kprod.ExpectSendMessageAndSucceed()
-	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, nil, teslaTask)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, nil, teslaTask, nil)
 
 	ownerAddr := randomAddr(t)
 
@@ -959,6 +1139,57 @@ func TestBurnSyntheticDevice(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNoRows)
 }
 
+func TestBurnSyntheticDeviceKeepsRowsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sdAddr := randomAddr(t)
+	settings := &config.Settings{DIMORegistryChainID: 1, SyntheticDeviceNFTAddress: sdAddr.Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+
+	mtr := models.MetaTransactionRequest{ID: ksuid.New().String(), Status: models.MetaTransactionRequestStatusConfirmed}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ud := models.UserDevice{
+		ID:                 ksuid.New().String(),
+		UserID:             "xdd",
+		DeviceDefinitionID: ksuid.New().String(),
+		TokenID:            types.NewNullDecimal(decimal.New(54, 0)),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	sd := models.SyntheticDevice{
+		VehicleTokenID:     ud.TokenID,
+		IntegrationTokenID: types.NewDecimal(decimal.New(2, 0)),
+		MintRequestID:      mtr.ID,
+		WalletChildNumber:  1,
+		WalletAddress:      randomAddr(t).Bytes(),
+		TokenID:            types.NewNullDecimal(decimal.New(4, 0)),
+	}
+	require.NoError(t, sd.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	deviceDefSvc.EXPECT().GetIntegrationByTokenID(gomock.Any(), uint64(2)).Return(nil, errors.New("device definitions unavailable"))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, nil, nil, nil, nil)
+
+	b, _ := json.Marshal(ContractEventData{
+		ChainID:   1,
+		EventName: "Transfer",
+		Contract:  sdAddr,
+		Arguments: []byte(fmt.Sprintf(`{"from": "%s", "to": "%s", "tokenId": 4}`, randomAddr(t), zeroAddr)),
+	})
+
+	require.Error(t, consumer.processEvent(ctx, &shared.CloudEvent[json.RawMessage]{Source: "chain/1", Type: contractEventCEType, Data: b}))
+
+	// Still there for the retry.
+	require.NoError(t, sd.Reload(ctx, pdb.DBS().Reader))
+}
+
 func initCEventsTestHelper(t *testing.T) cEventsTestHelper {
 	ctx := context.Background()
 	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
@@ -989,3 +1220,110 @@ func randomAddr(t *testing.T) common.Address {
 	}
 	return common.Address(addr)
 }
+
+func TestVehicleNodeBurned(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	registryAddr := randomAddr(t)
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: registryAddr.Hex()}
+	deviceDefSvc := NewMockDeviceDefinitionService(mockCtrl)
+
+	ud := models.UserDevice{
+		ID:           ksuid.New().String(),
+		UserID:       "xdd",
+		DefinitionID: "ford_escape_2021",
+		TokenID:      types.NewNullDecimal(decimal.New(61, 0)),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	priv := models.NFTPrivilege{
+		ContractAddress: randomAddr(t).Bytes(),
+		TokenID:         types.NewDecimal(decimal.New(61, 0)),
+		Privilege:       1,
+		UserAddress:     randomAddr(t).Bytes(),
+		Expiry:          time.Now().Add(time.Hour),
+	}
+	require.NoError(t, priv.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	deviceDefSvc.EXPECT().GetDeviceDefinitionBySlug(gomock.Any(), ud.DefinitionID).Return(&ddgrpc.GetDeviceDefinitionItemResponse{
+		Id:    ud.DefinitionID,
+		Make:  &ddgrpc.DeviceMake{Name: "Ford"},
+		Model: "Escape",
+		Year:  2021,
+	}, nil)
+
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageAndSucceed()
+	evt := NewEventService(&logger, settings, kprod)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, deviceDefSvc, evt, nil, nil, nil)
+
+	b, _ := json.Marshal(ContractEventData{
+		ChainID:   1,
+		EventName: "VehicleNodeBurnedDevAdmin",
+		Contract:  registryAddr,
+		Arguments: []byte(fmt.Sprintf(`{"vehicleNode": 61, "owner": "%s"}`, randomAddr(t))),
+	})
+	event := &shared.CloudEvent[json.RawMessage]{Source: "chain/1", Type: contractEventCEType, Data: b}
+
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.ErrorIs(t, ud.Reload(ctx, pdb.DBS().Reader), sql.ErrNoRows)
+	n, err := models.NFTPrivileges().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// The NFT's own Transfer to the zero address finds nothing left to do.
+	require.NoError(t, consumer.processEvent(ctx, event))
+}
+
+func TestAftermarketDeviceUnclaimedDevAdmin(t *testing.T) {
+	ctx := context.Background()
+	logger := zerolog.Nop()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	registryAddr := randomAddr(t)
+	settings := &config.Settings{DIMORegistryChainID: 1, DIMORegistryAddr: registryAddr.Hex()}
+
+	ad := models.AftermarketDevice{
+		Serial:          "unclaim-me",
+		EthereumAddress: randomAddr(t).Bytes(),
+		TokenID:         types.NewDecimal(decimal.New(17, 0)),
+		UserID:          null.StringFrom("xdd"),
+		OwnerAddress:    null.BytesFrom(randomAddr(t).Bytes()),
+		Beneficiary:     null.BytesFrom(randomAddr(t).Bytes()),
+	}
+	require.NoError(t, ad.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, nil, nil, nil, nil)
+
+	b, _ := json.Marshal(ContractEventData{
+		ChainID:   1,
+		EventName: "AftermarketDeviceUnclaimedDevAdmin",
+		Contract:  registryAddr,
+		Arguments: []byte(`{"aftermarketDeviceNode": 17}`),
+	})
+
+	require.NoError(t, consumer.processEvent(ctx, &shared.CloudEvent[json.RawMessage]{Source: "chain/1", Type: contractEventCEType, Data: b}))
+
+	require.NoError(t, ad.Reload(ctx, pdb.DBS().Reader))
+	require.False(t, ad.OwnerAddress.Valid)
+	require.False(t, ad.UserID.Valid)
+	require.False(t, ad.Beneficiary.Valid)
+
+	// A device we don't have is nothing to do.
+	b, _ = json.Marshal(ContractEventData{
+		ChainID:   1,
+		EventName: "AftermarketDeviceUnclaimedDevAdmin",
+		Contract:  registryAddr,
+		Arguments: []byte(`{"aftermarketDeviceNode": 18}`),
+	})
+
+	require.NoError(t, consumer.processEvent(ctx, &shared.CloudEvent[json.RawMessage]{Source: "chain/1", Type: contractEventCEType, Data: b}))
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+var unledgeredContractEvents = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "devices_api",
+		Subsystem: "contract_events",
+		Name:      "unledgered_total",
+		Help:      "Contract events handled without a ledger entry because they had no log position, by event name.",
+	},
+	[]string{"event"},
+)
+
+var lastProcessedBlock = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "devices_api",
+		Subsystem: "contract_events",
+		Name:      "last_processed_block",
+		Help:      "Highest block number of a contract event that has been handled, by contract.",
+	},
+	[]string{"contract"},
+)
+
+// processOnce handles an event exactly once. The ledger row goes in first, in the same
+// transaction as the handler's writes, so the two commit or roll back together. A
+// redelivery that arrives while the first delivery is in flight waits on the row, and
+// then skips the event if it committed. Messages and other side effects are queued on the
+// transaction and only go out once it commits.
+//
+// Events that change an ownership or a pairing are also checked against the latest
+// event applied for the same token. If that one is later in the chain, the event is
+// recorded as stale and not applied. Events for the same token are serialized by an
+// advisory lock on its key, so the check can't miss one that is being applied alongside.
+func (c *ContractsEventsConsumer) processOnce(ctx context.Context, e *ContractEventData) error {
+	key, err := c.contractEventEntityKey(e)
+	if err != nil {
+		return err
+	}
+
+	pce := models.ProcessedContractEvent{
+		ChainID:         e.ChainID,
+		BlockNumber:     e.Block.Number.Int64(),
+		TransactionHash: e.TransactionHash.Bytes(),
+		LogIndex:        int(*e.Index),
+		Contract:        e.Contract.Bytes(),
+		EventName:       e.EventName,
+		EntityKey:       null.NewString(key, key != ""),
+	}
+
+	logger := c.log.With().Str("event", e.EventName).Str("transactionHash", e.TransactionHash.Hex()).Uint("logIndex", *e.Index).Logger()
+
+	tx, err := BeginEventTx(ctx, c.db.DBS())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if key != "" {
+		if _, err := queries.Raw(`SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, key).ExecContext(ctx, tx); err != nil {
+			return fmt.Errorf("failed to lock %s: %w", key, err)
+		}
+
+		later, err := models.ProcessedContractEvents(
+			models.ProcessedContractEventWhere.EntityKey.EQ(pce.EntityKey),
+			models.ProcessedContractEventWhere.Stale.EQ(false),
+			qm.Where("("+models.ProcessedContractEventColumns.BlockNumber+", "+models.ProcessedContractEventColumns.LogIndex+") > (?, ?)", pce.BlockNumber, pce.LogIndex),
+		).Exists(ctx, tx)
+		if err != nil {
+			return err
+		}
+		pce.Stale = later
+	}
+
+	res, err := queries.Raw(`INSERT INTO devices_api.processed_contract_events (chain_id, block_number, transaction_hash, log_index, contract, event_name, entity_key, stale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING`,
+		pce.ChainID, pce.BlockNumber, pce.TransactionHash, pce.LogIndex, pce.Contract, pce.EventName, pce.EntityKey, pce.Stale,
+	).ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		logger.Info().Msg("Skipping contract event that was already processed.")
+		return nil
+	}
+
+	if pce.Stale {
+		logger.Warn().Str("entityKey", key).Msg("Not applying contract event; a later one for the same token has been.")
+	} else if err := c.handleEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	c.observeBlock(e.Contract, e.Block.Number)
+
+	return nil
+}
+
+// processUnledgered handles an event from an older producer that doesn't send the log
+// position. There is nothing to key the ledger on, so a redelivery is handled again. The
+// handler's writes still commit together, with side effects after.
+func (c *ContractsEventsConsumer) processUnledgered(ctx context.Context, e *ContractEventData) error {
+	unledgeredContractEvents.WithLabelValues(e.EventName).Inc()
+	c.log.Warn().Str("event", e.EventName).Str("transactionHash", e.TransactionHash.Hex()).Msg("Contract event has no log position, so redeliveries of it can't be detected.")
+
+	tx, err := BeginEventTx(ctx, c.db.DBS())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := c.handleEvent(ctx, tx, e); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// observeBlock moves the gauge for the contract forward. Partitions are consumed in
+// parallel, so events don't arrive in block order across them.
+func (c *ContractsEventsConsumer) observeBlock(contract common.Address, block *big.Int) {
+	n := block.Int64()
+
+	c.lastBlockMu.Lock()
+	defer c.lastBlockMu.Unlock()
+
+	if c.lastBlock == nil {
+		c.lastBlock = make(map[common.Address]int64)
+	}
+
+	if n <= c.lastBlock[contract] {
+		return
+	}
+
+	c.lastBlock[contract] = n
+	lastProcessedBlock.WithLabelValues(contract.Hex()).Set(float64(n))
+}
+
+// contractEventNodes picks out the token ids from the arguments of any event that
+// changes an ownership or a pairing. Names differ between events, hence the spread.
+type contractEventNodes struct {
+	TokenId               *big.Int
+	VehicleNode           *big.Int
+	AftermarketDeviceNode *big.Int
+	SyntheticDeviceNode   *big.Int
+	AdNode                *big.Int
+}
+
+// contractEventEntityKey returns the ownership or pairing that the event changes, or the
+// empty string if its order doesn't matter.
+func (c *ContractsEventsConsumer) contractEventEntityKey(e *ContractEventData) (string, error) {
+	var entity, aspect string
+	var node func(n *contractEventNodes) *big.Int
+
+	switch EventName(e.EventName) {
+	case Transfer:
+		aspect = "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.TokenId }
+		switch e.Contract {
+		case common.HexToAddress(c.settings.VehicleNFTAddress):
+			entity = ChainEntityVehicle
+		case common.HexToAddress(c.settings.AftermarketDeviceContractAddress):
+			entity = ChainEntityAftermarketDevice
+		case common.HexToAddress(c.settings.SyntheticDeviceNFTAddress):
+			entity = ChainEntitySyntheticDevice
+		default:
+			return "", nil
+		}
+	case VehicleNodeBurned, VehicleNodeBurnedDevAdmin:
+		entity, aspect = ChainEntityVehicle, "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.VehicleNode }
+	case SyntheticDeviceNodeBurned, SyntheticDeviceNodeBurnedDevAdmin:
+		entity, aspect = ChainEntitySyntheticDevice, "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.SyntheticDeviceNode }
+	case AftermarketDeviceNodeBurned:
+		entity, aspect = ChainEntityAftermarketDevice, "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.TokenId }
+	case AftermarketDeviceNodeBurnedDevAdmin:
+		entity, aspect = ChainEntityAftermarketDevice, "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.AdNode }
+	case AftermarketDeviceClaimed, AftermarketDeviceTransferredDevAdmin, AftermarketDeviceUnclaimedDevAdmin:
+		entity, aspect = ChainEntityAftermarketDevice, "owner"
+		node = func(n *contractEventNodes) *big.Int { return n.AftermarketDeviceNode }
+	case AftermarketDevicePaired, AftermarketDeviceUnpaired, AftermarketDeviceUnpairedDevAdmin:
+		entity, aspect = ChainEntityAftermarketDevice, "pairing"
+		node = func(n *contractEventNodes) *big.Int { return n.AftermarketDeviceNode }
+	default:
+		return "", nil
+	}
+
+	var args contractEventNodes
+	if err := json.Unmarshal(e.Arguments, &args); err != nil {
+		return "", err
+	}
+
+	tokenID := node(&args)
+	if tokenID == nil {
+		return "", fmt.Errorf("%s event is missing a token id", e.EventName)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", entity, tokenID, aspect), nil
+}
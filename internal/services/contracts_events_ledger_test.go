@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+
+	smock "github.com/IBM/sarama/mocks"
+)
+
+func TestContractEventsLedger(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	settings := &config.Settings{DIMORegistryChainID: 1, VehicleNFTAddress: "0x881d40237659c251811cec9c364ef91dc08d300c"}
+
+	original := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	newer := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+	older := common.HexToAddress("0x8ba1f109551bd432803012645ac136ddd64dba72")
+
+	mtr := models.MetaTransactionRequest{ID: ksuid.New().String()}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ud := models.UserDevice{
+		ID:                 ksuid.New().String(),
+		MintRequestID:      null.StringFrom(mtr.ID),
+		OwnerAddress:       null.BytesFrom(original.Bytes()),
+		TokenID:            types.NewNullDecimal(decimal.New(5, 0)),
+		DeviceDefinitionID: ksuid.New().String(),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	// Only the first delivery gets as far as the handler.
+	kprod := smock.NewSyncProducer(t, nil)
+	kprod.ExpectSendMessageAndSucceed()
+	evt := NewEventService(&logger, settings, kprod)
+	consumer := NewContractsEventsConsumer(pdb, &logger, settings, nil, nil, evt, nil, nil, nil)
+
+	transfer := func(block int, txHash string, from, to common.Address) {
+		event, err := marshalMockPayload(fmt.Sprintf(`
+		{
+			"type": "zone.dimo.contract.event",
+			"source": "chain/1",
+			"data": {
+				"chainId": 1,
+				"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+				"block": {"number": %d},
+				"transactionHash": "%s",
+				"index": 3,
+				"eventName": "Transfer",
+				"arguments": {
+					"from": "%s",
+					"to": "%s",
+					"tokenId": 5
+				}
+			}
+		}`, block, txHash, from.Hex(), to.Hex()))
+		require.NoError(t, err)
+		require.NoError(t, consumer.processEvent(ctx, event))
+	}
+
+	owner := func() common.Address {
+		require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+		return common.BytesToAddress(ud.OwnerAddress.Bytes)
+	}
+
+	newerTx := "0x1111111111111111111111111111111111111111111111111111111111111111"
+	olderTx := "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+	transfer(20, newerTx, original, newer)
+	assert.Equal(t, newer, owner())
+
+	// A redelivery is skipped even if the database has since changed.
+	ud.OwnerAddress = null.BytesFrom(original.Bytes())
+	_, err := ud.Update(ctx, pdb.DBS().Writer, boil.Whitelist(models.UserDeviceColumns.OwnerAddress))
+	require.NoError(t, err)
+
+	transfer(20, newerTx, original, newer)
+	assert.Equal(t, original, owner())
+
+	ud.OwnerAddress = null.BytesFrom(newer.Bytes())
+	_, err = ud.Update(ctx, pdb.DBS().Writer, boil.Whitelist(models.UserDeviceColumns.OwnerAddress))
+	require.NoError(t, err)
+
+	// An earlier transfer arriving late must not undo the later one.
+	transfer(10, olderTx, original, older)
+	assert.Equal(t, newer, owner())
+
+	pces, err := models.ProcessedContractEvents().All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, pces, 2)
+
+	for _, pce := range pces {
+		assert.Equal(t, "vehicle/5/owner", pce.EntityKey.String)
+		assert.Equal(t, pce.BlockNumber == 10, pce.Stale)
+	}
+
+	// Without a log index there's nothing to key the ledger on, so the event is just handled.
+	kprod.ExpectSendMessageAndSucceed()
+
+	event, err := marshalMockPayload(fmt.Sprintf(`
+	{
+		"type": "zone.dimo.contract.event",
+		"source": "chain/1",
+		"data": {
+			"chainId": 1,
+			"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+			"block": {"number": 30},
+			"transactionHash": "0x3333333333333333333333333333333333333333333333333333333333333333",
+			"eventName": "Transfer",
+			"arguments": {
+				"from": "%s",
+				"to": "%s",
+				"tokenId": 5
+			}
+		}
+	}`, newer.Hex(), older.Hex()))
+	require.NoError(t, err)
+	require.NoError(t, consumer.processEvent(ctx, event))
+	assert.Equal(t, older, owner())
+
+	n, err := models.ProcessedContractEvents().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	// A failed handler takes its ledger row down with it, so the redelivery is applied.
+	event, err = marshalMockPayload(fmt.Sprintf(`
+	{
+		"type": "zone.dimo.contract.event",
+		"source": "chain/1",
+		"data": {
+			"chainId": 1,
+			"contract": "0x881d40237659c251811cec9c364ef91dc08d300c",
+			"block": {"number": 40},
+			"transactionHash": "0x4444444444444444444444444444444444444444444444444444444444444444",
+			"index": 3,
+			"eventName": "Transfer",
+			"arguments": {
+				"from": "%s",
+				"to": "%s",
+				"tokenId": 6
+			}
+		}
+	}`, original.Hex(), newer.Hex()))
+	require.NoError(t, err)
+	require.Error(t, consumer.processEvent(ctx, event))
+
+	n, err = models.ProcessedContractEvents().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	ud2 := models.UserDevice{
+		ID:                 ksuid.New().String(),
+		OwnerAddress:       null.BytesFrom(original.Bytes()),
+		TokenID:            types.NewNullDecimal(decimal.New(6, 0)),
+		DeviceDefinitionID: ksuid.New().String(),
+	}
+	require.NoError(t, ud2.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	kprod.ExpectSendMessageAndSucceed()
+	require.NoError(t, consumer.processEvent(ctx, event))
+
+	require.NoError(t, ud2.Reload(ctx, pdb.DBS().Reader))
+	assert.Equal(t, newer, common.BytesToAddress(ud2.OwnerAddress.Bytes))
+
+	n, err = models.ProcessedContractEvents().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, n)
+}
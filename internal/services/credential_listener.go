@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
@@ -19,35 +20,43 @@ import (
 )
 
 type CredentialListener struct {
-	db  func() *db.ReaderWriter
-	log *zerolog.Logger
+	db     func() *db.ReaderWriter
+	log    *zerolog.Logger
+	cipher TaskCipher
+	dlq    *kafka.DeadLetterQueue
 }
 
-func NewCredentialListener(db func() *db.ReaderWriter, log *zerolog.Logger) *CredentialListener {
-	return &CredentialListener{db: db, log: log}
+func NewCredentialListener(db func() *db.ReaderWriter, log *zerolog.Logger, cipher TaskCipher, dlq *kafka.DeadLetterQueue) *CredentialListener {
+	return &CredentialListener{db: db, log: log, cipher: cipher, dlq: dlq}
 }
 
 func (i *CredentialListener) ProcessCredentialsMessages(messages <-chan *message.Message) {
 	for msg := range messages {
-		err := i.processMessage(msg)
+		err := i.dlq.HandleWatermill(msg, i.HandleMessage)
 		if err != nil {
 			i.log.Err(err).Msg("error processing credential msg")
+			if i.dlq != nil {
+				// The message neither went through nor made it to the dead-letter topic.
+				// Have watermill deliver it again rather than lose it.
+				msg.Nack()
+				continue
+			}
 		}
+		// Without a dead-letter topic, keep the pipeline moving no matter what.
+		msg.Ack()
 	}
 }
 
-func (i *CredentialListener) processMessage(msg *message.Message) error {
-	// Keep the pipeline moving no matter what.
-	defer func() { msg.Ack() }()
-
+// HandleMessage processes a single credential message. It is also what replay-dlq uses.
+func (i *CredentialListener) HandleMessage(_ context.Context, msg *kafka.Message) error {
 	// Deletion messages. We're the only actor that produces these, so ignore them.
-	if msg.Payload == nil {
+	if msg.Value == nil {
 		return nil
 	}
 
 	event := new(shared.CloudEvent[sdtask.CredentialData])
-	if err := json.Unmarshal(msg.Payload, event); err != nil {
-		return errors.Wrap(err, "error parsing device event payload")
+	if err := json.Unmarshal(msg.Value, event); err != nil {
+		return kafka.Permanent(errors.Wrap(err, "error parsing device event payload"))
 	}
 
 	return i.processEvent(event)
@@ -89,9 +98,21 @@ func (i *CredentialListener) processEvent(event *shared.CloudEvent[sdtask.Creden
 	}
 
 	// Upon initial connection, there will be message that we sent and there's no point in updating the database.
-	// TODO: Should we ignore these if they're expired?
-	if !integ.AccessToken.Valid || integ.AccessToken.String != accessToken {
+	// Only take credentials that expire later than the ones we have. Comparing tokens doesn't work since the
+	// ciphertexts differ by key, and a replayed or redelivered message must not roll back a newer refresh.
+	if !integ.AccessToken.Valid || !integ.AccessExpiresAt.Valid || expiry.After(integ.AccessExpiresAt.Time) {
 		i.log.Debug().Str("userDeviceId", userDeviceID).Str("integrationId", integrationID).Msgf("Saving new credentials.")
+
+		// The tasks encrypt with the legacy key. Storing that as-is would undo a rotation.
+		accessToken, err := i.cipher.ToCurrent(accessToken)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt access token: %w", err)
+		}
+		refreshToken, err := i.cipher.ToCurrent(refreshToken)
+		if err != nil {
+			return fmt.Errorf("failed to re-encrypt refresh token: %w", err)
+		}
+
 		integ.AccessToken = null.StringFrom(accessToken)
 		integ.RefreshToken = null.StringFrom(refreshToken)
 		integ.AccessExpiresAt = null.TimeFrom(expiry)
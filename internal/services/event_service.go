@@ -97,6 +97,32 @@ type UserDeviceIntegrationEvent struct {
 	Integration UserDeviceEventIntegration `json:"integration"`
 }
 
+// UserDeviceIntegrationStatusEvent is emitted when a vendor tells us that an
+// integration has stopped working.
+type UserDeviceIntegrationStatusEvent struct {
+	Timestamp   time.Time                  `json:"timestamp"`
+	UserID      string                     `json:"userId"`
+	Device      UserDeviceEventDevice      `json:"device"`
+	Integration UserDeviceEventIntegration `json:"integration"`
+	Status      string                     `json:"status"`
+	Reason      string                     `json:"reason"`
+}
+
+type UserDeviceEventGeofence struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UserDeviceGeofenceEvent struct {
+	Timestamp time.Time               `json:"timestamp"`
+	UserID    string                  `json:"userId"`
+	Device    UserDeviceEventDevice   `json:"device"`
+	Geofence  UserDeviceEventGeofence `json:"geofence"`
+	Latitude  float64                 `json:"latitude"`
+	Longitude float64                 `json:"longitude"`
+}
+
 type UserDeviceEventNFT struct {
 	TokenID *big.Int       `json:"tokenId"`
 	Owner   common.Address `json:"address"`
@@ -109,3 +135,32 @@ type UserDeviceMintEvent struct {
 	Device    UserDeviceEventDevice `json:"device"`
 	NFT       UserDeviceEventNFT    `json:"nft"`
 }
+
+// UserDeviceTransferEvent is emitted after a vehicle NFT changes hands and the
+// previous owner's state has been cleaned up.
+type UserDeviceTransferEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	// UserID is the new owner's user id.
+	UserID       string             `json:"userId"`
+	UserDeviceID string             `json:"userDeviceId"`
+	NFT          UserDeviceEventNFT `json:"nft"`
+	From         common.Address     `json:"from"`
+	Policy       string             `json:"policy"`
+	// Geofences lists the ids of the previous owner's geofences that were detached.
+	Geofences []string `json:"geofences"`
+	// Integrations lists the ids of the integrations whose credentials were wiped.
+	Integrations []string `json:"integrations"`
+}
+
+// NFTPrivilegeExpiringEvent warns that a privilege granted on a vehicle is
+// about to lapse.
+type NFTPrivilegeExpiringEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	TokenID   *big.Int  `json:"tokenId"`
+	// UserDeviceID and Owner are empty if we have no record of the vehicle.
+	UserDeviceID string         `json:"userDeviceId,omitempty"`
+	Owner        common.Address `json:"owner"`
+	Grantee      common.Address `json:"grantee"`
+	Privilege    int64          `json:"privilege"`
+	ExpiresAt    time.Time      `json:"expiresAt"`
+}
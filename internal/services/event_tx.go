@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DIMO-Network/shared/db"
+)
+
+// EventTx is a database transaction together with the work that must wait for it to
+// commit: Kafka messages, task shutdowns and anything else that can't be rolled back.
+// Handlers write through the transaction and queue the rest with AfterCommit, so that a
+// failed handler leaves nothing behind and a committed one sends everything once.
+type EventTx struct {
+	*sql.Tx
+	afterCommit []func()
+}
+
+// BeginEventTx starts a transaction on the writer.
+func BeginEventTx(ctx context.Context, dbs *db.ReaderWriter) (*EventTx, error) {
+	tx, err := dbs.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &EventTx{Tx: tx}, nil
+}
+
+// AfterCommit queues f to run once the transaction commits. It is dropped if the
+// transaction rolls back. The database changes can't be undone by then, so f should log
+// its own failures rather than report them.
+func (t *EventTx) AfterCommit(f func()) {
+	t.afterCommit = append(t.afterCommit, f)
+}
+
+// Commit commits the transaction and then runs the queued functions in order.
+func (t *EventTx) Commit() error {
+	if err := t.Tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, f := range t.afterCommit {
+		f()
+	}
+	t.afterCommit = nil
+
+	return nil
+}
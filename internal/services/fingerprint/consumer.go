@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/appmetrics"
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,13 +18,16 @@ import (
 
 	"github.com/DIMO-Network/devices-api/internal/config"
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
-	"github.com/DIMO-Network/shared/kafka"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
@@ -44,14 +48,10 @@ func NewConsumer(dbs db.Store, log *zerolog.Logger) *Consumer {
 	}
 }
 
-func RunConsumer(ctx context.Context, settings *config.Settings, logger *zerolog.Logger, dbs db.Store) error {
+func RunConsumer(ctx context.Context, settings *config.Settings, logger *zerolog.Logger, dbs db.Store, dlq *kafka.DeadLetterQueue) error {
 	consumer := NewConsumer(dbs, logger)
 
-	if err := kafka.Consume(ctx, kafka.Config{
-		Brokers: strings.Split(settings.KafkaBrokers, ","),
-		Topic:   settings.DeviceFingerprintTopic,
-		Group:   settings.DeviceFingerprintConsumerGroup,
-	}, consumer.HandleDeviceFingerprint, logger); err != nil {
+	if err := kafka.ConsumeWithDeadLetters(ctx, strings.Split(settings.KafkaBrokers, ","), settings.DeviceFingerprintTopic, settings.DeviceFingerprintConsumerGroup, dlq, consumer.HandleMessage, logger); err != nil {
 		logger.Fatal().Err(err).Msg("couldn't start device fingerprint consumer")
 	}
 	logger.Info().Msg("Starting transaction request status listener.")
@@ -59,6 +59,16 @@ func RunConsumer(ctx context.Context, settings *config.Settings, logger *zerolog
 	return nil
 }
 
+// HandleMessage processes a single fingerprint message. It is also what replay-dlq uses.
+func (c *Consumer) HandleMessage(ctx context.Context, msg *kafka.Message) error {
+	event := new(Event)
+	if err := json.Unmarshal(msg.Value, event); err != nil {
+		return kafka.Permanent(err)
+	}
+
+	return c.HandleDeviceFingerprint(ctx, event)
+}
+
 func (c *Consumer) HandleDeviceFingerprint(ctx context.Context, event *Event) error {
 	if !common.IsHexAddress(event.Subject) {
 		return fmt.Errorf("subject %q not a valid address", event.Subject)
@@ -85,40 +95,85 @@ func (c *Consumer) HandleDeviceFingerprint(ctx context.Context, event *Event) er
 		return fmt.Errorf("failed querying for device: %w", err)
 	}
 
-	ud := ad.R.VehicleToken
-	if ud == nil {
-		return nil
+	// TODO(elffjs): This logic is way too easy to get wrong.
+	var protocol, vin *string
+	if event.Source == "macaron/fingerprint" {
+		protocol, err = ExtractProtocolMacaronType1(string(event.Data))
+		if err == nil {
+			vin, err = ExtractVINMacaronType1(string(event.Data))
+		}
+	} else {
+		protocol, err = ExtractProtocol(event.Data)
+		if err == nil {
+			vin, err = ExtractVIN(event.Data)
+		}
+	}
+	if err != nil {
+		c.logger.Error().Err(err).Str("device", addr.Hex()).Msg("Couldn't parse fingerprint.")
+	}
+
+	observedAt := event.Time
+	if observedAt.IsZero() {
+		observedAt = time.Now()
+	}
+
+	fp := models.AftermarketDeviceFingerprint{
+		ID:                       ksuid.New().String(),
+		AftermarketDeviceAddress: ad.EthereumAddress,
+		Source:                   event.Source,
+		Protocol:                 null.StringFromPtr(protocol),
+		Vin:                      null.StringFromPtr(vin),
+		ObservedAt:               observedAt,
 	}
 
-	var md services.UserDeviceMetadata
-	if err = ad.R.VehicleToken.Metadata.Unmarshal(&md); err != nil {
-		c.logger.Error().Msgf("Could not unmarshal userdevice metadata for device: %s", ad.R.VehicleToken.ID)
+	tx, err := c.DBS.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
 		return err
 	}
+	defer tx.Rollback() //nolint
 
-	// TODO(elffjs): This logic is way too easy to get wrong.
-	var protocol *string
-	if md.CANProtocol == nil {
-		if event.Source == "macaron/fingerprint" {
-			protocol, err = ExtractProtocolMacaronType1(string(event.Data))
-		} else {
-			protocol, err = ExtractProtocol(event.Data)
-		}
-		if err != nil {
-			c.logger.Error().Err(err)
+	ud := ad.R.VehicleToken
+	if ud != nil {
+		fp.UserDeviceID = null.StringFrom(ud.ID)
+
+		var md services.UserDeviceMetadata
+		if err = ud.Metadata.Unmarshal(&md); err != nil {
+			c.logger.Error().Msgf("Could not unmarshal userdevice metadata for device: %s", ud.ID)
+			return err
 		}
 
-		if err == nil && protocol != nil {
+		// Keep the current protocol; earlier ones are in the fingerprint history.
+		if protocol != nil {
 			md.CANProtocol = protocol
 		}
-	}
 
-	err = ad.R.VehicleToken.Metadata.Marshal(&md)
-	if err != nil {
-		c.logger.Error().Msgf("could not marshal userdevice metadata for device: %s", ad.R.VehicleToken.ID)
-		if protocol != nil {
-			appmetrics.FingerprintRequestCount.With(prometheus.Labels{"protocol": *protocol, "status": "Failed"}).Inc()
+		if vin != nil {
+			fp.VinMismatch = ud.VinIdentifier.Valid && !strings.EqualFold(*vin, ud.VinIdentifier.String)
+			md.FingerprintVIN = vin
+			md.VINMismatch = fp.VinMismatch
+			if fp.VinMismatch {
+				c.logger.Warn().Str("userDeviceId", ud.ID).Str("device", addr.Hex()).Msgf("Fingerprint VIN %s doesn't match vehicle VIN %s.", *vin, ud.VinIdentifier.String)
+			}
+		}
+
+		if err := ud.Metadata.Marshal(&md); err != nil {
+			c.logger.Error().Msgf("could not marshal userdevice metadata for device: %s", ud.ID)
+			if protocol != nil {
+				appmetrics.FingerprintRequestCount.With(prometheus.Labels{"protocol": *protocol, "status": "Failed"}).Inc()
+			}
+			return err
 		}
+
+		if _, err := ud.Update(ctx, tx, boil.Whitelist(models.UserDeviceColumns.Metadata, models.UserDeviceColumns.UpdatedAt)); err != nil {
+			return fmt.Errorf("failed to update vehicle metadata: %w", err)
+		}
+	}
+
+	if err := fp.Insert(ctx, tx, boil.Infer()); err != nil {
+		return fmt.Errorf("failed to record fingerprint: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 
@@ -147,6 +202,53 @@ func ExtractProtocolMacaronType1(data string) (*string, error) {
 	return &protocol, nil
 }
 
+// ExtractVINMacaronType1 pulls out the VIN from macaron message type 1, if the
+// message is long enough to have one and the bytes look like a VIN. Devices
+// that couldn't read the VIN send padding or garbage there.
+func ExtractVINMacaronType1(data string) (*string, error) {
+	decodedBytes, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 data: %w", err)
+	}
+
+	// Type, timestamp, location and protocol come first.
+	const vinOffset = 1 + 4 + 8 + 1
+	if len(decodedBytes) < vinOffset+17 {
+		return nil, nil
+	}
+
+	vin := string(decodedBytes[vinOffset : vinOffset+17])
+	for _, r := range vin {
+		if !validVINChar(r) {
+			return nil, nil
+		}
+	}
+
+	return &vin, nil
+}
+
+// validVINChar reports whether r can appear in a VIN. I, O and Q are left out
+// so they can't be confused with 1 and 0.
+func validVINChar(r rune) bool {
+	return ('A' <= r && r <= 'Z' || '0' <= r && r <= '9') && r != 'I' && r != 'O' && r != 'Q'
+}
+
+func ExtractVIN(data []byte) (*string, error) {
+	partialData := new(struct {
+		VIN *string `json:"vin"`
+	})
+
+	if err := json.Unmarshal(data, partialData); err != nil {
+		return nil, fmt.Errorf("failed parsing data field: %w", err)
+	}
+
+	if partialData.VIN != nil && *partialData.VIN == "" {
+		return nil, nil
+	}
+
+	return partialData.VIN, nil
+}
+
 func ExtractProtocol(data []byte) (*string, error) {
 	partialData := new(struct {
 		Protocol *string `json:"protocol"`
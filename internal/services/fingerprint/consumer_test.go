@@ -5,19 +5,30 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/controllers/helpers"
+	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
+	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"go.uber.org/mock/gomock"
 )
 
@@ -228,3 +239,112 @@ func TestExtractProtocolMacaronType1(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractVIN(t *testing.T) {
+	vin, err := ExtractVIN([]byte(`{"protocol":"6","vin":"LRBFXCSA5KD124854"}`))
+	require.NoError(t, err)
+	require.NotNil(t, vin)
+	assert.Equal(t, "LRBFXCSA5KD124854", *vin)
+
+	vin, err = ExtractVIN([]byte(`{"protocol":"6","vin":""}`))
+	require.NoError(t, err)
+	assert.Nil(t, vin)
+
+	_, err = ExtractVIN([]byte(`caca`))
+	assert.Error(t, err)
+}
+
+func TestExtractVINMacaronType1(t *testing.T) {
+	vin, err := ExtractVINMacaronType1("AW+yb2VVFVFCV6pmvwZXQkFXWjMyMDMwMEY4Njc1Ng==")
+	require.NoError(t, err)
+	require.NotNil(t, vin)
+	assert.Equal(t, "WBAWZ320300F86756", *vin)
+
+	// Too short to have a VIN.
+	vin, err = ExtractVINMacaronType1(base64.StdEncoding.EncodeToString(make([]byte, 14)))
+	require.NoError(t, err)
+	assert.Nil(t, vin)
+
+	prefix := make([]byte, 14)
+	for _, bad := range []string{
+		string(make([]byte, 17)), // Zero padding.
+		"wbawz320300f86756",      // Lowercase.
+		"WBAWZ320300F8675-",      // Punctuation.
+		"WBAWZ32O300F86756",      // O isn't allowed.
+		"IBAWZ320300F86756",      // Nor is I.
+		"WBAWZ320300F8675Q",      // Nor Q.
+	} {
+		vin, err = ExtractVINMacaronType1(base64.StdEncoding.EncodeToString(append(prefix, bad...)))
+		require.NoError(t, err)
+		assert.Nil(t, vin, "VIN %q", bad)
+	}
+}
+
+func TestHandleDeviceFingerprint(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	pk, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(pk.PublicKey)
+
+	ud := test.SetupCreateUserDevice(t, "louxUser", ksuid.New().String(), nil, "WBAWZ320300F86756", pdb)
+	ud.TokenID = types.NewNullDecimal(decimal.New(3, 0))
+	_, err = ud.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	ad := test.SetupCreateMintedAftermarketDevice(t, "louxUser", "1b5d3bd3-6ef7-4c05-9f45-b7d9a5b0ee40", big.NewInt(13), addr, nil, pdb)
+	ad.VehicleTokenID = ud.TokenID
+	_, err = ad.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	consumer := NewConsumer(pdb, test.Logger())
+
+	send := func(at time.Time, data string) {
+		hash := crypto.Keccak256Hash([]byte(data))
+		sig, err := crypto.Sign(hash.Bytes(), pk)
+		require.NoError(t, err)
+		sig[64] += 27
+
+		event := &Event{
+			CloudEvent: shared.CloudEvent[json.RawMessage]{
+				Source:  "aftermarket/device/fingerprint",
+				Subject: addr.Hex(),
+				Time:    at,
+				Data:    json.RawMessage(data),
+			},
+			Signature: hexutil.Encode(sig),
+		}
+		require.NoError(t, consumer.HandleDeviceFingerprint(ctx, event))
+	}
+
+	start := time.Now().Truncate(time.Second)
+	send(start, `{"protocol":"7","vin":"WBAWZ320300F86756"}`)
+	send(start.Add(time.Minute), `{"protocol":"6","vin":"LRBFXCSA5KD124854"}`)
+
+	require.NoError(t, ud.Reload(ctx, pdb.DBS().Reader))
+	var md services.UserDeviceMetadata
+	require.NoError(t, ud.Metadata.Unmarshal(&md))
+	require.NotNil(t, md.CANProtocol)
+	assert.Equal(t, "6", *md.CANProtocol)
+	require.NotNil(t, md.FingerprintVIN)
+	assert.Equal(t, "LRBFXCSA5KD124854", *md.FingerprintVIN)
+	assert.True(t, md.VINMismatch)
+
+	fps, err := models.AftermarketDeviceFingerprints(
+		models.AftermarketDeviceFingerprintWhere.AftermarketDeviceAddress.EQ(ad.EthereumAddress),
+		qm.OrderBy(models.AftermarketDeviceFingerprintColumns.ObservedAt),
+	).All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, fps, 2)
+
+	assert.Equal(t, "7", fps[0].Protocol.String)
+	assert.False(t, fps[0].VinMismatch)
+	assert.Equal(t, ud.ID, fps[0].UserDeviceID.String)
+	assert.True(t, fps[0].ObservedAt.Equal(start))
+
+	assert.Equal(t, "6", fps[1].Protocol.String)
+	assert.Equal(t, "LRBFXCSA5KD124854", fps[1].Vin.String)
+	assert.True(t, fps[1].VinMismatch)
+}
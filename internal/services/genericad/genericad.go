@@ -14,7 +14,6 @@ import (
 	"github.com/DIMO-Network/devices-api/internal/services"
 	"github.com/DIMO-Network/devices-api/internal/utils"
 	"github.com/DIMO-Network/devices-api/models"
-	"github.com/DIMO-Network/shared/db"
 	"github.com/ericlagergren/decimal"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -24,8 +23,9 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
+// Integration manages the user device API integrations behind aftermarket device
+// pairings.
 type Integration struct {
-	db          func() *db.ReaderWriter
 	defs        services.DeviceDefinitionService
 	apReg       services.IngestRegistrar
 	eventer     services.EventService
@@ -34,7 +34,6 @@ type Integration struct {
 }
 
 func NewIntegration(
-	db func() *db.ReaderWriter,
 	defs services.DeviceDefinitionService,
 	apReg services.IngestRegistrar,
 	eventer services.EventService,
@@ -42,7 +41,6 @@ func NewIntegration(
 	logger *zerolog.Logger,
 ) *Integration {
 	return &Integration{
-		db:          db,
 		defs:        defs,
 		apReg:       apReg,
 		eventer:     eventer,
@@ -55,12 +53,9 @@ func intToDec(x *big.Int) types.NullDecimal {
 	return types.NewNullDecimal(new(decimal.Big).SetBigMantScale(x, 0))
 }
 
-func (i *Integration) Pair(ctx context.Context, amTokenID, vehicleTokenID *big.Int) error {
-	tx, err := i.db().Writer.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
+// Pair creates the integration for a newly paired aftermarket device, replacing any
+// that is already there. Registration with ingest and the events wait for tx to commit.
+func (i *Integration) Pair(ctx context.Context, tx *services.EventTx, amTokenID, vehicleTokenID *big.Int) error {
 	amDev, err := models.AftermarketDevices(
 		models.AftermarketDeviceWhere.TokenID.EQ(utils.BigToDecimal(amTokenID)),
 	).One(ctx, tx)
@@ -106,6 +101,9 @@ func (i *Integration) Pair(ctx context.Context, amTokenID, vehicleTokenID *big.I
 		if err != nil {
 			return err
 		}
+		if err := services.RecordIntegrationRemoval(ctx, tx, oldInt, services.IntegrationStatusSourceContractEvent, "Replaced by a new pairing."); err != nil {
+			return err
+		}
 	}
 
 	def, err := i.defs.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
@@ -124,80 +122,79 @@ func (i *Integration) Pair(ctx context.Context, amTokenID, vehicleTokenID *big.I
 		return err
 	}
 
-	if err = tx.Commit(); err != nil {
-		return errors.Wrap(err, "failed to commit new autopi integration")
-	}
-
-	err = i.apReg.Register2(&services.AftermarketDeviceVehicleMapping{
-		AftermarketDevice: services.AftermarketDeviceVehicleMappingAftermarketDevice{
-			Address:       common.BytesToAddress(amDev.EthereumAddress),
-			Token:         amTokenID,
-			Serial:        amDev.Serial,
-			IntegrationID: integ.Id,
-		},
-		Vehicle: services.AftermarketDeviceVehicleMappingVehicle{
-			Token:        vehicleTokenID,
-			UserDeviceID: ud.ID,
-		},
-	})
-	if err != nil {
+	if err = services.RecordIntegrationStatusChange(ctx, tx, &udai, "", services.IntegrationStatusSourceContractEvent, "Aftermarket device paired."); err != nil {
 		return err
 	}
 
-	_ = i.eventer.Emit(
-		&shared.CloudEvent[any]{
-			Type:    "com.dimo.zone.device.integration.create",
-			Source:  "devices-api",
-			Subject: ud.ID,
-			Data: services.UserDeviceIntegrationEvent{
-				Timestamp: time.Now(),
-				UserID:    ud.UserID,
-				Device: services.UserDeviceEventDevice{
-					ID:                 ud.ID,
-					DeviceDefinitionID: def.DeviceDefinitionId,
-					Make:               def.Make.Name,
-					Model:              def.Model,
-					Year:               int(def.Year),
-					VIN:                ud.VinIdentifier.String,
-				},
-				Integration: services.UserDeviceEventIntegration{
-					ID:     integ.Id,
-					Type:   integ.Type,
-					Style:  integ.Style,
-					Vendor: integ.Vendor,
-				},
+	tx.AfterCommit(func() {
+		err := i.apReg.Register2(&services.AftermarketDeviceVehicleMapping{
+			AftermarketDevice: services.AftermarketDeviceVehicleMappingAftermarketDevice{
+				Address:       common.BytesToAddress(amDev.EthereumAddress),
+				Token:         amTokenID,
+				Serial:        amDev.Serial,
+				IntegrationID: integ.Id,
 			},
-		},
-	)
+			Vehicle: services.AftermarketDeviceVehicleMappingVehicle{
+				Token:        vehicleTokenID,
+				UserDeviceID: ud.ID,
+			},
+		})
+		if err != nil {
+			i.logger.Err(err).Str("userDeviceId", ud.ID).Msg("Failed to register aftermarket device with ingest.")
+		}
 
-	region := ""
-	if ud.CountryCode.Valid {
-		countryRecord := constants.FindCountry(ud.CountryCode.String)
-		if countryRecord != nil {
-			region = countryRecord.Region
+		_ = i.eventer.Emit(
+			&shared.CloudEvent[any]{
+				Type:    "com.dimo.zone.device.integration.create",
+				Source:  "devices-api",
+				Subject: ud.ID,
+				Data: services.UserDeviceIntegrationEvent{
+					Timestamp: time.Now(),
+					UserID:    ud.UserID,
+					Device: services.UserDeviceEventDevice{
+						ID:                 ud.ID,
+						DeviceDefinitionID: def.DeviceDefinitionId,
+						Make:               def.Make.Name,
+						Model:              def.Model,
+						Year:               int(def.Year),
+						VIN:                ud.VinIdentifier.String,
+					},
+					Integration: services.UserDeviceEventIntegration{
+						ID:     integ.Id,
+						Type:   integ.Type,
+						Style:  integ.Style,
+						Vendor: integ.Vendor,
+					},
+				},
+			},
+		)
+
+		region := ""
+		if ud.CountryCode.Valid {
+			countryRecord := constants.FindCountry(ud.CountryCode.String)
+			if countryRecord != nil {
+				region = countryRecord.Region
+			}
 		}
-	}
-	_ = i.ddRegistrar.Register(services.DeviceDefinitionDTO{
-		IntegrationID:      integ.Id,
-		UserDeviceID:       ud.ID,
-		DeviceDefinitionID: ud.DeviceDefinitionID,
-		Make:               def.Make.Name,
-		Model:              def.Model,
-		Year:               int(def.Year),
-		Region:             region,
-		MakeSlug:           def.Make.NameSlug,
-		ModelSlug:          shared.SlugString(def.Model),
+		_ = i.ddRegistrar.Register(services.DeviceDefinitionDTO{
+			IntegrationID:      integ.Id,
+			UserDeviceID:       ud.ID,
+			DeviceDefinitionID: ud.DeviceDefinitionID,
+			Make:               def.Make.Name,
+			Model:              def.Model,
+			Year:               int(def.Year),
+			Region:             region,
+			MakeSlug:           def.Make.NameSlug,
+			ModelSlug:          shared.SlugString(def.Model),
+		})
 	})
 
 	return nil
 }
 
-func (i *Integration) Unpair(ctx context.Context, autoPiTokenID, vehicleTokenID *big.Int) error {
-	tx, err := i.db().Writer.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-
+// Unpair removes the integration behind an aftermarket device pairing.
+// Deregistration and the event wait for tx to commit.
+func (i *Integration) Unpair(ctx context.Context, tx *services.EventTx, autoPiTokenID, vehicleTokenID *big.Int) error {
 	ud, err := models.UserDevices(
 		models.UserDeviceWhere.TokenID.EQ(intToDec(vehicleTokenID)),
 	).One(ctx, tx)
@@ -227,21 +224,23 @@ func (i *Integration) Unpair(ctx context.Context, autoPiTokenID, vehicleTokenID
 		}
 	}
 
-	udai, err := models.FindUserDeviceAPIIntegration(ctx, i.db().Writer, ud.ID, integ.Id)
+	if integ == nil {
+		return fmt.Errorf("manufacturer %d does not have an associated integration", amDev.DeviceManufacturerTokenID)
+	}
+
+	udai, err := models.FindUserDeviceAPIIntegration(ctx, tx, ud.ID, integ.Id)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return err
 		}
 	} else {
-		_, err = udai.Delete(ctx, i.db().Writer)
+		_, err = udai.Delete(ctx, tx)
 		if err != nil {
 			return err
 		}
-	}
-
-	err = i.apReg.Deregister2(common.BytesToAddress(amDev.EthereumAddress))
-	if err != nil {
-		return err
+		if err := services.RecordIntegrationRemoval(ctx, tx, udai, services.IntegrationStatusSourceContractEvent, "Aftermarket device unpaired."); err != nil {
+			return err
+		}
 	}
 
 	def, err := i.defs.GetDeviceDefinitionBySlug(ctx, ud.DefinitionID)
@@ -249,26 +248,32 @@ func (i *Integration) Unpair(ctx context.Context, autoPiTokenID, vehicleTokenID
 		return err
 	}
 
-	_ = i.eventer.Emit(&shared.CloudEvent[any]{
-		Type:    "com.dimo.zone.device.integration.delete",
-		Source:  "devices-api",
-		Subject: ud.ID,
-		Data: services.UserDeviceIntegrationEvent{
-			Timestamp: time.Now(),
-			UserID:    ud.UserID,
-			Device: services.UserDeviceEventDevice{
-				ID:    ud.ID,
-				Make:  def.Make.Name,
-				Model: def.Model,
-				Year:  int(def.Year),
-			},
-			Integration: services.UserDeviceEventIntegration{
-				ID:     integ.Id,
-				Type:   integ.Type,
-				Style:  integ.Style,
-				Vendor: integ.Vendor,
+	tx.AfterCommit(func() {
+		if err := i.apReg.Deregister2(common.BytesToAddress(amDev.EthereumAddress)); err != nil {
+			i.logger.Err(err).Str("userDeviceId", ud.ID).Msg("Failed to deregister aftermarket device from ingest.")
+		}
+
+		_ = i.eventer.Emit(&shared.CloudEvent[any]{
+			Type:    "com.dimo.zone.device.integration.delete",
+			Source:  "devices-api",
+			Subject: ud.ID,
+			Data: services.UserDeviceIntegrationEvent{
+				Timestamp: time.Now(),
+				UserID:    ud.UserID,
+				Device: services.UserDeviceEventDevice{
+					ID:    ud.ID,
+					Make:  def.Make.Name,
+					Model: def.Model,
+					Year:  int(def.Year),
+				},
+				Integration: services.UserDeviceEventIntegration{
+					ID:     integ.Id,
+					Type:   integ.Type,
+					Style:  integ.Style,
+					Vendor: integ.Vendor,
+				},
 			},
-		},
+		})
 	})
 
 	return nil
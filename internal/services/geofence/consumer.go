@@ -0,0 +1,232 @@
+// Package geofence watches vehicle locations and emits events when vehicles
+// enter or leave their trigger geofences.
+package geofence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/h3"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/DIMO-Network/shared/kafka"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	EntryEventType = "zone.dimo.geofence.entry"
+	ExitEventType  = "zone.dimo.geofence.exit"
+)
+
+// LocationData is the part of a vehicle status event that we care about. The
+// subject of the event is the user device id.
+type LocationData struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
+type Consumer struct {
+	logger       *zerolog.Logger
+	DBS          db.Store
+	eventService services.EventService
+}
+
+func NewConsumer(dbs db.Store, log *zerolog.Logger, eventService services.EventService) *Consumer {
+	return &Consumer{
+		DBS:          dbs,
+		logger:       log,
+		eventService: eventService,
+	}
+}
+
+func RunConsumer(ctx context.Context, settings *config.Settings, logger *zerolog.Logger, dbs db.Store, eventService services.EventService) error {
+	consumer := NewConsumer(dbs, logger, eventService)
+
+	if err := kafka.Consume(ctx, kafka.Config{
+		Brokers: strings.Split(settings.KafkaBrokers, ","),
+		Topic:   settings.DeviceLocationTopic,
+		Group:   settings.GeofenceConsumerGroup,
+	}, consumer.HandleLocation, logger); err != nil {
+		return fmt.Errorf("couldn't start geofence consumer: %w", err)
+	}
+
+	logger.Info().Msg("Started geofence consumer.")
+
+	return nil
+}
+
+// HandleLocation compares the vehicle's new location against its trigger
+// fences. The first location we see for a fence only sets the starting state;
+// after that, crossing into a TriggerEntry fence or out of a TriggerExit fence
+// emits an event, if the fence is active at the time. Events are emitted once
+// the new states are committed, so a failed commit can't leave behind events
+// for transitions that never happened.
+func (c *Consumer) HandleLocation(ctx context.Context, event *shared.CloudEvent[LocationData]) error {
+	if event.Data.Latitude == nil || event.Data.Longitude == nil {
+		return nil
+	}
+
+	userDeviceID := event.Subject
+	lat, lng := *event.Data.Latitude, *event.Data.Longitude
+
+	observedAt := event.Time
+	if observedAt.IsZero() {
+		observedAt = time.Now()
+	}
+
+	point, err := h3.LatLngToCell(lat, lng, h3.MaxResolution)
+	if err != nil {
+		return fmt.Errorf("invalid location for device %s: %w", userDeviceID, err)
+	}
+
+	tx, err := c.DBS.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	links, err := models.UserDeviceToGeofences(
+		models.UserDeviceToGeofenceWhere.UserDeviceID.EQ(userDeviceID),
+		qm.Load(models.UserDeviceToGeofenceRels.Geofence),
+		qm.Load(models.UserDeviceToGeofenceRels.UserDevice),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	triggers := make([]*models.UserDeviceToGeofence, 0, len(links))
+	for _, link := range links {
+		if t := link.R.Geofence.Type; t == models.GeofenceTypeTriggerEntry || t == models.GeofenceTypeTriggerExit {
+			triggers = append(triggers, link)
+		}
+	}
+
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	// Lock the states so that concurrent updates for the same vehicle can't
+	// both emit the same transition.
+	states, err := models.UserDeviceGeofenceStates(
+		models.UserDeviceGeofenceStateWhere.UserDeviceID.EQ(userDeviceID),
+		qm.For("UPDATE"),
+	).All(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	stateByFence := make(map[string]*models.UserDeviceGeofenceState, len(states))
+	for _, s := range states {
+		stateByFence[s.GeofenceID] = s
+	}
+
+	var events []*shared.CloudEvent[any]
+
+	for _, link := range triggers {
+		fence := link.R.Geofence
+		inside := Contains(fence.H3Indexes, point)
+
+		state, ok := stateByFence[fence.ID]
+		if ok && observedAt.Before(state.ObservedAt) {
+			// Out-of-order message.
+			continue
+		}
+
+		if ok && state.Inside != inside {
+			active, err := IsActive(fence, observedAt)
+			if err != nil {
+				return err
+			}
+
+			// Outside of its schedule, a fence still tracks the vehicle but
+			// doesn't fire.
+			if eventType, emit := transitionEvent(fence.Type, inside); emit && active {
+				events = append(events, &shared.CloudEvent[any]{
+					Type:    eventType,
+					Source:  "devices-api",
+					Subject: userDeviceID,
+					Data: services.UserDeviceGeofenceEvent{
+						Timestamp: observedAt,
+						UserID:    link.R.UserDevice.UserID,
+						Device: services.UserDeviceEventDevice{
+							ID:           userDeviceID,
+							DefinitionID: link.R.UserDevice.DefinitionID,
+						},
+						Geofence: services.UserDeviceEventGeofence{
+							ID:   fence.ID,
+							Name: fence.Name,
+							Type: fence.Type,
+						},
+						Latitude:  lat,
+						Longitude: lng,
+					},
+				})
+			}
+		}
+
+		if !ok {
+			state = &models.UserDeviceGeofenceState{
+				UserDeviceID: userDeviceID,
+				GeofenceID:   fence.ID,
+			}
+		}
+		state.Inside = inside
+		state.ObservedAt = observedAt
+
+		if err := state.Upsert(ctx, tx, true,
+			[]string{models.UserDeviceGeofenceStateColumns.UserDeviceID, models.UserDeviceGeofenceStateColumns.GeofenceID},
+			boil.Whitelist(models.UserDeviceGeofenceStateColumns.Inside, models.UserDeviceGeofenceStateColumns.ObservedAt, models.UserDeviceGeofenceStateColumns.UpdatedAt),
+			boil.Infer(),
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := c.eventService.Emit(e); err != nil {
+			c.logger.Err(err).Str("userDeviceId", userDeviceID).Str("eventType", e.Type).Msg("Failed to emit geofence event.")
+		}
+	}
+
+	return nil
+}
+
+// transitionEvent gives the event type for a change in whether a vehicle is
+// inside a fence, if the fence's type asks for one.
+func transitionEvent(fenceType string, inside bool) (string, bool) {
+	switch {
+	case inside && fenceType == models.GeofenceTypeTriggerEntry:
+		return EntryEventType, true
+	case !inside && fenceType == models.GeofenceTypeTriggerExit:
+		return ExitEventType, true
+	default:
+		return "", false
+	}
+}
+
+// Contains reports whether the point, a cell at any resolution, lies within
+// one of the fence's cells. Unparseable fence cells are skipped.
+func Contains(indexes []string, point h3.Cell) bool {
+	res := point.Resolution()
+	for _, s := range indexes {
+		cell, err := h3.ParseCell(s)
+		if err != nil || cell.Resolution() > res {
+			continue
+		}
+		if point.Parent(cell.Resolution()) == cell {
+			return true
+		}
+	}
+	return false
+}
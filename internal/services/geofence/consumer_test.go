@@ -0,0 +1,212 @@
+package geofence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/h3"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"go.uber.org/mock/gomock"
+)
+
+const migrationsDirRelPath = "../../../migrations"
+
+type ConsumerTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	mockCtrl  *gomock.Controller
+	eventSvc  *mock_services.MockEventService
+	cons      *Consumer
+}
+
+func (s *ConsumerTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container = test.StartContainerDatabase(s.ctx, s.T(), migrationsDirRelPath)
+	s.mockCtrl = gomock.NewController(s.T())
+	s.eventSvc = mock_services.NewMockEventService(s.mockCtrl)
+	s.cons = NewConsumer(s.pdb, test.Logger(), s.eventSvc)
+}
+
+func (s *ConsumerTestSuite) TearDownTest() {
+	test.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+func (s *ConsumerTestSuite) TearDownSuite() {
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+	s.mockCtrl.Finish()
+}
+
+func TestConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(ConsumerTestSuite))
+}
+
+// Inside the res-9 cell 8928308280fffff.
+const insideLat, insideLng = 37.775938728915946, -122.41795063018799
+
+const outsideLat, outsideLng = 40.7128, -74.006
+
+func (s *ConsumerTestSuite) createFence(ud *models.UserDevice, fenceType string) *models.Geofence {
+	gf := models.Geofence{
+		ID:        ksuid.New().String(),
+		UserID:    ud.UserID,
+		Name:      "Home",
+		Type:      fenceType,
+		H3Indexes: types.StringArray{"8928308280fffff"},
+	}
+	s.Require().NoError(gf.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	link := models.UserDeviceToGeofence{UserDeviceID: ud.ID, GeofenceID: gf.ID}
+	s.Require().NoError(link.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+
+	return &gf
+}
+
+func (s *ConsumerTestSuite) location(udID string, at time.Time, lat, lng float64) *shared.CloudEvent[LocationData] {
+	return &shared.CloudEvent[LocationData]{
+		Subject: udID,
+		Time:    at,
+		Data:    LocationData{Latitude: &lat, Longitude: &lng},
+	}
+}
+
+func (s *ConsumerTestSuite) TestEntryAndExit() {
+	ud := test.SetupCreateUserDevice(s.T(), "louxUser", ksuid.New().String(), nil, "", s.pdb)
+	entry := s.createFence(&ud, models.GeofenceTypeTriggerEntry)
+	exit := s.createFence(&ud, models.GeofenceTypeTriggerExit)
+
+	start := time.Now().Truncate(time.Second)
+
+	// The first location only records where the vehicle starts.
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start, outsideLat, outsideLng)))
+
+	var emitted []*shared.CloudEvent[any]
+	s.eventSvc.EXPECT().Emit(gomock.Any()).DoAndReturn(func(event *shared.CloudEvent[any]) error {
+		emitted = append(emitted, event)
+		return nil
+	}).Times(2)
+
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start.Add(time.Minute), insideLat, insideLng)))
+	// Staying inside doesn't emit again.
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start.Add(2*time.Minute), insideLat, insideLng)))
+	// A stale location is ignored.
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start.Add(30*time.Second), outsideLat, outsideLng)))
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start.Add(3*time.Minute), outsideLat, outsideLng)))
+
+	s.Require().Len(emitted, 2)
+
+	s.Equal(EntryEventType, emitted[0].Type)
+	s.Equal(ud.ID, emitted[0].Subject)
+	s.Equal(entry.ID, emitted[0].Data.(services.UserDeviceGeofenceEvent).Geofence.ID)
+
+	s.Equal(ExitEventType, emitted[1].Type)
+	s.Equal(exit.ID, emitted[1].Data.(services.UserDeviceGeofenceEvent).Geofence.ID)
+
+	state, err := models.FindUserDeviceGeofenceState(s.ctx, s.pdb.DBS().Reader, ud.ID, entry.ID)
+	s.Require().NoError(err)
+	s.False(state.Inside)
+	s.True(state.ObservedAt.Equal(start.Add(3 * time.Minute)))
+}
+
+func (s *ConsumerTestSuite) TestIgnoresOtherFenceTypes() {
+	ud := test.SetupCreateUserDevice(s.T(), "louxUser", ksuid.New().String(), nil, "", s.pdb)
+	test.SetupCreateGeofence(s.T(), ud.UserID, "Privacy", &ud, s.pdb)
+
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, time.Now(), insideLat, insideLng)))
+
+	count, err := models.UserDeviceGeofenceStates().Count(s.ctx, s.pdb.DBS().Reader)
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
+func (s *ConsumerTestSuite) TestInactiveFenceDoesNotFire() {
+	ud := test.SetupCreateUserDevice(s.T(), "louxUser", ksuid.New().String(), nil, "", s.pdb)
+	gf := s.createFence(&ud, models.GeofenceTypeTriggerEntry)
+
+	start := time.Now().Truncate(time.Second)
+
+	gf.ActiveFrom = null.TimeFrom(start.Add(time.Hour))
+	_, err := gf.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start, outsideLat, outsideLng)))
+	// No call to Emit expected.
+	s.Require().NoError(s.cons.HandleLocation(s.ctx, s.location(ud.ID, start.Add(time.Minute), insideLat, insideLng)))
+
+	state, err := models.FindUserDeviceGeofenceState(s.ctx, s.pdb.DBS().Reader, ud.ID, gf.ID)
+	s.Require().NoError(err)
+	s.True(state.Inside)
+}
+
+func (s *ConsumerTestSuite) TestScheduleWatcher() {
+	ud := test.SetupCreateUserDevice(s.T(), "louxUser", ksuid.New().String(), nil, "", s.pdb)
+	gf := s.createFence(&ud, models.GeofenceTypePrivacyFence)
+
+	start := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	gf.ActiveUntil = null.TimeFrom(start.Add(time.Hour))
+	_, err := gf.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	// An unscheduled fence on the same vehicle.
+	s.createFence(&ud, models.GeofenceTypePrivacyFence)
+
+	// A fence whose schedule can't be read, on another vehicle.
+	ud2 := test.SetupCreateUserDevice(s.T(), "louxUser", ksuid.New().String(), nil, "", s.pdb)
+	broken := s.createFence(&ud2, models.GeofenceTypePrivacyFence)
+	broken.Schedule = null.JSONFrom([]byte(`{"timeZone": "Nowhere/Special"}`))
+	_, err = broken.Update(s.ctx, s.pdb.DBS().Writer, boil.Infer())
+	s.Require().NoError(err)
+
+	var emitted []string
+	emit := func(_ context.Context, _ boil.ContextExecutor, userDeviceID string, _ types.NullDecimal) error {
+		emitted = append(emitted, userDeviceID)
+		return nil
+	}
+
+	// Two replicas.
+	watcher := NewScheduleWatcher(s.pdb.DBS, test.Logger(), emit, time.Minute)
+	other := NewScheduleWatcher(s.pdb.DBS, test.Logger(), emit, time.Minute)
+
+	// The first check publishes every fence that never has been.
+	s.Require().NoError(watcher.Check(s.ctx, start))
+	s.Equal([]string{ud.ID}, emitted)
+
+	s.Require().NoError(other.Check(s.ctx, start.Add(time.Minute)))
+	s.Len(emitted, 1)
+
+	s.Require().NoError(other.Check(s.ctx, start.Add(time.Hour)))
+	s.Equal([]string{ud.ID, ud.ID}, emitted)
+
+	s.Require().NoError(watcher.Check(s.ctx, start.Add(time.Hour+time.Minute)))
+	s.Len(emitted, 2)
+
+	s.Require().NoError(gf.Reload(s.ctx, s.pdb.DBS().Reader))
+	s.Equal(null.BoolFrom(false), gf.PublishedActive)
+}
+
+func TestContains(t *testing.T) {
+	point, err := h3.LatLngToCell(insideLat, insideLng, h3.MaxResolution)
+	require.NoError(t, err)
+
+	assert.True(t, Contains([]string{"8928308280fffff"}, point))
+	assert.True(t, Contains([]string{"not a cell", "832830fffffffff"}, point))
+	assert.False(t, Contains([]string{"8c2a107289061ff"}, point))
+	assert.False(t, Contains(nil, point))
+}
@@ -0,0 +1,124 @@
+package geofence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+)
+
+// Schedule limits a geofence to certain times of the week.
+type Schedule struct {
+	// TimeZone is an IANA time zone name, such as "America/New_York".
+	TimeZone string `json:"timeZone" example:"America/New_York"`
+	// Windows are the times during which the fence is active.
+	Windows []Window `json:"windows"`
+}
+
+// Window is a daily time window. If End is not after Start then the window
+// runs past midnight into the next day.
+type Window struct {
+	// Days the window starts on, from "Mon" to "Sun". Empty means every day.
+	Days []string `json:"days" example:"Mon,Tue,Wed,Thu,Fri"`
+	// Start is a local time of the form "15:04".
+	Start string `json:"start" example:"22:00"`
+	// End is a local time of the form "15:04".
+	End string `json:"end" example:"06:00"`
+}
+
+var dayNames = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+const clockLayout = "15:04"
+
+// Validate checks the time zone, days, and times.
+func (s Schedule) Validate() error {
+	if s.TimeZone == "" {
+		return errors.New("timeZone is required")
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %q", s.TimeZone)
+	}
+	if len(s.Windows) == 0 {
+		return errors.New("at least one window is required")
+	}
+	for i, w := range s.Windows {
+		for _, d := range w.Days {
+			if !slices.Contains(dayNames, d) {
+				return fmt.Errorf("window %d: day %q must be one of %v", i, d, dayNames)
+			}
+		}
+		if _, err := time.Parse(clockLayout, w.Start); err != nil {
+			return fmt.Errorf("window %d: start must look like 22:00", i)
+		}
+		if _, err := time.Parse(clockLayout, w.End); err != nil {
+			return fmt.Errorf("window %d: end must look like 06:00", i)
+		}
+	}
+	return nil
+}
+
+// ActiveAt reports whether t falls inside one of the windows. The schedule
+// must be valid.
+func (s *Schedule) ActiveAt(t time.Time) bool {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+
+	for _, w := range s.Windows {
+		start, _ := time.Parse(clockLayout, w.Start)
+		end, _ := time.Parse(clockLayout, w.End)
+
+		// A window that started yesterday may still be open.
+		for _, offset := range []int{0, -1} {
+			day := time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, loc)
+			if len(w.Days) != 0 && !slices.Contains(w.Days, dayNames[day.Weekday()]) {
+				continue
+			}
+
+			from := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+			to := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+			if !to.After(from) {
+				to = time.Date(day.Year(), day.Month(), day.Day()+1, end.Hour(), end.Minute(), 0, 0, loc)
+			}
+
+			if !t.Before(from) && t.Before(to) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsScheduled reports whether the fence is limited in time at all.
+func IsScheduled(fence *models.Geofence) bool {
+	return fence.Schedule.Valid || fence.ActiveFrom.Valid || fence.ActiveUntil.Valid
+}
+
+// IsActive reports whether the fence applies at time t, taking into account
+// both its start and end dates and its weekly schedule.
+func IsActive(fence *models.Geofence, t time.Time) (bool, error) {
+	if fence.ActiveFrom.Valid && t.Before(fence.ActiveFrom.Time) {
+		return false, nil
+	}
+	if fence.ActiveUntil.Valid && !t.Before(fence.ActiveUntil.Time) {
+		return false, nil
+	}
+	if !fence.Schedule.Valid {
+		return true, nil
+	}
+
+	var s Schedule
+	if err := fence.Schedule.Unmarshal(&s); err != nil {
+		return false, fmt.Errorf("couldn't parse schedule for geofence %s: %w", fence.ID, err)
+	}
+	if err := s.Validate(); err != nil {
+		return false, fmt.Errorf("invalid schedule for geofence %s: %w", fence.ID, err)
+	}
+
+	return s.ActiveAt(t), nil
+}
@@ -0,0 +1,100 @@
+package geofence
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestSchedule_ActiveAt(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	nights := Schedule{
+		TimeZone: "America/New_York",
+		Windows:  []Window{{Start: "22:00", End: "06:00"}},
+	}
+	workHours := Schedule{
+		TimeZone: "America/New_York",
+		Windows:  []Window{{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"}},
+	}
+	fridayNights := Schedule{
+		TimeZone: "America/New_York",
+		Windows:  []Window{{Days: []string{"Fri"}, Start: "20:00", End: "02:00"}},
+	}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		at       time.Time
+		active   bool
+	}{
+		{"late evening", nights, time.Date(2026, 10, 14, 23, 0, 0, 0, ny), true},
+		{"early morning", nights, time.Date(2026, 10, 14, 5, 59, 0, 0, ny), true},
+		{"end is exclusive", nights, time.Date(2026, 10, 14, 6, 0, 0, 0, ny), false},
+		{"afternoon", nights, time.Date(2026, 10, 14, 15, 0, 0, 0, ny), false},
+		{"start in other zone", nights, time.Date(2026, 10, 15, 2, 0, 0, 0, time.UTC), true},
+		{"weekday", workHours, time.Date(2026, 10, 16, 12, 0, 0, 0, ny), true},
+		{"weekend", workHours, time.Date(2026, 10, 17, 12, 0, 0, 0, ny), false},
+		{"past midnight into saturday", fridayNights, time.Date(2026, 10, 17, 1, 0, 0, 0, ny), true},
+		{"past midnight into friday", fridayNights, time.Date(2026, 10, 16, 1, 0, 0, 0, ny), false},
+		// Clocks go back at 2:00 on Nov 1st, so the night is an hour longer.
+		{"daylight saving", nights, time.Date(2026, 11, 1, 10, 30, 0, 0, time.UTC), true},
+		{"daylight saving end", nights, time.Date(2026, 11, 1, 11, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.schedule.Validate())
+			assert.Equal(t, tt.active, tt.schedule.ActiveAt(tt.at))
+		})
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	assert.Error(t, Schedule{Windows: []Window{{Start: "22:00", End: "06:00"}}}.Validate())
+	assert.Error(t, Schedule{TimeZone: "Mars/Olympus_Mons", Windows: []Window{{Start: "22:00", End: "06:00"}}}.Validate())
+	assert.Error(t, Schedule{TimeZone: "UTC"}.Validate())
+	assert.Error(t, Schedule{TimeZone: "UTC", Windows: []Window{{Days: []string{"Monday"}, Start: "22:00", End: "06:00"}}}.Validate())
+	assert.Error(t, Schedule{TimeZone: "UTC", Windows: []Window{{Start: "10pm", End: "06:00"}}}.Validate())
+	assert.Error(t, Schedule{TimeZone: "UTC", Windows: []Window{{Start: "22:00", End: "24:00"}}}.Validate())
+}
+
+func TestIsActive(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	fence := &models.Geofence{}
+	active, err := IsActive(fence, now)
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.False(t, IsScheduled(fence))
+
+	fence.ActiveFrom = null.TimeFrom(now.Add(time.Hour))
+	active, err = IsActive(fence, now)
+	require.NoError(t, err)
+	assert.False(t, active)
+	assert.True(t, IsScheduled(fence))
+
+	fence.ActiveFrom = null.TimeFrom(now.Add(-time.Hour))
+	fence.ActiveUntil = null.TimeFrom(now)
+	active, err = IsActive(fence, now)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	fence.ActiveUntil = null.Time{}
+	b, err := json.Marshal(Schedule{TimeZone: "UTC", Windows: []Window{{Start: "11:00", End: "13:00"}}})
+	require.NoError(t, err)
+	fence.Schedule = null.JSONFrom(b)
+	active, err = IsActive(fence, now)
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	fence.Schedule = null.JSONFrom([]byte(`{"timeZone": "Nowhere"}`))
+	_, err = IsActive(fence, now)
+	assert.Error(t, err)
+}
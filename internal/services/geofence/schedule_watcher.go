@@ -0,0 +1,133 @@
+package geofence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ScheduleWatcher republishes the privacy fences of attached vehicles when a
+// scheduled privacy fence turns on or off. Each fence remembers the state it
+// was last published in, so every replica can run a watcher: fences are locked
+// while they're worked on, and a boundary is handled by whichever replica gets
+// to it first.
+type ScheduleWatcher struct {
+	dbs      func() *db.ReaderWriter
+	log      *zerolog.Logger
+	emit     services.PrivacyFenceEmitter
+	interval time.Duration
+}
+
+// NewScheduleWatcher creates a watcher that checks for schedule boundaries
+// every interval.
+func NewScheduleWatcher(dbs func() *db.ReaderWriter, log *zerolog.Logger, emit services.PrivacyFenceEmitter, interval time.Duration) *ScheduleWatcher {
+	return &ScheduleWatcher{dbs: dbs, log: log, emit: emit, interval: interval}
+}
+
+// Run checks on a timer until the context is cancelled.
+func (w *ScheduleWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Check(ctx, time.Now()); err != nil {
+			w.log.Err(err).Msg("Failed to republish scheduled privacy fences.")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check republishes fences for every vehicle attached to a scheduled privacy
+// fence whose state differs from the one last published. A fence's new state
+// is only recorded once its vehicles are updated, so a failure means a repeat
+// rather than a miss.
+func (w *ScheduleWatcher) Check(ctx context.Context, now time.Time) error {
+	fences, err := models.Geofences(
+		models.GeofenceWhere.Type.EQ(models.GeofenceTypePrivacyFence),
+		qm.Expr(
+			models.GeofenceWhere.Schedule.IsNotNull(),
+			qm.Or2(models.GeofenceWhere.ActiveFrom.IsNotNull()),
+			qm.Or2(models.GeofenceWhere.ActiveUntil.IsNotNull()),
+		),
+		qm.Select(models.GeofenceColumns.ID),
+	).All(ctx, w.dbs().Reader)
+	if err != nil {
+		return err
+	}
+
+	// A vehicle's update covers all of its fences, so it only needs one per check.
+	published := make(map[string]bool)
+
+	for _, fence := range fences {
+		if err := w.checkFence(ctx, fence.ID, now, published); err != nil {
+			// Try again next time.
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkFence republishes the vehicles attached to the fence if its state has
+// changed, unless another replica is already looking at it.
+func (w *ScheduleWatcher) checkFence(ctx context.Context, fenceID string, now time.Time, published map[string]bool) error {
+	tx, err := w.dbs().Writer.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	fence, err := models.Geofences(
+		models.GeofenceWhere.ID.EQ(fenceID),
+		qm.Load(qm.Rels(models.GeofenceRels.UserDeviceToGeofences, models.UserDeviceToGeofenceRels.UserDevice)),
+		qm.For("UPDATE SKIP LOCKED"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted, or another replica has it.
+			return nil
+		}
+		return err
+	}
+
+	active, err := IsActive(fence, now)
+	if err != nil {
+		w.log.Err(err).Str("geofenceId", fence.ID).Msg("Skipping geofence.")
+		return nil
+	}
+
+	if fence.PublishedActive.Valid && fence.PublishedActive.Bool == active {
+		return nil
+	}
+
+	for _, rel := range fence.R.UserDeviceToGeofences {
+		if published[rel.UserDeviceID] {
+			continue
+		}
+		if err := w.emit(ctx, tx, rel.UserDeviceID, rel.R.UserDevice.TokenID); err != nil {
+			return err
+		}
+		published[rel.UserDeviceID] = true
+	}
+
+	fence.PublishedActive = null.BoolFrom(active)
+	if _, err := fence.Update(ctx, tx, boil.Whitelist(models.GeofenceColumns.PublishedActive)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
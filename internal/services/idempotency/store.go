@@ -0,0 +1,154 @@
+// Package idempotency remembers the outcome of requests that carry an
+// idempotency key, so that a client retrying one gets the original result
+// instead of running it a second time.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+const uniqueViolation = "23505"
+
+var (
+	// ErrInProgress is returned when an earlier request with the same key has
+	// not finished yet.
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+	// ErrKeyReused is returned when the key was already used for a request with
+	// a different payload.
+	ErrKeyReused = errors.New("idempotency key was used for a different request")
+)
+
+// Store keeps idempotency records in Postgres. Records older than the window
+// are treated as if they did not exist.
+type Store struct {
+	dbs    func() *db.ReaderWriter
+	window time.Duration
+}
+
+// NewStore creates a store whose keys are honored for the given window.
+func NewStore(dbs func() *db.ReaderWriter, window time.Duration) *Store {
+	return &Store{dbs: dbs, window: window}
+}
+
+// Hash fingerprints a request payload for Claim.
+func Hash(payload []byte) []byte {
+	h := sha256.Sum256(payload)
+	return h[:]
+}
+
+// Claim reserves the key within the scope for a new request. If it returns a
+// nil record then the caller owns the key and must later call Complete or
+// Release. Otherwise the record holds the stored response of the original
+// request. ErrInProgress and ErrKeyReused are returned when there is nothing
+// to replay.
+func (s *Store) Claim(ctx context.Context, scope, key string, requestHash []byte) (*models.IdempotencyKey, error) {
+	// Expired keys may be reused, so get rid of any leftover record first.
+	_, err := models.IdempotencyKeys(
+		models.IdempotencyKeyWhere.Scope.EQ(scope),
+		models.IdempotencyKeyWhere.Key.EQ(key),
+		models.IdempotencyKeyWhere.CreatedAt.LT(time.Now().Add(-s.window)),
+	).DeleteAll(ctx, s.dbs().Writer)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &models.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+	}
+
+	err = rec.Insert(ctx, s.dbs().Writer, boil.Infer())
+	if err == nil {
+		return nil, nil
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != uniqueViolation {
+		return nil, err
+	}
+
+	rec, err = models.FindIdempotencyKey(ctx, s.dbs().Writer, scope, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Released between our insert and our read. Let the client retry.
+			return nil, ErrInProgress
+		}
+		return nil, err
+	}
+
+	if !bytes.Equal(rec.RequestHash, requestHash) {
+		return nil, ErrKeyReused
+	}
+
+	if !rec.StatusCode.Valid {
+		return nil, ErrInProgress
+	}
+
+	return rec, nil
+}
+
+// Complete stores the response for a key obtained from Claim.
+func (s *Store) Complete(ctx context.Context, scope, key string, statusCode int, body []byte) error {
+	_, err := models.IdempotencyKeys(
+		models.IdempotencyKeyWhere.Scope.EQ(scope),
+		models.IdempotencyKeyWhere.Key.EQ(key),
+	).UpdateAll(ctx, s.dbs().Writer, models.M{
+		models.IdempotencyKeyColumns.StatusCode:   null.IntFrom(statusCode),
+		models.IdempotencyKeyColumns.ResponseBody: null.BytesFrom(body),
+	})
+	return err
+}
+
+// Release gives up a key obtained from Claim without storing a response, so
+// that the request can be retried.
+func (s *Store) Release(ctx context.Context, scope, key string) error {
+	_, err := models.IdempotencyKeys(
+		models.IdempotencyKeyWhere.Scope.EQ(scope),
+		models.IdempotencyKeyWhere.Key.EQ(key),
+	).DeleteAll(ctx, s.dbs().Writer)
+	return err
+}
+
+// Purge deletes every record older than the window and returns how many it
+// removed. Claim only clears out an expired record when its key comes back, so
+// without this the table keeps every key ever used.
+func (s *Store) Purge(ctx context.Context) (int64, error) {
+	return models.IdempotencyKeys(
+		models.IdempotencyKeyWhere.CreatedAt.LT(time.Now().Add(-s.window)),
+	).DeleteAll(ctx, s.dbs().Writer)
+}
+
+// Run purges expired records every interval until the context is cancelled.
+func (s *Store) Run(ctx context.Context, log *zerolog.Logger, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.Purge(ctx)
+			if err != nil {
+				log.Err(err).Msg("Failed to purge expired idempotency keys.")
+				continue
+			}
+			if n != 0 {
+				log.Info().Int64("count", n).Msg("Purged expired idempotency keys.")
+			}
+		}
+	}
+}
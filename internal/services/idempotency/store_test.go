@@ -0,0 +1,44 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestStorePurge(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, "../../../migrations")
+	defer container.Terminate(ctx) //nolint
+
+	store := NewStore(pdb.DBS, time.Hour)
+
+	insert := func(key string, age time.Duration) *models.IdempotencyKey {
+		rec := &models.IdempotencyKey{
+			Scope:       "user POST /commands",
+			Key:         key,
+			RequestHash: Hash([]byte(key)),
+			CreatedAt:   time.Now().Add(-age),
+		}
+		require.NoError(t, rec.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return rec
+	}
+
+	insert("expired", 2*time.Hour)
+	fresh := insert("fresh", time.Minute)
+
+	n, err := store.Purge(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	keys, err := models.IdempotencyKeys().All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, fresh.Key, keys[0].Key)
+}
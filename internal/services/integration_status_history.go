@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// Where integration status changes come from. These are stored in the status
+// history.
+const (
+	IntegrationStatusSourceRegistration     = "registration"
+	IntegrationStatusSourceReauthentication = "reauthentication"
+	IntegrationStatusSourceTask             = "task"
+	IntegrationStatusSourceWebhook          = "webhook"
+	IntegrationStatusSourceContractEvent    = "contract_event"
+	IntegrationStatusSourceTokenRefresh     = "token_refresh"
+	IntegrationStatusSourceActivity         = "activity"
+	IntegrationStatusSourceUser             = "user"
+	IntegrationStatusSourceRPC              = "rpc"
+	IntegrationStatusSourceAdmin            = "admin"
+)
+
+// RecordIntegrationStatusChange stores the integration's move from the status
+// from to its current status. An empty from means that the integration was
+// just created. Nothing is stored if the status didn't change.
+func RecordIntegrationStatusChange(ctx context.Context, exec boil.ContextExecutor, udai *models.UserDeviceAPIIntegration, from, source, reason string) error {
+	if from == udai.Status {
+		return nil
+	}
+
+	h := models.UserDeviceAPIIntegrationStatusHistory{
+		ID:            ksuid.New().String(),
+		UserDeviceID:  udai.UserDeviceID,
+		IntegrationID: udai.IntegrationID,
+		FromStatus:    null.NewString(from, from != ""),
+		ToStatus:      null.StringFrom(udai.Status),
+		Source:        source,
+		Reason:        null.NewString(reason, reason != ""),
+	}
+
+	return h.Insert(ctx, exec, boil.Infer())
+}
+
+// RecordIntegrationRemoval stores the deletion of the integration.
+func RecordIntegrationRemoval(ctx context.Context, exec boil.ContextExecutor, udai *models.UserDeviceAPIIntegration, source, reason string) error {
+	h := models.UserDeviceAPIIntegrationStatusHistory{
+		ID:            ksuid.New().String(),
+		UserDeviceID:  udai.UserDeviceID,
+		IntegrationID: udai.IntegrationID,
+		FromStatus:    null.StringFrom(udai.Status),
+		Source:        source,
+		Reason:        null.NewString(reason, reason != ""),
+	}
+
+	return h.Insert(ctx, exec, boil.Infer())
+}
@@ -0,0 +1,60 @@
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// AESGCMCipher is a shared.Cipher that encrypts locally with AES-GCM, for
+// deployments without KMS. Ciphertext is the base64 of the nonce followed by
+// the sealed data.
+type AESGCMCipher struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMCipher creates a cipher from a 16, 24, or 32 byte key.
+func NewAESGCMCipher(key []byte) (*AESGCMCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESGCMCipher{aead: aead}, nil
+}
+
+func (c *AESGCMCipher) Encrypt(s string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(c.aead.Seal(nonce, nonce, []byte(s), nil)), nil
+}
+
+func (c *AESGCMCipher) Decrypt(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+
+	if len(b) < c.aead.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+
+	nonce, sealed := b[:c.aead.NonceSize()], b[c.aead.NonceSize():]
+	out, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,159 @@
+// Package keyring encrypts stored credentials under named keys, so that the
+// key used for new ciphertext can change while old ciphertext stays readable.
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/DIMO-Network/shared"
+)
+
+// separator divides the key id from the backend's ciphertext. It never
+// appears in the base64 produced by the KMS and AES-GCM backends, so legacy
+// ciphertext can't be mistaken for the new format.
+const separator = "$"
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Keyring is a shared.Cipher that prefixes ciphertext with the id of the key
+// that produced it: "<keyID>$<ciphertext>". New values are always encrypted
+// with the current key. Values without a prefix predate key ids and are
+// decrypted with the legacy cipher.
+type Keyring struct {
+	current string
+	keys    map[string]shared.Cipher
+	legacy  shared.Cipher
+}
+
+// New creates a keyring that encrypts with the key named current. If current
+// is empty then values are encrypted with the legacy cipher, without a prefix,
+// as they were before keyrings existed. Either keys or legacy may be empty,
+// but not both.
+func New(current string, keys map[string]shared.Cipher, legacy shared.Cipher) (*Keyring, error) {
+	for id := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid key id %q: only letters, digits, underscores, and dashes are allowed", id)
+		}
+	}
+
+	if current == "" {
+		if legacy == nil {
+			return nil, errors.New("no current key and no legacy cipher")
+		}
+	} else if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("current key %q is not in the keyring", current)
+	}
+
+	return &Keyring{current: current, keys: keys, legacy: legacy}, nil
+}
+
+// CurrentKeyID returns the id of the key used for new ciphertext. This is
+// empty if the legacy cipher is still in use.
+func (k *Keyring) CurrentKeyID() string {
+	return k.current
+}
+
+// KeyID returns the id of the key that produced the ciphertext, or the empty
+// string if it was produced by the legacy cipher.
+func KeyID(ciphertext string) string {
+	id, _, found := strings.Cut(ciphertext, separator)
+	if !found || !keyIDPattern.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+func (k *Keyring) Encrypt(s string) (string, error) {
+	if k.current == "" {
+		return k.legacy.Encrypt(s)
+	}
+
+	c, err := k.keys[k.current].Encrypt(s)
+	if err != nil {
+		return "", err
+	}
+
+	return k.current + separator + c, nil
+}
+
+func (k *Keyring) Decrypt(s string) (string, error) {
+	if id := KeyID(s); id != "" {
+		if c, ok := k.keys[id]; ok {
+			return c.Decrypt(s[len(id)+len(separator):])
+		}
+		if k.legacy == nil {
+			return "", fmt.Errorf("ciphertext was produced by key %q, which is not in the keyring", id)
+		}
+	}
+
+	if k.legacy == nil {
+		return "", errors.New("ciphertext has no key id and there is no legacy cipher")
+	}
+
+	return k.legacy.Decrypt(s)
+}
+
+// NeedsRotation reports whether the ciphertext was produced by something
+// other than the current key.
+func (k *Keyring) NeedsRotation(ciphertext string) bool {
+	return KeyID(ciphertext) != k.current
+}
+
+// ToLegacy returns the value encrypted with the legacy cipher, decrypting and
+// re-encrypting it if another key produced it. Services that only know the
+// legacy key, such as the polling tasks, need this form.
+func (k *Keyring) ToLegacy(ciphertext string) (string, error) {
+	if KeyID(ciphertext) == "" {
+		return ciphertext, nil
+	}
+	if k.legacy == nil {
+		return "", errors.New("there is no legacy cipher")
+	}
+
+	s, err := k.Decrypt(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return k.legacy.Encrypt(s)
+}
+
+// ToCurrent returns the value encrypted with the current key, decrypting and
+// re-encrypting it if another key produced it.
+func (k *Keyring) ToCurrent(ciphertext string) (string, error) {
+	if !k.NeedsRotation(ciphertext) {
+		return ciphertext, nil
+	}
+
+	s, err := k.Decrypt(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Encrypt(s)
+}
+
+// ParseKeys parses a comma-separated list of "keyID:material" pairs. The
+// material is backend-specific, and may itself contain colons.
+func ParseKeys(s string) (map[string]string, error) {
+	out := make(map[string]string)
+	if s == "" {
+		return out, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		id, material, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if !found || id == "" || material == "" {
+			return nil, fmt.Errorf("key entry %q is not of the form id:material", pair)
+		}
+		if _, ok := out[id]; ok {
+			return nil, fmt.Errorf("key %q is listed more than once", id)
+		}
+		out[id] = material
+	}
+
+	return out, nil
+}
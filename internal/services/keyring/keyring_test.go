@@ -0,0 +1,132 @@
+package keyring
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func mustAES(t *testing.T, b byte) *AESGCMCipher {
+	c, err := NewAESGCMCipher(bytes.Repeat([]byte{b}, 32))
+	require.NoError(t, err)
+	return c
+}
+
+func TestKeyringRotation(t *testing.T) {
+	legacy := new(shared.ROT13Cipher)
+	k1, k2 := mustAES(t, 1), mustAES(t, 2)
+
+	legacyCT, err := legacy.Encrypt("secret")
+	require.NoError(t, err)
+
+	before, err := New("k1", map[string]shared.Cipher{"k1": k1}, legacy)
+	require.NoError(t, err)
+
+	oldCT, err := before.Encrypt("secret")
+	require.NoError(t, err)
+	require.Equal(t, "k1", KeyID(oldCT))
+	require.True(t, before.NeedsRotation(legacyCT))
+	require.False(t, before.NeedsRotation(oldCT))
+
+	after, err := New("k2", map[string]shared.Cipher{"k1": k1, "k2": k2}, legacy)
+	require.NoError(t, err)
+
+	// Everything written before the rotation is still readable.
+	for _, ct := range []string{legacyCT, oldCT} {
+		pt, err := after.Decrypt(ct)
+		require.NoError(t, err)
+		require.Equal(t, "secret", pt)
+		require.True(t, after.NeedsRotation(ct))
+	}
+
+	newCT, err := after.Encrypt("secret")
+	require.NoError(t, err)
+	require.Equal(t, "k2", KeyID(newCT))
+	require.False(t, after.NeedsRotation(newCT))
+
+	// Once k1 is retired, its ciphertext can't be read.
+	retired, err := New("k2", map[string]shared.Cipher{"k2": k2}, nil)
+	require.NoError(t, err)
+	_, err = retired.Decrypt(oldCT)
+	require.Error(t, err)
+}
+
+func TestKeyringLegacyOnly(t *testing.T) {
+	legacy := new(shared.ROT13Cipher)
+
+	k, err := New("", nil, legacy)
+	require.NoError(t, err)
+
+	ct, err := k.Encrypt("secret")
+	require.NoError(t, err)
+	require.Equal(t, "frperg", ct)
+	require.False(t, k.NeedsRotation(ct))
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := New("missing", map[string]shared.Cipher{"k1": mustAES(t, 1)}, nil)
+	require.Error(t, err)
+
+	_, err = New("", nil, nil)
+	require.Error(t, err)
+
+	_, err = New("bad$id", map[string]shared.Cipher{"bad$id": mustAES(t, 1)}, nil)
+	require.Error(t, err)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("2024:arn:aws:kms:us-east-2:123:key/abc, 2025:def")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"2024": "arn:aws:kms:us-east-2:123:key/abc",
+		"2025": "def",
+	}, keys)
+
+	_, err = ParseKeys("nocolon")
+	require.Error(t, err)
+
+	_, err = ParseKeys("a:1,a:2")
+	require.Error(t, err)
+}
+
+func TestKeyringConversions(t *testing.T) {
+	legacy := new(shared.ROT13Cipher)
+
+	k, err := New("k2", map[string]shared.Cipher{"k1": mustAES(t, 1), "k2": mustAES(t, 2)}, legacy)
+	require.NoError(t, err)
+
+	legacyCT, err := legacy.Encrypt("secret")
+	require.NoError(t, err)
+	currentCT, err := k.Encrypt("secret")
+	require.NoError(t, err)
+
+	// Legacy ciphertext passes through untouched on the way to the tasks.
+	out, err := k.ToLegacy(legacyCT)
+	require.NoError(t, err)
+	require.Equal(t, legacyCT, out)
+
+	out, err = k.ToLegacy(currentCT)
+	require.NoError(t, err)
+	require.Equal(t, "", KeyID(out))
+	pt, err := legacy.Decrypt(out)
+	require.NoError(t, err)
+	require.Equal(t, "secret", pt)
+
+	out, err = k.ToCurrent(currentCT)
+	require.NoError(t, err)
+	require.Equal(t, currentCT, out)
+
+	out, err = k.ToCurrent(legacyCT)
+	require.NoError(t, err)
+	require.Equal(t, "k2", KeyID(out))
+	pt, err = k.Decrypt(out)
+	require.NoError(t, err)
+	require.Equal(t, "secret", pt)
+
+	noLegacy, err := New("k2", map[string]shared.Cipher{"k2": mustAES(t, 2)}, nil)
+	require.NoError(t, err)
+	_, err = noLegacy.ToLegacy(currentCT)
+	require.Error(t, err)
+}
@@ -57,17 +57,17 @@ func (mr *MockSmartcarClientMockRecorder) ExchangeCode(ctx, code, redirectURI an
 }
 
 // GetAvailableCommands mocks base method.
-func (m *MockSmartcarClient) GetAvailableCommands() *services.UserDeviceAPIIntegrationsMetadataCommands {
+func (m *MockSmartcarClient) GetAvailableCommands(permissions []string) *services.UserDeviceAPIIntegrationsMetadataCommands {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAvailableCommands")
+	ret := m.ctrl.Call(m, "GetAvailableCommands", permissions)
 	ret0, _ := ret[0].(*services.UserDeviceAPIIntegrationsMetadataCommands)
 	return ret0
 }
 
 // GetAvailableCommands indicates an expected call of GetAvailableCommands.
-func (mr *MockSmartcarClientMockRecorder) GetAvailableCommands() *gomock.Call {
+func (mr *MockSmartcarClientMockRecorder) GetAvailableCommands(permissions any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableCommands", reflect.TypeOf((*MockSmartcarClient)(nil).GetAvailableCommands))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableCommands", reflect.TypeOf((*MockSmartcarClient)(nil).GetAvailableCommands), permissions)
 }
 
 // GetEndpoints mocks base method.
@@ -115,6 +115,21 @@ func (mr *MockSmartcarClientMockRecorder) GetInfo(ctx, accessToken, id any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInfo", reflect.TypeOf((*MockSmartcarClient)(nil).GetInfo), ctx, accessToken, id)
 }
 
+// GetPermissions mocks base method.
+func (m *MockSmartcarClient) GetPermissions(ctx context.Context, accessToken, id string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissions", ctx, accessToken, id)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissions indicates an expected call of GetPermissions.
+func (mr *MockSmartcarClientMockRecorder) GetPermissions(ctx, accessToken, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissions", reflect.TypeOf((*MockSmartcarClient)(nil).GetPermissions), ctx, accessToken, id)
+}
+
 // GetUserID mocks base method.
 func (m *MockSmartcarClient) GetUserID(ctx context.Context, accessToken string) (string, error) {
 	m.ctrl.T.Helper()
@@ -145,17 +160,32 @@ func (mr *MockSmartcarClientMockRecorder) GetVIN(ctx, accessToken, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVIN", reflect.TypeOf((*MockSmartcarClient)(nil).GetVIN), ctx, accessToken, id)
 }
 
-// HasDoorControl mocks base method.
-func (m *MockSmartcarClient) HasDoorControl(ctx context.Context, accessToken, id string) (bool, error) {
+// GetVehicleIDs mocks base method.
+func (m *MockSmartcarClient) GetVehicleIDs(ctx context.Context, accessToken string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasDoorControl", ctx, accessToken, id)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetVehicleIDs", ctx, accessToken)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVehicleIDs indicates an expected call of GetVehicleIDs.
+func (mr *MockSmartcarClientMockRecorder) GetVehicleIDs(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleIDs", reflect.TypeOf((*MockSmartcarClient)(nil).GetVehicleIDs), ctx, accessToken)
+}
+
+// RefreshToken mocks base method.
+func (m *MockSmartcarClient) RefreshToken(ctx context.Context, refreshToken string) (*smartcar.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*smartcar.Token)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HasDoorControl indicates an expected call of HasDoorControl.
-func (mr *MockSmartcarClientMockRecorder) HasDoorControl(ctx, accessToken, id any) *gomock.Call {
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockSmartcarClientMockRecorder) RefreshToken(ctx, refreshToken any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDoorControl", reflect.TypeOf((*MockSmartcarClient)(nil).HasDoorControl), ctx, accessToken, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockSmartcarClient)(nil).RefreshToken), ctx, refreshToken)
 }
@@ -68,6 +68,51 @@ func (mr *MockSmartcarTaskServiceMockRecorder) Refresh(udai any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSmartcarTaskService)(nil).Refresh), udai)
 }
 
+// SetChargeLimit mocks base method.
+func (m *MockSmartcarTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargeLimit", udai, percent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChargeLimit indicates an expected call of SetChargeLimit.
+func (mr *MockSmartcarTaskServiceMockRecorder) SetChargeLimit(udai, percent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargeLimit", reflect.TypeOf((*MockSmartcarTaskService)(nil).SetChargeLimit), udai, percent)
+}
+
+// StartCharge mocks base method.
+func (m *MockSmartcarTaskService) StartCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCharge", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCharge indicates an expected call of StartCharge.
+func (mr *MockSmartcarTaskServiceMockRecorder) StartCharge(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCharge", reflect.TypeOf((*MockSmartcarTaskService)(nil).StartCharge), udai)
+}
+
+// StartClimate mocks base method.
+func (m *MockSmartcarTaskService) StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartClimate", udai, temperature)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartClimate indicates an expected call of StartClimate.
+func (mr *MockSmartcarTaskServiceMockRecorder) StartClimate(udai, temperature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartClimate", reflect.TypeOf((*MockSmartcarTaskService)(nil).StartClimate), udai, temperature)
+}
+
 // StartPoll mocks base method.
 func (m *MockSmartcarTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
 	m.ctrl.T.Helper()
@@ -82,6 +127,36 @@ func (mr *MockSmartcarTaskServiceMockRecorder) StartPoll(udai, sd any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPoll", reflect.TypeOf((*MockSmartcarTaskService)(nil).StartPoll), udai, sd)
 }
 
+// StopCharge mocks base method.
+func (m *MockSmartcarTaskService) StopCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCharge", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopCharge indicates an expected call of StopCharge.
+func (mr *MockSmartcarTaskServiceMockRecorder) StopCharge(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCharge", reflect.TypeOf((*MockSmartcarTaskService)(nil).StopCharge), udai)
+}
+
+// StopClimate mocks base method.
+func (m *MockSmartcarTaskService) StopClimate(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopClimate", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopClimate indicates an expected call of StopClimate.
+func (mr *MockSmartcarTaskServiceMockRecorder) StopClimate(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopClimate", reflect.TypeOf((*MockSmartcarTaskService)(nil).StopClimate), udai)
+}
+
 // StopPoll mocks base method.
 func (m *MockSmartcarTaskService) StopPoll(udai *models.UserDeviceAPIIntegration) error {
 	m.ctrl.T.Helper()
@@ -110,3 +185,17 @@ func (mr *MockSmartcarTaskServiceMockRecorder) UnlockDoors(udai any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockDoors", reflect.TypeOf((*MockSmartcarTaskService)(nil).UnlockDoors), udai)
 }
+
+// UpdateCredentials mocks base method.
+func (m *MockSmartcarTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", udai, sd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockSmartcarTaskServiceMockRecorder) UpdateCredentials(udai, sd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockSmartcarTaskService)(nil).UpdateCredentials), udai, sd)
+}
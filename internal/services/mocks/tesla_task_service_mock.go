@@ -84,6 +84,51 @@ func (mr *MockTeslaTaskServiceMockRecorder) OpenTrunk(udai any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenTrunk", reflect.TypeOf((*MockTeslaTaskService)(nil).OpenTrunk), udai)
 }
 
+// SetChargeLimit mocks base method.
+func (m *MockTeslaTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChargeLimit", udai, percent)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChargeLimit indicates an expected call of SetChargeLimit.
+func (mr *MockTeslaTaskServiceMockRecorder) SetChargeLimit(udai, percent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChargeLimit", reflect.TypeOf((*MockTeslaTaskService)(nil).SetChargeLimit), udai, percent)
+}
+
+// StartCharge mocks base method.
+func (m *MockTeslaTaskService) StartCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCharge", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCharge indicates an expected call of StartCharge.
+func (mr *MockTeslaTaskServiceMockRecorder) StartCharge(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCharge", reflect.TypeOf((*MockTeslaTaskService)(nil).StartCharge), udai)
+}
+
+// StartClimate mocks base method.
+func (m *MockTeslaTaskService) StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartClimate", udai, temperature)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartClimate indicates an expected call of StartClimate.
+func (mr *MockTeslaTaskServiceMockRecorder) StartClimate(udai, temperature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartClimate", reflect.TypeOf((*MockTeslaTaskService)(nil).StartClimate), udai, temperature)
+}
+
 // StartPoll mocks base method.
 func (m *MockTeslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
 	m.ctrl.T.Helper()
@@ -98,6 +143,36 @@ func (mr *MockTeslaTaskServiceMockRecorder) StartPoll(udai, sd any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartPoll", reflect.TypeOf((*MockTeslaTaskService)(nil).StartPoll), udai, sd)
 }
 
+// StopCharge mocks base method.
+func (m *MockTeslaTaskService) StopCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCharge", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopCharge indicates an expected call of StopCharge.
+func (mr *MockTeslaTaskServiceMockRecorder) StopCharge(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCharge", reflect.TypeOf((*MockTeslaTaskService)(nil).StopCharge), udai)
+}
+
+// StopClimate mocks base method.
+func (m *MockTeslaTaskService) StopClimate(udai *models.UserDeviceAPIIntegration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopClimate", udai)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopClimate indicates an expected call of StopClimate.
+func (mr *MockTeslaTaskServiceMockRecorder) StopClimate(udai any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopClimate", reflect.TypeOf((*MockTeslaTaskService)(nil).StopClimate), udai)
+}
+
 // StopPoll mocks base method.
 func (m *MockTeslaTaskService) StopPoll(udai *models.UserDeviceAPIIntegration) error {
 	m.ctrl.T.Helper()
@@ -126,3 +201,17 @@ func (mr *MockTeslaTaskServiceMockRecorder) UnlockDoors(udai any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockDoors", reflect.TypeOf((*MockTeslaTaskService)(nil).UnlockDoors), udai)
 }
+
+// UpdateCredentials mocks base method.
+func (m *MockTeslaTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCredentials", udai, sd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCredentials indicates an expected call of UpdateCredentials.
+func (mr *MockTeslaTaskServiceMockRecorder) UpdateCredentials(udai, sd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCredentials", reflect.TypeOf((*MockTeslaTaskService)(nil).UpdateCredentials), udai, sd)
+}
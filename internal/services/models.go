@@ -143,6 +143,10 @@ type UserDeviceMetadata struct {
 	GeoDecodedStateProv     *string         `json:"geoDecodedStateProv"`
 	// CANProtocol is the protocol that was detected by edge-network from the autopi.
 	CANProtocol *string `json:"canProtocol,omitempty"`
+	// FingerprintVIN is the VIN most recently reported by the paired aftermarket device.
+	FingerprintVIN *string `json:"fingerprintVin,omitempty"`
+	// VINMismatch is set when FingerprintVIN differs from the VIN we have for the vehicle.
+	VINMismatch bool `json:"vinMismatch,omitempty"`
 }
 
 // AftermarketDeviceMetadata json metadata for table AftermarketDevice
@@ -0,0 +1,95 @@
+// Package oauth describes the vendors whose vehicles are connected by having
+// the user sign in with the vendor and handing us an authorization code.
+package oauth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+)
+
+// ErrInvalidCode is returned by ExchangeCode when the vendor rejects the
+// authorization code itself, as opposed to failing for some other reason.
+var ErrInvalidCode = errors.New("authorization code invalid, expired, or revoked")
+
+// Token is the result of an authorization code exchange.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// Vehicle is a vehicle that a set of credentials can reach.
+type Vehicle struct {
+	// ExternalID is the vendor's id for the vehicle. This is what ends up in
+	// user_device_api_integrations.external_id.
+	ExternalID string
+	VIN        string
+}
+
+// Definition is the device definition that a VIN decoded to.
+type Definition struct {
+	ID    string
+	Make  string
+	Model string
+	Year  int
+}
+
+// Provider is implemented once per OAuth-based integration vendor.
+type Provider interface {
+	// ExchangeCode trades an authorization code for credentials.
+	ExchangeCode(ctx context.Context, code, redirectURI string) (*Token, error)
+	// RequiredScopes are the scopes that the user must grant for the
+	// integration to work.
+	RequiredScopes() []string
+	// GrantedScopes lists the scopes that the user actually granted.
+	GrantedScopes(ctx context.Context, token *Token) ([]string, error)
+	// ListVehicles lists the vehicles that the access token can reach.
+	ListVehicles(ctx context.Context, accessToken string) ([]Vehicle, error)
+	// DecodeVIN finds the device definition for one of those vehicles.
+	DecodeVIN(ctx context.Context, vin string) (*Definition, error)
+	// PrepareMetadata checks that the access token can reach the vehicle
+	// and fills in the vendor-specific parts of the integration metadata.
+	PrepareMetadata(ctx context.Context, accessToken, externalID string, md *services.UserDeviceAPIIntegrationsMetadata) error
+}
+
+// Providers maps integration vendor names to their providers.
+type Providers map[string]Provider
+
+// MissingScopes returns the required scopes that the token was not granted.
+func MissingScopes(ctx context.Context, p Provider, token *Token) ([]string, error) {
+	required := p.RequiredScopes()
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	granted, err := p.GrantedScopes(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	grantedSet := make(map[string]struct{}, len(granted))
+	for _, s := range granted {
+		grantedSet[s] = struct{}{}
+	}
+
+	var missing []string
+	for _, s := range required {
+		if _, ok := grantedSet[s]; !ok {
+			missing = append(missing, s)
+		}
+	}
+
+	return missing, nil
+}
+
+// ParseScopes splits a comma-separated scope list from the settings.
+func ParseScopes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,50 @@
+package oauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mock_services "github.com/DIMO-Network/devices-api/internal/services/mocks"
+)
+
+func TestMissingScopesTesla(t *testing.T) {
+	access, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"scp": []string{"vehicle_cmds", "offline_access"},
+	}).SignedString([]byte("xdd"))
+	require.NoError(t, err)
+
+	p := NewTeslaProvider(nil, nil, []string{"vehicle_device_data", "vehicle_cmds"})
+
+	missing, err := MissingScopes(context.Background(), p, &Token{AccessToken: access})
+	require.NoError(t, err)
+	require.Equal(t, []string{"vehicle_device_data"}, missing)
+}
+
+func TestMissingScopesSmartcar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_services.NewMockSmartcarClient(ctrl)
+
+	client.EXPECT().GetVehicleIDs(gomock.Any(), "access").Return([]string{"a", "b"}, nil)
+	client.EXPECT().GetPermissions(gomock.Any(), "access", "a").Return([]string{"read_vin", "read_odometer", "read_location"}, nil)
+	client.EXPECT().GetPermissions(gomock.Any(), "access", "b").Return([]string{"read_vin", "read_location"}, nil)
+
+	p := NewSmartcarProvider(client, nil, []string{"read_vin", "read_odometer"})
+
+	// Only one of the vehicles granted the odometer.
+	missing, err := MissingScopes(context.Background(), p, &Token{AccessToken: "access"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"read_odometer"}, missing)
+}
+
+func TestMissingScopesNoneRequired(t *testing.T) {
+	// No calls to the client are expected.
+	p := NewSmartcarProvider(mock_services.NewMockSmartcarClient(gomock.NewController(t)), nil, nil)
+
+	missing, err := MissingScopes(context.Background(), p, &Token{AccessToken: "access"})
+	require.NoError(t, err)
+	require.Empty(t, missing)
+}
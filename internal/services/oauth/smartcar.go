@@ -0,0 +1,120 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/DIMO-Network/devices-api/internal/services"
+)
+
+type smartcarProvider struct {
+	client         services.SmartcarClient
+	ddSvc          services.DeviceDefinitionService
+	requiredScopes []string
+}
+
+// NewSmartcarProvider creates the provider for Smartcar Connect.
+func NewSmartcarProvider(client services.SmartcarClient, ddSvc services.DeviceDefinitionService, requiredScopes []string) Provider {
+	return &smartcarProvider{
+		client:         client,
+		ddSvc:          ddSvc,
+		requiredScopes: requiredScopes,
+	}
+}
+
+func (p *smartcarProvider) ExchangeCode(ctx context.Context, code, redirectURI string) (*Token, error) {
+	tok, err := p.client.ExchangeCode(ctx, code, redirectURI)
+	if err != nil {
+		var scErr *services.SmartcarError
+		if errors.As(err, &scErr) && scErr.Code >= 400 && scErr.Code < 500 {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCode, err)
+		}
+		return nil, err
+	}
+
+	return &Token{
+		AccessToken:  tok.Access,
+		RefreshToken: tok.Refresh,
+		Expiry:       tok.AccessExpiry,
+	}, nil
+}
+
+func (p *smartcarProvider) RequiredScopes() []string {
+	return p.requiredScopes
+}
+
+// GrantedScopes returns the permissions that every connected vehicle has.
+// Smartcar grants permissions per vehicle, so a scope only counts if all of
+// them have it.
+func (p *smartcarProvider) GrantedScopes(ctx context.Context, token *Token) ([]string, error) {
+	ids, err := p.client.GetVehicleIDs(ctx, token.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var granted []string
+	for i, id := range ids {
+		perms, err := p.client.GetPermissions(ctx, token.AccessToken, id)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			granted = perms
+			continue
+		}
+		granted = slices.DeleteFunc(granted, func(s string) bool { return !slices.Contains(perms, s) })
+	}
+
+	return granted, nil
+}
+
+func (p *smartcarProvider) ListVehicles(ctx context.Context, accessToken string) ([]Vehicle, error) {
+	ids, err := p.client.GetVehicleIDs(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Vehicle, len(ids))
+	for i, id := range ids {
+		vin, err := p.client.GetVIN(ctx, accessToken, id)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get VIN for Smartcar vehicle %s: %w", id, err)
+		}
+		out[i] = Vehicle{ExternalID: id, VIN: vin}
+	}
+	return out, nil
+}
+
+func (p *smartcarProvider) DecodeVIN(ctx context.Context, vin string) (*Definition, error) {
+	res, err := p.ddSvc.DecodeVIN(ctx, vin, "", 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	dd, err := p.ddSvc.GetDeviceDefinitionBySlug(ctx, res.DefinitionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Definition{ID: dd.DeviceDefinitionId, Make: dd.Make.Name, Model: dd.Model, Year: int(dd.Year)}, nil
+}
+
+func (p *smartcarProvider) PrepareMetadata(ctx context.Context, accessToken, externalID string, md *services.UserDeviceAPIIntegrationsMetadata) error {
+	// This fails if the token can't reach the vehicle.
+	endpoints, err := p.client.GetEndpoints(ctx, accessToken, externalID)
+	if err != nil {
+		return err
+	}
+
+	perms, err := p.client.GetPermissions(ctx, accessToken, externalID)
+	if err != nil {
+		return err
+	}
+
+	md.SmartcarEndpoints = endpoints
+	md.Commands = p.client.GetAvailableCommands(perms)
+
+	return nil
+}
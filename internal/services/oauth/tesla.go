@@ -0,0 +1,118 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/services"
+	"github.com/DIMO-Network/shared"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type teslaProvider struct {
+	fleetAPI       services.TeslaFleetAPIService
+	ddSvc          services.DeviceDefinitionService
+	requiredScopes []string
+}
+
+// NewTeslaProvider creates the provider for Tesla Fleet API connections.
+func NewTeslaProvider(fleetAPI services.TeslaFleetAPIService, ddSvc services.DeviceDefinitionService, requiredScopes []string) Provider {
+	return &teslaProvider{
+		fleetAPI:       fleetAPI,
+		ddSvc:          ddSvc,
+		requiredScopes: requiredScopes,
+	}
+}
+
+func (p *teslaProvider) ExchangeCode(ctx context.Context, code, redirectURI string) (*Token, error) {
+	res, err := p.fleetAPI.CompleteTeslaAuthCodeExchange(ctx, code, redirectURI)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAuthCode) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCode, err)
+		}
+		return nil, err
+	}
+
+	return &Token{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		Expiry:       res.Expiry,
+	}, nil
+}
+
+func (p *teslaProvider) RequiredScopes() []string {
+	return p.requiredScopes
+}
+
+type partialTeslaClaims struct {
+	jwt.RegisteredClaims
+	Scopes []string `json:"scp"`
+}
+
+// GrantedScopes reads the scopes out of the access token, which is a JWT.
+func (p *teslaProvider) GrantedScopes(_ context.Context, token *Token) ([]string, error) {
+	return TeslaScopes(token.AccessToken)
+}
+
+// TeslaScopes reads the scopes out of a Tesla access token without verifying
+// it.
+func TeslaScopes(accessToken string) ([]string, error) {
+	var claims partialTeslaClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, &claims); err != nil {
+		return nil, fmt.Errorf("couldn't parse access token: %w", err)
+	}
+	return claims.Scopes, nil
+}
+
+func (p *teslaProvider) ListVehicles(ctx context.Context, accessToken string) ([]Vehicle, error) {
+	vehicles, err := p.fleetAPI.GetVehicles(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Vehicle, len(vehicles))
+	for i, v := range vehicles {
+		out[i] = Vehicle{ExternalID: strconv.Itoa(v.ID), VIN: v.VIN}
+	}
+	return out, nil
+}
+
+// DecodeVIN works out the model and year from the VIN itself. This is
+// reliable for Teslas and much faster than a full decode.
+func (p *teslaProvider) DecodeVIN(ctx context.Context, vin string) (*Definition, error) {
+	teslaMake := "Tesla"
+	model := shared.VIN(vin).TeslaModel()
+	year := shared.VIN(vin).Year()
+
+	res, err := p.ddSvc.FindDeviceDefinitionByMMY(ctx, teslaMake, model, year)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Definition{ID: res.DeviceDefinitionId, Make: teslaMake, Model: model, Year: year}, nil
+}
+
+func (p *teslaProvider) PrepareMetadata(ctx context.Context, accessToken, externalID string, md *services.UserDeviceAPIIntegrationsMetadata) error {
+	teslaID, err := strconv.Atoi(externalID)
+	if err != nil {
+		return fmt.Errorf("couldn't parse Tesla vehicle id %q: %w", externalID, err)
+	}
+
+	// Make sure that these credentials have access to this particular vehicle.
+	if _, err := p.fleetAPI.GetVehicle(ctx, accessToken, teslaID); err != nil {
+		return err
+	}
+
+	commands, err := p.fleetAPI.GetAvailableCommands(accessToken)
+	if err != nil {
+		return err
+	}
+
+	md.TeslaAPIVersion = constants.TeslaAPIV2
+	md.Commands = commands
+
+	return nil
+}
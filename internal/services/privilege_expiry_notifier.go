@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+const privilegeExpiringEventType = "com.dimo.zone.device.privilege.expiring"
+
+// privilegeExpiryBatchSize caps the number of warnings sent per tick.
+const privilegeExpiryBatchSize = 100
+
+// PrivilegeExpiryNotifier emits an event for every vehicle privilege that is
+// about to expire, once per expiry.
+type PrivilegeExpiryNotifier struct {
+	dbs         func() *db.ReaderWriter
+	log         *zerolog.Logger
+	evtSvc      EventService
+	vehicleAddr common.Address
+	notice      time.Duration
+	interval    time.Duration
+}
+
+// NewPrivilegeExpiryNotifier creates a notifier that warns about privileges on
+// the vehicle contract expiring within notice. It checks every interval.
+func NewPrivilegeExpiryNotifier(dbs func() *db.ReaderWriter, log *zerolog.Logger, evtSvc EventService, vehicleAddr common.Address, notice, interval time.Duration) *PrivilegeExpiryNotifier {
+	return &PrivilegeExpiryNotifier{dbs: dbs, log: log, evtSvc: evtSvc, vehicleAddr: vehicleAddr, notice: notice, interval: interval}
+}
+
+// Run notifies on a timer until the context is cancelled.
+func (n *PrivilegeExpiryNotifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c, err := n.Notify(ctx)
+			if err != nil {
+				n.log.Err(err).Int("count", c).Msg("Failed to send privilege expiry warnings.")
+				continue
+			}
+			if c != 0 {
+				n.log.Info().Int("count", c).Msg("Sent privilege expiry warnings.")
+			}
+		}
+	}
+}
+
+// Notify emits warnings for unwarned privileges expiring within the notice
+// period and returns the number sent. Privileges are locked while they're
+// worked on, so several replicas can run the notifier at once. A privilege is
+// only marked once its event is out, so a failure means a repeat rather than a
+// miss.
+func (n *PrivilegeExpiryNotifier) Notify(ctx context.Context) (int, error) {
+	for sent := 0; sent < privilegeExpiryBatchSize; sent++ {
+		ok, err := n.notifyOne(ctx)
+		if err != nil || !ok {
+			return sent, err
+		}
+	}
+
+	return privilegeExpiryBatchSize, nil
+}
+
+// notifyOne warns about the soonest-expiring unlocked privilege. It returns
+// false if there was nothing to do.
+func (n *PrivilegeExpiryNotifier) notifyOne(ctx context.Context) (bool, error) {
+	now := time.Now()
+
+	tx, err := n.dbs().Writer.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint
+
+	priv, err := models.NFTPrivileges(
+		models.NFTPrivilegeWhere.ContractAddress.EQ(n.vehicleAddr.Bytes()),
+		models.NFTPrivilegeWhere.ExpiryNotifiedAt.IsNull(),
+		models.NFTPrivilegeWhere.Expiry.GT(now),
+		models.NFTPrivilegeWhere.Expiry.LTE(now.Add(n.notice)),
+		qm.OrderBy(models.NFTPrivilegeColumns.Expiry),
+		qm.For("UPDATE SKIP LOCKED"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	data := NFTPrivilegeExpiringEvent{
+		Timestamp: now,
+		TokenID:   priv.TokenID.Int(nil),
+		Grantee:   common.BytesToAddress(priv.UserAddress),
+		Privilege: priv.Privilege,
+		ExpiresAt: priv.Expiry,
+	}
+	subject := priv.TokenID.String()
+
+	ud, err := models.UserDevices(
+		models.UserDeviceWhere.TokenID.EQ(types.NewNullDecimal(priv.TokenID.Big)),
+	).One(ctx, tx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return false, err
+		}
+	} else {
+		data.UserDeviceID = ud.ID
+		data.Owner = common.BytesToAddress(ud.OwnerAddress.Bytes)
+		subject = ud.ID
+	}
+
+	if err := n.evtSvc.Emit(&shared.CloudEvent[any]{
+		Type:    privilegeExpiringEventType,
+		Source:  "devices-api",
+		Subject: subject,
+		Data:    data,
+	}); err != nil {
+		return false, err
+	}
+
+	priv.ExpiryNotifiedAt = null.TimeFrom(now)
+	if _, err := priv.Update(ctx, tx, boil.Whitelist(models.NFTPrivilegeColumns.ExpiryNotifiedAt)); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/internal/utils"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+type recordingEventService struct {
+	events []*shared.CloudEvent[any]
+}
+
+func (r *recordingEventService) Emit(event *shared.CloudEvent[any]) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func TestPrivilegeExpiryNotifier(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	vehicleAddr := common.HexToAddress("0x881d40237659c251811cec9c364ef91dc08d300c")
+	owner := common.HexToAddress("0xdafea492d9c6733ae3d56b7ed1adb60692c98bc5")
+	grantee := common.HexToAddress("0x4675c7e5baafbffbca748158becba61ef3b0a263")
+
+	ud := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	_ = test.SetupCreateVehicleNFT(t, ud, big.NewInt(5), null.BytesFrom(owner.Bytes()), pdb)
+
+	insert := func(privilege int64, expiry time.Time) *models.NFTPrivilege {
+		p := &models.NFTPrivilege{
+			ContractAddress: vehicleAddr.Bytes(),
+			TokenID:         utils.BigToDecimal(big.NewInt(5)),
+			Privilege:       privilege,
+			UserAddress:     grantee.Bytes(),
+			Expiry:          expiry,
+		}
+		require.NoError(t, p.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+		return p
+	}
+
+	soon := insert(1, time.Now().Add(time.Hour))
+	later := insert(2, time.Now().Add(48*time.Hour))
+	expired := insert(3, time.Now().Add(-time.Hour))
+
+	evts := &recordingEventService{}
+	notifier := NewPrivilegeExpiryNotifier(pdb.DBS, &logger, evts, vehicleAddr, 24*time.Hour, time.Minute)
+
+	n, err := notifier.Notify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.Len(t, evts.events, 1)
+	require.Equal(t, privilegeExpiringEventType, evts.events[0].Type)
+	require.Equal(t, ud.ID, evts.events[0].Subject)
+
+	data := evts.events[0].Data.(NFTPrivilegeExpiringEvent)
+	require.Equal(t, grantee, data.Grantee)
+	require.Equal(t, owner, data.Owner)
+	require.EqualValues(t, 1, data.Privilege)
+
+	require.NoError(t, soon.Reload(ctx, pdb.DBS().Reader))
+	require.True(t, soon.ExpiryNotifiedAt.Valid)
+	require.NoError(t, later.Reload(ctx, pdb.DBS().Reader))
+	require.False(t, later.ExpiryNotifiedAt.Valid)
+	require.NoError(t, expired.Reload(ctx, pdb.DBS().Reader))
+	require.False(t, expired.ExpiryNotifiedAt.Valid)
+
+	// Only one warning per expiry.
+	n, err = notifier.Notify(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// Another replica is warning about this one.
+	locked := insert(4, time.Now().Add(time.Hour))
+
+	tx, err := pdb.DBS().Writer.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint
+	_, err = models.NFTPrivileges(
+		models.NFTPrivilegeWhere.Privilege.EQ(locked.Privilege),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	require.NoError(t, err)
+
+	n, err = notifier.Notify(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Len(t, evts.events, 1)
+}
@@ -1,11 +1,15 @@
 package registry
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/contracts"
+	"github.com/DIMO-Network/devices-api/internal/services/idempotency"
 	"github.com/DIMO-Network/shared"
 	"github.com/IBM/sarama"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,6 +23,25 @@ type Client struct {
 	Producer     sarama.SyncProducer
 	RequestTopic string
 	Contract     Contract
+	// Idempotency and IdempotencyKey are optional. When both are set, at most
+	// one transaction is sent for the key; later attempts get a
+	// DuplicateRequestError.
+	Idempotency    *idempotency.Store
+	IdempotencyKey string
+}
+
+// idempotencyScope separates meta-transaction keys from HTTP response keys.
+const idempotencyScope = "meta-transaction"
+
+// DuplicateRequestError is returned when a transaction was already sent for
+// the client's idempotency key.
+type DuplicateRequestError struct {
+	// RequestID is the id of the transaction request that was sent first.
+	RequestID string
+}
+
+func (e *DuplicateRequestError) Error() string {
+	return fmt.Sprintf("transaction already requested for this idempotency key, as request %s", e.RequestID)
 }
 
 type Contract struct {
@@ -410,6 +433,36 @@ func (c *Client) MintVehicleAndSdWithDeviceDefinitionSign(requestID string, data
 }
 
 func (c *Client) sendRequest(requestID string, data []byte) error {
+	if c.Idempotency == nil || c.IdempotencyKey == "" {
+		return c.produceRequest(requestID, data)
+	}
+
+	ctx := context.Background()
+
+	// Every request made under the key is for the same operation, so there is
+	// no payload to compare.
+	rec, err := c.Idempotency.Claim(ctx, idempotencyScope, c.IdempotencyKey, idempotency.Hash(nil))
+	if err != nil {
+		if errors.Is(err, idempotency.ErrInProgress) {
+			return &DuplicateRequestError{}
+		}
+		return err
+	}
+	if rec != nil {
+		return &DuplicateRequestError{RequestID: string(rec.ResponseBody.Bytes)}
+	}
+
+	if err := c.produceRequest(requestID, data); err != nil {
+		if rerr := c.Idempotency.Release(ctx, idempotencyScope, c.IdempotencyKey); rerr != nil {
+			return errors.Join(err, rerr)
+		}
+		return err
+	}
+
+	return c.Idempotency.Complete(ctx, idempotencyScope, c.IdempotencyKey, 0, []byte(requestID))
+}
+
+func (c *Client) produceRequest(requestID string, data []byte) error {
 	event := shared.CloudEvent[RequestData]{
 		ID:          ksuid.New().String(),
 		Source:      "devices-api",
@@ -23,13 +23,20 @@ type SmartcarClient interface {
 	// The reason redirectURI is there is the frontend wanted flexibility. It's probably a
 	// bad idea that the client can pass this in.
 	ExchangeCode(ctx context.Context, code, redirectURI string) (*smartcar.Token, error)
+	// RefreshToken trades a refresh token for a new access and refresh token pair.
+	RefreshToken(ctx context.Context, refreshToken string) (*smartcar.Token, error)
 	GetUserID(ctx context.Context, accessToken string) (string, error)
 	GetExternalID(ctx context.Context, accessToken string) (string, error)
+	// GetVehicleIDs lists the Smartcar ids of every vehicle the access token can reach.
+	GetVehicleIDs(ctx context.Context, accessToken string) ([]string, error)
+	// GetPermissions lists the scopes granted to the access token for the vehicle.
+	GetPermissions(ctx context.Context, accessToken string, id string) ([]string, error)
 	GetEndpoints(ctx context.Context, accessToken string, id string) ([]string, error)
-	HasDoorControl(ctx context.Context, accessToken string, id string) (bool, error)
 	GetVIN(ctx context.Context, accessToken string, id string) (string, error)
 	GetInfo(ctx context.Context, accessToken string, id string) (*smartcar.Info, error)
-	GetAvailableCommands() *UserDeviceAPIIntegrationsMetadataCommands
+	// GetAvailableCommands returns the commands that the granted permissions allow, or nil if
+	// there are none.
+	GetAvailableCommands(permissions []string) *UserDeviceAPIIntegrationsMetadataCommands
 }
 
 type smartcarClient struct {
@@ -52,7 +59,12 @@ func NewSmartcarClient(settings *config.Settings) SmartcarClient {
 	}
 }
 
-const smartcarDoorPermission = "control_security"
+// Commands unlocked by each of Smartcar's control permissions.
+var scopeToCommands = map[string][]string{
+	"control_security": {constants.DoorsUnlock, constants.DoorsLock},
+	"control_charge":   {constants.ChargeStart, constants.ChargeStop, constants.ChargeLimit},
+	"control_climate":  {constants.ClimateStart, constants.ClimateStop},
+}
 
 var scopeToEndpoints = map[string][]string{
 	"read_engine_oil":   {"/engine/oil"},
@@ -91,6 +103,19 @@ func (s *smartcarClient) ExchangeCode(ctx context.Context, code, redirectURI str
 	v.Set("grant_type", "authorization_code")
 	v.Set("redirect_uri", redirectURI)
 
+	return s.requestToken(ctx, v)
+}
+
+func (s *smartcarClient) RefreshToken(ctx context.Context, refreshToken string) (*smartcar.Token, error) {
+	v := url.Values{}
+	v.Set("grant_type", "refresh_token")
+	v.Set("refresh_token", refreshToken)
+
+	return s.requestToken(ctx, v)
+}
+
+// requestToken posts the form to Smartcar's token endpoint.
+func (s *smartcarClient) requestToken(ctx context.Context, v url.Values) (*smartcar.Token, error) {
 	req, err := http.NewRequestWithContext(ctx, "POST", s.exchangeURL, strings.NewReader(v.Encode()))
 	if err != nil {
 		return nil, err
@@ -156,8 +181,18 @@ func (s *smartcarClient) GetExternalID(ctx context.Context, accessToken string)
 	return (*ids)[0], nil
 }
 
-// GetEndpoints returns the Smartcar read endpoints granted to the access token.
-func (s *smartcarClient) GetEndpoints(ctx context.Context, accessToken string, id string) ([]string, error) {
+func (s *smartcarClient) GetVehicleIDs(ctx context.Context, accessToken string) ([]string, error) {
+	ids, err := s.officialClient.GetVehicleIDs(ctx, &smartcar.VehicleIDsParams{Access: accessToken})
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil {
+		return nil, errors.New("nil vehicle id list")
+	}
+	return *ids, nil
+}
+
+func (s *smartcarClient) GetPermissions(ctx context.Context, accessToken string, id string) ([]string, error) {
 	v := s.officialClient.NewVehicle(&smartcar.VehicleParams{
 		ID:          id,
 		AccessToken: accessToken,
@@ -170,23 +205,11 @@ func (s *smartcarClient) GetEndpoints(ctx context.Context, accessToken string, i
 	if perms == nil {
 		return nil, errors.New("nil permissions object")
 	}
-
-	endpoints := []string{}
-
-	for _, perm := range perms.Permissions {
-		scopeEndpoints, ok := scopeToEndpoints[perm]
-		if !ok {
-			continue
-		}
-		endpoints = append(endpoints, scopeEndpoints...)
-	}
-
-	return endpoints, nil
+	return perms.Permissions, nil
 }
 
-// HasDoorControl returns true if the access token can open and close doors.
-// TODO(elffjs): Probably silly to have both this and GetEndpoints.
-func (s *smartcarClient) HasDoorControl(ctx context.Context, accessToken string, id string) (bool, error) {
+// GetEndpoints returns the Smartcar read endpoints granted to the access token.
+func (s *smartcarClient) GetEndpoints(ctx context.Context, accessToken string, id string) ([]string, error) {
 	v := s.officialClient.NewVehicle(&smartcar.VehicleParams{
 		ID:          id,
 		AccessToken: accessToken,
@@ -194,19 +217,23 @@ func (s *smartcarClient) HasDoorControl(ctx context.Context, accessToken string,
 	})
 	perms, err := v.GetPermissions(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	if perms == nil {
-		return false, errors.New("nil permissions object")
+		return nil, errors.New("nil permissions object")
 	}
 
+	endpoints := []string{}
+
 	for _, perm := range perms.Permissions {
-		if perm == smartcarDoorPermission {
-			return true, nil
+		scopeEndpoints, ok := scopeToEndpoints[perm]
+		if !ok {
+			continue
 		}
+		endpoints = append(endpoints, scopeEndpoints...)
 	}
 
-	return false, nil
+	return endpoints, nil
 }
 
 func (s *smartcarClient) GetVIN(ctx context.Context, accessToken string, id string) (string, error) {
@@ -241,8 +268,15 @@ func (s *smartcarClient) GetInfo(ctx context.Context, accessToken string, id str
 	return info, nil
 }
 
-func (s *smartcarClient) GetAvailableCommands() *UserDeviceAPIIntegrationsMetadataCommands {
-	return &UserDeviceAPIIntegrationsMetadataCommands{
-		Enabled: []string{constants.DoorsUnlock, constants.DoorsLock},
+func (s *smartcarClient) GetAvailableCommands(permissions []string) *UserDeviceAPIIntegrationsMetadataCommands {
+	var enabled []string
+	for _, perm := range permissions {
+		enabled = append(enabled, scopeToCommands[perm]...)
 	}
+
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	return &UserDeviceAPIIntegrationsMetadataCommands{Enabled: enabled}
 }
@@ -5,11 +5,10 @@ import (
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
-	"github.com/DIMO-Network/shared/sdtask"
 	"github.com/IBM/sarama"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/segmentio/ksuid"
 )
 
@@ -18,21 +17,53 @@ import (
 type SmartcarTaskService interface {
 	StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	StopPoll(udai *models.UserDeviceAPIIntegration) error
+	// UpdateCredentials sends the integration's current credentials to its
+	// running polling task.
+	UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	Refresh(udai *models.UserDeviceAPIIntegration) error
 	UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
 	LockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
+	StartCharge(udai *models.UserDeviceAPIIntegration) (string, error)
+	StopCharge(udai *models.UserDeviceAPIIntegration) (string, error)
+	SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error)
+	StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error)
+	StopClimate(udai *models.UserDeviceAPIIntegration) (string, error)
 }
 
-func NewSmartcarTaskService(settings *config.Settings, producer sarama.SyncProducer) SmartcarTaskService {
+func NewSmartcarTaskService(settings *config.Settings, producer sarama.SyncProducer, cipher TaskCipher) SmartcarTaskService {
 	return &smartcarTaskService{
 		Producer: producer,
 		Settings: settings,
+		Cipher:   cipher,
 	}
 }
 
+// RegisterSmartcarCommands adds the commands that Smartcar integrations can
+// execute to the registry.
+func RegisterSmartcarCommands(r *CommandRegistry, svc SmartcarTaskService) {
+	r.Register(constants.SmartCarVendor, constants.DoorsUnlock, withoutParams(svc.UnlockDoors))
+	r.Register(constants.SmartCarVendor, constants.DoorsLock, withoutParams(svc.LockDoors))
+	r.Register(constants.SmartCarVendor, constants.ChargeStart, withoutParams(svc.StartCharge))
+	r.Register(constants.SmartCarVendor, constants.ChargeStop, withoutParams(svc.StopCharge))
+	r.Register(constants.SmartCarVendor, constants.ChargeLimit, func(udai *models.UserDeviceAPIIntegration, params CommandParams) (string, error) {
+		if err := params.Validate(constants.ChargeLimit); err != nil {
+			return "", err
+		}
+		return svc.SetChargeLimit(udai, *params.ChargeLimit)
+	})
+	r.Register(constants.SmartCarVendor, constants.ClimateStart, func(udai *models.UserDeviceAPIIntegration, params CommandParams) (string, error) {
+		if err := params.Validate(constants.ClimateStart); err != nil {
+			return "", err
+		}
+		return svc.StartClimate(udai, params.Temperature)
+	})
+	r.Register(constants.SmartCarVendor, constants.ClimateStop, withoutParams(svc.StopClimate))
+}
+
 type smartcarTaskService struct {
 	Producer sarama.SyncProducer
 	Settings *config.Settings
+	Cipher   TaskCipher
 }
 
 type SmartcarIdentifiers struct {
@@ -71,40 +102,17 @@ func (t *smartcarTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, s
 		},
 	}
 
-	tokenID, _ := sd.TokenID.Int64()
-	integrationTokenID, _ := sd.IntegrationTokenID.Int64()
-	vehicleTokenID, _ := sd.VehicleTokenID.Int64()
-
-	tc := shared.CloudEvent[sdtask.CredentialData]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.smartcar.poll.credential",
-		Data: sdtask.CredentialData{
-			TaskID:        udai.TaskID.String,
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			AccessToken:   udai.AccessToken.String,
-			Expiry:        udai.AccessExpiresAt.Time,
-			RefreshToken:  udai.RefreshToken.String,
-			SyntheticDevice: &sdtask.SyntheticDevice{
-				TokenID:            int(tokenID),
-				Address:            common.BytesToAddress(sd.WalletAddress),
-				IntegrationTokenID: int(integrationTokenID),
-				WalletChildNumber:  sd.WalletChildNumber,
-				VehicleTokenID:     int(vehicleTokenID),
-			},
-		},
+	ttb, err := json.Marshal(tt)
+	if err != nil {
+		return err
 	}
 
-	ttb, err := json.Marshal(tt)
+	ce, err := credentialEvent(t.Cipher, udai, sd, smartcarCredentialEventType, 0)
 	if err != nil {
 		return err
 	}
 
-	tcb, err := json.Marshal(tc)
+	tcb, err := json.Marshal(ce)
 	if err != nil {
 		return err
 	}
@@ -167,6 +175,28 @@ func (t *smartcarTaskService) Refresh(udai *models.UserDeviceAPIIntegration) err
 	return err
 }
 
+func (t *smartcarTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	ce, err := credentialEvent(t.Cipher, udai, sd, smartcarCredentialEventType, 0)
+	if err != nil {
+		return err
+	}
+
+	tcb, err := json.Marshal(ce)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = t.Producer.SendMessage(
+		&sarama.ProducerMessage{
+			Topic: t.Settings.TaskCredentialTopic,
+			Key:   sarama.StringEncoder(udai.TaskID.String),
+			Value: sarama.ByteEncoder(tcb),
+		},
+	)
+
+	return err
+}
+
 func (t *smartcarTaskService) StopPoll(udai *models.UserDeviceAPIIntegration) error {
 	var taskKey = udai.TaskID.String
 
@@ -217,51 +247,55 @@ type SmartcarDoorTask struct {
 	UserDeviceID  string              `json:"userDeviceId"`
 	IntegrationID string              `json:"integrationId"`
 	Identifiers   SmartcarIdentifiers `json:"identifiers"`
+	ChargeLimit   *float64            `json:"chargeLimit,omitempty"`
+	Temperature   *float64            `json:"temperature,omitempty"`
 }
 
 func (t *smartcarTaskService) UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
-	tt := shared.CloudEvent[SmartcarDoorTask]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.smartcar.doors.unlock",
-		Data: SmartcarDoorTask{
-			TaskID:        udai.TaskID.String,
-			SubTaskID:     ksuid.New().String(),
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			Identifiers: SmartcarIdentifiers{
-				ID: udai.ExternalID.String,
-			},
-		},
-	}
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.doors.unlock", nil)
+}
 
-	ttb, err := json.Marshal(tt)
-	if err != nil {
-		return "", err
-	}
+func (t *smartcarTaskService) LockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.doors.lock", nil)
+}
 
-	_, _, err = t.Producer.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: t.Settings.TaskRunNowTopic,
-			Key:   sarama.StringEncoder(udai.TaskID.String),
-			Value: sarama.ByteEncoder(ttb),
-		},
-	)
+func (t *smartcarTaskService) StartCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.charge.start", nil)
+}
 
-	return tt.Data.SubTaskID, err
+func (t *smartcarTaskService) StopCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.charge.stop", nil)
 }
 
-func (t *smartcarTaskService) LockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
+// SetChargeLimit sets the target state of charge, in percent.
+func (t *smartcarTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.charge.limit", func(st *SmartcarDoorTask) {
+		st.ChargeLimit = &percent
+	})
+}
+
+// StartClimate turns on preconditioning. If temperature, in degrees Celsius, is
+// nil then the vehicle keeps its current setting.
+func (t *smartcarTaskService) StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.climate.start", func(st *SmartcarDoorTask) {
+		st.Temperature = temperature
+	})
+}
+
+func (t *smartcarTaskService) StopClimate(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.smartcar.climate.stop", nil)
+}
+
+// sendCommand emits a command task of the given type on the run-now topic and
+// returns its sub-task id. If non-nil, setParams fills in command arguments.
+func (t *smartcarTaskService) sendCommand(udai *models.UserDeviceAPIIntegration, eventType string, setParams func(*SmartcarDoorTask)) (string, error) {
 	tt := shared.CloudEvent[SmartcarDoorTask]{
 		ID:          ksuid.New().String(),
 		Source:      "dimo/integration/" + udai.IntegrationID,
 		SpecVersion: "1.0",
 		Subject:     udai.UserDeviceID,
 		Time:        time.Now(),
-		Type:        "zone.dimo.task.smartcar.doors.lock",
+		Type:        eventType,
 		Data: SmartcarDoorTask{
 			TaskID:        udai.TaskID.String,
 			SubTaskID:     ksuid.New().String(),
@@ -273,6 +307,10 @@ func (t *smartcarTaskService) LockDoors(udai *models.UserDeviceAPIIntegration) (
 		},
 	}
 
+	if setParams != nil {
+		setParams(&tt.Data)
+	}
+
 	ttb, err := json.Marshal(tt)
 	if err != nil {
 		return "", err
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/sarama"
+	smock "github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/models"
+)
+
+func TestSmartcarChargeCommand(t *testing.T) {
+	// Only the charging permission was granted.
+	md := UserDeviceAPIIntegrationsMetadata{
+		Commands: NewSmartcarClient(&config.Settings{}).GetAvailableCommands([]string{"read_charge", "control_charge"}),
+	}
+
+	settings := &config.Settings{TaskRunNowTopic: "task.run.now"}
+	producer := smock.NewSyncProducer(t, nil)
+	defer producer.Close() //nolint
+
+	var sent struct {
+		Type string `json:"type"`
+		Data struct {
+			SubTaskID string `json:"subTaskId"`
+		} `json:"data"`
+	}
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(pm *sarama.ProducerMessage) error {
+		require.Equal(t, settings.TaskRunNowTopic, pm.Topic)
+		b, err := pm.Value.Encode()
+		require.NoError(t, err)
+		return json.Unmarshal(b, &sent)
+	})
+
+	registry := NewCommandRegistry()
+	RegisterSmartcarCommands(registry, NewSmartcarTaskService(settings, producer, nil))
+
+	_, err := registry.Executor(constants.SmartCarVendor, constants.ClimateStart, &md)
+	require.ErrorIs(t, err, ErrCommandNotEnabled)
+
+	fn, err := registry.Executor(constants.SmartCarVendor, constants.ChargeStart, &md)
+	require.NoError(t, err)
+
+	udai := &models.UserDeviceAPIIntegration{UserDeviceID: "device", IntegrationID: "smartcar", TaskID: null.StringFrom("task"), ExternalID: null.StringFrom("vehicle")}
+	subTaskID, err := fn(udai, CommandParams{})
+	require.NoError(t, err)
+
+	require.Equal(t, "zone.dimo.task.smartcar.charge.start", sent.Type)
+	require.Equal(t, subTaskID, sent.Data.SubTaskID)
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/sdtask"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	smartcarCredentialEventType = "zone.dimo.task.smartcar.poll.credential"
+	teslaCredentialEventType    = "zone.dimo.task.tesla.poll.credential.v2"
+)
+
+// TaskCipher translates stored credentials to and from the format that the
+// polling tasks can read. The tasks only know the legacy key, so credentials
+// under a newer key are re-encrypted on the way out, and the tasks' refreshed
+// credentials are moved onto the current key on the way in.
+type TaskCipher interface {
+	ToLegacy(ciphertext string) (string, error)
+	ToCurrent(ciphertext string) (string, error)
+}
+
+// credentialEvent builds the message that hands the integration's stored
+// credentials, still encrypted, to the polling task.
+func credentialEvent(cipher TaskCipher, udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice, eventType string, version int) (*shared.CloudEvent[sdtask.CredentialData], error) {
+	var accessToken, refreshToken string
+	if udai.AccessToken.Valid {
+		var err error
+		if accessToken, err = cipher.ToLegacy(udai.AccessToken.String); err != nil {
+			return nil, fmt.Errorf("failed to convert access token for task: %w", err)
+		}
+	}
+	if udai.RefreshToken.Valid {
+		var err error
+		if refreshToken, err = cipher.ToLegacy(udai.RefreshToken.String); err != nil {
+			return nil, fmt.Errorf("failed to convert refresh token for task: %w", err)
+		}
+	}
+
+	tokenID, _ := sd.TokenID.Int64()
+	integrationTokenID, _ := sd.IntegrationTokenID.Int64()
+	vehicleTokenID, _ := sd.VehicleTokenID.Int64()
+
+	return &shared.CloudEvent[sdtask.CredentialData]{
+		ID:          ksuid.New().String(),
+		Source:      "dimo/integration/" + udai.IntegrationID,
+		SpecVersion: "1.0",
+		Subject:     udai.UserDeviceID,
+		Time:        time.Now(),
+		Type:        eventType,
+		Data: sdtask.CredentialData{
+			TaskID:        udai.TaskID.String,
+			UserDeviceID:  udai.UserDeviceID,
+			IntegrationID: udai.IntegrationID,
+			AccessToken:   accessToken,
+			Expiry:        udai.AccessExpiresAt.Time,
+			RefreshToken:  refreshToken,
+			Version:       version,
+			SyntheticDevice: &sdtask.SyntheticDevice{
+				TokenID:            int(tokenID),
+				Address:            common.BytesToAddress(sd.WalletAddress),
+				IntegrationTokenID: int(integrationTokenID),
+				WalletChildNumber:  sd.WalletChildNumber,
+				VehicleTokenID:     int(vehicleTokenID),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/kafka"
+	"github.com/DIMO-Network/devices-api/internal/services/keyring"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/ericlagergren/decimal"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func TestTaskCredentialsAfterRotation(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+
+	// The tasks only know the legacy cipher.
+	legacy := new(shared.ROT13Cipher)
+	newKey, err := keyring.NewAESGCMCipher(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	cipher, err := keyring.New("k2", map[string]shared.Cipher{"k2": newKey}, legacy)
+	require.NoError(t, err)
+
+	ud := test.SetupCreateUserDevice(t, "testUser", ksuid.New().String(), nil, "", pdb)
+	udai := test.SetupCreateUserDeviceAPIIntegration(t, "", "smartcar-id", ud.ID, ksuid.New().String(), pdb)
+
+	// As left behind by rotate-credentials.
+	access, err := cipher.Encrypt("access-1")
+	require.NoError(t, err)
+	refresh, err := cipher.Encrypt("refresh-1")
+	require.NoError(t, err)
+
+	udai.TaskID = null.StringFrom(ksuid.New().String())
+	udai.AccessToken = null.StringFrom(access)
+	udai.RefreshToken = null.StringFrom(refresh)
+	udai.AccessExpiresAt = null.TimeFrom(time.Now().Add(time.Hour).Truncate(time.Millisecond))
+	_, err = udai.Update(ctx, pdb.DBS().Writer, boil.Infer())
+	require.NoError(t, err)
+
+	one := types.NewNullDecimal(decimal.New(1, 0))
+	sd := &models.SyntheticDevice{TokenID: one, IntegrationTokenID: types.NewDecimal(decimal.New(1, 0)), VehicleTokenID: one, WalletAddress: make([]byte, 20)}
+
+	ce, err := credentialEvent(cipher, &udai, sd, smartcarCredentialEventType, 0)
+	require.NoError(t, err)
+
+	pt, err := legacy.Decrypt(ce.Data.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", pt)
+	pt, err = legacy.Decrypt(ce.Data.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-1", pt)
+
+	// The task refreshes and sends back new tokens under the legacy cipher.
+	ce.Data.AccessToken, err = legacy.Encrypt("access-2")
+	require.NoError(t, err)
+	ce.Data.RefreshToken, err = legacy.Encrypt("refresh-2")
+	require.NoError(t, err)
+	ce.Data.Expiry = ce.Data.Expiry.Add(time.Hour)
+
+	b, err := json.Marshal(ce)
+	require.NoError(t, err)
+
+	listener := NewCredentialListener(pdb.DBS, &logger, cipher, nil)
+	require.NoError(t, listener.HandleMessage(ctx, &kafka.Message{Value: b}))
+
+	require.NoError(t, udai.Reload(ctx, pdb.DBS().Reader))
+
+	for token, want := range map[string]string{udai.AccessToken.String: "access-2", udai.RefreshToken.String: "refresh-2"} {
+		assert.Equal(t, "k2", keyring.KeyID(token), "stored credentials should stay on the current key")
+		pt, err := cipher.Decrypt(token)
+		require.NoError(t, err)
+		assert.Equal(t, want, pt)
+	}
+
+	// A replay of an older refresh must not roll the credentials back.
+	ce.Data.AccessToken, err = legacy.Encrypt("access-0")
+	require.NoError(t, err)
+	ce.Data.Expiry = ce.Data.Expiry.Add(-2 * time.Hour)
+
+	b, err = json.Marshal(ce)
+	require.NoError(t, err)
+	require.NoError(t, listener.HandleMessage(ctx, &kafka.Message{Value: b}))
+
+	require.NoError(t, udai.Reload(ctx, pdb.DBS().Reader))
+	pt, err = cipher.Decrypt(udai.AccessToken.String)
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", pt)
+}
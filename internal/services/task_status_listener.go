@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/kafka"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
 	"github.com/DIMO-Network/shared/db"
@@ -30,6 +31,7 @@ type TaskStatusListener struct {
 	DeviceDefSvc DeviceDefinitionService
 	prod         sarama.SyncProducer
 	settings     *config.Settings
+	dlq          *kafka.DeadLetterQueue
 }
 
 type TaskStatusData struct {
@@ -38,28 +40,38 @@ type TaskStatusData struct {
 	UserDeviceID  string `json:"userDeviceId"`
 	IntegrationID string `json:"integrationId"`
 	Status        string `json:"status"`
+	// Result is the vendor's response to a command, if there was one.
+	Result json.RawMessage `json:"result,omitempty"`
+	// FailureReason explains why a command failed.
+	FailureReason string `json:"failureReason,omitempty"`
 }
 
-func NewTaskStatusListener(db func() *db.ReaderWriter, log *zerolog.Logger, ddSvc DeviceDefinitionService, prod sarama.SyncProducer, settings *config.Settings) *TaskStatusListener {
-	return &TaskStatusListener{db: db, log: log, DeviceDefSvc: ddSvc, prod: prod, settings: settings}
+func NewTaskStatusListener(db func() *db.ReaderWriter, log *zerolog.Logger, ddSvc DeviceDefinitionService, prod sarama.SyncProducer, settings *config.Settings, dlq *kafka.DeadLetterQueue) *TaskStatusListener {
+	return &TaskStatusListener{db: db, log: log, DeviceDefSvc: ddSvc, prod: prod, settings: settings, dlq: dlq}
 }
 
 func (i *TaskStatusListener) ProcessTaskUpdates(messages <-chan *message.Message) {
 	for msg := range messages {
-		err := i.processMessage(msg)
+		err := i.dlq.HandleWatermill(msg, i.HandleMessage)
 		if err != nil {
 			i.log.Err(err).Msg("error processing task status message")
+			if i.dlq != nil {
+				// The message neither went through nor made it to the dead-letter topic.
+				// Have watermill deliver it again rather than lose it.
+				msg.Nack()
+				continue
+			}
 		}
+		// Without a dead-letter topic, keep the pipeline moving no matter what.
+		msg.Ack()
 	}
 }
 
-func (i *TaskStatusListener) processMessage(msg *message.Message) error {
-	// Keep the pipeline moving no matter what.
-	defer func() { msg.Ack() }()
-
+// HandleMessage processes a single task status message. It is also what replay-dlq uses.
+func (i *TaskStatusListener) HandleMessage(_ context.Context, msg *kafka.Message) error {
 	event := new(shared.CloudEvent[TaskStatusData])
-	if err := json.Unmarshal(msg.Payload, event); err != nil {
-		return errors.Wrap(err, "error parsing task status payload")
+	if err := json.Unmarshal(msg.Value, event); err != nil {
+		return kafka.Permanent(errors.Wrap(err, "error parsing task status payload"))
 	}
 
 	return i.processEvent(event)
@@ -74,7 +86,7 @@ func (i *TaskStatusListener) processEvent(event *shared.CloudEvent[TaskStatusDat
 	case commandStatusEventType:
 		return i.processCommandStatusEvent(event)
 	default:
-		return fmt.Errorf("unexpected event type %s", event.Type)
+		return kafka.Permanent(fmt.Errorf("unexpected event type %s", event.Type))
 	}
 }
 
@@ -120,9 +132,24 @@ func (i *TaskStatusListener) processSmartcarPollStatusEvent(event *shared.CloudE
 		}
 	}
 
+	from := udai.Status
 	udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
-	_, err = udai.Update(context.Background(), i.db().Writer, boil.Infer())
-	return err
+
+	tx, err := i.db().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := udai.Update(ctx, tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := RecordIntegrationStatusChange(ctx, tx, udai, from, IntegrationStatusSourceTask, "Polling task reported an authentication failure."); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (i *TaskStatusListener) processTeslaPollStatusEvent(event *shared.CloudEvent[TaskStatusData]) error {
@@ -166,10 +193,24 @@ func (i *TaskStatusListener) processTeslaPollStatusEvent(event *shared.CloudEven
 			i.log.Err(err).Msg("Failed to null out credential message for failed job.")
 		}
 	}
+	from := udai.Status
 	udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
 
-	_, err = udai.Update(context.Background(), i.db().Writer, boil.Infer())
-	return err
+	tx, err := i.db().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := udai.Update(ctx, tx, boil.Infer()); err != nil {
+		return err
+	}
+
+	if err := RecordIntegrationStatusChange(ctx, tx, udai, from, IntegrationStatusSourceTask, "Polling task reported an authentication failure."); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (i *TaskStatusListener) processCommandStatusEvent(event *shared.CloudEvent[TaskStatusData]) error {
@@ -179,10 +220,29 @@ func (i *TaskStatusListener) processCommandStatusEvent(event *shared.CloudEvent[
 	}
 
 	if event.Data.Status != models.DeviceCommandRequestStatusComplete && event.Data.Status != models.DeviceCommandRequestStatusFailed {
-		return fmt.Errorf("unexpected command status %q", dcr.Status)
+		return fmt.Errorf("unexpected command status %q", event.Data.Status)
+	}
+
+	// A late answer still tells us what happened to a timed-out command, but the
+	// user asked us to forget about a cancelled one.
+	if dcr.Status != models.DeviceCommandRequestStatusPending && dcr.Status != models.DeviceCommandRequestStatusTimedOut {
+		i.log.Info().
+			Str("subTaskId", event.Data.SubTaskID).
+			Str("status", dcr.Status).
+			Msg("Ignoring status update for finished command request.")
+		return nil
 	}
 
 	dcr.Status = event.Data.Status
+	if len(event.Data.Result) != 0 {
+		dcr.Result = null.JSONFrom(event.Data.Result)
+	}
+	if event.Data.FailureReason != "" {
+		dcr.FailureReason = null.StringFrom(event.Data.FailureReason)
+	} else if dcr.Status == models.DeviceCommandRequestStatusComplete {
+		dcr.FailureReason = null.String{}
+	}
+
 	_, err = dcr.Update(context.Background(), i.db().Writer, boil.Infer())
 	if err != nil {
 		return fmt.Errorf("failed to update command request: %w", err)
@@ -25,6 +25,7 @@ import (
 //go:generate mockgen -source tesla_fleet_api_service.go -destination mocks/tesla_fleet_api_service_mock.go
 type TeslaFleetAPIService interface {
 	CompleteTeslaAuthCodeExchange(ctx context.Context, authCode, redirectURI string) (*TeslaAuthCodeResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*TeslaAuthCodeResponse, error)
 	GetVehicles(ctx context.Context, token string) ([]TeslaVehicle, error)
 	GetVehicle(ctx context.Context, token string, vehicleID int) (*TeslaVehicle, error)
 	WakeUpVehicle(ctx context.Context, token string, vehicleID int) error
@@ -166,6 +167,38 @@ func (t *teslaFleetAPIService) CompleteTeslaAuthCodeExchange(ctx context.Context
 	}, nil
 }
 
+// RefreshToken calls Tesla Fleet API to trade a refresh token for a new auth and refresh token
+func (t *teslaFleetAPIService) RefreshToken(ctx context.Context, refreshToken string) (*TeslaAuthCodeResponse, error) {
+	conf := oauth2.Config{
+		ClientID:     t.Settings.TeslaClientID,
+		ClientSecret: t.Settings.TeslaClientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL: t.Settings.TeslaTokenURL,
+		},
+		Scopes: teslaScopes,
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	// An expired token forces the source to use the refresh token.
+	tok, err := conf.TokenSource(ctxTimeout, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		var e *oauth2.RetrieveError
+		if errors.As(err, &e) {
+			return nil, fmt.Errorf("error refreshing token: %s %s", e.ErrorCode, e.ErrorDescription)
+		}
+		return nil, fmt.Errorf("error refreshing token: %w", err)
+	}
+
+	return &TeslaAuthCodeResponse{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		Expiry:       tok.Expiry,
+		TokenType:    tok.TokenType,
+	}, nil
+}
+
 // GetVehicles calls Tesla Fleet API to get a list of vehicles using authorization token
 func (t *teslaFleetAPIService) GetVehicles(ctx context.Context, token string) ([]TeslaVehicle, error) {
 	out := make([]TeslaVehicle, 0)
@@ -253,15 +286,15 @@ func (t *teslaFleetAPIService) GetAvailableCommands(token string) (*UserDeviceAP
 	disabled := []string{}
 
 	if slices.Contains(claims.Scopes, teslaCommandScope) {
-		enabled = append(enabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen)
+		enabled = append(enabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen, constants.ClimateStart, constants.ClimateStop)
 	} else {
-		disabled = append(disabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen)
+		disabled = append(disabled, constants.DoorsLock, constants.DoorsUnlock, constants.TrunkOpen, constants.FrunkOpen, constants.ClimateStart, constants.ClimateStop)
 	}
 
 	if slices.Contains(claims.Scopes, teslaCommandScope) || slices.Contains(claims.Scopes, teslaChargingScope) {
-		enabled = append(enabled, constants.ChargeLimit)
+		enabled = append(enabled, constants.ChargeLimit, constants.ChargeStart, constants.ChargeStop)
 	} else {
-		disabled = append(disabled, constants.ChargeLimit)
+		disabled = append(disabled, constants.ChargeLimit, constants.ChargeStart, constants.ChargeStop)
 	}
 
 	return &UserDeviceAPIIntegrationsMetadataCommands{
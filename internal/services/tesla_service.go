@@ -81,7 +81,7 @@ func (t *teslaService) WakeUpVehicle(ownerAccessToken string, id int) error {
 
 func (t *teslaService) GetAvailableCommands() *UserDeviceAPIIntegrationsMetadataCommands {
 	return &UserDeviceAPIIntegrationsMetadataCommands{
-		Enabled: []string{constants.DoorsUnlock, constants.DoorsLock, constants.TrunkOpen, constants.FrunkOpen, constants.ChargeLimit},
+		Enabled: []string{constants.DoorsUnlock, constants.DoorsLock, constants.TrunkOpen, constants.FrunkOpen, constants.ChargeLimit, constants.ChargeStart, constants.ChargeStop, constants.ClimateStart, constants.ClimateStop},
 	}
 }
 
@@ -7,11 +7,10 @@ import (
 	"time"
 
 	"github.com/DIMO-Network/devices-api/internal/config"
+	"github.com/DIMO-Network/devices-api/internal/constants"
 	"github.com/DIMO-Network/devices-api/models"
 	"github.com/DIMO-Network/shared"
-	"github.com/DIMO-Network/shared/sdtask"
 	"github.com/IBM/sarama"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/segmentio/ksuid"
 )
 
@@ -20,25 +19,59 @@ import (
 type TeslaTaskService interface {
 	StartPoll(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	StopPoll(udai *models.UserDeviceAPIIntegration) error
+	// UpdateCredentials sends the integration's current credentials to its
+	// running polling task.
+	UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error
 	UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
 	LockDoors(udai *models.UserDeviceAPIIntegration) (string, error)
 	OpenTrunk(udai *models.UserDeviceAPIIntegration) (string, error)
 	OpenFrunk(udai *models.UserDeviceAPIIntegration) (string, error)
+	StartCharge(udai *models.UserDeviceAPIIntegration) (string, error)
+	StopCharge(udai *models.UserDeviceAPIIntegration) (string, error)
+	SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error)
+	StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error)
+	StopClimate(udai *models.UserDeviceAPIIntegration) (string, error)
 }
 
-func NewTeslaTaskService(settings *config.Settings, producer sarama.SyncProducer) TeslaTaskService {
+func NewTeslaTaskService(settings *config.Settings, producer sarama.SyncProducer, cipher TaskCipher) TeslaTaskService {
 	return &teslaTaskService{
 		Producer: producer,
 		Settings: settings,
+		Cipher:   cipher,
 	}
 }
 
+// RegisterTeslaCommands adds the commands that Tesla integrations can execute
+// to the registry.
+func RegisterTeslaCommands(r *CommandRegistry, svc TeslaTaskService) {
+	r.Register(constants.TeslaVendor, constants.DoorsUnlock, withoutParams(svc.UnlockDoors))
+	r.Register(constants.TeslaVendor, constants.DoorsLock, withoutParams(svc.LockDoors))
+	r.Register(constants.TeslaVendor, constants.TrunkOpen, withoutParams(svc.OpenTrunk))
+	r.Register(constants.TeslaVendor, constants.FrunkOpen, withoutParams(svc.OpenFrunk))
+	r.Register(constants.TeslaVendor, constants.ChargeStart, withoutParams(svc.StartCharge))
+	r.Register(constants.TeslaVendor, constants.ChargeStop, withoutParams(svc.StopCharge))
+	r.Register(constants.TeslaVendor, constants.ChargeLimit, func(udai *models.UserDeviceAPIIntegration, params CommandParams) (string, error) {
+		if err := params.Validate(constants.ChargeLimit); err != nil {
+			return "", err
+		}
+		return svc.SetChargeLimit(udai, *params.ChargeLimit)
+	})
+	r.Register(constants.TeslaVendor, constants.ClimateStart, func(udai *models.UserDeviceAPIIntegration, params CommandParams) (string, error) {
+		if err := params.Validate(constants.ClimateStart); err != nil {
+			return "", err
+		}
+		return svc.StartClimate(udai, params.Temperature)
+	})
+	r.Register(constants.TeslaVendor, constants.ClimateStop, withoutParams(svc.StopClimate))
+}
+
 // Make sure we satisfy the interface.
 var _ TeslaTaskService = &teslaTaskService{}
 
 type teslaTaskService struct {
 	Producer sarama.SyncProducer
 	Settings *config.Settings
+	Cipher   TaskCipher
 }
 
 type TeslaIdentifiers struct {
@@ -85,41 +118,17 @@ func (t *teslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *
 		},
 	}
 
-	tokenID, _ := sd.TokenID.Int64()
-	integrationTokenID, _ := sd.IntegrationTokenID.Int64()
-	vehicleTokenID, _ := sd.VehicleTokenID.Int64()
-
-	tc := shared.CloudEvent[sdtask.CredentialData]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.poll.credential.v2",
-		Data: sdtask.CredentialData{
-			TaskID:        udai.TaskID.String,
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			AccessToken:   udai.AccessToken.String,
-			Expiry:        udai.AccessExpiresAt.Time,
-			RefreshToken:  udai.RefreshToken.String,
-			Version:       meta.TeslaAPIVersion,
-			SyntheticDevice: &sdtask.SyntheticDevice{
-				TokenID:            int(tokenID),
-				Address:            common.BytesToAddress(sd.WalletAddress),
-				IntegrationTokenID: int(integrationTokenID),
-				WalletChildNumber:  sd.WalletChildNumber,
-				VehicleTokenID:     int(vehicleTokenID),
-			},
-		},
+	ttb, err := json.Marshal(tt)
+	if err != nil {
+		return err
 	}
 
-	ttb, err := json.Marshal(tt)
+	ce, err := credentialEvent(t.Cipher, udai, sd, teslaCredentialEventType, meta.TeslaAPIVersion)
 	if err != nil {
 		return err
 	}
 
-	tcb, err := json.Marshal(tc)
+	tcb, err := json.Marshal(ce)
 	if err != nil {
 		return err
 	}
@@ -142,6 +151,33 @@ func (t *teslaTaskService) StartPoll(udai *models.UserDeviceAPIIntegration, sd *
 	return err
 }
 
+func (t *teslaTaskService) UpdateCredentials(udai *models.UserDeviceAPIIntegration, sd *models.SyntheticDevice) error {
+	var meta UserDeviceAPIIntegrationsMetadata
+	if err := udai.Metadata.Unmarshal(&meta); err != nil {
+		return fmt.Errorf("couldn't unmarshal metadata: %w", err)
+	}
+
+	ce, err := credentialEvent(t.Cipher, udai, sd, teslaCredentialEventType, meta.TeslaAPIVersion)
+	if err != nil {
+		return err
+	}
+
+	tcb, err := json.Marshal(ce)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = t.Producer.SendMessage(
+		&sarama.ProducerMessage{
+			Topic: t.Settings.TaskCredentialTopic,
+			Key:   sarama.StringEncoder(udai.TaskID.String),
+			Value: sarama.ByteEncoder(tcb),
+		},
+	)
+
+	return err
+}
+
 func (t *teslaTaskService) StopPoll(udai *models.UserDeviceAPIIntegration) error {
 	var taskKey string
 	if udai.TaskID.Valid {
@@ -198,129 +234,55 @@ type TeslaDoorTask struct {
 	IntegrationID string           `json:"integrationId"`
 	Identifiers   TeslaIdentifiers `json:"identifiers"` // Don't actually need vehicleId.
 	ChargeLimit   *float64         `json:"chargeLimit,omitempty"`
+	Temperature   *float64         `json:"temperature,omitempty"`
 }
 
 func (t *teslaTaskService) UnlockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
-	id, err := strconv.Atoi(udai.ExternalID.String)
-	if err != nil {
-		return "", err
-	}
-
-	tt := shared.CloudEvent[TeslaDoorTask]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.doors.unlock",
-		Data: TeslaDoorTask{
-			TaskID:        udai.TaskID.String,
-			SubTaskID:     ksuid.New().String(),
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			Identifiers: TeslaIdentifiers{
-				ID: id,
-			},
-		},
-	}
-
-	ttb, err := json.Marshal(tt)
-	if err != nil {
-		return "", err
-	}
-
-	_, _, err = t.Producer.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: t.Settings.TaskRunNowTopic,
-			Key:   sarama.StringEncoder(udai.TaskID.String),
-			Value: sarama.ByteEncoder(ttb),
-		},
-	)
-
-	return tt.Data.SubTaskID, err
+	return t.sendCommand(udai, "zone.dimo.task.tesla.doors.unlock", nil)
 }
 
 func (t *teslaTaskService) LockDoors(udai *models.UserDeviceAPIIntegration) (string, error) {
-	id, err := strconv.Atoi(udai.ExternalID.String)
-	if err != nil {
-		return "", err
-	}
-
-	tt := shared.CloudEvent[TeslaDoorTask]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.doors.lock",
-		Data: TeslaDoorTask{
-			TaskID:        udai.TaskID.String,
-			SubTaskID:     ksuid.New().String(),
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			Identifiers: TeslaIdentifiers{
-				ID: id,
-			},
-		},
-	}
-
-	ttb, err := json.Marshal(tt)
-	if err != nil {
-		return "", err
-	}
+	return t.sendCommand(udai, "zone.dimo.task.tesla.doors.lock", nil)
+}
 
-	_, _, err = t.Producer.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: t.Settings.TaskRunNowTopic,
-			Key:   sarama.StringEncoder(udai.TaskID.String),
-			Value: sarama.ByteEncoder(ttb),
-		},
-	)
+func (t *teslaTaskService) OpenTrunk(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.trunk.open", nil)
+}
 
-	return tt.Data.SubTaskID, err
+func (t *teslaTaskService) OpenFrunk(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.frunk.open", nil)
 }
 
-func (t *teslaTaskService) OpenTrunk(udai *models.UserDeviceAPIIntegration) (string, error) {
-	id, err := strconv.Atoi(udai.ExternalID.String)
-	if err != nil {
-		return "", err
-	}
+func (t *teslaTaskService) StartCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.charge.start", nil)
+}
 
-	tt := shared.CloudEvent[TeslaDoorTask]{
-		ID:          ksuid.New().String(),
-		Source:      "dimo/integration/" + udai.IntegrationID,
-		SpecVersion: "1.0",
-		Subject:     udai.UserDeviceID,
-		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.trunk.open",
-		Data: TeslaDoorTask{
-			TaskID:        udai.TaskID.String,
-			SubTaskID:     ksuid.New().String(),
-			UserDeviceID:  udai.UserDeviceID,
-			IntegrationID: udai.IntegrationID,
-			Identifiers: TeslaIdentifiers{
-				ID: id,
-			},
-		},
-	}
+func (t *teslaTaskService) StopCharge(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.charge.stop", nil)
+}
 
-	ttb, err := json.Marshal(tt)
-	if err != nil {
-		return "", err
-	}
+// SetChargeLimit sets the target state of charge, in percent.
+func (t *teslaTaskService) SetChargeLimit(udai *models.UserDeviceAPIIntegration, percent float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.charge.limit", func(td *TeslaDoorTask) {
+		td.ChargeLimit = &percent
+	})
+}
 
-	_, _, err = t.Producer.SendMessage(
-		&sarama.ProducerMessage{
-			Topic: t.Settings.TaskRunNowTopic,
-			Key:   sarama.StringEncoder(udai.TaskID.String),
-			Value: sarama.ByteEncoder(ttb),
-		},
-	)
+// StartClimate turns on preconditioning. If temperature, in degrees Celsius, is
+// nil then the vehicle keeps its current setting.
+func (t *teslaTaskService) StartClimate(udai *models.UserDeviceAPIIntegration, temperature *float64) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.climate.start", func(td *TeslaDoorTask) {
+		td.Temperature = temperature
+	})
+}
 
-	return tt.Data.SubTaskID, err
+func (t *teslaTaskService) StopClimate(udai *models.UserDeviceAPIIntegration) (string, error) {
+	return t.sendCommand(udai, "zone.dimo.task.tesla.climate.stop", nil)
 }
 
-func (t *teslaTaskService) OpenFrunk(udai *models.UserDeviceAPIIntegration) (string, error) {
+// sendCommand emits a command task of the given type on the run-now topic and
+// returns its sub-task id. If non-nil, setParams fills in command arguments.
+func (t *teslaTaskService) sendCommand(udai *models.UserDeviceAPIIntegration, eventType string, setParams func(*TeslaDoorTask)) (string, error) {
 	id, err := strconv.Atoi(udai.ExternalID.String)
 	if err != nil {
 		return "", err
@@ -332,7 +294,7 @@ func (t *teslaTaskService) OpenFrunk(udai *models.UserDeviceAPIIntegration) (str
 		SpecVersion: "1.0",
 		Subject:     udai.UserDeviceID,
 		Time:        time.Now(),
-		Type:        "zone.dimo.task.tesla.frunk.open",
+		Type:        eventType,
 		Data: TeslaDoorTask{
 			TaskID:        udai.TaskID.String,
 			SubTaskID:     ksuid.New().String(),
@@ -344,6 +306,10 @@ func (t *teslaTaskService) OpenFrunk(udai *models.UserDeviceAPIIntegration) (str
 		},
 	}
 
+	if setParams != nil {
+		setParams(&tt.Data)
+	}
+
 	ttb, err := json.Marshal(tt)
 	if err != nil {
 		return "", err
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// maxTokenRefreshFailures is how many refreshes in a row may fail before we
+// give up on the integration's credentials.
+const maxTokenRefreshFailures = 5
+
+// TokenRefresher refreshes the OAuth credentials of Smartcar and Tesla
+// integrations shortly before they expire and hands the new credentials to
+// the polling tasks. Failed refreshes are retried on each pass; once they have
+// failed too many times in a row the integration is put into
+// AuthenticationFailure and polling stops.
+type TokenRefresher struct {
+	dbs           func() *db.ReaderWriter
+	log           *zerolog.Logger
+	cipher        shared.Cipher
+	ddSvc         DeviceDefinitionService
+	smartcar      SmartcarClient
+	teslaFleetAPI TeslaFleetAPIService
+	smartcarTask  SmartcarTaskService
+	teslaTask     TeslaTaskService
+	window        time.Duration
+	interval      time.Duration
+}
+
+// NewTokenRefresher creates a refresher that renews access tokens expiring
+// within window. It checks every interval.
+func NewTokenRefresher(dbs func() *db.ReaderWriter, log *zerolog.Logger, cipher shared.Cipher, ddSvc DeviceDefinitionService, smartcar SmartcarClient, teslaFleetAPI TeslaFleetAPIService, smartcarTask SmartcarTaskService, teslaTask TeslaTaskService, window, interval time.Duration) *TokenRefresher {
+	return &TokenRefresher{
+		dbs:           dbs,
+		log:           log,
+		cipher:        cipher,
+		ddSvc:         ddSvc,
+		smartcar:      smartcar,
+		teslaFleetAPI: teslaFleetAPI,
+		smartcarTask:  smartcarTask,
+		teslaTask:     teslaTask,
+		window:        window,
+		interval:      interval,
+	}
+}
+
+// Run refreshes on a timer until the context is cancelled.
+func (r *TokenRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := r.Refresh(ctx)
+			if err != nil {
+				r.log.Err(err).Msg("Failed to refresh integration credentials.")
+				continue
+			}
+			if n != 0 {
+				r.log.Info().Int("count", n).Msg("Refreshed integration credentials.")
+			}
+		}
+	}
+}
+
+// due selects the running integrations whose access tokens expire within the
+// window.
+func (r *TokenRefresher) due() []qm.QueryMod {
+	return []qm.QueryMod{
+		models.UserDeviceAPIIntegrationWhere.Status.IN([]string{models.UserDeviceAPIIntegrationStatusActive, models.UserDeviceAPIIntegrationStatusPendingFirstData}),
+		models.UserDeviceAPIIntegrationWhere.TaskID.IsNotNull(),
+		models.UserDeviceAPIIntegrationWhere.RefreshToken.IsNotNull(),
+		models.UserDeviceAPIIntegrationWhere.AccessExpiresAt.LT(null.TimeFrom(time.Now().Add(r.window))),
+	}
+}
+
+// Refresh renews the credentials of every running integration whose access
+// token expires within the window and returns the number it renewed. Each
+// integration is locked while it's refreshed, so several replicas can run the
+// refresher at once without spending the same refresh token twice.
+func (r *TokenRefresher) Refresh(ctx context.Context) (int, error) {
+	udais, err := models.UserDeviceAPIIntegrations(
+		append(r.due(), qm.Load(qm.Rels(models.UserDeviceAPIIntegrationRels.UserDevice, models.UserDeviceRels.VehicleTokenSyntheticDevice)))...,
+	).All(ctx, r.dbs().Reader)
+	if err != nil {
+		return 0, err
+	}
+
+	vendors := make(map[string]string)
+	refreshed := 0
+
+	for _, udai := range udais {
+		logger := r.log.With().Str("userDeviceId", udai.UserDeviceID).Str("integrationId", udai.IntegrationID).Logger()
+
+		vendor, ok := vendors[udai.IntegrationID]
+		if !ok {
+			integ, err := r.ddSvc.GetIntegrationByID(ctx, udai.IntegrationID)
+			if err != nil {
+				logger.Err(err).Msg("Couldn't look up integration.")
+				continue
+			}
+			vendor = integ.Vendor
+			vendors[udai.IntegrationID] = vendor
+		}
+
+		ok, err := r.refresh(ctx, udai, vendor)
+		if err != nil {
+			logger.Err(err).Msg("Failed to refresh credentials.")
+			continue
+		}
+		if ok {
+			refreshed++
+		}
+	}
+
+	return refreshed, nil
+}
+
+// refresh renews a single integration's credentials. It reports false if the
+// integration is not one we know how to refresh.
+func (r *TokenRefresher) refresh(ctx context.Context, udai *models.UserDeviceAPIIntegration, vendor string) (bool, error) {
+	sd := udai.R.UserDevice.R.VehicleTokenSyntheticDevice
+	if sd == nil {
+		// Only synthetic device tasks read from the credential topic.
+		return false, nil
+	}
+
+	tx, err := r.dbs().Writer.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint
+
+	// Skip the integration if another replica has it locked or, checking the
+	// conditions again, has already refreshed it.
+	udai, err = models.UserDeviceAPIIntegrations(
+		append(r.due(),
+			models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(udai.UserDeviceID),
+			models.UserDeviceAPIIntegrationWhere.IntegrationID.EQ(udai.IntegrationID),
+			qm.For("UPDATE SKIP LOCKED"),
+		)...,
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var md UserDeviceAPIIntegrationsMetadata
+	if err := udai.Metadata.Unmarshal(&md); err != nil {
+		return false, fmt.Errorf("couldn't parse metadata: %w", err)
+	}
+
+	refreshToken, err := r.cipher.Decrypt(udai.RefreshToken.String)
+	if err != nil {
+		return false, fmt.Errorf("couldn't decrypt refresh token: %w", err)
+	}
+
+	var (
+		access, refresh string
+		expiry          time.Time
+	)
+
+	switch vendor {
+	case constants.SmartCarVendor:
+		tok, err := r.smartcar.RefreshToken(ctx, refreshToken)
+		if err != nil {
+			return false, r.recordFailure(ctx, tx, udai, vendor, err)
+		}
+		access, refresh, expiry = tok.Access, tok.Refresh, tok.AccessExpiry
+	case constants.TeslaVendor:
+		if md.TeslaAPIVersion != constants.TeslaAPIV2 {
+			// Owner API tokens are refreshed by the task itself.
+			return false, nil
+		}
+		tok, err := r.teslaFleetAPI.RefreshToken(ctx, refreshToken)
+		if err != nil {
+			return false, r.recordFailure(ctx, tx, udai, vendor, err)
+		}
+		access, refresh, expiry = tok.AccessToken, tok.RefreshToken, tok.Expiry
+	default:
+		return false, nil
+	}
+
+	encAccess, err := r.cipher.Encrypt(access)
+	if err != nil {
+		return false, err
+	}
+	encRefresh, err := r.cipher.Encrypt(refresh)
+	if err != nil {
+		return false, err
+	}
+
+	udai.AccessToken = null.StringFrom(encAccess)
+	udai.RefreshToken = null.StringFrom(encRefresh)
+	udai.AccessExpiresAt = null.TimeFrom(expiry)
+	udai.TokenRefreshFailures = 0
+
+	cols := models.UserDeviceAPIIntegrationColumns
+	if _, err := udai.Update(ctx, tx, boil.Whitelist(cols.AccessToken, cols.RefreshToken, cols.AccessExpiresAt, cols.TokenRefreshFailures, cols.UpdatedAt)); err != nil {
+		return false, fmt.Errorf("failed to save refreshed credentials: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to save refreshed credentials: %w", err)
+	}
+
+	if vendor == constants.SmartCarVendor {
+		err = r.smartcarTask.UpdateCredentials(udai, sd)
+	} else {
+		err = r.teslaTask.UpdateCredentials(udai, sd)
+	}
+	if err != nil {
+		return true, fmt.Errorf("saved refreshed credentials but failed to send them to the task: %w", err)
+	}
+
+	return true, nil
+}
+
+// recordFailure counts a failed refresh against the integration and commits the
+// transaction holding its lock. Once there have been too many failures in a row
+// it marks the integration as failed, with the last error as the reason, and
+// stops polling.
+func (r *TokenRefresher) recordFailure(ctx context.Context, tx *sql.Tx, udai *models.UserDeviceAPIIntegration, vendor string, refreshErr error) error {
+	udai.TokenRefreshFailures++
+	from := udai.Status
+
+	cols := models.UserDeviceAPIIntegrationColumns
+	update := []string{cols.TokenRefreshFailures, cols.UpdatedAt}
+
+	// Kept to stop the task once the failure is committed.
+	taskID := udai.TaskID
+
+	failed := udai.TokenRefreshFailures >= maxTokenRefreshFailures
+	if failed {
+		r.log.Info().Str("userDeviceId", udai.UserDeviceID).Str("integrationId", udai.IntegrationID).Msg("Setting integration to failed because its credentials couldn't be refreshed.")
+
+		udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+		udai.FailureReason = null.StringFrom(fmt.Sprintf("Couldn't refresh access token after %d attempts: %s", udai.TokenRefreshFailures, refreshErr))
+		udai.TaskID = null.String{}
+		update = append(update, cols.Status, cols.FailureReason, cols.TaskID)
+	}
+
+	if _, err := udai.Update(ctx, tx, boil.Whitelist(update...)); err != nil {
+		return fmt.Errorf("failed to record refresh failure: %w", err)
+	}
+
+	if err := RecordIntegrationStatusChange(ctx, tx, udai, from, IntegrationStatusSourceTokenRefresh, udai.FailureReason.String); err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to record refresh failure: %w", err)
+	}
+
+	if failed {
+		stopped := *udai
+		stopped.TaskID = taskID
+
+		var err error
+		if vendor == constants.SmartCarVendor {
+			err = r.smartcarTask.StopPoll(&stopped)
+		} else {
+			err = r.teslaTask.StopPoll(&stopped)
+		}
+		if err != nil {
+			r.log.Err(err).Str("userDeviceId", udai.UserDeviceID).Msg("Failed to stop polling job.")
+		}
+	}
+
+	return fmt.Errorf("refresh attempt %d failed: %w", udai.TokenRefreshFailures, refreshErr)
+}
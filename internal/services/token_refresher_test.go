@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/internal/constants"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/ericlagergren/decimal"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	smartcar "github.com/smartcar/go-sdk"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeSmartcarRefresher struct {
+	SmartcarClient
+	err   error
+	calls int
+}
+
+func (f *fakeSmartcarRefresher) RefreshToken(_ context.Context, refreshToken string) (*smartcar.Token, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &smartcar.Token{
+		Access:       "new-access",
+		Refresh:      "new-" + refreshToken,
+		AccessExpiry: time.Now().Add(2 * time.Hour),
+	}, nil
+}
+
+type fakeSmartcarTask struct {
+	SmartcarTaskService
+	updated []string
+	stopped []string
+}
+
+func (f *fakeSmartcarTask) UpdateCredentials(udai *models.UserDeviceAPIIntegration, _ *models.SyntheticDevice) error {
+	f.updated = append(f.updated, udai.UserDeviceID)
+	return nil
+}
+
+func (f *fakeSmartcarTask) StopPoll(udai *models.UserDeviceAPIIntegration) error {
+	f.stopped = append(f.stopped, udai.UserDeviceID)
+	return nil
+}
+
+func TestTokenRefresher(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	cipher := new(shared.ROT13Cipher)
+	integrationID := ksuid.New().String()
+
+	ctrl := gomock.NewController(t)
+	ddSvc := NewMockDeviceDefinitionService(ctrl)
+	ddSvc.EXPECT().GetIntegrationByID(gomock.Any(), integrationID).Return(test.BuildIntegrationGRPC(integrationID, constants.SmartCarVendor, 0, 0), nil).AnyTimes()
+
+	insert := func(vehicleID int64, expiresIn time.Duration, failures int) *models.UserDeviceAPIIntegration {
+		return insertTokenRefresherIntegration(t, pdb, cipher, integrationID, vehicleID, expiresIn, failures)
+	}
+
+	expiring := insert(1, 5*time.Minute, 2)
+	fresh := insert(2, 2*time.Hour, 0)
+
+	// Refresh the first one successfully.
+	client := &fakeSmartcarRefresher{}
+	task := &fakeSmartcarTask{}
+	refresher := NewTokenRefresher(pdb.DBS, &logger, cipher, ddSvc, client, nil, task, nil, 15*time.Minute, time.Minute)
+
+	n, err := refresher.Refresh(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{expiring.UserDeviceID}, task.updated)
+
+	require.NoError(t, expiring.Reload(ctx, pdb.DBS().Reader))
+	access, err := cipher.Decrypt(expiring.AccessToken.String)
+	require.NoError(t, err)
+	require.Equal(t, "new-access", access)
+	refresh, err := cipher.Decrypt(expiring.RefreshToken.String)
+	require.NoError(t, err)
+	require.Equal(t, "new-refresh", refresh)
+	require.True(t, expiring.AccessExpiresAt.Time.After(time.Now().Add(time.Hour)))
+	require.Zero(t, expiring.TokenRefreshFailures)
+
+	require.NoError(t, fresh.Reload(ctx, pdb.DBS().Reader))
+	access, err = cipher.Decrypt(fresh.AccessToken.String)
+	require.NoError(t, err)
+	require.Equal(t, "access", access)
+
+	// Now fail on the last allowed attempt.
+	exhausted := insert(3, 5*time.Minute, maxTokenRefreshFailures-1)
+	client.err = errors.New("invalid_grant")
+
+	n, err = refresher.Refresh(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Equal(t, []string{exhausted.UserDeviceID}, task.stopped)
+
+	require.NoError(t, exhausted.Reload(ctx, pdb.DBS().Reader))
+	require.Equal(t, models.UserDeviceAPIIntegrationStatusAuthenticationFailure, exhausted.Status)
+	require.Equal(t, maxTokenRefreshFailures, exhausted.TokenRefreshFailures)
+	require.Contains(t, exhausted.FailureReason.String, "invalid_grant")
+	require.False(t, exhausted.TaskID.Valid)
+
+	// Another replica is refreshing this one.
+	locked := insert(4, 5*time.Minute, 0)
+	client.err = nil
+	client.calls = 0
+
+	tx, err := pdb.DBS().Writer.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint
+	_, err = models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(locked.UserDeviceID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	require.NoError(t, err)
+
+	n, err = refresher.Refresh(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Zero(t, client.calls)
+}
+
+func insertTokenRefresherIntegration(t *testing.T, pdb db.Store, cipher shared.Cipher, integrationID string, vehicleID int64, expiresIn time.Duration, failures int) *models.UserDeviceAPIIntegration {
+	ctx := context.Background()
+
+	mtr := models.MetaTransactionRequest{ID: ksuid.New().String(), Status: models.MetaTransactionRequestStatusMined}
+	require.NoError(t, mtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	ud := models.UserDevice{
+		ID:            ksuid.New().String(),
+		MintRequestID: null.StringFrom(mtr.ID),
+		TokenID:       types.NewNullDecimal(decimal.New(vehicleID, 0)),
+		OwnerAddress:  null.BytesFrom(common.BigToAddress(big.NewInt(vehicleID)).Bytes()),
+	}
+	require.NoError(t, ud.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	sdMtr := models.MetaTransactionRequest{ID: ksuid.New().String(), Status: models.MetaTransactionRequestStatusMined}
+	require.NoError(t, sdMtr.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	sd := models.SyntheticDevice{
+		VehicleTokenID:     ud.TokenID,
+		IntegrationTokenID: types.NewDecimal(decimal.New(1, 0)),
+		WalletChildNumber:  int(vehicleID),
+		WalletAddress:      common.BigToAddress(big.NewInt(1000 + vehicleID)).Bytes(),
+		MintRequestID:      sdMtr.ID,
+	}
+	require.NoError(t, sd.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	access, err := cipher.Encrypt("access")
+	require.NoError(t, err)
+	refresh, err := cipher.Encrypt("refresh")
+	require.NoError(t, err)
+
+	udai := models.UserDeviceAPIIntegration{
+		UserDeviceID:         ud.ID,
+		IntegrationID:        integrationID,
+		Status:               models.UserDeviceAPIIntegrationStatusActive,
+		AccessToken:          null.StringFrom(access),
+		RefreshToken:         null.StringFrom(refresh),
+		AccessExpiresAt:      null.TimeFrom(time.Now().Add(expiresIn)),
+		TaskID:               null.StringFrom(ksuid.New().String()),
+		TokenRefreshFailures: failures,
+	}
+	require.NoError(t, udai.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	return &udai
+}
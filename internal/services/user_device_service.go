@@ -59,7 +59,7 @@ func (uds *userDeviceService) CreateIntegration(ctx context.Context, tx *sql.Tx,
 	if err := integration.Insert(ctx, tx, boil.Infer()); err != nil {
 		return errors.Wrap(err, "unexpected database error inserting new Smartcar integration registration")
 	}
-	return nil
+	return RecordIntegrationStatusChange(ctx, tx, integration, "", IntegrationStatusSourceRegistration, "")
 }
 
 func NewUserDeviceService(deviceDefSvc DeviceDefinitionService, log zerolog.Logger, dbs func() *db.ReaderWriter, eventService EventService, usersClient pb.UserServiceClient) UserDeviceService {
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	// VehicleTransferPolicyKeep leaves the vehicle's integrations running for the
+	// new owner. Geofences are always detached.
+	VehicleTransferPolicyKeep = "keep"
+	// VehicleTransferPolicyWipe additionally stops polling and clears the credentials
+	// of every integration that was authorized by the previous owner, and removes
+	// their error code history.
+	VehicleTransferPolicyWipe = "wipe"
+)
+
+// ValidateVehicleTransferPolicy checks a configured transfer policy. Empty means keep.
+func ValidateVehicleTransferPolicy(policy string) error {
+	switch policy {
+	case "", VehicleTransferPolicyKeep, VehicleTransferPolicyWipe:
+		return nil
+	default:
+		return fmt.Errorf("unrecognized vehicle transfer policy %q, expected %q or %q", policy, VehicleTransferPolicyKeep, VehicleTransferPolicyWipe)
+	}
+}
+
+// VehicleTransferCleanup is the web2 state tied to the previous owner of a vehicle that
+// a transfer removes. Documents are not listed: they are stored under the previous
+// owner's user id and never become visible to the new owner.
+type VehicleTransferCleanup struct {
+	UserDeviceID string
+	Policy       string
+	// Geofences are the ids of the geofences that will be detached from the vehicle.
+	Geofences []string
+	// Integrations hold the previous owner's credentials. Only populated under the
+	// wipe policy.
+	Integrations models.UserDeviceAPIIntegrationSlice
+	// ErrorCodeQueries is the number of diagnostic code lookups that will be deleted.
+	// Only populated under the wipe policy.
+	ErrorCodeQueries int64
+}
+
+// PlanVehicleTransferCleanup works out what a transfer of the given vehicle would clean
+// up under the policy, without changing anything. An empty policy means keep.
+func PlanVehicleTransferCleanup(ctx context.Context, exec boil.ContextExecutor, userDeviceID, policy string) (*VehicleTransferCleanup, error) {
+	if err := ValidateVehicleTransferPolicy(policy); err != nil {
+		return nil, err
+	}
+	if policy == "" {
+		policy = VehicleTransferPolicyKeep
+	}
+
+	cl := &VehicleTransferCleanup{
+		UserDeviceID: userDeviceID,
+		Policy:       policy,
+	}
+
+	links, err := models.UserDeviceToGeofences(
+		models.UserDeviceToGeofenceWhere.UserDeviceID.EQ(userDeviceID),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range links {
+		cl.Geofences = append(cl.Geofences, l.GeofenceID)
+	}
+
+	if policy != VehicleTransferPolicyWipe {
+		return cl, nil
+	}
+
+	// Hardware integrations move with the paired device; only the ones running on
+	// OAuth credentials belong to the previous owner.
+	cl.Integrations, err = models.UserDeviceAPIIntegrations(
+		models.UserDeviceAPIIntegrationWhere.UserDeviceID.EQ(userDeviceID),
+		qm.Expr(
+			models.UserDeviceAPIIntegrationWhere.AccessToken.IsNotNull(),
+			qm.Or2(models.UserDeviceAPIIntegrationWhere.RefreshToken.IsNotNull()),
+		),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.ErrorCodeQueries, err = models.ErrorCodeQueries(
+		models.ErrorCodeQueryWhere.UserDeviceID.EQ(userDeviceID),
+	).Count(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl, nil
+}
+
+// Apply removes the planned state. The integrations themselves are kept, in a failed
+// state, so that the new owner can reauthenticate against any synthetic device that was
+// minted for them. It returns copies of the wiped integrations that had a running task,
+// as they were before the wipe; stop those only once exec's transaction has committed.
+func (cl *VehicleTransferCleanup) Apply(ctx context.Context, exec boil.ContextExecutor) (models.UserDeviceAPIIntegrationSlice, error) {
+	if _, err := models.UserDeviceToGeofences(
+		models.UserDeviceToGeofenceWhere.UserDeviceID.EQ(cl.UserDeviceID),
+	).DeleteAll(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	if _, err := models.UserDeviceGeofenceStates(
+		models.UserDeviceGeofenceStateWhere.UserDeviceID.EQ(cl.UserDeviceID),
+	).DeleteAll(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	if cl.Policy != VehicleTransferPolicyWipe {
+		return nil, nil
+	}
+
+	var running models.UserDeviceAPIIntegrationSlice
+
+	for _, udai := range cl.Integrations {
+		if udai.TaskID.Valid {
+			before := *udai
+			running = append(running, &before)
+		}
+
+		from := udai.Status
+		udai.Status = models.UserDeviceAPIIntegrationStatusAuthenticationFailure
+		udai.FailureReason = null.StringFrom("Vehicle transferred to a new owner.")
+		udai.AccessToken = null.String{}
+		udai.RefreshToken = null.String{}
+		udai.AccessExpiresAt = null.Time{}
+		udai.TaskID = null.String{}
+		udai.TokenRefreshFailures = 0
+
+		if _, err := udai.Update(ctx, exec, boil.Infer()); err != nil {
+			return nil, err
+		}
+
+		if err := RecordIntegrationStatusChange(ctx, exec, udai, from, IntegrationStatusSourceContractEvent, "Vehicle transferred to a new owner."); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := models.ErrorCodeQueries(
+		models.ErrorCodeQueryWhere.UserDeviceID.EQ(cl.UserDeviceID),
+	).DeleteAll(ctx, exec); err != nil {
+		return nil, err
+	}
+
+	return running, nil
+}
+
+// IntegrationIDs lists the ids of the integrations that will be wiped.
+func (cl *VehicleTransferCleanup) IntegrationIDs() []string {
+	ids := make([]string, len(cl.Integrations))
+	for i, udai := range cl.Integrations {
+		ids[i] = udai.IntegrationID
+	}
+	return ids
+}
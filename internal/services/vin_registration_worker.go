@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// vinRegistrationBatchSize caps the number of rows registered per tick.
+const vinRegistrationBatchSize = 50
+
+// VINRegistrationWorker registers the vehicles listed in batch VIN registration
+// jobs, one row at a time, recording the outcome on each row.
+type VINRegistrationWorker struct {
+	dbs           func() *db.ReaderWriter
+	log           *zerolog.Logger
+	deviceDefSvc  DeviceDefinitionService
+	userDeviceSvc UserDeviceService
+	interval      time.Duration
+}
+
+// NewVINRegistrationWorker creates a worker that picks up pending rows every
+// interval.
+func NewVINRegistrationWorker(dbs func() *db.ReaderWriter, log *zerolog.Logger, deviceDefSvc DeviceDefinitionService, userDeviceSvc UserDeviceService, interval time.Duration) *VINRegistrationWorker {
+	return &VINRegistrationWorker{dbs: dbs, log: log, deviceDefSvc: deviceDefSvc, userDeviceSvc: userDeviceSvc, interval: interval}
+}
+
+// Run processes rows on a timer until the context is cancelled.
+func (w *VINRegistrationWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := w.Process(ctx)
+			if err != nil {
+				w.log.Err(err).Int("count", n).Msg("Failed to process VIN registration rows.")
+				continue
+			}
+			if n != 0 {
+				w.log.Info().Int("count", n).Msg("Processed VIN registration rows.")
+			}
+		}
+	}
+}
+
+// Process handles up to a batch of pending rows and returns the number it
+// finished. Rows are locked while they're worked on, so several replicas can
+// run the worker at once.
+func (w *VINRegistrationWorker) Process(ctx context.Context) (int, error) {
+	for n := 0; n < vinRegistrationBatchSize; n++ {
+		ok, err := w.processOne(ctx)
+		if err != nil || !ok {
+			return n, err
+		}
+	}
+
+	return vinRegistrationBatchSize, nil
+}
+
+// processOne registers the oldest unlocked pending row. It returns false if
+// there was nothing to do.
+func (w *VINRegistrationWorker) processOne(ctx context.Context) (bool, error) {
+	tx, err := w.dbs().Writer.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback() //nolint
+
+	row, err := models.VinRegistrationJobRows(
+		models.VinRegistrationJobRowWhere.Status.EQ(models.VinRegistrationRowStatusPending),
+		qm.OrderBy(models.VinRegistrationJobRowColumns.JobID+", "+models.VinRegistrationJobRowColumns.RowNumber),
+		qm.For("UPDATE SKIP LOCKED"),
+	).One(ctx, tx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	job, err := models.FindVinRegistrationJob(ctx, tx, row.JobID)
+	if err != nil {
+		return false, err
+	}
+
+	// The vehicle is created outside of this transaction. Should the commit below
+	// fail, the retry finds the vehicle and reports the row as a duplicate.
+	if err := w.register(ctx, job.UserID, row); err != nil {
+		return false, err
+	}
+
+	row.UpdatedAt = time.Now()
+	if _, err := row.Update(ctx, tx, boil.Infer()); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	// Done after the commit so that, of two workers finishing a job's last rows
+	// at the same time, at least one sees them both.
+	if _, err := queries.Raw(`UPDATE devices_api.vin_registration_jobs j SET completed_at = now(), updated_at = now()
+		WHERE j.id = $1 AND j.completed_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM devices_api.vin_registration_job_rows r WHERE r.job_id = j.id AND r.status = $2)`,
+		job.ID, models.VinRegistrationRowStatusPending,
+	).ExecContext(ctx, w.dbs().Writer); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// register decodes the row's VIN and creates the vehicle, setting the row's status
+// and, where there is one, failure reason. Failures that belong to the row are
+// recorded on it; only database errors are returned.
+func (w *VINRegistrationWorker) register(ctx context.Context, userID string, row *models.VinRegistrationJobRow) error {
+	logger := w.log.With().Str("userId", userID).Str("jobId", row.JobID).Int("row", row.RowNumber).Str("vin", row.Vin).Logger()
+
+	existing, err := models.UserDevices(
+		models.UserDeviceWhere.VinIdentifier.EQ(null.StringFrom(row.Vin)),
+		qm.Expr(
+			models.UserDeviceWhere.VinConfirmed.EQ(true),
+			qm.Or2(models.UserDeviceWhere.UserID.EQ(userID)),
+		),
+	).One(ctx, w.dbs().Reader)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if existing != nil {
+		row.Status = models.VinRegistrationRowStatusDuplicate
+		if existing.UserID == userID {
+			row.UserDeviceID = null.StringFrom(existing.ID)
+		} else {
+			row.FailureReason = null.StringFrom("VIN already registered to a different user.")
+		}
+		return nil
+	}
+
+	decoded, err := w.deviceDefSvc.DecodeVIN(ctx, row.Vin, "", 0, row.CountryCode)
+	if err != nil {
+		logger.Err(err).Msg("Failed to decode VIN.")
+		row.Status = models.VinRegistrationRowStatusDecodeFailed
+		row.FailureReason = null.StringFrom("Unable to decode VIN.")
+		return nil
+	}
+	if decoded.DefinitionId == "" {
+		row.Status = models.VinRegistrationRowStatusDecodeFailed
+		row.FailureReason = null.StringFrom("Unable to decode VIN.")
+		return nil
+	}
+
+	ud, _, err := w.userDeviceSvc.CreateUserDevice(ctx, decoded.DefinitionId, decoded.DeviceStyleId, row.CountryCode, userID, &row.Vin, row.CanProtocol.Ptr(), false)
+	if err != nil {
+		row.Status = models.VinRegistrationRowStatusFailed
+		if errors.Is(err, ErrEmailUnverified) {
+			row.FailureReason = null.StringFrom("Email not verified.")
+		} else {
+			logger.Err(err).Msg("Failed to create vehicle.")
+			row.FailureReason = null.StringFrom("Failed to create vehicle.")
+		}
+		return nil
+	}
+
+	row.Status = models.VinRegistrationRowStatusCreated
+	row.UserDeviceID = null.StringFrom(ud.ID)
+
+	return nil
+}
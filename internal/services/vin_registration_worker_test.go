@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	ddgrpc "github.com/DIMO-Network/device-definitions-api/pkg/grpc"
+	"github.com/DIMO-Network/devices-api/internal/test"
+	"github.com/DIMO-Network/devices-api/models"
+	"github.com/DIMO-Network/shared/db"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.uber.org/mock/gomock"
+)
+
+// insertingUserDeviceService creates vehicles without any of the definition
+// lookups.
+type insertingUserDeviceService struct {
+	UserDeviceService
+	pdb db.Store
+}
+
+func (s *insertingUserDeviceService) CreateUserDevice(ctx context.Context, definitionID, _, countryCode, userID string, vin, _ *string, vinConfirmed bool) (*models.UserDevice, *ddgrpc.GetDeviceDefinitionItemResponse, error) {
+	ud := &models.UserDevice{
+		ID:            ksuid.New().String(),
+		UserID:        userID,
+		DefinitionID:  definitionID,
+		CountryCode:   null.StringFrom(countryCode),
+		VinIdentifier: null.StringFromPtr(vin),
+		VinConfirmed:  vinConfirmed,
+	}
+	if err := ud.Insert(ctx, s.pdb.DBS().Writer, boil.Infer()); err != nil {
+		return nil, nil, err
+	}
+	return ud, &ddgrpc.GetDeviceDefinitionItemResponse{Id: definitionID}, nil
+}
+
+func TestVINRegistrationWorker(t *testing.T) {
+	ctx := context.Background()
+	pdb, container := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	defer container.Terminate(ctx) //nolint
+
+	logger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	ddSvc := NewMockDeviceDefinitionService(ctrl)
+
+	const userID = "fleetUser"
+	const (
+		existingVIN = "1FMCU0G6XMUA00001"
+		otherVIN    = "1FMCU0G6XMUA00002"
+		goodVIN     = "1FMCU0G6XMUA00003"
+		badVIN      = "1FMCU0G6XMUA00004"
+	)
+
+	existing := test.SetupCreateUserDevice(t, userID, "ford_escape_2021", nil, existingVIN, pdb)
+	_ = test.SetupCreateUserDevice(t, "someoneElse", "ford_escape_2021", nil, otherVIN, pdb)
+
+	job := models.VinRegistrationJob{ID: ksuid.New().String(), UserID: userID}
+	require.NoError(t, job.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	for i, vin := range []string{existingVIN, otherVIN, goodVIN, badVIN} {
+		row := models.VinRegistrationJobRow{
+			JobID:       job.ID,
+			RowNumber:   i,
+			Vin:         vin,
+			CountryCode: "USA",
+			Status:      models.VinRegistrationRowStatusPending,
+		}
+		require.NoError(t, row.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+	}
+
+	ddSvc.EXPECT().DecodeVIN(gomock.Any(), goodVIN, "", 0, "USA").Return(&ddgrpc.DecodeVinResponse{DefinitionId: "ford_escape_2021"}, nil)
+	ddSvc.EXPECT().DecodeVIN(gomock.Any(), badVIN, "", 0, "USA").Return(&ddgrpc.DecodeVinResponse{}, nil)
+
+	worker := NewVINRegistrationWorker(pdb.DBS, &logger, ddSvc, &insertingUserDeviceService{pdb: pdb}, 0)
+
+	n, err := worker.Process(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	rows, err := models.VinRegistrationJobRows(
+		models.VinRegistrationJobRowWhere.JobID.EQ(job.ID),
+		qm.OrderBy(models.VinRegistrationJobRowColumns.RowNumber),
+	).All(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	require.Equal(t, models.VinRegistrationRowStatusDuplicate, rows[0].Status)
+	require.Equal(t, null.StringFrom(existing.ID), rows[0].UserDeviceID)
+
+	// Someone else's vehicle isn't revealed.
+	require.Equal(t, models.VinRegistrationRowStatusDuplicate, rows[1].Status)
+	require.False(t, rows[1].UserDeviceID.Valid)
+
+	require.Equal(t, models.VinRegistrationRowStatusCreated, rows[2].Status)
+	created, err := models.FindUserDevice(ctx, pdb.DBS().Reader, rows[2].UserDeviceID.String)
+	require.NoError(t, err)
+	require.Equal(t, userID, created.UserID)
+	require.Equal(t, null.StringFrom(goodVIN), created.VinIdentifier)
+
+	require.Equal(t, models.VinRegistrationRowStatusDecodeFailed, rows[3].Status)
+
+	require.NoError(t, job.Reload(ctx, pdb.DBS().Reader))
+	require.True(t, job.CompletedAt.Valid)
+
+	n, err = worker.Process(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+ALTER TYPE device_command_request_status ADD VALUE 'TimedOut';
+ALTER TYPE device_command_request_status ADD VALUE 'Cancelled';
+
+ALTER TABLE device_command_requests
+    ADD COLUMN result jsonb,
+    ADD COLUMN failure_reason text;
+
+CREATE INDEX device_command_requests_pending_created_at_idx ON device_command_requests (created_at) WHERE status = 'Pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP INDEX device_command_requests_pending_created_at_idx;
+
+ALTER TABLE device_command_requests
+    DROP COLUMN failure_reason,
+    DROP COLUMN result;
+-- No easy way to subtract from an enum.
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+ALTER TABLE device_command_requests ADD COLUMN requester text;
+
+CREATE INDEX device_command_requests_user_device_id_created_at_idx ON device_command_requests (user_device_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP INDEX device_command_requests_user_device_id_created_at_idx;
+
+ALTER TABLE device_command_requests DROP COLUMN requester;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE idempotency_keys (
+    scope text NOT NULL,
+    key text NOT NULL,
+    request_hash bytea NOT NULL,
+    status_code integer,
+    response_body bytea,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT idempotency_keys_pkey PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx ON idempotency_keys (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE idempotency_keys;
+-- +goose StatementEnd
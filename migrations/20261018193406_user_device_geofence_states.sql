@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE user_device_geofence_states (
+    user_device_id char(27) NOT NULL,
+    geofence_id char(27) NOT NULL,
+    inside boolean NOT NULL,
+    observed_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT user_device_geofence_states_pkey PRIMARY KEY (user_device_id, geofence_id),
+    CONSTRAINT user_device_geofence_states_user_device_id_fkey FOREIGN KEY (user_device_id) REFERENCES user_devices (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT user_device_geofence_states_geofence_id_fkey FOREIGN KEY (geofence_id) REFERENCES geofences (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE user_device_geofence_states;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE geofences ADD COLUMN shape jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE geofences DROP COLUMN shape;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE geofences
+    ADD COLUMN schedule jsonb,
+    ADD COLUMN active_from timestamptz,
+    ADD COLUMN active_until timestamptz;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE geofences
+    DROP COLUMN active_until,
+    DROP COLUMN active_from,
+    DROP COLUMN schedule;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE aftermarket_device_fingerprints (
+    id char(27) NOT NULL,
+    aftermarket_device_address bytea NOT NULL,
+    user_device_id char(27),
+    source text NOT NULL,
+    protocol text,
+    vin text,
+    vin_mismatch boolean NOT NULL DEFAULT FALSE,
+    observed_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT aftermarket_device_fingerprints_pkey PRIMARY KEY (id),
+    CONSTRAINT aftermarket_device_fingerprints_aftermarket_device_address_fkey FOREIGN KEY (aftermarket_device_address) REFERENCES aftermarket_devices (ethereum_address) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT aftermarket_device_fingerprints_user_device_id_fkey FOREIGN KEY (user_device_id) REFERENCES user_devices (id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX aftermarket_device_fingerprints_aftermarket_device_address_observed_at_idx ON aftermarket_device_fingerprints (aftermarket_device_address, observed_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE aftermarket_device_fingerprints;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE user_device_api_integrations
+    ADD COLUMN token_refresh_failures integer NOT NULL DEFAULT 0,
+    ADD COLUMN failure_reason text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+
+ALTER TABLE user_device_api_integrations
+    DROP COLUMN failure_reason,
+    DROP COLUMN token_refresh_failures;
+-- +goose StatementEnd
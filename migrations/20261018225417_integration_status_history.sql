@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE user_device_api_integration_status_history (
+    id char(27) NOT NULL,
+    user_device_id char(27) NOT NULL,
+    integration_id char(27) NOT NULL,
+    from_status user_device_api_integration_status,
+    to_status user_device_api_integration_status,
+    source text NOT NULL,
+    reason text,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT user_device_api_integration_status_history_pkey PRIMARY KEY (id),
+    CONSTRAINT user_device_api_integration_status_history_user_device_id_fkey FOREIGN KEY (user_device_id) REFERENCES user_devices (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN user_device_api_integration_status_history.from_status IS 'Null when the integration was created.';
+COMMENT ON COLUMN user_device_api_integration_status_history.to_status IS 'Null when the integration was removed.';
+
+CREATE INDEX user_device_api_integration_status_history_device_integration_idx ON user_device_api_integration_status_history (user_device_id, integration_id, created_at DESC);
+CREATE INDEX user_device_api_integration_status_history_created_at_idx ON user_device_api_integration_status_history (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE user_device_api_integration_status_history;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+ALTER TABLE nft_privileges ADD COLUMN expiry_notified_at timestamptz;
+
+COMMENT ON COLUMN nft_privileges.expiry_notified_at IS 'When the grantee was warned that the privilege is about to expire. Cleared when the expiry changes.';
+
+CREATE INDEX nft_privileges_expiry_idx ON nft_privileges (expiry) WHERE expiry_notified_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP INDEX nft_privileges_expiry_idx;
+ALTER TABLE nft_privileges DROP COLUMN expiry_notified_at;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TYPE vin_registration_row_status AS ENUM ('Pending', 'Created', 'Duplicate', 'DecodeFailed', 'Failed');
+
+CREATE TABLE vin_registration_jobs (
+    id char(27) NOT NULL,
+    user_id text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamptz,
+
+    CONSTRAINT vin_registration_jobs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX vin_registration_jobs_user_id_idx ON vin_registration_jobs (user_id);
+
+CREATE TABLE vin_registration_job_rows (
+    job_id char(27) NOT NULL,
+    row_number int NOT NULL,
+    vin text NOT NULL,
+    country_code text NOT NULL,
+    can_protocol text,
+    status vin_registration_row_status NOT NULL DEFAULT 'Pending',
+    user_device_id char(27),
+    failure_reason text,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT vin_registration_job_rows_pkey PRIMARY KEY (job_id, row_number),
+    CONSTRAINT vin_registration_job_rows_job_id_fkey FOREIGN KEY (job_id) REFERENCES vin_registration_jobs (id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN vin_registration_job_rows.row_number IS 'Zero-based position of the row in the submitted list.';
+COMMENT ON COLUMN vin_registration_job_rows.user_device_id IS 'The vehicle created for the row or, for duplicates, the requester''s existing vehicle with that VIN.';
+
+CREATE INDEX vin_registration_job_rows_pending_idx ON vin_registration_job_rows (job_id, row_number) WHERE status = 'Pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE vin_registration_job_rows;
+DROP TABLE vin_registration_jobs;
+DROP TYPE vin_registration_row_status;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE vehicle_mint_batches (
+    id char(27) NOT NULL,
+    user_id text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT vehicle_mint_batches_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX vehicle_mint_batches_user_id_idx ON vehicle_mint_batches (user_id);
+
+CREATE TABLE vehicle_mint_batch_vehicles (
+    batch_id char(27) NOT NULL,
+    user_device_id char(27) NOT NULL,
+    mint_request_id char(27),
+    failure_reason text,
+
+    CONSTRAINT vehicle_mint_batch_vehicles_pkey PRIMARY KEY (batch_id, user_device_id),
+    CONSTRAINT vehicle_mint_batch_vehicles_batch_id_fkey FOREIGN KEY (batch_id) REFERENCES vehicle_mint_batches (id) ON DELETE CASCADE,
+    CONSTRAINT vehicle_mint_batch_vehicles_user_device_id_fkey FOREIGN KEY (user_device_id) REFERENCES user_devices (id) ON DELETE CASCADE,
+    CONSTRAINT vehicle_mint_batch_vehicles_mint_request_id_fkey FOREIGN KEY (mint_request_id) REFERENCES meta_transaction_requests (id) ON DELETE SET NULL
+);
+
+COMMENT ON COLUMN vehicle_mint_batch_vehicles.mint_request_id IS 'Null when the vehicle was rejected before a transaction was sent.';
+COMMENT ON COLUMN vehicle_mint_batch_vehicles.failure_reason IS 'Why the vehicle was rejected.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE vehicle_mint_batch_vehicles;
+DROP TABLE vehicle_mint_batches;
+-- +goose StatementEnd
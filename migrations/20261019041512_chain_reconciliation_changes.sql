@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE chain_reconciliation_changes (
+    id char(27) NOT NULL,
+    run_id char(27) NOT NULL,
+    entity text NOT NULL,
+    token_id numeric(78, 0) NOT NULL,
+    field text NOT NULL,
+    old_value text,
+    new_value text,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chain_reconciliation_changes_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX chain_reconciliation_changes_entity_token_id_idx ON chain_reconciliation_changes (entity, token_id);
+
+COMMENT ON TABLE chain_reconciliation_changes IS 'Every repair made by the reconcile-chain command.';
+COMMENT ON COLUMN chain_reconciliation_changes.entity IS 'One of vehicle, aftermarket_device and synthetic_device.';
+COMMENT ON COLUMN chain_reconciliation_changes.old_value IS 'Value in the database before the repair. Addresses are hex, token ids decimal.';
+COMMENT ON COLUMN chain_reconciliation_changes.new_value IS 'Value read from the chain.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE chain_reconciliation_changes;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+CREATE TABLE processed_contract_events (
+    chain_id bigint NOT NULL,
+    block_number bigint NOT NULL,
+    transaction_hash bytea NOT NULL
+        CONSTRAINT processed_contract_events_transaction_hash_check CHECK (length(transaction_hash) = 32),
+    log_index integer NOT NULL,
+    contract bytea NOT NULL
+        CONSTRAINT processed_contract_events_contract_check CHECK (length(contract) = 20),
+    event_name text NOT NULL,
+    entity_key text,
+    stale boolean NOT NULL DEFAULT FALSE,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT processed_contract_events_pkey PRIMARY KEY (chain_id, block_number, transaction_hash, log_index)
+);
+
+CREATE INDEX processed_contract_events_entity_key_idx ON processed_contract_events (entity_key, block_number, log_index) WHERE entity_key IS NOT NULL;
+
+COMMENT ON TABLE processed_contract_events IS 'Every contract event the consumer has handled, so that redeliveries are skipped.';
+COMMENT ON COLUMN processed_contract_events.entity_key IS 'Ownership or pairing the event changes, for example vehicle/5/owner. An event is not applied over a later one with the same key.';
+COMMENT ON COLUMN processed_contract_events.stale IS 'True if the event was recorded but not applied because a later event for the same key had already been.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+DROP TABLE processed_contract_events;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+ALTER TABLE geofences ADD COLUMN published_active boolean;
+
+COMMENT ON COLUMN geofences.published_active IS 'Whether the fence was active when the schedule watcher last republished its vehicles. Null if it never has.';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = devices_api, public;
+ALTER TABLE geofences DROP COLUMN published_active;
+-- +goose StatementEnd
@@ -110,113 +110,6 @@ var AftermarketDeviceTableColumns = struct {
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelper__byte struct{ field string }
-
-func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 type whereHelpertypes_Decimal struct{ field string }
 
 func (w whereHelpertypes_Decimal) EQ(x types.Decimal) qm.QueryMod {
@@ -346,29 +239,32 @@ var AftermarketDeviceWhere = struct {
 
 // AftermarketDeviceRels is where relationship names are stored.
 var AftermarketDeviceRels = struct {
-	VehicleToken                    string
-	ClaimMetaTransactionRequest     string
-	PairRequest                     string
-	UnpairRequest                   string
-	AutopiUnitAutopiJobs            string
-	SerialUserDeviceAPIIntegrations string
+	VehicleToken                                          string
+	ClaimMetaTransactionRequest                           string
+	PairRequest                                           string
+	UnpairRequest                                         string
+	AftermarketDeviceAddressAftermarketDeviceFingerprints string
+	AutopiUnitAutopiJobs                                  string
+	SerialUserDeviceAPIIntegrations                       string
 }{
-	VehicleToken:                    "VehicleToken",
-	ClaimMetaTransactionRequest:     "ClaimMetaTransactionRequest",
-	PairRequest:                     "PairRequest",
-	UnpairRequest:                   "UnpairRequest",
+	VehicleToken:                "VehicleToken",
+	ClaimMetaTransactionRequest: "ClaimMetaTransactionRequest",
+	PairRequest:                 "PairRequest",
+	UnpairRequest:               "UnpairRequest",
+	AftermarketDeviceAddressAftermarketDeviceFingerprints: "AftermarketDeviceAddressAftermarketDeviceFingerprints",
 	AutopiUnitAutopiJobs:            "AutopiUnitAutopiJobs",
 	SerialUserDeviceAPIIntegrations: "SerialUserDeviceAPIIntegrations",
 }
 
 // aftermarketDeviceR is where relationships are stored.
 type aftermarketDeviceR struct {
-	VehicleToken                    *UserDevice                   `boil:"VehicleToken" json:"VehicleToken" toml:"VehicleToken" yaml:"VehicleToken"`
-	ClaimMetaTransactionRequest     *MetaTransactionRequest       `boil:"ClaimMetaTransactionRequest" json:"ClaimMetaTransactionRequest" toml:"ClaimMetaTransactionRequest" yaml:"ClaimMetaTransactionRequest"`
-	PairRequest                     *MetaTransactionRequest       `boil:"PairRequest" json:"PairRequest" toml:"PairRequest" yaml:"PairRequest"`
-	UnpairRequest                   *MetaTransactionRequest       `boil:"UnpairRequest" json:"UnpairRequest" toml:"UnpairRequest" yaml:"UnpairRequest"`
-	AutopiUnitAutopiJobs            AutopiJobSlice                `boil:"AutopiUnitAutopiJobs" json:"AutopiUnitAutopiJobs" toml:"AutopiUnitAutopiJobs" yaml:"AutopiUnitAutopiJobs"`
-	SerialUserDeviceAPIIntegrations UserDeviceAPIIntegrationSlice `boil:"SerialUserDeviceAPIIntegrations" json:"SerialUserDeviceAPIIntegrations" toml:"SerialUserDeviceAPIIntegrations" yaml:"SerialUserDeviceAPIIntegrations"`
+	VehicleToken                                          *UserDevice                       `boil:"VehicleToken" json:"VehicleToken" toml:"VehicleToken" yaml:"VehicleToken"`
+	ClaimMetaTransactionRequest                           *MetaTransactionRequest           `boil:"ClaimMetaTransactionRequest" json:"ClaimMetaTransactionRequest" toml:"ClaimMetaTransactionRequest" yaml:"ClaimMetaTransactionRequest"`
+	PairRequest                                           *MetaTransactionRequest           `boil:"PairRequest" json:"PairRequest" toml:"PairRequest" yaml:"PairRequest"`
+	UnpairRequest                                         *MetaTransactionRequest           `boil:"UnpairRequest" json:"UnpairRequest" toml:"UnpairRequest" yaml:"UnpairRequest"`
+	AftermarketDeviceAddressAftermarketDeviceFingerprints AftermarketDeviceFingerprintSlice `boil:"AftermarketDeviceAddressAftermarketDeviceFingerprints" json:"AftermarketDeviceAddressAftermarketDeviceFingerprints" toml:"AftermarketDeviceAddressAftermarketDeviceFingerprints" yaml:"AftermarketDeviceAddressAftermarketDeviceFingerprints"`
+	AutopiUnitAutopiJobs                                  AutopiJobSlice                    `boil:"AutopiUnitAutopiJobs" json:"AutopiUnitAutopiJobs" toml:"AutopiUnitAutopiJobs" yaml:"AutopiUnitAutopiJobs"`
+	SerialUserDeviceAPIIntegrations                       UserDeviceAPIIntegrationSlice     `boil:"SerialUserDeviceAPIIntegrations" json:"SerialUserDeviceAPIIntegrations" toml:"SerialUserDeviceAPIIntegrations" yaml:"SerialUserDeviceAPIIntegrations"`
 }
 
 // NewStruct creates a new relationship struct
@@ -404,6 +300,13 @@ func (r *aftermarketDeviceR) GetUnpairRequest() *MetaTransactionRequest {
 	return r.UnpairRequest
 }
 
+func (r *aftermarketDeviceR) GetAftermarketDeviceAddressAftermarketDeviceFingerprints() AftermarketDeviceFingerprintSlice {
+	if r == nil {
+		return nil
+	}
+	return r.AftermarketDeviceAddressAftermarketDeviceFingerprints
+}
+
 func (r *aftermarketDeviceR) GetAutopiUnitAutopiJobs() AutopiJobSlice {
 	if r == nil {
 		return nil
@@ -778,6 +681,20 @@ func (o *AftermarketDevice) UnpairRequest(mods ...qm.QueryMod) metaTransactionRe
 	return MetaTransactionRequests(queryMods...)
 }
 
+// AftermarketDeviceAddressAftermarketDeviceFingerprints retrieves all the aftermarket_device_fingerprint's AftermarketDeviceFingerprints with an executor via aftermarket_device_address column.
+func (o *AftermarketDevice) AftermarketDeviceAddressAftermarketDeviceFingerprints(mods ...qm.QueryMod) aftermarketDeviceFingerprintQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"aftermarket_device_fingerprints\".\"aftermarket_device_address\"=?", o.EthereumAddress),
+	)
+
+	return AftermarketDeviceFingerprints(queryMods...)
+}
+
 // AutopiUnitAutopiJobs retrieves all the autopi_job's AutopiJobs with an executor via autopi_unit_id column.
 func (o *AftermarketDevice) AutopiUnitAutopiJobs(mods ...qm.QueryMod) autopiJobQuery {
 	var queryMods []qm.QueryMod
@@ -1302,6 +1219,119 @@ func (aftermarketDeviceL) LoadUnpairRequest(ctx context.Context, e boil.ContextE
 	return nil
 }
 
+// LoadAftermarketDeviceAddressAftermarketDeviceFingerprints allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (aftermarketDeviceL) LoadAftermarketDeviceAddressAftermarketDeviceFingerprints(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAftermarketDevice interface{}, mods queries.Applicator) error {
+	var slice []*AftermarketDevice
+	var object *AftermarketDevice
+
+	if singular {
+		var ok bool
+		object, ok = maybeAftermarketDevice.(*AftermarketDevice)
+		if !ok {
+			object = new(AftermarketDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAftermarketDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAftermarketDevice))
+			}
+		}
+	} else {
+		s, ok := maybeAftermarketDevice.(*[]*AftermarketDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAftermarketDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAftermarketDevice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &aftermarketDeviceR{}
+		}
+		args[object.EthereumAddress] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &aftermarketDeviceR{}
+			}
+			args[obj.EthereumAddress] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.aftermarket_device_fingerprints`),
+		qm.WhereIn(`devices_api.aftermarket_device_fingerprints.aftermarket_device_address in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load aftermarket_device_fingerprints")
+	}
+
+	var resultSlice []*AftermarketDeviceFingerprint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice aftermarket_device_fingerprints")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on aftermarket_device_fingerprints")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_device_fingerprints")
+	}
+
+	if len(aftermarketDeviceFingerprintAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AftermarketDeviceAddressAftermarketDeviceFingerprints = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &aftermarketDeviceFingerprintR{}
+			}
+			foreign.R.AftermarketDeviceAddressAftermarketDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.EthereumAddress, foreign.AftermarketDeviceAddress) {
+				local.R.AftermarketDeviceAddressAftermarketDeviceFingerprints = append(local.R.AftermarketDeviceAddressAftermarketDeviceFingerprints, foreign)
+				if foreign.R == nil {
+					foreign.R = &aftermarketDeviceFingerprintR{}
+				}
+				foreign.R.AftermarketDeviceAddressAftermarketDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAutopiUnitAutopiJobs allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (aftermarketDeviceL) LoadAutopiUnitAutopiJobs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAftermarketDevice interface{}, mods queries.Applicator) error {
@@ -1804,6 +1834,59 @@ func (o *AftermarketDevice) RemoveUnpairRequest(ctx context.Context, exec boil.C
 	return nil
 }
 
+// AddAftermarketDeviceAddressAftermarketDeviceFingerprints adds the given related objects to the existing relationships
+// of the aftermarket_device, optionally inserting them as new records.
+// Appends related to o.R.AftermarketDeviceAddressAftermarketDeviceFingerprints.
+// Sets related.R.AftermarketDeviceAddressAftermarketDevice appropriately.
+func (o *AftermarketDevice) AddAftermarketDeviceAddressAftermarketDeviceFingerprints(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AftermarketDeviceFingerprint) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.AftermarketDeviceAddress, o.EthereumAddress)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"aftermarket_device_fingerprints\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"aftermarket_device_address"}),
+				strmangle.WhereClause("\"", "\"", 2, aftermarketDeviceFingerprintPrimaryKeyColumns),
+			)
+			values := []interface{}{o.EthereumAddress, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.AftermarketDeviceAddress, o.EthereumAddress)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &aftermarketDeviceR{
+			AftermarketDeviceAddressAftermarketDeviceFingerprints: related,
+		}
+	} else {
+		o.R.AftermarketDeviceAddressAftermarketDeviceFingerprints = append(o.R.AftermarketDeviceAddressAftermarketDeviceFingerprints, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &aftermarketDeviceFingerprintR{
+				AftermarketDeviceAddressAftermarketDevice: o,
+			}
+		} else {
+			rel.R.AftermarketDeviceAddressAftermarketDevice = o
+		}
+	}
+	return nil
+}
+
 // AddAutopiUnitAutopiJobs adds the given related objects to the existing relationships
 // of the aftermarket_device, optionally inserting them as new records.
 // Appends related to o.R.AutopiUnitAutopiJobs.
@@ -4,31 +4,51 @@
 package models
 
 var TableNames = struct {
-	AftermarketDevices        string
-	AutopiJobs                string
-	DCN                       string
-	DeviceCommandRequests     string
-	ErrorCodeQueries          string
-	Geofences                 string
-	MetaTransactionRequests   string
-	NFTPrivileges             string
-	PartialAftermarketDevices string
-	SyntheticDevices          string
-	UserDeviceAPIIntegrations string
-	UserDeviceToGeofence      string
-	UserDevices               string
+	AftermarketDeviceFingerprints         string
+	AftermarketDevices                    string
+	AutopiJobs                            string
+	ChainReconciliationChanges            string
+	DCN                                   string
+	DeviceCommandRequests                 string
+	ErrorCodeQueries                      string
+	Geofences                             string
+	IdempotencyKeys                       string
+	MetaTransactionRequests               string
+	NFTPrivileges                         string
+	PartialAftermarketDevices             string
+	ProcessedContractEvents               string
+	SyntheticDevices                      string
+	UserDeviceAPIIntegrationStatusHistory string
+	UserDeviceAPIIntegrations             string
+	UserDeviceGeofenceStates              string
+	UserDeviceToGeofence                  string
+	UserDevices                           string
+	VehicleMintBatchVehicles              string
+	VehicleMintBatches                    string
+	VinRegistrationJobRows                string
+	VinRegistrationJobs                   string
 }{
-	AftermarketDevices:        "aftermarket_devices",
-	AutopiJobs:                "autopi_jobs",
-	DCN:                       "dcn",
-	DeviceCommandRequests:     "device_command_requests",
-	ErrorCodeQueries:          "error_code_queries",
-	Geofences:                 "geofences",
-	MetaTransactionRequests:   "meta_transaction_requests",
-	NFTPrivileges:             "nft_privileges",
-	PartialAftermarketDevices: "partial_aftermarket_devices",
-	SyntheticDevices:          "synthetic_devices",
-	UserDeviceAPIIntegrations: "user_device_api_integrations",
-	UserDeviceToGeofence:      "user_device_to_geofence",
-	UserDevices:               "user_devices",
+	AftermarketDeviceFingerprints:         "aftermarket_device_fingerprints",
+	AftermarketDevices:                    "aftermarket_devices",
+	AutopiJobs:                            "autopi_jobs",
+	ChainReconciliationChanges:            "chain_reconciliation_changes",
+	DCN:                                   "dcn",
+	DeviceCommandRequests:                 "device_command_requests",
+	ErrorCodeQueries:                      "error_code_queries",
+	Geofences:                             "geofences",
+	IdempotencyKeys:                       "idempotency_keys",
+	MetaTransactionRequests:               "meta_transaction_requests",
+	NFTPrivileges:                         "nft_privileges",
+	PartialAftermarketDevices:             "partial_aftermarket_devices",
+	ProcessedContractEvents:               "processed_contract_events",
+	SyntheticDevices:                      "synthetic_devices",
+	UserDeviceAPIIntegrationStatusHistory: "user_device_api_integration_status_history",
+	UserDeviceAPIIntegrations:             "user_device_api_integrations",
+	UserDeviceGeofenceStates:              "user_device_geofence_states",
+	UserDeviceToGeofence:                  "user_device_to_geofence",
+	UserDevices:                           "user_devices",
+	VehicleMintBatchVehicles:              "vehicle_mint_batch_vehicles",
+	VehicleMintBatches:                    "vehicle_mint_batches",
+	VinRegistrationJobRows:                "vin_registration_job_rows",
+	VinRegistrationJobs:                   "vin_registration_jobs",
 }
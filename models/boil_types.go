@@ -53,9 +53,11 @@ func makeCacheKey(cols boil.Columns, nzDefaults []string) string {
 
 // Enum values for DeviceCommandRequestStatus
 const (
-	DeviceCommandRequestStatusPending  string = "Pending"
-	DeviceCommandRequestStatusComplete string = "Complete"
-	DeviceCommandRequestStatusFailed   string = "Failed"
+	DeviceCommandRequestStatusPending   string = "Pending"
+	DeviceCommandRequestStatusComplete  string = "Complete"
+	DeviceCommandRequestStatusFailed    string = "Failed"
+	DeviceCommandRequestStatusTimedOut  string = "TimedOut"
+	DeviceCommandRequestStatusCancelled string = "Cancelled"
 )
 
 func AllDeviceCommandRequestStatus() []string {
@@ -63,6 +65,8 @@ func AllDeviceCommandRequestStatus() []string {
 		DeviceCommandRequestStatusPending,
 		DeviceCommandRequestStatusComplete,
 		DeviceCommandRequestStatusFailed,
+		DeviceCommandRequestStatusTimedOut,
+		DeviceCommandRequestStatusCancelled,
 	}
 }
 
@@ -120,3 +124,22 @@ func AllUserDeviceAPIIntegrationStatus() []string {
 		UserDeviceAPIIntegrationStatusAuthenticationFailure,
 	}
 }
+
+// Enum values for VinRegistrationRowStatus
+const (
+	VinRegistrationRowStatusPending      string = "Pending"
+	VinRegistrationRowStatusCreated      string = "Created"
+	VinRegistrationRowStatusDuplicate    string = "Duplicate"
+	VinRegistrationRowStatusDecodeFailed string = "DecodeFailed"
+	VinRegistrationRowStatusFailed       string = "Failed"
+)
+
+func AllVinRegistrationRowStatus() []string {
+	return []string{
+		VinRegistrationRowStatusPending,
+		VinRegistrationRowStatusCreated,
+		VinRegistrationRowStatusDuplicate,
+		VinRegistrationRowStatusDecodeFailed,
+		VinRegistrationRowStatusFailed,
+	}
+}
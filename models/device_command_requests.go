@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,13 +24,16 @@ import (
 
 // DeviceCommandRequest is an object representing the database table.
 type DeviceCommandRequest struct {
-	ID            string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	UserDeviceID  string    `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
-	IntegrationID string    `boil:"integration_id" json:"integration_id" toml:"integration_id" yaml:"integration_id"`
-	Command       string    `boil:"command" json:"command" toml:"command" yaml:"command"`
-	Status        string    `boil:"status" json:"status" toml:"status" yaml:"status"`
-	CreatedAt     time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt     time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID            string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserDeviceID  string      `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	IntegrationID string      `boil:"integration_id" json:"integration_id" toml:"integration_id" yaml:"integration_id"`
+	Command       string      `boil:"command" json:"command" toml:"command" yaml:"command"`
+	Status        string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	CreatedAt     time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt     time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Result        null.JSON   `boil:"result" json:"result,omitempty" toml:"result" yaml:"result,omitempty"`
+	FailureReason null.String `boil:"failure_reason" json:"failure_reason,omitempty" toml:"failure_reason" yaml:"failure_reason,omitempty"`
+	Requester     null.String `boil:"requester" json:"requester,omitempty" toml:"requester" yaml:"requester,omitempty"`
 
 	R *deviceCommandRequestR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L deviceCommandRequestL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -43,6 +47,9 @@ var DeviceCommandRequestColumns = struct {
 	Status        string
 	CreatedAt     string
 	UpdatedAt     string
+	Result        string
+	FailureReason string
+	Requester     string
 }{
 	ID:            "id",
 	UserDeviceID:  "user_device_id",
@@ -51,6 +58,9 @@ var DeviceCommandRequestColumns = struct {
 	Status:        "status",
 	CreatedAt:     "created_at",
 	UpdatedAt:     "updated_at",
+	Result:        "result",
+	FailureReason: "failure_reason",
+	Requester:     "requester",
 }
 
 var DeviceCommandRequestTableColumns = struct {
@@ -61,6 +71,9 @@ var DeviceCommandRequestTableColumns = struct {
 	Status        string
 	CreatedAt     string
 	UpdatedAt     string
+	Result        string
+	FailureReason string
+	Requester     string
 }{
 	ID:            "device_command_requests.id",
 	UserDeviceID:  "device_command_requests.user_device_id",
@@ -69,6 +82,9 @@ var DeviceCommandRequestTableColumns = struct {
 	Status:        "device_command_requests.status",
 	CreatedAt:     "device_command_requests.created_at",
 	UpdatedAt:     "device_command_requests.updated_at",
+	Result:        "device_command_requests.result",
+	FailureReason: "device_command_requests.failure_reason",
+	Requester:     "device_command_requests.requester",
 }
 
 // Generated where
@@ -81,6 +97,9 @@ var DeviceCommandRequestWhere = struct {
 	Status        whereHelperstring
 	CreatedAt     whereHelpertime_Time
 	UpdatedAt     whereHelpertime_Time
+	Result        whereHelpernull_JSON
+	FailureReason whereHelpernull_String
+	Requester     whereHelpernull_String
 }{
 	ID:            whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"id\""},
 	UserDeviceID:  whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"user_device_id\""},
@@ -89,6 +108,9 @@ var DeviceCommandRequestWhere = struct {
 	Status:        whereHelperstring{field: "\"devices_api\".\"device_command_requests\".\"status\""},
 	CreatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"device_command_requests\".\"created_at\""},
 	UpdatedAt:     whereHelpertime_Time{field: "\"devices_api\".\"device_command_requests\".\"updated_at\""},
+	Result:        whereHelpernull_JSON{field: "\"devices_api\".\"device_command_requests\".\"result\""},
+	FailureReason: whereHelpernull_String{field: "\"devices_api\".\"device_command_requests\".\"failure_reason\""},
+	Requester:     whereHelpernull_String{field: "\"devices_api\".\"device_command_requests\".\"requester\""},
 }
 
 // DeviceCommandRequestRels is where relationship names are stored.
@@ -119,8 +141,8 @@ func (r *deviceCommandRequestR) GetUserDevice() *UserDevice {
 type deviceCommandRequestL struct{}
 
 var (
-	deviceCommandRequestAllColumns            = []string{"id", "user_device_id", "integration_id", "command", "status", "created_at", "updated_at"}
-	deviceCommandRequestColumnsWithoutDefault = []string{"id", "user_device_id", "integration_id", "command", "status"}
+	deviceCommandRequestAllColumns            = []string{"id", "user_device_id", "integration_id", "command", "status", "created_at", "updated_at", "result", "failure_reason", "requester"}
+	deviceCommandRequestColumnsWithoutDefault = []string{"id", "user_device_id", "integration_id", "command", "status", "result", "failure_reason", "requester"}
 	deviceCommandRequestColumnsWithDefault    = []string{"created_at", "updated_at"}
 	deviceCommandRequestPrimaryKeyColumns     = []string{"id"}
 	deviceCommandRequestGeneratedColumns      = []string{}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -24,52 +25,77 @@ import (
 
 // Geofence is an object representing the database table.
 type Geofence struct {
-	ID        string            `boil:"id" json:"id" toml:"id" yaml:"id"`
-	UserID    string            `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
-	Name      string            `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Type      string            `boil:"type" json:"type" toml:"type" yaml:"type"`
-	H3Indexes types.StringArray `boil:"h3_indexes" json:"h3_indexes,omitempty" toml:"h3_indexes" yaml:"h3_indexes,omitempty"`
-	CreatedAt time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID              string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserID          string            `boil:"user_id" json:"user_id" toml:"user_id" yaml:"user_id"`
+	Name            string            `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Type            string            `boil:"type" json:"type" toml:"type" yaml:"type"`
+	H3Indexes       types.StringArray `boil:"h3_indexes" json:"h3_indexes,omitempty" toml:"h3_indexes" yaml:"h3_indexes,omitempty"`
+	Shape           null.JSON         `boil:"shape" json:"shape,omitempty" toml:"shape" yaml:"shape,omitempty"`
+	Schedule        null.JSON         `boil:"schedule" json:"schedule,omitempty" toml:"schedule" yaml:"schedule,omitempty"`
+	ActiveFrom      null.Time         `boil:"active_from" json:"active_from,omitempty" toml:"active_from" yaml:"active_from,omitempty"`
+	ActiveUntil     null.Time         `boil:"active_until" json:"active_until,omitempty" toml:"active_until" yaml:"active_until,omitempty"`
+	CreatedAt       time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt       time.Time         `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	PublishedActive null.Bool         `boil:"published_active" json:"published_active,omitempty" toml:"published_active" yaml:"published_active,omitempty"`
 
 	R *geofenceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L geofenceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var GeofenceColumns = struct {
-	ID        string
-	UserID    string
-	Name      string
-	Type      string
-	H3Indexes string
-	CreatedAt string
-	UpdatedAt string
+	ID              string
+	UserID          string
+	Name            string
+	Type            string
+	H3Indexes       string
+	Shape           string
+	Schedule        string
+	ActiveFrom      string
+	ActiveUntil     string
+	CreatedAt       string
+	UpdatedAt       string
+	PublishedActive string
 }{
-	ID:        "id",
-	UserID:    "user_id",
-	Name:      "name",
-	Type:      "type",
-	H3Indexes: "h3_indexes",
-	CreatedAt: "created_at",
-	UpdatedAt: "updated_at",
+	ID:              "id",
+	UserID:          "user_id",
+	Name:            "name",
+	Type:            "type",
+	H3Indexes:       "h3_indexes",
+	Shape:           "shape",
+	Schedule:        "schedule",
+	ActiveFrom:      "active_from",
+	ActiveUntil:     "active_until",
+	CreatedAt:       "created_at",
+	UpdatedAt:       "updated_at",
+	PublishedActive: "published_active",
 }
 
 var GeofenceTableColumns = struct {
-	ID        string
-	UserID    string
-	Name      string
-	Type      string
-	H3Indexes string
-	CreatedAt string
-	UpdatedAt string
+	ID              string
+	UserID          string
+	Name            string
+	Type            string
+	H3Indexes       string
+	Shape           string
+	Schedule        string
+	ActiveFrom      string
+	ActiveUntil     string
+	CreatedAt       string
+	UpdatedAt       string
+	PublishedActive string
 }{
-	ID:        "geofences.id",
-	UserID:    "geofences.user_id",
-	Name:      "geofences.name",
-	Type:      "geofences.type",
-	H3Indexes: "geofences.h3_indexes",
-	CreatedAt: "geofences.created_at",
-	UpdatedAt: "geofences.updated_at",
+	ID:              "geofences.id",
+	UserID:          "geofences.user_id",
+	Name:            "geofences.name",
+	Type:            "geofences.type",
+	H3Indexes:       "geofences.h3_indexes",
+	Shape:           "geofences.shape",
+	Schedule:        "geofences.schedule",
+	ActiveFrom:      "geofences.active_from",
+	ActiveUntil:     "geofences.active_until",
+	CreatedAt:       "geofences.created_at",
+	UpdatedAt:       "geofences.updated_at",
+	PublishedActive: "geofences.published_active",
 }
 
 // Generated where
@@ -100,34 +126,71 @@ func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
 }
 
+type whereHelpernull_Bool struct{ field string }
+
+func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bool) NEQ(x null.Bool) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bool) LT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bool) LTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bool) GT(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var GeofenceWhere = struct {
-	ID        whereHelperstring
-	UserID    whereHelperstring
-	Name      whereHelperstring
-	Type      whereHelperstring
-	H3Indexes whereHelpertypes_StringArray
-	CreatedAt whereHelpertime_Time
-	UpdatedAt whereHelpertime_Time
+	ID              whereHelperstring
+	UserID          whereHelperstring
+	Name            whereHelperstring
+	Type            whereHelperstring
+	H3Indexes       whereHelpertypes_StringArray
+	Shape           whereHelpernull_JSON
+	Schedule        whereHelpernull_JSON
+	ActiveFrom      whereHelpernull_Time
+	ActiveUntil     whereHelpernull_Time
+	CreatedAt       whereHelpertime_Time
+	UpdatedAt       whereHelpertime_Time
+	PublishedActive whereHelpernull_Bool
 }{
-	ID:        whereHelperstring{field: "\"devices_api\".\"geofences\".\"id\""},
-	UserID:    whereHelperstring{field: "\"devices_api\".\"geofences\".\"user_id\""},
-	Name:      whereHelperstring{field: "\"devices_api\".\"geofences\".\"name\""},
-	Type:      whereHelperstring{field: "\"devices_api\".\"geofences\".\"type\""},
-	H3Indexes: whereHelpertypes_StringArray{field: "\"devices_api\".\"geofences\".\"h3_indexes\""},
-	CreatedAt: whereHelpertime_Time{field: "\"devices_api\".\"geofences\".\"created_at\""},
-	UpdatedAt: whereHelpertime_Time{field: "\"devices_api\".\"geofences\".\"updated_at\""},
+	ID:              whereHelperstring{field: "\"devices_api\".\"geofences\".\"id\""},
+	UserID:          whereHelperstring{field: "\"devices_api\".\"geofences\".\"user_id\""},
+	Name:            whereHelperstring{field: "\"devices_api\".\"geofences\".\"name\""},
+	Type:            whereHelperstring{field: "\"devices_api\".\"geofences\".\"type\""},
+	H3Indexes:       whereHelpertypes_StringArray{field: "\"devices_api\".\"geofences\".\"h3_indexes\""},
+	Shape:           whereHelpernull_JSON{field: "\"devices_api\".\"geofences\".\"shape\""},
+	Schedule:        whereHelpernull_JSON{field: "\"devices_api\".\"geofences\".\"schedule\""},
+	ActiveFrom:      whereHelpernull_Time{field: "\"devices_api\".\"geofences\".\"active_from\""},
+	ActiveUntil:     whereHelpernull_Time{field: "\"devices_api\".\"geofences\".\"active_until\""},
+	CreatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"geofences\".\"created_at\""},
+	UpdatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"geofences\".\"updated_at\""},
+	PublishedActive: whereHelpernull_Bool{field: "\"devices_api\".\"geofences\".\"published_active\""},
 }
 
 // GeofenceRels is where relationship names are stored.
 var GeofenceRels = struct {
-	UserDeviceToGeofences string
+	UserDeviceGeofenceStates string
+	UserDeviceToGeofences    string
 }{
-	UserDeviceToGeofences: "UserDeviceToGeofences",
+	UserDeviceGeofenceStates: "UserDeviceGeofenceStates",
+	UserDeviceToGeofences:    "UserDeviceToGeofences",
 }
 
 // geofenceR is where relationships are stored.
 type geofenceR struct {
-	UserDeviceToGeofences UserDeviceToGeofenceSlice `boil:"UserDeviceToGeofences" json:"UserDeviceToGeofences" toml:"UserDeviceToGeofences" yaml:"UserDeviceToGeofences"`
+	UserDeviceGeofenceStates UserDeviceGeofenceStateSlice `boil:"UserDeviceGeofenceStates" json:"UserDeviceGeofenceStates" toml:"UserDeviceGeofenceStates" yaml:"UserDeviceGeofenceStates"`
+	UserDeviceToGeofences    UserDeviceToGeofenceSlice    `boil:"UserDeviceToGeofences" json:"UserDeviceToGeofences" toml:"UserDeviceToGeofences" yaml:"UserDeviceToGeofences"`
 }
 
 // NewStruct creates a new relationship struct
@@ -135,6 +198,13 @@ func (*geofenceR) NewStruct() *geofenceR {
 	return &geofenceR{}
 }
 
+func (r *geofenceR) GetUserDeviceGeofenceStates() UserDeviceGeofenceStateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserDeviceGeofenceStates
+}
+
 func (r *geofenceR) GetUserDeviceToGeofences() UserDeviceToGeofenceSlice {
 	if r == nil {
 		return nil
@@ -146,8 +216,8 @@ func (r *geofenceR) GetUserDeviceToGeofences() UserDeviceToGeofenceSlice {
 type geofenceL struct{}
 
 var (
-	geofenceAllColumns            = []string{"id", "user_id", "name", "type", "h3_indexes", "created_at", "updated_at"}
-	geofenceColumnsWithoutDefault = []string{"id", "user_id", "name"}
+	geofenceAllColumns            = []string{"id", "user_id", "name", "type", "h3_indexes", "shape", "schedule", "active_from", "active_until", "created_at", "updated_at", "published_active"}
+	geofenceColumnsWithoutDefault = []string{"id", "user_id", "name", "shape", "schedule", "active_from", "active_until", "published_active"}
 	geofenceColumnsWithDefault    = []string{"type", "h3_indexes", "created_at", "updated_at"}
 	geofencePrimaryKeyColumns     = []string{"id"}
 	geofenceGeneratedColumns      = []string{}
@@ -458,6 +528,20 @@ func (q geofenceQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (b
 	return count > 0, nil
 }
 
+// UserDeviceGeofenceStates retrieves all the user_device_geofence_state's UserDeviceGeofenceStates with an executor.
+func (o *Geofence) UserDeviceGeofenceStates(mods ...qm.QueryMod) userDeviceGeofenceStateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"user_device_geofence_states\".\"geofence_id\"=?", o.ID),
+	)
+
+	return UserDeviceGeofenceStates(queryMods...)
+}
+
 // UserDeviceToGeofences retrieves all the user_device_to_geofence's UserDeviceToGeofences with an executor.
 func (o *Geofence) UserDeviceToGeofences(mods ...qm.QueryMod) userDeviceToGeofenceQuery {
 	var queryMods []qm.QueryMod
@@ -472,6 +556,119 @@ func (o *Geofence) UserDeviceToGeofences(mods ...qm.QueryMod) userDeviceToGeofen
 	return UserDeviceToGeofences(queryMods...)
 }
 
+// LoadUserDeviceGeofenceStates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (geofenceL) LoadUserDeviceGeofenceStates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGeofence interface{}, mods queries.Applicator) error {
+	var slice []*Geofence
+	var object *Geofence
+
+	if singular {
+		var ok bool
+		object, ok = maybeGeofence.(*Geofence)
+		if !ok {
+			object = new(Geofence)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeGeofence)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeGeofence))
+			}
+		}
+	} else {
+		s, ok := maybeGeofence.(*[]*Geofence)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeGeofence)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeGeofence))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &geofenceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &geofenceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.user_device_geofence_states`),
+		qm.WhereIn(`devices_api.user_device_geofence_states.geofence_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_device_geofence_states")
+	}
+
+	var resultSlice []*UserDeviceGeofenceState
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_device_geofence_states")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_device_geofence_states")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_geofence_states")
+	}
+
+	if len(userDeviceGeofenceStateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserDeviceGeofenceStates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userDeviceGeofenceStateR{}
+			}
+			foreign.R.Geofence = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.GeofenceID {
+				local.R.UserDeviceGeofenceStates = append(local.R.UserDeviceGeofenceStates, foreign)
+				if foreign.R == nil {
+					foreign.R = &userDeviceGeofenceStateR{}
+				}
+				foreign.R.Geofence = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadUserDeviceToGeofences allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (geofenceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.ContextExecutor, singular bool, maybeGeofence interface{}, mods queries.Applicator) error {
@@ -585,6 +782,59 @@ func (geofenceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.ContextEx
 	return nil
 }
 
+// AddUserDeviceGeofenceStates adds the given related objects to the existing relationships
+// of the geofence, optionally inserting them as new records.
+// Appends related to o.R.UserDeviceGeofenceStates.
+// Sets related.R.Geofence appropriately.
+func (o *Geofence) AddUserDeviceGeofenceStates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserDeviceGeofenceState) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.GeofenceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"user_device_geofence_states\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"geofence_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userDeviceGeofenceStatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserDeviceID, rel.GeofenceID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.GeofenceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &geofenceR{
+			UserDeviceGeofenceStates: related,
+		}
+	} else {
+		o.R.UserDeviceGeofenceStates = append(o.R.UserDeviceGeofenceStates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userDeviceGeofenceStateR{
+				Geofence: o,
+			}
+		} else {
+			rel.R.Geofence = o
+		}
+	}
+	return nil
+}
+
 // AddUserDeviceToGeofences adds the given related objects to the existing relationships
 // of the geofence, optionally inserting them as new records.
 // Appends related to o.R.UserDeviceToGeofences.
@@ -94,6 +94,7 @@ var MetaTransactionRequestRels = struct {
 	BurnRequestSyntheticDevice                   string
 	BurnRequestUserDevice                        string
 	MintRequestUserDevice                        string
+	MintRequestVehicleMintBatchVehicles          string
 }{
 	ClaimMetaTransactionRequestAftermarketDevice: "ClaimMetaTransactionRequestAftermarketDevice",
 	PairRequestAftermarketDevice:                 "PairRequestAftermarketDevice",
@@ -102,17 +103,19 @@ var MetaTransactionRequestRels = struct {
 	BurnRequestSyntheticDevice:                   "BurnRequestSyntheticDevice",
 	BurnRequestUserDevice:                        "BurnRequestUserDevice",
 	MintRequestUserDevice:                        "MintRequestUserDevice",
+	MintRequestVehicleMintBatchVehicles:          "MintRequestVehicleMintBatchVehicles",
 }
 
 // metaTransactionRequestR is where relationships are stored.
 type metaTransactionRequestR struct {
-	ClaimMetaTransactionRequestAftermarketDevice *AftermarketDevice `boil:"ClaimMetaTransactionRequestAftermarketDevice" json:"ClaimMetaTransactionRequestAftermarketDevice" toml:"ClaimMetaTransactionRequestAftermarketDevice" yaml:"ClaimMetaTransactionRequestAftermarketDevice"`
-	PairRequestAftermarketDevice                 *AftermarketDevice `boil:"PairRequestAftermarketDevice" json:"PairRequestAftermarketDevice" toml:"PairRequestAftermarketDevice" yaml:"PairRequestAftermarketDevice"`
-	UnpairRequestAftermarketDevice               *AftermarketDevice `boil:"UnpairRequestAftermarketDevice" json:"UnpairRequestAftermarketDevice" toml:"UnpairRequestAftermarketDevice" yaml:"UnpairRequestAftermarketDevice"`
-	MintRequestSyntheticDevice                   *SyntheticDevice   `boil:"MintRequestSyntheticDevice" json:"MintRequestSyntheticDevice" toml:"MintRequestSyntheticDevice" yaml:"MintRequestSyntheticDevice"`
-	BurnRequestSyntheticDevice                   *SyntheticDevice   `boil:"BurnRequestSyntheticDevice" json:"BurnRequestSyntheticDevice" toml:"BurnRequestSyntheticDevice" yaml:"BurnRequestSyntheticDevice"`
-	BurnRequestUserDevice                        *UserDevice        `boil:"BurnRequestUserDevice" json:"BurnRequestUserDevice" toml:"BurnRequestUserDevice" yaml:"BurnRequestUserDevice"`
-	MintRequestUserDevice                        *UserDevice        `boil:"MintRequestUserDevice" json:"MintRequestUserDevice" toml:"MintRequestUserDevice" yaml:"MintRequestUserDevice"`
+	ClaimMetaTransactionRequestAftermarketDevice *AftermarketDevice           `boil:"ClaimMetaTransactionRequestAftermarketDevice" json:"ClaimMetaTransactionRequestAftermarketDevice" toml:"ClaimMetaTransactionRequestAftermarketDevice" yaml:"ClaimMetaTransactionRequestAftermarketDevice"`
+	PairRequestAftermarketDevice                 *AftermarketDevice           `boil:"PairRequestAftermarketDevice" json:"PairRequestAftermarketDevice" toml:"PairRequestAftermarketDevice" yaml:"PairRequestAftermarketDevice"`
+	UnpairRequestAftermarketDevice               *AftermarketDevice           `boil:"UnpairRequestAftermarketDevice" json:"UnpairRequestAftermarketDevice" toml:"UnpairRequestAftermarketDevice" yaml:"UnpairRequestAftermarketDevice"`
+	MintRequestSyntheticDevice                   *SyntheticDevice             `boil:"MintRequestSyntheticDevice" json:"MintRequestSyntheticDevice" toml:"MintRequestSyntheticDevice" yaml:"MintRequestSyntheticDevice"`
+	BurnRequestSyntheticDevice                   *SyntheticDevice             `boil:"BurnRequestSyntheticDevice" json:"BurnRequestSyntheticDevice" toml:"BurnRequestSyntheticDevice" yaml:"BurnRequestSyntheticDevice"`
+	BurnRequestUserDevice                        *UserDevice                  `boil:"BurnRequestUserDevice" json:"BurnRequestUserDevice" toml:"BurnRequestUserDevice" yaml:"BurnRequestUserDevice"`
+	MintRequestUserDevice                        *UserDevice                  `boil:"MintRequestUserDevice" json:"MintRequestUserDevice" toml:"MintRequestUserDevice" yaml:"MintRequestUserDevice"`
+	MintRequestVehicleMintBatchVehicles          VehicleMintBatchVehicleSlice `boil:"MintRequestVehicleMintBatchVehicles" json:"MintRequestVehicleMintBatchVehicles" toml:"MintRequestVehicleMintBatchVehicles" yaml:"MintRequestVehicleMintBatchVehicles"`
 }
 
 // NewStruct creates a new relationship struct
@@ -169,6 +172,13 @@ func (r *metaTransactionRequestR) GetMintRequestUserDevice() *UserDevice {
 	return r.MintRequestUserDevice
 }
 
+func (r *metaTransactionRequestR) GetMintRequestVehicleMintBatchVehicles() VehicleMintBatchVehicleSlice {
+	if r == nil {
+		return nil
+	}
+	return r.MintRequestVehicleMintBatchVehicles
+}
+
 // metaTransactionRequestL is where Load methods for each relationship are stored.
 type metaTransactionRequestL struct{}
 
@@ -562,6 +572,20 @@ func (o *MetaTransactionRequest) MintRequestUserDevice(mods ...qm.QueryMod) user
 	return UserDevices(queryMods...)
 }
 
+// MintRequestVehicleMintBatchVehicles retrieves all the vehicle_mint_batch_vehicle's VehicleMintBatchVehicles with an executor via mint_request_id column.
+func (o *MetaTransactionRequest) MintRequestVehicleMintBatchVehicles(mods ...qm.QueryMod) vehicleMintBatchVehicleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"vehicle_mint_batch_vehicles\".\"mint_request_id\"=?", o.ID),
+	)
+
+	return VehicleMintBatchVehicles(queryMods...)
+}
+
 // LoadClaimMetaTransactionRequestAftermarketDevice allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
 func (metaTransactionRequestL) LoadClaimMetaTransactionRequestAftermarketDevice(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
@@ -1381,6 +1405,119 @@ func (metaTransactionRequestL) LoadMintRequestUserDevice(ctx context.Context, e
 	return nil
 }
 
+// LoadMintRequestVehicleMintBatchVehicles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (metaTransactionRequestL) LoadMintRequestVehicleMintBatchVehicles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeMetaTransactionRequest interface{}, mods queries.Applicator) error {
+	var slice []*MetaTransactionRequest
+	var object *MetaTransactionRequest
+
+	if singular {
+		var ok bool
+		object, ok = maybeMetaTransactionRequest.(*MetaTransactionRequest)
+		if !ok {
+			object = new(MetaTransactionRequest)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeMetaTransactionRequest))
+			}
+		}
+	} else {
+		s, ok := maybeMetaTransactionRequest.(*[]*MetaTransactionRequest)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeMetaTransactionRequest)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeMetaTransactionRequest))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &metaTransactionRequestR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &metaTransactionRequestR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.vehicle_mint_batch_vehicles`),
+		qm.WhereIn(`devices_api.vehicle_mint_batch_vehicles.mint_request_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load vehicle_mint_batch_vehicles")
+	}
+
+	var resultSlice []*VehicleMintBatchVehicle
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice vehicle_mint_batch_vehicles")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on vehicle_mint_batch_vehicles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for vehicle_mint_batch_vehicles")
+	}
+
+	if len(vehicleMintBatchVehicleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.MintRequestVehicleMintBatchVehicles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &vehicleMintBatchVehicleR{}
+			}
+			foreign.R.MintRequest = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.MintRequestID) {
+				local.R.MintRequestVehicleMintBatchVehicles = append(local.R.MintRequestVehicleMintBatchVehicles, foreign)
+				if foreign.R == nil {
+					foreign.R = &vehicleMintBatchVehicleR{}
+				}
+				foreign.R.MintRequest = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetClaimMetaTransactionRequestAftermarketDevice of the metaTransactionRequest to the related item.
 // Sets o.R.ClaimMetaTransactionRequestAftermarketDevice to related.
 // Adds o to related.R.ClaimMetaTransactionRequest.
@@ -1875,6 +2012,133 @@ func (o *MetaTransactionRequest) RemoveMintRequestUserDevice(ctx context.Context
 	return nil
 }
 
+// AddMintRequestVehicleMintBatchVehicles adds the given related objects to the existing relationships
+// of the meta_transaction_request, optionally inserting them as new records.
+// Appends related to o.R.MintRequestVehicleMintBatchVehicles.
+// Sets related.R.MintRequest appropriately.
+func (o *MetaTransactionRequest) AddMintRequestVehicleMintBatchVehicles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleMintBatchVehicle) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.MintRequestID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"vehicle_mint_batch_vehicles\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"mint_request_id"}),
+				strmangle.WhereClause("\"", "\"", 2, vehicleMintBatchVehiclePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.BatchID, rel.UserDeviceID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.MintRequestID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &metaTransactionRequestR{
+			MintRequestVehicleMintBatchVehicles: related,
+		}
+	} else {
+		o.R.MintRequestVehicleMintBatchVehicles = append(o.R.MintRequestVehicleMintBatchVehicles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &vehicleMintBatchVehicleR{
+				MintRequest: o,
+			}
+		} else {
+			rel.R.MintRequest = o
+		}
+	}
+	return nil
+}
+
+// SetMintRequestVehicleMintBatchVehicles removes all previously related items of the
+// meta_transaction_request replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.MintRequest's MintRequestVehicleMintBatchVehicles accordingly.
+// Replaces o.R.MintRequestVehicleMintBatchVehicles with related.
+// Sets related.R.MintRequest's MintRequestVehicleMintBatchVehicles accordingly.
+func (o *MetaTransactionRequest) SetMintRequestVehicleMintBatchVehicles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleMintBatchVehicle) error {
+	query := "update \"devices_api\".\"vehicle_mint_batch_vehicles\" set \"mint_request_id\" = null where \"mint_request_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.MintRequestVehicleMintBatchVehicles {
+			queries.SetScanner(&rel.MintRequestID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.MintRequest = nil
+		}
+		o.R.MintRequestVehicleMintBatchVehicles = nil
+	}
+
+	return o.AddMintRequestVehicleMintBatchVehicles(ctx, exec, insert, related...)
+}
+
+// RemoveMintRequestVehicleMintBatchVehicles relationships from objects passed in.
+// Removes related items from R.MintRequestVehicleMintBatchVehicles (uses pointer comparison, removal does not keep order)
+// Sets related.R.MintRequest.
+func (o *MetaTransactionRequest) RemoveMintRequestVehicleMintBatchVehicles(ctx context.Context, exec boil.ContextExecutor, related ...*VehicleMintBatchVehicle) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.MintRequestID, nil)
+		if rel.R != nil {
+			rel.R.MintRequest = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("mint_request_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.MintRequestVehicleMintBatchVehicles {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.MintRequestVehicleMintBatchVehicles)
+			if ln > 1 && i < ln-1 {
+				o.R.MintRequestVehicleMintBatchVehicles[i] = o.R.MintRequestVehicleMintBatchVehicles[ln-1]
+			}
+			o.R.MintRequestVehicleMintBatchVehicles = o.R.MintRequestVehicleMintBatchVehicles[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // MetaTransactionRequests retrieves all the records using an executor.
 func MetaTransactionRequests(mods ...qm.QueryMod) metaTransactionRequestQuery {
 	mods = append(mods, qm.From("\"devices_api\".\"meta_transaction_requests\""))
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -24,52 +25,57 @@ import (
 
 // NFTPrivilege is an object representing the database table.
 type NFTPrivilege struct {
-	ContractAddress []byte        `boil:"contract_address" json:"contract_address" toml:"contract_address" yaml:"contract_address"`
-	TokenID         types.Decimal `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
-	Privilege       int64         `boil:"privilege" json:"privilege" toml:"privilege" yaml:"privilege"`
-	UserAddress     []byte        `boil:"user_address" json:"user_address" toml:"user_address" yaml:"user_address"`
-	Expiry          time.Time     `boil:"expiry" json:"expiry" toml:"expiry" yaml:"expiry"`
-	CreatedAt       time.Time     `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt       time.Time     `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ContractAddress  []byte        `boil:"contract_address" json:"contract_address" toml:"contract_address" yaml:"contract_address"`
+	TokenID          types.Decimal `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
+	Privilege        int64         `boil:"privilege" json:"privilege" toml:"privilege" yaml:"privilege"`
+	UserAddress      []byte        `boil:"user_address" json:"user_address" toml:"user_address" yaml:"user_address"`
+	Expiry           time.Time     `boil:"expiry" json:"expiry" toml:"expiry" yaml:"expiry"`
+	CreatedAt        time.Time     `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt        time.Time     `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ExpiryNotifiedAt null.Time     `boil:"expiry_notified_at" json:"expiry_notified_at,omitempty" toml:"expiry_notified_at" yaml:"expiry_notified_at,omitempty"`
 
 	R *nftPrivilegeR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L nftPrivilegeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var NFTPrivilegeColumns = struct {
-	ContractAddress string
-	TokenID         string
-	Privilege       string
-	UserAddress     string
-	Expiry          string
-	CreatedAt       string
-	UpdatedAt       string
+	ContractAddress  string
+	TokenID          string
+	Privilege        string
+	UserAddress      string
+	Expiry           string
+	CreatedAt        string
+	UpdatedAt        string
+	ExpiryNotifiedAt string
 }{
-	ContractAddress: "contract_address",
-	TokenID:         "token_id",
-	Privilege:       "privilege",
-	UserAddress:     "user_address",
-	Expiry:          "expiry",
-	CreatedAt:       "created_at",
-	UpdatedAt:       "updated_at",
+	ContractAddress:  "contract_address",
+	TokenID:          "token_id",
+	Privilege:        "privilege",
+	UserAddress:      "user_address",
+	Expiry:           "expiry",
+	CreatedAt:        "created_at",
+	UpdatedAt:        "updated_at",
+	ExpiryNotifiedAt: "expiry_notified_at",
 }
 
 var NFTPrivilegeTableColumns = struct {
-	ContractAddress string
-	TokenID         string
-	Privilege       string
-	UserAddress     string
-	Expiry          string
-	CreatedAt       string
-	UpdatedAt       string
+	ContractAddress  string
+	TokenID          string
+	Privilege        string
+	UserAddress      string
+	Expiry           string
+	CreatedAt        string
+	UpdatedAt        string
+	ExpiryNotifiedAt string
 }{
-	ContractAddress: "nft_privileges.contract_address",
-	TokenID:         "nft_privileges.token_id",
-	Privilege:       "nft_privileges.privilege",
-	UserAddress:     "nft_privileges.user_address",
-	Expiry:          "nft_privileges.expiry",
-	CreatedAt:       "nft_privileges.created_at",
-	UpdatedAt:       "nft_privileges.updated_at",
+	ContractAddress:  "nft_privileges.contract_address",
+	TokenID:          "nft_privileges.token_id",
+	Privilege:        "nft_privileges.privilege",
+	UserAddress:      "nft_privileges.user_address",
+	Expiry:           "nft_privileges.expiry",
+	CreatedAt:        "nft_privileges.created_at",
+	UpdatedAt:        "nft_privileges.updated_at",
+	ExpiryNotifiedAt: "nft_privileges.expiry_notified_at",
 }
 
 // Generated where
@@ -98,21 +104,23 @@ func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
 }
 
 var NFTPrivilegeWhere = struct {
-	ContractAddress whereHelper__byte
-	TokenID         whereHelpertypes_Decimal
-	Privilege       whereHelperint64
-	UserAddress     whereHelper__byte
-	Expiry          whereHelpertime_Time
-	CreatedAt       whereHelpertime_Time
-	UpdatedAt       whereHelpertime_Time
+	ContractAddress  whereHelper__byte
+	TokenID          whereHelpertypes_Decimal
+	Privilege        whereHelperint64
+	UserAddress      whereHelper__byte
+	Expiry           whereHelpertime_Time
+	CreatedAt        whereHelpertime_Time
+	UpdatedAt        whereHelpertime_Time
+	ExpiryNotifiedAt whereHelpernull_Time
 }{
-	ContractAddress: whereHelper__byte{field: "\"devices_api\".\"nft_privileges\".\"contract_address\""},
-	TokenID:         whereHelpertypes_Decimal{field: "\"devices_api\".\"nft_privileges\".\"token_id\""},
-	Privilege:       whereHelperint64{field: "\"devices_api\".\"nft_privileges\".\"privilege\""},
-	UserAddress:     whereHelper__byte{field: "\"devices_api\".\"nft_privileges\".\"user_address\""},
-	Expiry:          whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"expiry\""},
-	CreatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"created_at\""},
-	UpdatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"updated_at\""},
+	ContractAddress:  whereHelper__byte{field: "\"devices_api\".\"nft_privileges\".\"contract_address\""},
+	TokenID:          whereHelpertypes_Decimal{field: "\"devices_api\".\"nft_privileges\".\"token_id\""},
+	Privilege:        whereHelperint64{field: "\"devices_api\".\"nft_privileges\".\"privilege\""},
+	UserAddress:      whereHelper__byte{field: "\"devices_api\".\"nft_privileges\".\"user_address\""},
+	Expiry:           whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"expiry\""},
+	CreatedAt:        whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"created_at\""},
+	UpdatedAt:        whereHelpertime_Time{field: "\"devices_api\".\"nft_privileges\".\"updated_at\""},
+	ExpiryNotifiedAt: whereHelpernull_Time{field: "\"devices_api\".\"nft_privileges\".\"expiry_notified_at\""},
 }
 
 // NFTPrivilegeRels is where relationship names are stored.
@@ -132,8 +140,8 @@ func (*nftPrivilegeR) NewStruct() *nftPrivilegeR {
 type nftPrivilegeL struct{}
 
 var (
-	nftPrivilegeAllColumns            = []string{"contract_address", "token_id", "privilege", "user_address", "expiry", "created_at", "updated_at"}
-	nftPrivilegeColumnsWithoutDefault = []string{"contract_address", "token_id", "privilege", "user_address", "expiry"}
+	nftPrivilegeAllColumns            = []string{"contract_address", "token_id", "privilege", "user_address", "expiry", "created_at", "updated_at", "expiry_notified_at"}
+	nftPrivilegeColumnsWithoutDefault = []string{"contract_address", "token_id", "privilege", "user_address", "expiry", "expiry_notified_at"}
 	nftPrivilegeColumnsWithDefault    = []string{"created_at", "updated_at"}
 	nftPrivilegePrimaryKeyColumns     = []string{"contract_address", "token_id", "privilege", "user_address"}
 	nftPrivilegeGeneratedColumns      = []string{}
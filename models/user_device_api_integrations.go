@@ -24,107 +24,121 @@ import (
 
 // UserDeviceAPIIntegration is an object representing the database table.
 type UserDeviceAPIIntegration struct {
-	UserDeviceID    string      `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
-	IntegrationID   string      `boil:"integration_id" json:"integration_id" toml:"integration_id" yaml:"integration_id"`
-	Status          string      `boil:"status" json:"status" toml:"status" yaml:"status"`
-	AccessToken     null.String `boil:"access_token" json:"access_token,omitempty" toml:"access_token" yaml:"access_token,omitempty"`
-	AccessExpiresAt null.Time   `boil:"access_expires_at" json:"access_expires_at,omitempty" toml:"access_expires_at" yaml:"access_expires_at,omitempty"`
-	RefreshToken    null.String `boil:"refresh_token" json:"refresh_token,omitempty" toml:"refresh_token" yaml:"refresh_token,omitempty"`
-	ExternalID      null.String `boil:"external_id" json:"external_id,omitempty" toml:"external_id" yaml:"external_id,omitempty"`
-	CreatedAt       time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt       time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	Metadata        null.JSON   `boil:"metadata" json:"metadata,omitempty" toml:"metadata" yaml:"metadata,omitempty"`
-	TaskID          null.String `boil:"task_id" json:"task_id,omitempty" toml:"task_id" yaml:"task_id,omitempty"`
-	Serial          null.String `boil:"serial" json:"serial,omitempty" toml:"serial" yaml:"serial,omitempty"`
+	UserDeviceID         string      `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	IntegrationID        string      `boil:"integration_id" json:"integration_id" toml:"integration_id" yaml:"integration_id"`
+	Status               string      `boil:"status" json:"status" toml:"status" yaml:"status"`
+	AccessToken          null.String `boil:"access_token" json:"access_token,omitempty" toml:"access_token" yaml:"access_token,omitempty"`
+	AccessExpiresAt      null.Time   `boil:"access_expires_at" json:"access_expires_at,omitempty" toml:"access_expires_at" yaml:"access_expires_at,omitempty"`
+	RefreshToken         null.String `boil:"refresh_token" json:"refresh_token,omitempty" toml:"refresh_token" yaml:"refresh_token,omitempty"`
+	ExternalID           null.String `boil:"external_id" json:"external_id,omitempty" toml:"external_id" yaml:"external_id,omitempty"`
+	CreatedAt            time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt            time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Metadata             null.JSON   `boil:"metadata" json:"metadata,omitempty" toml:"metadata" yaml:"metadata,omitempty"`
+	TaskID               null.String `boil:"task_id" json:"task_id,omitempty" toml:"task_id" yaml:"task_id,omitempty"`
+	Serial               null.String `boil:"serial" json:"serial,omitempty" toml:"serial" yaml:"serial,omitempty"`
+	TokenRefreshFailures int         `boil:"token_refresh_failures" json:"token_refresh_failures" toml:"token_refresh_failures" yaml:"token_refresh_failures"`
+	FailureReason        null.String `boil:"failure_reason" json:"failure_reason,omitempty" toml:"failure_reason" yaml:"failure_reason,omitempty"`
 
 	R *userDeviceAPIIntegrationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userDeviceAPIIntegrationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserDeviceAPIIntegrationColumns = struct {
-	UserDeviceID    string
-	IntegrationID   string
-	Status          string
-	AccessToken     string
-	AccessExpiresAt string
-	RefreshToken    string
-	ExternalID      string
-	CreatedAt       string
-	UpdatedAt       string
-	Metadata        string
-	TaskID          string
-	Serial          string
+	UserDeviceID         string
+	IntegrationID        string
+	Status               string
+	AccessToken          string
+	AccessExpiresAt      string
+	RefreshToken         string
+	ExternalID           string
+	CreatedAt            string
+	UpdatedAt            string
+	Metadata             string
+	TaskID               string
+	Serial               string
+	TokenRefreshFailures string
+	FailureReason        string
 }{
-	UserDeviceID:    "user_device_id",
-	IntegrationID:   "integration_id",
-	Status:          "status",
-	AccessToken:     "access_token",
-	AccessExpiresAt: "access_expires_at",
-	RefreshToken:    "refresh_token",
-	ExternalID:      "external_id",
-	CreatedAt:       "created_at",
-	UpdatedAt:       "updated_at",
-	Metadata:        "metadata",
-	TaskID:          "task_id",
-	Serial:          "serial",
+	UserDeviceID:         "user_device_id",
+	IntegrationID:        "integration_id",
+	Status:               "status",
+	AccessToken:          "access_token",
+	AccessExpiresAt:      "access_expires_at",
+	RefreshToken:         "refresh_token",
+	ExternalID:           "external_id",
+	CreatedAt:            "created_at",
+	UpdatedAt:            "updated_at",
+	Metadata:             "metadata",
+	TaskID:               "task_id",
+	Serial:               "serial",
+	TokenRefreshFailures: "token_refresh_failures",
+	FailureReason:        "failure_reason",
 }
 
 var UserDeviceAPIIntegrationTableColumns = struct {
-	UserDeviceID    string
-	IntegrationID   string
-	Status          string
-	AccessToken     string
-	AccessExpiresAt string
-	RefreshToken    string
-	ExternalID      string
-	CreatedAt       string
-	UpdatedAt       string
-	Metadata        string
-	TaskID          string
-	Serial          string
+	UserDeviceID         string
+	IntegrationID        string
+	Status               string
+	AccessToken          string
+	AccessExpiresAt      string
+	RefreshToken         string
+	ExternalID           string
+	CreatedAt            string
+	UpdatedAt            string
+	Metadata             string
+	TaskID               string
+	Serial               string
+	TokenRefreshFailures string
+	FailureReason        string
 }{
-	UserDeviceID:    "user_device_api_integrations.user_device_id",
-	IntegrationID:   "user_device_api_integrations.integration_id",
-	Status:          "user_device_api_integrations.status",
-	AccessToken:     "user_device_api_integrations.access_token",
-	AccessExpiresAt: "user_device_api_integrations.access_expires_at",
-	RefreshToken:    "user_device_api_integrations.refresh_token",
-	ExternalID:      "user_device_api_integrations.external_id",
-	CreatedAt:       "user_device_api_integrations.created_at",
-	UpdatedAt:       "user_device_api_integrations.updated_at",
-	Metadata:        "user_device_api_integrations.metadata",
-	TaskID:          "user_device_api_integrations.task_id",
-	Serial:          "user_device_api_integrations.serial",
+	UserDeviceID:         "user_device_api_integrations.user_device_id",
+	IntegrationID:        "user_device_api_integrations.integration_id",
+	Status:               "user_device_api_integrations.status",
+	AccessToken:          "user_device_api_integrations.access_token",
+	AccessExpiresAt:      "user_device_api_integrations.access_expires_at",
+	RefreshToken:         "user_device_api_integrations.refresh_token",
+	ExternalID:           "user_device_api_integrations.external_id",
+	CreatedAt:            "user_device_api_integrations.created_at",
+	UpdatedAt:            "user_device_api_integrations.updated_at",
+	Metadata:             "user_device_api_integrations.metadata",
+	TaskID:               "user_device_api_integrations.task_id",
+	Serial:               "user_device_api_integrations.serial",
+	TokenRefreshFailures: "user_device_api_integrations.token_refresh_failures",
+	FailureReason:        "user_device_api_integrations.failure_reason",
 }
 
 // Generated where
 
 var UserDeviceAPIIntegrationWhere = struct {
-	UserDeviceID    whereHelperstring
-	IntegrationID   whereHelperstring
-	Status          whereHelperstring
-	AccessToken     whereHelpernull_String
-	AccessExpiresAt whereHelpernull_Time
-	RefreshToken    whereHelpernull_String
-	ExternalID      whereHelpernull_String
-	CreatedAt       whereHelpertime_Time
-	UpdatedAt       whereHelpertime_Time
-	Metadata        whereHelpernull_JSON
-	TaskID          whereHelpernull_String
-	Serial          whereHelpernull_String
+	UserDeviceID         whereHelperstring
+	IntegrationID        whereHelperstring
+	Status               whereHelperstring
+	AccessToken          whereHelpernull_String
+	AccessExpiresAt      whereHelpernull_Time
+	RefreshToken         whereHelpernull_String
+	ExternalID           whereHelpernull_String
+	CreatedAt            whereHelpertime_Time
+	UpdatedAt            whereHelpertime_Time
+	Metadata             whereHelpernull_JSON
+	TaskID               whereHelpernull_String
+	Serial               whereHelpernull_String
+	TokenRefreshFailures whereHelperint
+	FailureReason        whereHelpernull_String
 }{
-	UserDeviceID:    whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"user_device_id\""},
-	IntegrationID:   whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"integration_id\""},
-	Status:          whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"status\""},
-	AccessToken:     whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"access_token\""},
-	AccessExpiresAt: whereHelpernull_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"access_expires_at\""},
-	RefreshToken:    whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"refresh_token\""},
-	ExternalID:      whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"external_id\""},
-	CreatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"created_at\""},
-	UpdatedAt:       whereHelpertime_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"updated_at\""},
-	Metadata:        whereHelpernull_JSON{field: "\"devices_api\".\"user_device_api_integrations\".\"metadata\""},
-	TaskID:          whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"task_id\""},
-	Serial:          whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"serial\""},
+	UserDeviceID:         whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"user_device_id\""},
+	IntegrationID:        whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"integration_id\""},
+	Status:               whereHelperstring{field: "\"devices_api\".\"user_device_api_integrations\".\"status\""},
+	AccessToken:          whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"access_token\""},
+	AccessExpiresAt:      whereHelpernull_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"access_expires_at\""},
+	RefreshToken:         whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"refresh_token\""},
+	ExternalID:           whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"external_id\""},
+	CreatedAt:            whereHelpertime_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"created_at\""},
+	UpdatedAt:            whereHelpertime_Time{field: "\"devices_api\".\"user_device_api_integrations\".\"updated_at\""},
+	Metadata:             whereHelpernull_JSON{field: "\"devices_api\".\"user_device_api_integrations\".\"metadata\""},
+	TaskID:               whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"task_id\""},
+	Serial:               whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"serial\""},
+	TokenRefreshFailures: whereHelperint{field: "\"devices_api\".\"user_device_api_integrations\".\"token_refresh_failures\""},
+	FailureReason:        whereHelpernull_String{field: "\"devices_api\".\"user_device_api_integrations\".\"failure_reason\""},
 }
 
 // UserDeviceAPIIntegrationRels is where relationship names are stored.
@@ -165,9 +179,9 @@ func (r *userDeviceAPIIntegrationR) GetSerialAftermarketDevice() *AftermarketDev
 type userDeviceAPIIntegrationL struct{}
 
 var (
-	userDeviceAPIIntegrationAllColumns            = []string{"user_device_id", "integration_id", "status", "access_token", "access_expires_at", "refresh_token", "external_id", "created_at", "updated_at", "metadata", "task_id", "serial"}
+	userDeviceAPIIntegrationAllColumns            = []string{"user_device_id", "integration_id", "status", "access_token", "access_expires_at", "refresh_token", "external_id", "created_at", "updated_at", "metadata", "task_id", "serial", "token_refresh_failures", "failure_reason"}
 	userDeviceAPIIntegrationColumnsWithoutDefault = []string{"user_device_id", "integration_id", "status"}
-	userDeviceAPIIntegrationColumnsWithDefault    = []string{"access_token", "access_expires_at", "refresh_token", "external_id", "created_at", "updated_at", "metadata", "task_id", "serial"}
+	userDeviceAPIIntegrationColumnsWithDefault    = []string{"access_token", "access_expires_at", "refresh_token", "external_id", "created_at", "updated_at", "metadata", "task_id", "serial", "token_refresh_failures", "failure_reason"}
 	userDeviceAPIIntegrationPrimaryKeyColumns     = []string{"user_device_id", "integration_id"}
 	userDeviceAPIIntegrationGeneratedColumns      = []string{}
 )
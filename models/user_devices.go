@@ -135,15 +135,6 @@ var UserDeviceTableColumns = struct {
 
 // Generated where
 
-type whereHelperbool struct{ field string }
-
-func (w whereHelperbool) EQ(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperbool) NEQ(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperbool) LT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-
 var UserDeviceWhere = struct {
 	ID                 whereHelperstring
 	UserID             whereHelperstring
@@ -188,38 +179,50 @@ var UserDeviceWhere = struct {
 
 // UserDeviceRels is where relationship names are stored.
 var UserDeviceRels = struct {
-	BurnRequest                   string
-	MintRequest                   string
-	VehicleTokenAftermarketDevice string
-	VehicleTokenSyntheticDevice   string
-	AutopiJobs                    string
-	DeviceCommandRequests         string
-	ErrorCodeQueries              string
-	UserDeviceAPIIntegrations     string
-	UserDeviceToGeofences         string
+	BurnRequest                             string
+	MintRequest                             string
+	VehicleTokenAftermarketDevice           string
+	VehicleTokenSyntheticDevice             string
+	AftermarketDeviceFingerprints           string
+	AutopiJobs                              string
+	DeviceCommandRequests                   string
+	ErrorCodeQueries                        string
+	UserDeviceAPIIntegrationStatusHistories string
+	UserDeviceAPIIntegrations               string
+	UserDeviceGeofenceStates                string
+	UserDeviceToGeofences                   string
+	VehicleMintBatchVehicles                string
 }{
-	BurnRequest:                   "BurnRequest",
-	MintRequest:                   "MintRequest",
-	VehicleTokenAftermarketDevice: "VehicleTokenAftermarketDevice",
-	VehicleTokenSyntheticDevice:   "VehicleTokenSyntheticDevice",
-	AutopiJobs:                    "AutopiJobs",
-	DeviceCommandRequests:         "DeviceCommandRequests",
-	ErrorCodeQueries:              "ErrorCodeQueries",
-	UserDeviceAPIIntegrations:     "UserDeviceAPIIntegrations",
-	UserDeviceToGeofences:         "UserDeviceToGeofences",
+	BurnRequest:                             "BurnRequest",
+	MintRequest:                             "MintRequest",
+	VehicleTokenAftermarketDevice:           "VehicleTokenAftermarketDevice",
+	VehicleTokenSyntheticDevice:             "VehicleTokenSyntheticDevice",
+	AftermarketDeviceFingerprints:           "AftermarketDeviceFingerprints",
+	AutopiJobs:                              "AutopiJobs",
+	DeviceCommandRequests:                   "DeviceCommandRequests",
+	ErrorCodeQueries:                        "ErrorCodeQueries",
+	UserDeviceAPIIntegrationStatusHistories: "UserDeviceAPIIntegrationStatusHistories",
+	UserDeviceAPIIntegrations:               "UserDeviceAPIIntegrations",
+	UserDeviceGeofenceStates:                "UserDeviceGeofenceStates",
+	UserDeviceToGeofences:                   "UserDeviceToGeofences",
+	VehicleMintBatchVehicles:                "VehicleMintBatchVehicles",
 }
 
 // userDeviceR is where relationships are stored.
 type userDeviceR struct {
-	BurnRequest                   *MetaTransactionRequest       `boil:"BurnRequest" json:"BurnRequest" toml:"BurnRequest" yaml:"BurnRequest"`
-	MintRequest                   *MetaTransactionRequest       `boil:"MintRequest" json:"MintRequest" toml:"MintRequest" yaml:"MintRequest"`
-	VehicleTokenAftermarketDevice *AftermarketDevice            `boil:"VehicleTokenAftermarketDevice" json:"VehicleTokenAftermarketDevice" toml:"VehicleTokenAftermarketDevice" yaml:"VehicleTokenAftermarketDevice"`
-	VehicleTokenSyntheticDevice   *SyntheticDevice              `boil:"VehicleTokenSyntheticDevice" json:"VehicleTokenSyntheticDevice" toml:"VehicleTokenSyntheticDevice" yaml:"VehicleTokenSyntheticDevice"`
-	AutopiJobs                    AutopiJobSlice                `boil:"AutopiJobs" json:"AutopiJobs" toml:"AutopiJobs" yaml:"AutopiJobs"`
-	DeviceCommandRequests         DeviceCommandRequestSlice     `boil:"DeviceCommandRequests" json:"DeviceCommandRequests" toml:"DeviceCommandRequests" yaml:"DeviceCommandRequests"`
-	ErrorCodeQueries              ErrorCodeQuerySlice           `boil:"ErrorCodeQueries" json:"ErrorCodeQueries" toml:"ErrorCodeQueries" yaml:"ErrorCodeQueries"`
-	UserDeviceAPIIntegrations     UserDeviceAPIIntegrationSlice `boil:"UserDeviceAPIIntegrations" json:"UserDeviceAPIIntegrations" toml:"UserDeviceAPIIntegrations" yaml:"UserDeviceAPIIntegrations"`
-	UserDeviceToGeofences         UserDeviceToGeofenceSlice     `boil:"UserDeviceToGeofences" json:"UserDeviceToGeofences" toml:"UserDeviceToGeofences" yaml:"UserDeviceToGeofences"`
+	BurnRequest                             *MetaTransactionRequest                    `boil:"BurnRequest" json:"BurnRequest" toml:"BurnRequest" yaml:"BurnRequest"`
+	MintRequest                             *MetaTransactionRequest                    `boil:"MintRequest" json:"MintRequest" toml:"MintRequest" yaml:"MintRequest"`
+	VehicleTokenAftermarketDevice           *AftermarketDevice                         `boil:"VehicleTokenAftermarketDevice" json:"VehicleTokenAftermarketDevice" toml:"VehicleTokenAftermarketDevice" yaml:"VehicleTokenAftermarketDevice"`
+	VehicleTokenSyntheticDevice             *SyntheticDevice                           `boil:"VehicleTokenSyntheticDevice" json:"VehicleTokenSyntheticDevice" toml:"VehicleTokenSyntheticDevice" yaml:"VehicleTokenSyntheticDevice"`
+	AftermarketDeviceFingerprints           AftermarketDeviceFingerprintSlice          `boil:"AftermarketDeviceFingerprints" json:"AftermarketDeviceFingerprints" toml:"AftermarketDeviceFingerprints" yaml:"AftermarketDeviceFingerprints"`
+	AutopiJobs                              AutopiJobSlice                             `boil:"AutopiJobs" json:"AutopiJobs" toml:"AutopiJobs" yaml:"AutopiJobs"`
+	DeviceCommandRequests                   DeviceCommandRequestSlice                  `boil:"DeviceCommandRequests" json:"DeviceCommandRequests" toml:"DeviceCommandRequests" yaml:"DeviceCommandRequests"`
+	ErrorCodeQueries                        ErrorCodeQuerySlice                        `boil:"ErrorCodeQueries" json:"ErrorCodeQueries" toml:"ErrorCodeQueries" yaml:"ErrorCodeQueries"`
+	UserDeviceAPIIntegrationStatusHistories UserDeviceAPIIntegrationStatusHistorySlice `boil:"UserDeviceAPIIntegrationStatusHistories" json:"UserDeviceAPIIntegrationStatusHistories" toml:"UserDeviceAPIIntegrationStatusHistories" yaml:"UserDeviceAPIIntegrationStatusHistories"`
+	UserDeviceAPIIntegrations               UserDeviceAPIIntegrationSlice              `boil:"UserDeviceAPIIntegrations" json:"UserDeviceAPIIntegrations" toml:"UserDeviceAPIIntegrations" yaml:"UserDeviceAPIIntegrations"`
+	UserDeviceGeofenceStates                UserDeviceGeofenceStateSlice               `boil:"UserDeviceGeofenceStates" json:"UserDeviceGeofenceStates" toml:"UserDeviceGeofenceStates" yaml:"UserDeviceGeofenceStates"`
+	UserDeviceToGeofences                   UserDeviceToGeofenceSlice                  `boil:"UserDeviceToGeofences" json:"UserDeviceToGeofences" toml:"UserDeviceToGeofences" yaml:"UserDeviceToGeofences"`
+	VehicleMintBatchVehicles                VehicleMintBatchVehicleSlice               `boil:"VehicleMintBatchVehicles" json:"VehicleMintBatchVehicles" toml:"VehicleMintBatchVehicles" yaml:"VehicleMintBatchVehicles"`
 }
 
 // NewStruct creates a new relationship struct
@@ -255,6 +258,13 @@ func (r *userDeviceR) GetVehicleTokenSyntheticDevice() *SyntheticDevice {
 	return r.VehicleTokenSyntheticDevice
 }
 
+func (r *userDeviceR) GetAftermarketDeviceFingerprints() AftermarketDeviceFingerprintSlice {
+	if r == nil {
+		return nil
+	}
+	return r.AftermarketDeviceFingerprints
+}
+
 func (r *userDeviceR) GetAutopiJobs() AutopiJobSlice {
 	if r == nil {
 		return nil
@@ -276,6 +286,13 @@ func (r *userDeviceR) GetErrorCodeQueries() ErrorCodeQuerySlice {
 	return r.ErrorCodeQueries
 }
 
+func (r *userDeviceR) GetUserDeviceAPIIntegrationStatusHistories() UserDeviceAPIIntegrationStatusHistorySlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserDeviceAPIIntegrationStatusHistories
+}
+
 func (r *userDeviceR) GetUserDeviceAPIIntegrations() UserDeviceAPIIntegrationSlice {
 	if r == nil {
 		return nil
@@ -283,6 +300,13 @@ func (r *userDeviceR) GetUserDeviceAPIIntegrations() UserDeviceAPIIntegrationSli
 	return r.UserDeviceAPIIntegrations
 }
 
+func (r *userDeviceR) GetUserDeviceGeofenceStates() UserDeviceGeofenceStateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserDeviceGeofenceStates
+}
+
 func (r *userDeviceR) GetUserDeviceToGeofences() UserDeviceToGeofenceSlice {
 	if r == nil {
 		return nil
@@ -290,6 +314,13 @@ func (r *userDeviceR) GetUserDeviceToGeofences() UserDeviceToGeofenceSlice {
 	return r.UserDeviceToGeofences
 }
 
+func (r *userDeviceR) GetVehicleMintBatchVehicles() VehicleMintBatchVehicleSlice {
+	if r == nil {
+		return nil
+	}
+	return r.VehicleMintBatchVehicles
+}
+
 // userDeviceL is where Load methods for each relationship are stored.
 type userDeviceL struct{}
 
@@ -650,6 +681,20 @@ func (o *UserDevice) VehicleTokenSyntheticDevice(mods ...qm.QueryMod) syntheticD
 	return SyntheticDevices(queryMods...)
 }
 
+// AftermarketDeviceFingerprints retrieves all the aftermarket_device_fingerprint's AftermarketDeviceFingerprints with an executor.
+func (o *UserDevice) AftermarketDeviceFingerprints(mods ...qm.QueryMod) aftermarketDeviceFingerprintQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"aftermarket_device_fingerprints\".\"user_device_id\"=?", o.ID),
+	)
+
+	return AftermarketDeviceFingerprints(queryMods...)
+}
+
 // AutopiJobs retrieves all the autopi_job's AutopiJobs with an executor.
 func (o *UserDevice) AutopiJobs(mods ...qm.QueryMod) autopiJobQuery {
 	var queryMods []qm.QueryMod
@@ -692,6 +737,20 @@ func (o *UserDevice) ErrorCodeQueries(mods ...qm.QueryMod) errorCodeQueryQuery {
 	return ErrorCodeQueries(queryMods...)
 }
 
+// UserDeviceAPIIntegrationStatusHistories retrieves all the user_device_api_integration_status_history's UserDeviceAPIIntegrationStatusHistories with an executor.
+func (o *UserDevice) UserDeviceAPIIntegrationStatusHistories(mods ...qm.QueryMod) userDeviceAPIIntegrationStatusHistoryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"user_device_api_integration_status_history\".\"user_device_id\"=?", o.ID),
+	)
+
+	return UserDeviceAPIIntegrationStatusHistories(queryMods...)
+}
+
 // UserDeviceAPIIntegrations retrieves all the user_device_api_integration's UserDeviceAPIIntegrations with an executor.
 func (o *UserDevice) UserDeviceAPIIntegrations(mods ...qm.QueryMod) userDeviceAPIIntegrationQuery {
 	var queryMods []qm.QueryMod
@@ -706,6 +765,20 @@ func (o *UserDevice) UserDeviceAPIIntegrations(mods ...qm.QueryMod) userDeviceAP
 	return UserDeviceAPIIntegrations(queryMods...)
 }
 
+// UserDeviceGeofenceStates retrieves all the user_device_geofence_state's UserDeviceGeofenceStates with an executor.
+func (o *UserDevice) UserDeviceGeofenceStates(mods ...qm.QueryMod) userDeviceGeofenceStateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"user_device_geofence_states\".\"user_device_id\"=?", o.ID),
+	)
+
+	return UserDeviceGeofenceStates(queryMods...)
+}
+
 // UserDeviceToGeofences retrieves all the user_device_to_geofence's UserDeviceToGeofences with an executor.
 func (o *UserDevice) UserDeviceToGeofences(mods ...qm.QueryMod) userDeviceToGeofenceQuery {
 	var queryMods []qm.QueryMod
@@ -720,6 +793,20 @@ func (o *UserDevice) UserDeviceToGeofences(mods ...qm.QueryMod) userDeviceToGeof
 	return UserDeviceToGeofences(queryMods...)
 }
 
+// VehicleMintBatchVehicles retrieves all the vehicle_mint_batch_vehicle's VehicleMintBatchVehicles with an executor.
+func (o *UserDevice) VehicleMintBatchVehicles(mods ...qm.QueryMod) vehicleMintBatchVehicleQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"devices_api\".\"vehicle_mint_batch_vehicles\".\"user_device_id\"=?", o.ID),
+	)
+
+	return VehicleMintBatchVehicles(queryMods...)
+}
+
 // LoadBurnRequest allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (userDeviceL) LoadBurnRequest(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
@@ -1202,6 +1289,119 @@ func (userDeviceL) LoadVehicleTokenSyntheticDevice(ctx context.Context, e boil.C
 	return nil
 }
 
+// LoadAftermarketDeviceFingerprints allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userDeviceL) LoadAftermarketDeviceFingerprints(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+	var slice []*UserDevice
+	var object *UserDevice
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserDevice.(*UserDevice)
+		if !ok {
+			object = new(UserDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserDevice))
+			}
+		}
+	} else {
+		s, ok := maybeUserDevice.(*[]*UserDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserDevice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userDeviceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userDeviceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.aftermarket_device_fingerprints`),
+		qm.WhereIn(`devices_api.aftermarket_device_fingerprints.user_device_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load aftermarket_device_fingerprints")
+	}
+
+	var resultSlice []*AftermarketDeviceFingerprint
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice aftermarket_device_fingerprints")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on aftermarket_device_fingerprints")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for aftermarket_device_fingerprints")
+	}
+
+	if len(aftermarketDeviceFingerprintAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AftermarketDeviceFingerprints = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &aftermarketDeviceFingerprintR{}
+			}
+			foreign.R.UserDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.UserDeviceID) {
+				local.R.AftermarketDeviceFingerprints = append(local.R.AftermarketDeviceFingerprints, foreign)
+				if foreign.R == nil {
+					foreign.R = &aftermarketDeviceFingerprintR{}
+				}
+				foreign.R.UserDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadAutopiJobs allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userDeviceL) LoadAutopiJobs(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
@@ -1541,9 +1741,9 @@ func (userDeviceL) LoadErrorCodeQueries(ctx context.Context, e boil.ContextExecu
 	return nil
 }
 
-// LoadUserDeviceAPIIntegrations allows an eager lookup of values, cached into the
+// LoadUserDeviceAPIIntegrationStatusHistories allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+func (userDeviceL) LoadUserDeviceAPIIntegrationStatusHistories(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
 	var slice []*UserDevice
 	var object *UserDevice
 
@@ -1596,8 +1796,8 @@ func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.Con
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.user_device_api_integrations`),
-		qm.WhereIn(`devices_api.user_device_api_integrations.user_device_id in ?`, argsSlice...),
+		qm.From(`devices_api.user_device_api_integration_status_history`),
+		qm.WhereIn(`devices_api.user_device_api_integration_status_history.user_device_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1605,22 +1805,22 @@ func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.Con
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load user_device_api_integrations")
+		return errors.Wrap(err, "failed to eager load user_device_api_integration_status_history")
 	}
 
-	var resultSlice []*UserDeviceAPIIntegration
+	var resultSlice []*UserDeviceAPIIntegrationStatusHistory
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice user_device_api_integrations")
+		return errors.Wrap(err, "failed to bind eager loaded slice user_device_api_integration_status_history")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on user_device_api_integrations")
+		return errors.Wrap(err, "failed to close results in eager load on user_device_api_integration_status_history")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_api_integrations")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_api_integration_status_history")
 	}
 
-	if len(userDeviceAPIIntegrationAfterSelectHooks) != 0 {
+	if len(userDeviceAPIIntegrationStatusHistoryAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1628,10 +1828,10 @@ func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.Con
 		}
 	}
 	if singular {
-		object.R.UserDeviceAPIIntegrations = resultSlice
+		object.R.UserDeviceAPIIntegrationStatusHistories = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &userDeviceAPIIntegrationR{}
+				foreign.R = &userDeviceAPIIntegrationStatusHistoryR{}
 			}
 			foreign.R.UserDevice = object
 		}
@@ -1641,9 +1841,9 @@ func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.Con
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserDeviceID {
-				local.R.UserDeviceAPIIntegrations = append(local.R.UserDeviceAPIIntegrations, foreign)
+				local.R.UserDeviceAPIIntegrationStatusHistories = append(local.R.UserDeviceAPIIntegrationStatusHistories, foreign)
 				if foreign.R == nil {
-					foreign.R = &userDeviceAPIIntegrationR{}
+					foreign.R = &userDeviceAPIIntegrationStatusHistoryR{}
 				}
 				foreign.R.UserDevice = local
 				break
@@ -1654,9 +1854,9 @@ func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.Con
 	return nil
 }
 
-// LoadUserDeviceToGeofences allows an eager lookup of values, cached into the
+// LoadUserDeviceAPIIntegrations allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+func (userDeviceL) LoadUserDeviceAPIIntegrations(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
 	var slice []*UserDevice
 	var object *UserDevice
 
@@ -1709,8 +1909,8 @@ func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.Context
 	}
 
 	query := NewQuery(
-		qm.From(`devices_api.user_device_to_geofence`),
-		qm.WhereIn(`devices_api.user_device_to_geofence.user_device_id in ?`, argsSlice...),
+		qm.From(`devices_api.user_device_api_integrations`),
+		qm.WhereIn(`devices_api.user_device_api_integrations.user_device_id in ?`, argsSlice...),
 	)
 	if mods != nil {
 		mods.Apply(query)
@@ -1718,22 +1918,22 @@ func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.Context
 
 	results, err := query.QueryContext(ctx, e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load user_device_to_geofence")
+		return errors.Wrap(err, "failed to eager load user_device_api_integrations")
 	}
 
-	var resultSlice []*UserDeviceToGeofence
+	var resultSlice []*UserDeviceAPIIntegration
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice user_device_to_geofence")
+		return errors.Wrap(err, "failed to bind eager loaded slice user_device_api_integrations")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on user_device_to_geofence")
+		return errors.Wrap(err, "failed to close results in eager load on user_device_api_integrations")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_to_geofence")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_api_integrations")
 	}
 
-	if len(userDeviceToGeofenceAfterSelectHooks) != 0 {
+	if len(userDeviceAPIIntegrationAfterSelectHooks) != 0 {
 		for _, obj := range resultSlice {
 			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
 				return err
@@ -1741,10 +1941,10 @@ func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.Context
 		}
 	}
 	if singular {
-		object.R.UserDeviceToGeofences = resultSlice
+		object.R.UserDeviceAPIIntegrations = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &userDeviceToGeofenceR{}
+				foreign.R = &userDeviceAPIIntegrationR{}
 			}
 			foreign.R.UserDevice = object
 		}
@@ -1754,9 +1954,9 @@ func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.Context
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
 			if local.ID == foreign.UserDeviceID {
-				local.R.UserDeviceToGeofences = append(local.R.UserDeviceToGeofences, foreign)
+				local.R.UserDeviceAPIIntegrations = append(local.R.UserDeviceAPIIntegrations, foreign)
 				if foreign.R == nil {
-					foreign.R = &userDeviceToGeofenceR{}
+					foreign.R = &userDeviceAPIIntegrationR{}
 				}
 				foreign.R.UserDevice = local
 				break
@@ -1767,43 +1967,382 @@ func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.Context
 	return nil
 }
 
-// SetBurnRequest of the userDevice to the related item.
-// Sets o.R.BurnRequest to related.
-// Adds o to related.R.BurnRequestUserDevice.
-func (o *UserDevice) SetBurnRequest(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MetaTransactionRequest) error {
-	var err error
-	if insert {
-		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
-	}
-
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"devices_api\".\"user_devices\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"burn_request_id"}),
-		strmangle.WhereClause("\"", "\"", 2, userDevicePrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
-
-	if boil.IsDebug(ctx) {
-		writer := boil.DebugWriterFrom(ctx)
-		fmt.Fprintln(writer, updateQuery)
-		fmt.Fprintln(writer, values)
-	}
-	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
-	}
+// LoadUserDeviceGeofenceStates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userDeviceL) LoadUserDeviceGeofenceStates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+	var slice []*UserDevice
+	var object *UserDevice
 
-	queries.Assign(&o.BurnRequestID, related.ID)
-	if o.R == nil {
-		o.R = &userDeviceR{
-			BurnRequest: related,
+	if singular {
+		var ok bool
+		object, ok = maybeUserDevice.(*UserDevice)
+		if !ok {
+			object = new(UserDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserDevice))
+			}
 		}
 	} else {
-		o.R.BurnRequest = related
+		s, ok := maybeUserDevice.(*[]*UserDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserDevice))
+			}
+		}
 	}
 
-	if related.R == nil {
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userDeviceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userDeviceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.user_device_geofence_states`),
+		qm.WhereIn(`devices_api.user_device_geofence_states.user_device_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_device_geofence_states")
+	}
+
+	var resultSlice []*UserDeviceGeofenceState
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_device_geofence_states")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_device_geofence_states")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_geofence_states")
+	}
+
+	if len(userDeviceGeofenceStateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserDeviceGeofenceStates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userDeviceGeofenceStateR{}
+			}
+			foreign.R.UserDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserDeviceID {
+				local.R.UserDeviceGeofenceStates = append(local.R.UserDeviceGeofenceStates, foreign)
+				if foreign.R == nil {
+					foreign.R = &userDeviceGeofenceStateR{}
+				}
+				foreign.R.UserDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserDeviceToGeofences allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userDeviceL) LoadUserDeviceToGeofences(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+	var slice []*UserDevice
+	var object *UserDevice
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserDevice.(*UserDevice)
+		if !ok {
+			object = new(UserDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserDevice))
+			}
+		}
+	} else {
+		s, ok := maybeUserDevice.(*[]*UserDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserDevice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userDeviceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userDeviceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.user_device_to_geofence`),
+		qm.WhereIn(`devices_api.user_device_to_geofence.user_device_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_device_to_geofence")
+	}
+
+	var resultSlice []*UserDeviceToGeofence
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_device_to_geofence")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_device_to_geofence")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_device_to_geofence")
+	}
+
+	if len(userDeviceToGeofenceAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.UserDeviceToGeofences = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userDeviceToGeofenceR{}
+			}
+			foreign.R.UserDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserDeviceID {
+				local.R.UserDeviceToGeofences = append(local.R.UserDeviceToGeofences, foreign)
+				if foreign.R == nil {
+					foreign.R = &userDeviceToGeofenceR{}
+				}
+				foreign.R.UserDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadVehicleMintBatchVehicles allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userDeviceL) LoadVehicleMintBatchVehicles(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUserDevice interface{}, mods queries.Applicator) error {
+	var slice []*UserDevice
+	var object *UserDevice
+
+	if singular {
+		var ok bool
+		object, ok = maybeUserDevice.(*UserDevice)
+		if !ok {
+			object = new(UserDevice)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUserDevice))
+			}
+		}
+	} else {
+		s, ok := maybeUserDevice.(*[]*UserDevice)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUserDevice)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUserDevice))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userDeviceR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userDeviceR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`devices_api.vehicle_mint_batch_vehicles`),
+		qm.WhereIn(`devices_api.vehicle_mint_batch_vehicles.user_device_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load vehicle_mint_batch_vehicles")
+	}
+
+	var resultSlice []*VehicleMintBatchVehicle
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice vehicle_mint_batch_vehicles")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on vehicle_mint_batch_vehicles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for vehicle_mint_batch_vehicles")
+	}
+
+	if len(vehicleMintBatchVehicleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.VehicleMintBatchVehicles = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &vehicleMintBatchVehicleR{}
+			}
+			foreign.R.UserDevice = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserDeviceID {
+				local.R.VehicleMintBatchVehicles = append(local.R.VehicleMintBatchVehicles, foreign)
+				if foreign.R == nil {
+					foreign.R = &vehicleMintBatchVehicleR{}
+				}
+				foreign.R.UserDevice = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetBurnRequest of the userDevice to the related item.
+// Sets o.R.BurnRequest to related.
+// Adds o to related.R.BurnRequestUserDevice.
+func (o *UserDevice) SetBurnRequest(ctx context.Context, exec boil.ContextExecutor, insert bool, related *MetaTransactionRequest) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"devices_api\".\"user_devices\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"burn_request_id"}),
+		strmangle.WhereClause("\"", "\"", 2, userDevicePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.BurnRequestID, related.ID)
+	if o.R == nil {
+		o.R = &userDeviceR{
+			BurnRequest: related,
+		}
+	} else {
+		o.R.BurnRequest = related
+	}
+
+	if related.R == nil {
 		related.R = &metaTransactionRequestR{
 			BurnRequestUserDevice: o,
 		}
@@ -2053,6 +2592,133 @@ func (o *UserDevice) RemoveVehicleTokenSyntheticDevice(ctx context.Context, exec
 	return nil
 }
 
+// AddAftermarketDeviceFingerprints adds the given related objects to the existing relationships
+// of the user_device, optionally inserting them as new records.
+// Appends related to o.R.AftermarketDeviceFingerprints.
+// Sets related.R.UserDevice appropriately.
+func (o *UserDevice) AddAftermarketDeviceFingerprints(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AftermarketDeviceFingerprint) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.UserDeviceID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"aftermarket_device_fingerprints\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_device_id"}),
+				strmangle.WhereClause("\"", "\"", 2, aftermarketDeviceFingerprintPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.UserDeviceID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userDeviceR{
+			AftermarketDeviceFingerprints: related,
+		}
+	} else {
+		o.R.AftermarketDeviceFingerprints = append(o.R.AftermarketDeviceFingerprints, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &aftermarketDeviceFingerprintR{
+				UserDevice: o,
+			}
+		} else {
+			rel.R.UserDevice = o
+		}
+	}
+	return nil
+}
+
+// SetAftermarketDeviceFingerprints removes all previously related items of the
+// user_device replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.UserDevice's AftermarketDeviceFingerprints accordingly.
+// Replaces o.R.AftermarketDeviceFingerprints with related.
+// Sets related.R.UserDevice's AftermarketDeviceFingerprints accordingly.
+func (o *UserDevice) SetAftermarketDeviceFingerprints(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AftermarketDeviceFingerprint) error {
+	query := "update \"devices_api\".\"aftermarket_device_fingerprints\" set \"user_device_id\" = null where \"user_device_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.AftermarketDeviceFingerprints {
+			queries.SetScanner(&rel.UserDeviceID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.UserDevice = nil
+		}
+		o.R.AftermarketDeviceFingerprints = nil
+	}
+
+	return o.AddAftermarketDeviceFingerprints(ctx, exec, insert, related...)
+}
+
+// RemoveAftermarketDeviceFingerprints relationships from objects passed in.
+// Removes related items from R.AftermarketDeviceFingerprints (uses pointer comparison, removal does not keep order)
+// Sets related.R.UserDevice.
+func (o *UserDevice) RemoveAftermarketDeviceFingerprints(ctx context.Context, exec boil.ContextExecutor, related ...*AftermarketDeviceFingerprint) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.UserDeviceID, nil)
+		if rel.R != nil {
+			rel.R.UserDevice = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("user_device_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.AftermarketDeviceFingerprints {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.AftermarketDeviceFingerprints)
+			if ln > 1 && i < ln-1 {
+				o.R.AftermarketDeviceFingerprints[i] = o.R.AftermarketDeviceFingerprints[ln-1]
+			}
+			o.R.AftermarketDeviceFingerprints = o.R.AftermarketDeviceFingerprints[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // AddAutopiJobs adds the given related objects to the existing relationships
 // of the user_device, optionally inserting them as new records.
 // Appends related to o.R.AutopiJobs.
@@ -2286,6 +2952,59 @@ func (o *UserDevice) AddErrorCodeQueries(ctx context.Context, exec boil.ContextE
 	return nil
 }
 
+// AddUserDeviceAPIIntegrationStatusHistories adds the given related objects to the existing relationships
+// of the user_device, optionally inserting them as new records.
+// Appends related to o.R.UserDeviceAPIIntegrationStatusHistories.
+// Sets related.R.UserDevice appropriately.
+func (o *UserDevice) AddUserDeviceAPIIntegrationStatusHistories(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserDeviceAPIIntegrationStatusHistory) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserDeviceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"user_device_api_integration_status_history\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_device_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userDeviceAPIIntegrationStatusHistoryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserDeviceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userDeviceR{
+			UserDeviceAPIIntegrationStatusHistories: related,
+		}
+	} else {
+		o.R.UserDeviceAPIIntegrationStatusHistories = append(o.R.UserDeviceAPIIntegrationStatusHistories, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userDeviceAPIIntegrationStatusHistoryR{
+				UserDevice: o,
+			}
+		} else {
+			rel.R.UserDevice = o
+		}
+	}
+	return nil
+}
+
 // AddUserDeviceAPIIntegrations adds the given related objects to the existing relationships
 // of the user_device, optionally inserting them as new records.
 // Appends related to o.R.UserDeviceAPIIntegrations.
@@ -2339,6 +3058,59 @@ func (o *UserDevice) AddUserDeviceAPIIntegrations(ctx context.Context, exec boil
 	return nil
 }
 
+// AddUserDeviceGeofenceStates adds the given related objects to the existing relationships
+// of the user_device, optionally inserting them as new records.
+// Appends related to o.R.UserDeviceGeofenceStates.
+// Sets related.R.UserDevice appropriately.
+func (o *UserDevice) AddUserDeviceGeofenceStates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*UserDeviceGeofenceState) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserDeviceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"user_device_geofence_states\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_device_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userDeviceGeofenceStatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserDeviceID, rel.GeofenceID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserDeviceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userDeviceR{
+			UserDeviceGeofenceStates: related,
+		}
+	} else {
+		o.R.UserDeviceGeofenceStates = append(o.R.UserDeviceGeofenceStates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userDeviceGeofenceStateR{
+				UserDevice: o,
+			}
+		} else {
+			rel.R.UserDevice = o
+		}
+	}
+	return nil
+}
+
 // AddUserDeviceToGeofences adds the given related objects to the existing relationships
 // of the user_device, optionally inserting them as new records.
 // Appends related to o.R.UserDeviceToGeofences.
@@ -2392,6 +3164,59 @@ func (o *UserDevice) AddUserDeviceToGeofences(ctx context.Context, exec boil.Con
 	return nil
 }
 
+// AddVehicleMintBatchVehicles adds the given related objects to the existing relationships
+// of the user_device, optionally inserting them as new records.
+// Appends related to o.R.VehicleMintBatchVehicles.
+// Sets related.R.UserDevice appropriately.
+func (o *UserDevice) AddVehicleMintBatchVehicles(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleMintBatchVehicle) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserDeviceID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"devices_api\".\"vehicle_mint_batch_vehicles\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_device_id"}),
+				strmangle.WhereClause("\"", "\"", 2, vehicleMintBatchVehiclePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.BatchID, rel.UserDeviceID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserDeviceID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userDeviceR{
+			VehicleMintBatchVehicles: related,
+		}
+	} else {
+		o.R.VehicleMintBatchVehicles = append(o.R.VehicleMintBatchVehicles, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &vehicleMintBatchVehicleR{
+				UserDevice: o,
+			}
+		} else {
+			rel.R.UserDevice = o
+		}
+	}
+	return nil
+}
+
 // UserDevices retrieves all the records using an executor.
 func UserDevices(mods ...qm.QueryMod) userDeviceQuery {
 	mods = append(mods, qm.From("\"devices_api\".\"user_devices\""))
@@ -1735,6 +1735,515 @@ func (x *DeleteUnMintedUserDeviceRequest) GetUserDeviceId() string {
 	return ""
 }
 
+type GetIntegrationHealthSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Transitions are counted from this time onward. Defaults to one day ago.
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *GetIntegrationHealthSummaryRequest) Reset() {
+	*x = GetIntegrationHealthSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIntegrationHealthSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIntegrationHealthSummaryRequest) ProtoMessage() {}
+
+func (x *GetIntegrationHealthSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIntegrationHealthSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetIntegrationHealthSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetIntegrationHealthSummaryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type GetIntegrationHealthSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Statuses []*IntegrationStatusSummary `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+}
+
+func (x *GetIntegrationHealthSummaryResponse) Reset() {
+	*x = GetIntegrationHealthSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetIntegrationHealthSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIntegrationHealthSummaryResponse) ProtoMessage() {}
+
+func (x *GetIntegrationHealthSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIntegrationHealthSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetIntegrationHealthSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetIntegrationHealthSummaryResponse) GetStatuses() []*IntegrationStatusSummary {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type IntegrationStatusSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IntegrationId string `protobuf:"bytes,1,opt,name=integration_id,json=integrationId,proto3" json:"integration_id,omitempty"`
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Number of integrations currently in this status.
+	Count int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Number of times an integration moved into this status since the
+	// requested time.
+	Transitions int64 `protobuf:"varint,4,opt,name=transitions,proto3" json:"transitions,omitempty"`
+}
+
+func (x *IntegrationStatusSummary) Reset() {
+	*x = IntegrationStatusSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegrationStatusSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegrationStatusSummary) ProtoMessage() {}
+
+func (x *IntegrationStatusSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegrationStatusSummary.ProtoReflect.Descriptor instead.
+func (*IntegrationStatusSummary) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IntegrationStatusSummary) GetIntegrationId() string {
+	if x != nil {
+		return x.IntegrationId
+	}
+	return ""
+}
+
+func (x *IntegrationStatusSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IntegrationStatusSummary) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *IntegrationStatusSummary) GetTransitions() int64 {
+	if x != nil {
+		return x.Transitions
+	}
+	return 0
+}
+
+type ListPrivilegesForVehicleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenId int64 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+}
+
+func (x *ListPrivilegesForVehicleRequest) Reset() {
+	*x = ListPrivilegesForVehicleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPrivilegesForVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrivilegesForVehicleRequest) ProtoMessage() {}
+
+func (x *ListPrivilegesForVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrivilegesForVehicleRequest.ProtoReflect.Descriptor instead.
+func (*ListPrivilegesForVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListPrivilegesForVehicleRequest) GetTokenId() int64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+type ListPrivilegesForVehicleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Privileges []*VehiclePrivilege `protobuf:"bytes,1,rep,name=privileges,proto3" json:"privileges,omitempty"`
+}
+
+func (x *ListPrivilegesForVehicleResponse) Reset() {
+	*x = ListPrivilegesForVehicleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPrivilegesForVehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPrivilegesForVehicleResponse) ProtoMessage() {}
+
+func (x *ListPrivilegesForVehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPrivilegesForVehicleResponse.ProtoReflect.Descriptor instead.
+func (*ListPrivilegesForVehicleResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListPrivilegesForVehicleResponse) GetPrivileges() []*VehiclePrivilege {
+	if x != nil {
+		return x.Privileges
+	}
+	return nil
+}
+
+type VehiclePrivilege struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenId int64 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// Address of the grantee.
+	UserAddress []byte                 `protobuf:"bytes,2,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+	PrivilegeId int64                  `protobuf:"varint,3,opt,name=privilege_id,json=privilegeId,proto3" json:"privilege_id,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *VehiclePrivilege) Reset() {
+	*x = VehiclePrivilege{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VehiclePrivilege) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehiclePrivilege) ProtoMessage() {}
+
+func (x *VehiclePrivilege) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehiclePrivilege.ProtoReflect.Descriptor instead.
+func (*VehiclePrivilege) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VehiclePrivilege) GetTokenId() int64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *VehiclePrivilege) GetUserAddress() []byte {
+	if x != nil {
+		return x.UserAddress
+	}
+	return nil
+}
+
+func (x *VehiclePrivilege) GetPrivilegeId() int64 {
+	if x != nil {
+		return x.PrivilegeId
+	}
+	return 0
+}
+
+func (x *VehiclePrivilege) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *VehiclePrivilege) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListVehiclesSharedWithAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserAddress []byte `protobuf:"bytes,1,opt,name=user_address,json=userAddress,proto3" json:"user_address,omitempty"`
+}
+
+func (x *ListVehiclesSharedWithAddressRequest) Reset() {
+	*x = ListVehiclesSharedWithAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVehiclesSharedWithAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesSharedWithAddressRequest) ProtoMessage() {}
+
+func (x *ListVehiclesSharedWithAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesSharedWithAddressRequest.ProtoReflect.Descriptor instead.
+func (*ListVehiclesSharedWithAddressRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListVehiclesSharedWithAddressRequest) GetUserAddress() []byte {
+	if x != nil {
+		return x.UserAddress
+	}
+	return nil
+}
+
+type ListVehiclesSharedWithAddressResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vehicles []*SharedVehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+}
+
+func (x *ListVehiclesSharedWithAddressResponse) Reset() {
+	*x = ListVehiclesSharedWithAddressResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVehiclesSharedWithAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesSharedWithAddressResponse) ProtoMessage() {}
+
+func (x *ListVehiclesSharedWithAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesSharedWithAddressResponse.ProtoReflect.Descriptor instead.
+func (*ListVehiclesSharedWithAddressResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListVehiclesSharedWithAddressResponse) GetVehicles() []*SharedVehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type SharedVehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenId int64 `protobuf:"varint,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// Empty if we have no record of the vehicle.
+	UserDeviceId string              `protobuf:"bytes,2,opt,name=user_device_id,json=userDeviceId,proto3" json:"user_device_id,omitempty"`
+	OwnerAddress []byte              `protobuf:"bytes,3,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	Privileges   []*VehiclePrivilege `protobuf:"bytes,4,rep,name=privileges,proto3" json:"privileges,omitempty"`
+}
+
+func (x *SharedVehicle) Reset() {
+	*x = SharedVehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_user_devices_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SharedVehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedVehicle) ProtoMessage() {}
+
+func (x *SharedVehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_user_devices_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedVehicle.ProtoReflect.Descriptor instead.
+func (*SharedVehicle) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_user_devices_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SharedVehicle) GetTokenId() int64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *SharedVehicle) GetUserDeviceId() string {
+	if x != nil {
+		return x.UserDeviceId
+	}
+	return ""
+}
+
+func (x *SharedVehicle) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *SharedVehicle) GetPrivileges() []*VehiclePrivilege {
+	if x != nil {
+		return x.Privileges
+	}
+	return nil
+}
+
 var File_pkg_grpc_user_devices_proto protoreflect.FileDescriptor
 
 var file_pkg_grpc_user_devices_proto_rawDesc = []byte{
@@ -1998,109 +2507,199 @@ var file_pkg_grpc_user_devices_proto_rawDesc = []byte{
 	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x75, 0x73,
 	0x65, 0x72, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64,
-	0x32, 0xb3, 0x0c, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65,
-	0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x16, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e,
-	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x4d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x42, 0x79, 0x56, 0x49, 0x4e, 0x12, 0x22, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x42, 0x79, 0x56, 0x49, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64,
+	0x22, 0x56, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x64, 0x0a, 0x23, 0x47, 0x65, 0x74, 0x49,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3d, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x91,
+	0x01, 0x0a, 0x18, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x69,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x3c, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c,
+	0x65, 0x67, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64,
+	0x22, 0x5d, 0x0a, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67,
+	0x65, 0x73, 0x46, 0x6f, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c,
+	0x65, 0x67, 0x65, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x22,
+	0xe9, 0x01, 0x0a, 0x10, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c,
+	0x65, 0x67, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x49, 0x0a, 0x24, 0x4c,
+	0x69, 0x73, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x57, 0x69, 0x74, 0x68, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x5b, 0x0a, 0x25, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64,
+	0x12, 0x24, 0x0a, 0x0e, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x70,
+	0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76,
+	0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x32, 0x9e, 0x0f, 0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64,
 	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
 	0x65, 0x12, 0x55, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x42, 0x79, 0x45, 0x74, 0x68, 0x41, 0x64, 0x64, 0x72, 0x12, 0x26, 0x2e, 0x64, 0x65,
+	0x63, 0x65, 0x42, 0x79, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x12, 0x26, 0x2e, 0x64, 0x65,
 	0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x42, 0x79, 0x45, 0x74, 0x68, 0x41, 0x64, 0x64, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x63, 0x65, 0x42, 0x79, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73,
-	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x69, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74,
-	0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73,
-	0x65, 0x72, 0x12, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x55,
-	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x25, 0x2e, 0x64,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x61, 0x72, 0x64,
-	0x77, 0x61, 0x72, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x41, 0x70,
-	0x70, 0x6c, 0x79, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x1b, 0x47,
-	0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x41, 0x75,
-	0x74, 0x6f, 0x50, 0x49, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x12, 0x2b, 0x2e, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x56, 0x49, 0x4e, 0x12, 0x22,
+	0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x56, 0x49, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x45, 0x74, 0x68, 0x41, 0x64, 0x64,
+	0x72, 0x12, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x45, 0x74, 0x68, 0x41, 0x64,
+	0x64, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x69,
+	0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x12, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x27, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x15, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x12, 0x25, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x64, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72,
+	0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x71, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
 	0x63, 0x65, 0x42, 0x79, 0x41, 0x75, 0x74, 0x6f, 0x50, 0x49, 0x55, 0x6e, 0x69, 0x74, 0x49, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x41, 0x75, 0x74, 0x6f,
-	0x50, 0x49, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
-	0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x68, 0x69,
-	0x63, 0x6c, 0x65, 0x73, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x6c, 0x61,
-	0x69, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x47, 0x72, 0x6f, 0x77,
-	0x74, 0x68, 0x12, 0x51, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x19, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x56,
-	0x49, 0x4e, 0x12, 0x29, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x46,
-	0x72, 0x6f, 0x6d, 0x56, 0x49, 0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x49,
-	0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x1d, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x2e, 0x64, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b,
-	0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74,
-	0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x18, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x28, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x65, 0x0a, 0x1c, 0x43, 0x6c, 0x65,
-	0x61, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x2d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x6c, 0x65, 0x61,
-	0x72, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x5e, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
-	0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x2e,
-	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x55, 0x73, 0x65, 0x72,
-	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x12, 0x46, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
-	0x65, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5c, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x55, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64, 0x55, 0x73, 0x65,
-	0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x12, 0x2b, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x79, 0x41, 0x75, 0x74, 0x6f, 0x50, 0x49,
+	0x55, 0x6e, 0x69, 0x74, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x41, 0x75, 0x74, 0x6f, 0x50, 0x49, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x65, 0x64, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x12, 0x51, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x72, 0x0a, 0x19, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x49, 0x4e, 0x12, 0x29, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x49, 0x4e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x46, 0x72, 0x6f, 0x6d, 0x56, 0x49, 0x4e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x63, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x30,
+	0x01, 0x12, 0x5c, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x28, 0x2e,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
+	0x65, 0x0a, 0x1c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x4d, 0x65, 0x74, 0x61, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x70, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x29, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x74,
+	0x6f, 0x70, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x44, 0x49, 0x4d, 0x4f, 0x2d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
-	0x6b, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x1d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5c,
+	0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x6e, 0x4d, 0x69, 0x6e, 0x74, 0x65, 0x64,
+	0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x28, 0x2e, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x6e, 0x4d, 0x69, 0x6e,
+	0x74, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x78, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x2b, 0x2e, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72,
+	0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x12, 0x28, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65, 0x67, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x64,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x73, 0x46, 0x6f, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x74,
+	0x68, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x57, 0x69, 0x74, 0x68, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x44, 0x49, 0x4d, 0x4f, 0x2d, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x2f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2115,88 +2714,110 @@ func file_pkg_grpc_user_devices_proto_rawDescGZIP() []byte {
 	return file_pkg_grpc_user_devices_proto_rawDescData
 }
 
-var file_pkg_grpc_user_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_pkg_grpc_user_devices_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_pkg_grpc_user_devices_proto_goTypes = []interface{}{
-	(*GetUserDeviceByAutoPIUnitIdRequest)(nil),   // 0: devices.GetUserDeviceByAutoPIUnitIdRequest
-	(*GetUserDeviceRequest)(nil),                 // 1: devices.GetUserDeviceRequest
-	(*GetUserDeviceByVINRequest)(nil),            // 2: devices.GetUserDeviceByVINRequest
-	(*GetUserDeviceByEthAddrRequest)(nil),        // 3: devices.GetUserDeviceByEthAddrRequest
-	(*GetUserDeviceByTokenIdRequest)(nil),        // 4: devices.GetUserDeviceByTokenIdRequest
-	(*UpdateUserDeviceMetadataRequest)(nil),      // 5: devices.UpdateUserDeviceMetadataRequest
-	(*UserDevice)(nil),                           // 6: devices.UserDevice
-	(*SyntheticDevice)(nil),                      // 7: devices.SyntheticDevice
-	(*UserDeviceIntegration)(nil),                // 8: devices.UserDeviceIntegration
-	(*UserDeviceAutoPIUnitResponse)(nil),         // 9: devices.UserDeviceAutoPIUnitResponse
-	(*ListUserDevicesForUserRequest)(nil),        // 10: devices.ListUserDevicesForUserRequest
-	(*ListUserDevicesForUserResponse)(nil),       // 11: devices.ListUserDevicesForUserResponse
-	(*ApplyHardwareTemplateRequest)(nil),         // 12: devices.ApplyHardwareTemplateRequest
-	(*ApplyHardwareTemplateResponse)(nil),        // 13: devices.ApplyHardwareTemplateResponse
-	(*ClaimedVehiclesGrowth)(nil),                // 14: devices.ClaimedVehiclesGrowth
-	(*CreateTemplateRequest)(nil),                // 15: devices.CreateTemplateRequest
-	(*CreateTemplateResponse)(nil),               // 16: devices.CreateTemplateResponse
-	(*RegisterUserDeviceFromVINRequest)(nil),     // 17: devices.RegisterUserDeviceFromVINRequest
-	(*RegisterUserDeviceFromVINResponse)(nil),    // 18: devices.RegisterUserDeviceFromVINResponse
-	(*VinCredential)(nil),                        // 19: devices.VinCredential
-	(*UpdateDeviceIntegrationStatusRequest)(nil), // 20: devices.UpdateDeviceIntegrationStatusRequest
-	(*IssueVinCredentialRequest)(nil),            // 21: devices.IssueVinCredentialRequest
-	(*IssueVinCredentialResponse)(nil),           // 22: devices.IssueVinCredentialResponse
-	(*GetAllUserDeviceRequest)(nil),              // 23: devices.GetAllUserDeviceRequest
-	(*ClearMetaTransactionRequestsResponse)(nil), // 24: devices.ClearMetaTransactionRequestsResponse
-	(*StopUserDeviceIntegrationRequest)(nil),     // 25: devices.StopUserDeviceIntegrationRequest
-	(*DeleteVehicleRequest)(nil),                 // 26: devices.DeleteVehicleRequest
-	(*DeleteUnMintedUserDeviceRequest)(nil),      // 27: devices.DeleteUnMintedUserDeviceRequest
-	(*timestamppb.Timestamp)(nil),                // 28: google.protobuf.Timestamp
-	(*AftermarketDevice)(nil),                    // 29: devices.AftermarketDevice
-	(*emptypb.Empty)(nil),                        // 30: google.protobuf.Empty
+	(*GetUserDeviceByAutoPIUnitIdRequest)(nil),    // 0: devices.GetUserDeviceByAutoPIUnitIdRequest
+	(*GetUserDeviceRequest)(nil),                  // 1: devices.GetUserDeviceRequest
+	(*GetUserDeviceByVINRequest)(nil),             // 2: devices.GetUserDeviceByVINRequest
+	(*GetUserDeviceByEthAddrRequest)(nil),         // 3: devices.GetUserDeviceByEthAddrRequest
+	(*GetUserDeviceByTokenIdRequest)(nil),         // 4: devices.GetUserDeviceByTokenIdRequest
+	(*UpdateUserDeviceMetadataRequest)(nil),       // 5: devices.UpdateUserDeviceMetadataRequest
+	(*UserDevice)(nil),                            // 6: devices.UserDevice
+	(*SyntheticDevice)(nil),                       // 7: devices.SyntheticDevice
+	(*UserDeviceIntegration)(nil),                 // 8: devices.UserDeviceIntegration
+	(*UserDeviceAutoPIUnitResponse)(nil),          // 9: devices.UserDeviceAutoPIUnitResponse
+	(*ListUserDevicesForUserRequest)(nil),         // 10: devices.ListUserDevicesForUserRequest
+	(*ListUserDevicesForUserResponse)(nil),        // 11: devices.ListUserDevicesForUserResponse
+	(*ApplyHardwareTemplateRequest)(nil),          // 12: devices.ApplyHardwareTemplateRequest
+	(*ApplyHardwareTemplateResponse)(nil),         // 13: devices.ApplyHardwareTemplateResponse
+	(*ClaimedVehiclesGrowth)(nil),                 // 14: devices.ClaimedVehiclesGrowth
+	(*CreateTemplateRequest)(nil),                 // 15: devices.CreateTemplateRequest
+	(*CreateTemplateResponse)(nil),                // 16: devices.CreateTemplateResponse
+	(*RegisterUserDeviceFromVINRequest)(nil),      // 17: devices.RegisterUserDeviceFromVINRequest
+	(*RegisterUserDeviceFromVINResponse)(nil),     // 18: devices.RegisterUserDeviceFromVINResponse
+	(*VinCredential)(nil),                         // 19: devices.VinCredential
+	(*UpdateDeviceIntegrationStatusRequest)(nil),  // 20: devices.UpdateDeviceIntegrationStatusRequest
+	(*IssueVinCredentialRequest)(nil),             // 21: devices.IssueVinCredentialRequest
+	(*IssueVinCredentialResponse)(nil),            // 22: devices.IssueVinCredentialResponse
+	(*GetAllUserDeviceRequest)(nil),               // 23: devices.GetAllUserDeviceRequest
+	(*ClearMetaTransactionRequestsResponse)(nil),  // 24: devices.ClearMetaTransactionRequestsResponse
+	(*StopUserDeviceIntegrationRequest)(nil),      // 25: devices.StopUserDeviceIntegrationRequest
+	(*DeleteVehicleRequest)(nil),                  // 26: devices.DeleteVehicleRequest
+	(*DeleteUnMintedUserDeviceRequest)(nil),       // 27: devices.DeleteUnMintedUserDeviceRequest
+	(*GetIntegrationHealthSummaryRequest)(nil),    // 28: devices.GetIntegrationHealthSummaryRequest
+	(*GetIntegrationHealthSummaryResponse)(nil),   // 29: devices.GetIntegrationHealthSummaryResponse
+	(*IntegrationStatusSummary)(nil),              // 30: devices.IntegrationStatusSummary
+	(*ListPrivilegesForVehicleRequest)(nil),       // 31: devices.ListPrivilegesForVehicleRequest
+	(*ListPrivilegesForVehicleResponse)(nil),      // 32: devices.ListPrivilegesForVehicleResponse
+	(*VehiclePrivilege)(nil),                      // 33: devices.VehiclePrivilege
+	(*ListVehiclesSharedWithAddressRequest)(nil),  // 34: devices.ListVehiclesSharedWithAddressRequest
+	(*ListVehiclesSharedWithAddressResponse)(nil), // 35: devices.ListVehiclesSharedWithAddressResponse
+	(*SharedVehicle)(nil),                         // 36: devices.SharedVehicle
+	(*timestamppb.Timestamp)(nil),                 // 37: google.protobuf.Timestamp
+	(*AftermarketDevice)(nil),                     // 38: devices.AftermarketDevice
+	(*emptypb.Empty)(nil),                         // 39: google.protobuf.Empty
 }
 var file_pkg_grpc_user_devices_proto_depIdxs = []int32{
-	28, // 0: devices.UserDevice.opted_in_at:type_name -> google.protobuf.Timestamp
+	37, // 0: devices.UserDevice.opted_in_at:type_name -> google.protobuf.Timestamp
 	8,  // 1: devices.UserDevice.integrations:type_name -> devices.UserDeviceIntegration
 	19, // 2: devices.UserDevice.latest_vin_credential:type_name -> devices.VinCredential
-	29, // 3: devices.UserDevice.aftermarket_device:type_name -> devices.AftermarketDevice
+	38, // 3: devices.UserDevice.aftermarket_device:type_name -> devices.AftermarketDevice
 	7,  // 4: devices.UserDevice.syntheticDevice:type_name -> devices.SyntheticDevice
 	6,  // 5: devices.ListUserDevicesForUserResponse.user_devices:type_name -> devices.UserDevice
-	28, // 6: devices.VinCredential.expiration:type_name -> google.protobuf.Timestamp
-	28, // 7: devices.IssueVinCredentialRequest.expires_at:type_name -> google.protobuf.Timestamp
-	1,  // 8: devices.UserDeviceService.GetUserDevice:input_type -> devices.GetUserDeviceRequest
-	4,  // 9: devices.UserDeviceService.GetUserDeviceByTokenId:input_type -> devices.GetUserDeviceByTokenIdRequest
-	2,  // 10: devices.UserDeviceService.GetUserDeviceByVIN:input_type -> devices.GetUserDeviceByVINRequest
-	3,  // 11: devices.UserDeviceService.GetUserDeviceByEthAddr:input_type -> devices.GetUserDeviceByEthAddrRequest
-	10, // 12: devices.UserDeviceService.ListUserDevicesForUser:input_type -> devices.ListUserDevicesForUserRequest
-	12, // 13: devices.UserDeviceService.ApplyHardwareTemplate:input_type -> devices.ApplyHardwareTemplateRequest
-	0,  // 14: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:input_type -> devices.GetUserDeviceByAutoPIUnitIdRequest
-	30, // 15: devices.UserDeviceService.GetClaimedVehiclesGrowth:input_type -> google.protobuf.Empty
-	15, // 16: devices.UserDeviceService.CreateTemplate:input_type -> devices.CreateTemplateRequest
-	17, // 17: devices.UserDeviceService.RegisterUserDeviceFromVIN:input_type -> devices.RegisterUserDeviceFromVINRequest
-	20, // 18: devices.UserDeviceService.UpdateDeviceIntegrationStatus:input_type -> devices.UpdateDeviceIntegrationStatusRequest
-	23, // 19: devices.UserDeviceService.GetAllUserDevice:input_type -> devices.GetAllUserDeviceRequest
-	5,  // 20: devices.UserDeviceService.UpdateUserDeviceMetadata:input_type -> devices.UpdateUserDeviceMetadataRequest
-	30, // 21: devices.UserDeviceService.ClearMetaTransactionRequests:input_type -> google.protobuf.Empty
-	25, // 22: devices.UserDeviceService.StopUserDeviceIntegration:input_type -> devices.StopUserDeviceIntegrationRequest
-	26, // 23: devices.UserDeviceService.DeleteVehicle:input_type -> devices.DeleteVehicleRequest
-	27, // 24: devices.UserDeviceService.DeleteUnMintedUserDevice:input_type -> devices.DeleteUnMintedUserDeviceRequest
-	6,  // 25: devices.UserDeviceService.GetUserDevice:output_type -> devices.UserDevice
-	6,  // 26: devices.UserDeviceService.GetUserDeviceByTokenId:output_type -> devices.UserDevice
-	6,  // 27: devices.UserDeviceService.GetUserDeviceByVIN:output_type -> devices.UserDevice
-	6,  // 28: devices.UserDeviceService.GetUserDeviceByEthAddr:output_type -> devices.UserDevice
-	11, // 29: devices.UserDeviceService.ListUserDevicesForUser:output_type -> devices.ListUserDevicesForUserResponse
-	13, // 30: devices.UserDeviceService.ApplyHardwareTemplate:output_type -> devices.ApplyHardwareTemplateResponse
-	9,  // 31: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:output_type -> devices.UserDeviceAutoPIUnitResponse
-	14, // 32: devices.UserDeviceService.GetClaimedVehiclesGrowth:output_type -> devices.ClaimedVehiclesGrowth
-	16, // 33: devices.UserDeviceService.CreateTemplate:output_type -> devices.CreateTemplateResponse
-	18, // 34: devices.UserDeviceService.RegisterUserDeviceFromVIN:output_type -> devices.RegisterUserDeviceFromVINResponse
-	6,  // 35: devices.UserDeviceService.UpdateDeviceIntegrationStatus:output_type -> devices.UserDevice
-	6,  // 36: devices.UserDeviceService.GetAllUserDevice:output_type -> devices.UserDevice
-	30, // 37: devices.UserDeviceService.UpdateUserDeviceMetadata:output_type -> google.protobuf.Empty
-	24, // 38: devices.UserDeviceService.ClearMetaTransactionRequests:output_type -> devices.ClearMetaTransactionRequestsResponse
-	30, // 39: devices.UserDeviceService.StopUserDeviceIntegration:output_type -> google.protobuf.Empty
-	30, // 40: devices.UserDeviceService.DeleteVehicle:output_type -> google.protobuf.Empty
-	30, // 41: devices.UserDeviceService.DeleteUnMintedUserDevice:output_type -> google.protobuf.Empty
-	25, // [25:42] is the sub-list for method output_type
-	8,  // [8:25] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	37, // 6: devices.VinCredential.expiration:type_name -> google.protobuf.Timestamp
+	37, // 7: devices.IssueVinCredentialRequest.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 8: devices.GetIntegrationHealthSummaryRequest.since:type_name -> google.protobuf.Timestamp
+	30, // 9: devices.GetIntegrationHealthSummaryResponse.statuses:type_name -> devices.IntegrationStatusSummary
+	33, // 10: devices.ListPrivilegesForVehicleResponse.privileges:type_name -> devices.VehiclePrivilege
+	37, // 11: devices.VehiclePrivilege.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 12: devices.VehiclePrivilege.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 13: devices.ListVehiclesSharedWithAddressResponse.vehicles:type_name -> devices.SharedVehicle
+	33, // 14: devices.SharedVehicle.privileges:type_name -> devices.VehiclePrivilege
+	1,  // 15: devices.UserDeviceService.GetUserDevice:input_type -> devices.GetUserDeviceRequest
+	4,  // 16: devices.UserDeviceService.GetUserDeviceByTokenId:input_type -> devices.GetUserDeviceByTokenIdRequest
+	2,  // 17: devices.UserDeviceService.GetUserDeviceByVIN:input_type -> devices.GetUserDeviceByVINRequest
+	3,  // 18: devices.UserDeviceService.GetUserDeviceByEthAddr:input_type -> devices.GetUserDeviceByEthAddrRequest
+	10, // 19: devices.UserDeviceService.ListUserDevicesForUser:input_type -> devices.ListUserDevicesForUserRequest
+	12, // 20: devices.UserDeviceService.ApplyHardwareTemplate:input_type -> devices.ApplyHardwareTemplateRequest
+	0,  // 21: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:input_type -> devices.GetUserDeviceByAutoPIUnitIdRequest
+	39, // 22: devices.UserDeviceService.GetClaimedVehiclesGrowth:input_type -> google.protobuf.Empty
+	15, // 23: devices.UserDeviceService.CreateTemplate:input_type -> devices.CreateTemplateRequest
+	17, // 24: devices.UserDeviceService.RegisterUserDeviceFromVIN:input_type -> devices.RegisterUserDeviceFromVINRequest
+	20, // 25: devices.UserDeviceService.UpdateDeviceIntegrationStatus:input_type -> devices.UpdateDeviceIntegrationStatusRequest
+	23, // 26: devices.UserDeviceService.GetAllUserDevice:input_type -> devices.GetAllUserDeviceRequest
+	5,  // 27: devices.UserDeviceService.UpdateUserDeviceMetadata:input_type -> devices.UpdateUserDeviceMetadataRequest
+	39, // 28: devices.UserDeviceService.ClearMetaTransactionRequests:input_type -> google.protobuf.Empty
+	25, // 29: devices.UserDeviceService.StopUserDeviceIntegration:input_type -> devices.StopUserDeviceIntegrationRequest
+	26, // 30: devices.UserDeviceService.DeleteVehicle:input_type -> devices.DeleteVehicleRequest
+	27, // 31: devices.UserDeviceService.DeleteUnMintedUserDevice:input_type -> devices.DeleteUnMintedUserDeviceRequest
+	28, // 32: devices.UserDeviceService.GetIntegrationHealthSummary:input_type -> devices.GetIntegrationHealthSummaryRequest
+	31, // 33: devices.UserDeviceService.ListPrivilegesForVehicle:input_type -> devices.ListPrivilegesForVehicleRequest
+	34, // 34: devices.UserDeviceService.ListVehiclesSharedWithAddress:input_type -> devices.ListVehiclesSharedWithAddressRequest
+	6,  // 35: devices.UserDeviceService.GetUserDevice:output_type -> devices.UserDevice
+	6,  // 36: devices.UserDeviceService.GetUserDeviceByTokenId:output_type -> devices.UserDevice
+	6,  // 37: devices.UserDeviceService.GetUserDeviceByVIN:output_type -> devices.UserDevice
+	6,  // 38: devices.UserDeviceService.GetUserDeviceByEthAddr:output_type -> devices.UserDevice
+	11, // 39: devices.UserDeviceService.ListUserDevicesForUser:output_type -> devices.ListUserDevicesForUserResponse
+	13, // 40: devices.UserDeviceService.ApplyHardwareTemplate:output_type -> devices.ApplyHardwareTemplateResponse
+	9,  // 41: devices.UserDeviceService.GetUserDeviceByAutoPIUnitId:output_type -> devices.UserDeviceAutoPIUnitResponse
+	14, // 42: devices.UserDeviceService.GetClaimedVehiclesGrowth:output_type -> devices.ClaimedVehiclesGrowth
+	16, // 43: devices.UserDeviceService.CreateTemplate:output_type -> devices.CreateTemplateResponse
+	18, // 44: devices.UserDeviceService.RegisterUserDeviceFromVIN:output_type -> devices.RegisterUserDeviceFromVINResponse
+	6,  // 45: devices.UserDeviceService.UpdateDeviceIntegrationStatus:output_type -> devices.UserDevice
+	6,  // 46: devices.UserDeviceService.GetAllUserDevice:output_type -> devices.UserDevice
+	39, // 47: devices.UserDeviceService.UpdateUserDeviceMetadata:output_type -> google.protobuf.Empty
+	24, // 48: devices.UserDeviceService.ClearMetaTransactionRequests:output_type -> devices.ClearMetaTransactionRequestsResponse
+	39, // 49: devices.UserDeviceService.StopUserDeviceIntegration:output_type -> google.protobuf.Empty
+	39, // 50: devices.UserDeviceService.DeleteVehicle:output_type -> google.protobuf.Empty
+	39, // 51: devices.UserDeviceService.DeleteUnMintedUserDevice:output_type -> google.protobuf.Empty
+	29, // 52: devices.UserDeviceService.GetIntegrationHealthSummary:output_type -> devices.GetIntegrationHealthSummaryResponse
+	32, // 53: devices.UserDeviceService.ListPrivilegesForVehicle:output_type -> devices.ListPrivilegesForVehicleResponse
+	35, // 54: devices.UserDeviceService.ListVehiclesSharedWithAddress:output_type -> devices.ListVehiclesSharedWithAddressResponse
+	35, // [35:55] is the sub-list for method output_type
+	15, // [15:35] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_pkg_grpc_user_devices_proto_init() }
@@ -2542,6 +3163,114 @@ func file_pkg_grpc_user_devices_proto_init() {
 				return nil
 			}
 		}
+		file_pkg_grpc_user_devices_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetIntegrationHealthSummaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetIntegrationHealthSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegrationStatusSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPrivilegesForVehicleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPrivilegesForVehicleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VehiclePrivilege); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVehiclesSharedWithAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVehiclesSharedWithAddressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_user_devices_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SharedVehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_pkg_grpc_user_devices_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	file_pkg_grpc_user_devices_proto_msgTypes[6].OneofWrappers = []interface{}{}
@@ -2551,7 +3280,7 @@ func file_pkg_grpc_user_devices_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_grpc_user_devices_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
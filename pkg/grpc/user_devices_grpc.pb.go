@@ -37,6 +37,9 @@ const (
 	UserDeviceService_StopUserDeviceIntegration_FullMethodName     = "/devices.UserDeviceService/StopUserDeviceIntegration"
 	UserDeviceService_DeleteVehicle_FullMethodName                 = "/devices.UserDeviceService/DeleteVehicle"
 	UserDeviceService_DeleteUnMintedUserDevice_FullMethodName      = "/devices.UserDeviceService/DeleteUnMintedUserDevice"
+	UserDeviceService_GetIntegrationHealthSummary_FullMethodName   = "/devices.UserDeviceService/GetIntegrationHealthSummary"
+	UserDeviceService_ListPrivilegesForVehicle_FullMethodName      = "/devices.UserDeviceService/ListPrivilegesForVehicle"
+	UserDeviceService_ListVehiclesSharedWithAddress_FullMethodName = "/devices.UserDeviceService/ListVehiclesSharedWithAddress"
 )
 
 // UserDeviceServiceClient is the client API for UserDeviceService service.
@@ -63,6 +66,12 @@ type UserDeviceServiceClient interface {
 	DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// used by dimo admin to delete unminted user_device records
 	DeleteUnMintedUserDevice(ctx context.Context, in *DeleteUnMintedUserDeviceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// used by ops to count integrations by status and see how often they change
+	GetIntegrationHealthSummary(ctx context.Context, in *GetIntegrationHealthSummaryRequest, opts ...grpc.CallOption) (*GetIntegrationHealthSummaryResponse, error)
+	// lists the unexpired privileges granted on a vehicle
+	ListPrivilegesForVehicle(ctx context.Context, in *ListPrivilegesForVehicleRequest, opts ...grpc.CallOption) (*ListPrivilegesForVehicleResponse, error)
+	// lists the vehicles on which an address holds unexpired privileges
+	ListVehiclesSharedWithAddress(ctx context.Context, in *ListVehiclesSharedWithAddressRequest, opts ...grpc.CallOption) (*ListVehiclesSharedWithAddressResponse, error)
 }
 
 type userDeviceServiceClient struct {
@@ -249,6 +258,33 @@ func (c *userDeviceServiceClient) DeleteUnMintedUserDevice(ctx context.Context,
 	return out, nil
 }
 
+func (c *userDeviceServiceClient) GetIntegrationHealthSummary(ctx context.Context, in *GetIntegrationHealthSummaryRequest, opts ...grpc.CallOption) (*GetIntegrationHealthSummaryResponse, error) {
+	out := new(GetIntegrationHealthSummaryResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_GetIntegrationHealthSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) ListPrivilegesForVehicle(ctx context.Context, in *ListPrivilegesForVehicleRequest, opts ...grpc.CallOption) (*ListPrivilegesForVehicleResponse, error) {
+	out := new(ListPrivilegesForVehicleResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_ListPrivilegesForVehicle_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDeviceServiceClient) ListVehiclesSharedWithAddress(ctx context.Context, in *ListVehiclesSharedWithAddressRequest, opts ...grpc.CallOption) (*ListVehiclesSharedWithAddressResponse, error) {
+	out := new(ListVehiclesSharedWithAddressResponse)
+	err := c.cc.Invoke(ctx, UserDeviceService_ListVehiclesSharedWithAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserDeviceServiceServer is the server API for UserDeviceService service.
 // All implementations must embed UnimplementedUserDeviceServiceServer
 // for forward compatibility
@@ -273,6 +309,12 @@ type UserDeviceServiceServer interface {
 	DeleteVehicle(context.Context, *DeleteVehicleRequest) (*emptypb.Empty, error)
 	// used by dimo admin to delete unminted user_device records
 	DeleteUnMintedUserDevice(context.Context, *DeleteUnMintedUserDeviceRequest) (*emptypb.Empty, error)
+	// used by ops to count integrations by status and see how often they change
+	GetIntegrationHealthSummary(context.Context, *GetIntegrationHealthSummaryRequest) (*GetIntegrationHealthSummaryResponse, error)
+	// lists the unexpired privileges granted on a vehicle
+	ListPrivilegesForVehicle(context.Context, *ListPrivilegesForVehicleRequest) (*ListPrivilegesForVehicleResponse, error)
+	// lists the vehicles on which an address holds unexpired privileges
+	ListVehiclesSharedWithAddress(context.Context, *ListVehiclesSharedWithAddressRequest) (*ListVehiclesSharedWithAddressResponse, error)
 	mustEmbedUnimplementedUserDeviceServiceServer()
 }
 
@@ -331,6 +373,15 @@ func (UnimplementedUserDeviceServiceServer) DeleteVehicle(context.Context, *Dele
 func (UnimplementedUserDeviceServiceServer) DeleteUnMintedUserDevice(context.Context, *DeleteUnMintedUserDeviceRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUnMintedUserDevice not implemented")
 }
+func (UnimplementedUserDeviceServiceServer) GetIntegrationHealthSummary(context.Context, *GetIntegrationHealthSummaryRequest) (*GetIntegrationHealthSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIntegrationHealthSummary not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) ListPrivilegesForVehicle(context.Context, *ListPrivilegesForVehicleRequest) (*ListPrivilegesForVehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPrivilegesForVehicle not implemented")
+}
+func (UnimplementedUserDeviceServiceServer) ListVehiclesSharedWithAddress(context.Context, *ListVehiclesSharedWithAddressRequest) (*ListVehiclesSharedWithAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVehiclesSharedWithAddress not implemented")
+}
 func (UnimplementedUserDeviceServiceServer) mustEmbedUnimplementedUserDeviceServiceServer() {}
 
 // UnsafeUserDeviceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -653,6 +704,60 @@ func _UserDeviceService_DeleteUnMintedUserDevice_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserDeviceService_GetIntegrationHealthSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIntegrationHealthSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).GetIntegrationHealthSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_GetIntegrationHealthSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).GetIntegrationHealthSummary(ctx, req.(*GetIntegrationHealthSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_ListPrivilegesForVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPrivilegesForVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).ListPrivilegesForVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_ListPrivilegesForVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).ListPrivilegesForVehicle(ctx, req.(*ListPrivilegesForVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDeviceService_ListVehiclesSharedWithAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVehiclesSharedWithAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDeviceServiceServer).ListVehiclesSharedWithAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserDeviceService_ListVehiclesSharedWithAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDeviceServiceServer).ListVehiclesSharedWithAddress(ctx, req.(*ListVehiclesSharedWithAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserDeviceService_ServiceDesc is the grpc.ServiceDesc for UserDeviceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -724,6 +829,18 @@ var UserDeviceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUnMintedUserDevice",
 			Handler:    _UserDeviceService_DeleteUnMintedUserDevice_Handler,
 		},
+		{
+			MethodName: "GetIntegrationHealthSummary",
+			Handler:    _UserDeviceService_GetIntegrationHealthSummary_Handler,
+		},
+		{
+			MethodName: "ListPrivilegesForVehicle",
+			Handler:    _UserDeviceService_ListPrivilegesForVehicle_Handler,
+		},
+		{
+			MethodName: "ListVehiclesSharedWithAddress",
+			Handler:    _UserDeviceService_ListVehiclesSharedWithAddress_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{